@@ -10,6 +10,7 @@ import (
 	"log/slog"
 
 	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/metrics"
 )
 
 type Cacher interface {
@@ -45,6 +46,7 @@ func (s *cache) Shutdown() {
 
 func (s *cache) Get(ctx context.Context, key string) ([]byte, error) {
 	val, err := s.Client.Fetch(key)
+	metrics.IncCacheRequest(err == nil)
 	if err != nil {
 		s.Logger.Error("cache get failed", slog.Any("error", err))
 		return nil, err
@@ -0,0 +1,162 @@
+package emailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"log/slog"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
+)
+
+// Message is an email with an optional plain text alternative of its HTML
+// body and optional attachments.
+type Message struct {
+	Sender      string
+	Recipients  []string
+	Subject     string
+	HTML        string
+	Text        string
+	Attachments []*Attachment
+}
+
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Transport delivers the messages, see `NewEmailer` for which one gets used.
+type Transport interface {
+	Name() string
+	Deliver(ctx context.Context, m *Message) error
+}
+
+type Emailer interface {
+	Send(ctx context.Context, sender, subject, recipient, htmlContent string) error
+	SendMessage(ctx context.Context, m *Message) error
+	GetSenderEmail() string
+	GetDomainName() string // Deprecated
+	GetBackendDomainName() string
+	GetFrontendDomainName() string
+	GetMaintenanceEmail() string
+}
+
+type emailer struct {
+	Transport        Transport
+	UUID             uuid.Provider
+	Logger           *slog.Logger
+	senderEmail      string
+	apiDomainName    string
+	appDomainName    string
+	maintenanceEmail string
+}
+
+// NewEmailer returns the emailer using the transport picked in our config.
+func NewEmailer(cfg *c.Conf, logger *slog.Logger, uuidp uuid.Provider) Emailer {
+	logger.Debug("emailer initializing...", slog.String("transport", cfg.Emailer.Transport))
+	var t Transport
+	switch cfg.Emailer.Transport {
+	case "mailgun":
+		t = NewMailgunTransport(cfg.Emailer.Mailgun.Domain, cfg.Emailer.Mailgun.APIKey, cfg.Emailer.Mailgun.APIBase)
+	case "smtp":
+		t = NewSMTPTransport(cfg.Emailer.SMTP.Host, cfg.Emailer.SMTP.Port, cfg.Emailer.SMTP.Username, cfg.Emailer.SMTP.Password, cfg.Emailer.SMTP.StartTLS)
+	case "file":
+		t = NewFileTransport(cfg.Emailer.FileDirectoryPath)
+	case "memory":
+		t = NewMemoryTransport()
+	default:
+		logger.Error("unsupported emailer transport", slog.String("transport", cfg.Emailer.Transport))
+		panic(fmt.Sprintf("unsupported emailer transport: %v", cfg.Emailer.Transport))
+	}
+	logger.Debug("emailer was initialized.")
+	return NewEmailerWithTransport(cfg, logger, uuidp, t)
+}
+
+// NewEmailerWithTransport returns the emailer using the transport, for example
+// tests pass a `MemoryTransport` to inspect what was sent.
+func NewEmailerWithTransport(cfg *c.Conf, logger *slog.Logger, uuidp uuid.Provider, t Transport) Emailer {
+	return &emailer{
+		Transport:        t,
+		UUID:             uuidp,
+		Logger:           logger,
+		senderEmail:      cfg.Emailer.SenderEmail,
+		apiDomainName:    cfg.AppServer.APIDomainName,
+		appDomainName:    cfg.AppServer.AppDomainName,
+		maintenanceEmail: cfg.Emailer.MaintenanceEmail,
+	}
+}
+
+func (me *emailer) Send(ctx context.Context, sender, subject, recipient, body string) error {
+	return me.SendMessage(ctx, &Message{
+		Sender:     sender,
+		Recipients: []string{recipient},
+		Subject:    subject,
+		HTML:       body,
+	})
+}
+
+func (me *emailer) SendMessage(ctx context.Context, m *Message) error {
+	if err := m.validate(); err != nil {
+		me.Logger.Error("emailer message validation error", slog.Any("err", err))
+		return err
+	}
+	if m.Sender == "" {
+		m.Sender = me.senderEmail
+	}
+
+	if err := me.Transport.Deliver(ctx, m); err != nil {
+		me.Logger.Error("emailer failed sending",
+			slog.String("transport", me.Transport.Name()),
+			slog.Any("err", err))
+		return err
+	}
+
+	me.Logger.Debug("sent email",
+		slog.String("transport", me.Transport.Name()),
+		slog.String("sender", m.Sender),
+		slog.String("subject", m.Subject),
+		slog.String("recipients", strings.Join(m.Recipients, ",")))
+	return nil
+}
+
+func (m *Message) validate() error {
+	if len(m.Recipients) == 0 {
+		return errors.New("email has no recipients")
+	}
+	for _, r := range m.Recipients {
+		if strings.ContainsAny(r, "\r\n") {
+			return fmt.Errorf("email recipient is invalid: %q", r)
+		}
+	}
+	if strings.ContainsAny(m.Subject, "\r\n") {
+		return errors.New("email subject contains a line break")
+	}
+	if m.HTML == "" && m.Text == "" {
+		return errors.New("email has no body")
+	}
+	return nil
+}
+
+func (me *emailer) GetDomainName() string {
+	return me.appDomainName
+}
+
+func (me *emailer) GetSenderEmail() string {
+	return me.senderEmail
+}
+
+func (me *emailer) GetBackendDomainName() string {
+	return me.apiDomainName
+}
+
+func (me *emailer) GetFrontendDomainName() string {
+	return me.appDomainName
+}
+
+func (me *emailer) GetMaintenanceEmail() string {
+	return me.maintenanceEmail
+}
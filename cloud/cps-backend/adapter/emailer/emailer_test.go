@@ -0,0 +1,143 @@
+package emailer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+func newTestEmailer(t Transport) Emailer {
+	cfg := &c.Conf{}
+	cfg.Emailer.SenderEmail = "noreply@cpsapp.ca"
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewEmailerWithTransport(cfg, logger, nil, t)
+}
+
+func TestSendWithMemoryTransport(t *testing.T) {
+	mt := NewMemoryTransport()
+	e := newTestEmailer(mt)
+
+	if err := e.Send(context.Background(), "", "Hello", "bob@example.com", "<p>Hi</p>"); err != nil {
+		t.Fatal(err)
+	}
+	messages := mt.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("Incorrect number of messages, got: %v, want: %v.", len(messages), 1)
+	}
+	if m := messages[0]; m.Sender != "noreply@cpsapp.ca" || m.Recipients[0] != "bob@example.com" || m.HTML != "<p>Hi</p>" {
+		t.Errorf("Incorrect message, got: %+v.", m)
+	}
+
+	mt.Reset()
+	if len(mt.Messages()) != 0 {
+		t.Errorf("Expected no messages after reset.")
+	}
+}
+
+func TestSendMessageValidation(t *testing.T) {
+	e := newTestEmailer(NewMemoryTransport())
+	data := map[string]*Message{
+		"no recipients":   {Subject: "Hello", HTML: "Hi"},
+		"no body":         {Subject: "Hello", Recipients: []string{"bob@example.com"}},
+		"header injected": {Subject: "Hello\r\nBcc: eve@example.com", Recipients: []string{"bob@example.com"}, HTML: "Hi"},
+	}
+	for name, m := range data {
+		if err := e.SendMessage(context.Background(), m); err == nil {
+			t.Errorf("Expected error for message with %v.", name)
+		}
+	}
+}
+
+func TestMessageBytes(t *testing.T) {
+	m := &Message{
+		Sender:     "noreply@cpsapp.ca",
+		Recipients: []string{"bob@example.com", "alice@example.com"},
+		Subject:    "Your invoice",
+		HTML:       "<p>Your invoice is attached.</p>",
+		Text:       "Your invoice is attached.",
+		Attachments: []*Attachment{
+			{Filename: "invoice.pdf", Data: bytes.Repeat([]byte("%PDF"), 100)},
+		},
+	}
+	data, err := m.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := msg.Header.Get("To"); actual != "bob@example.com, alice@example.com" {
+		t.Errorf("Incorrect recipients, got: %v.", actual)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("Incorrect content type, got: %v.", mediaType)
+	}
+
+	// The first part holds the alternatives and the second the attachment.
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	body, err := mr.NextPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mediaType, params, _ = mime.ParseMediaType(body.Header.Get("Content-Type"))
+	if mediaType != "multipart/alternative" {
+		t.Fatalf("Incorrect body content type, got: %v.", mediaType)
+	}
+	ar := multipart.NewReader(body, params["boundary"])
+	for _, expected := range []string{"text/plain", "text/html"} {
+		p, err := ar.NextPart()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual, _, _ := mime.ParseMediaType(p.Header.Get("Content-Type")); actual != expected {
+			t.Errorf("Incorrect alternative, got: %v, want: %v.", actual, expected)
+		}
+	}
+
+	attachment, err := mr.NextPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attachment.FileName() != "invoice.pdf" {
+		t.Errorf("Incorrect attachment filename, got: %v.", attachment.FileName())
+	}
+	if actual := attachment.Header.Get("Content-Type"); actual != "application/pdf" {
+		t.Errorf("Incorrect attachment content type, got: %v.", actual)
+	}
+}
+
+func TestSendWithFileTransport(t *testing.T) {
+	dir := t.TempDir()
+	e := newTestEmailer(NewFileTransport(dir))
+
+	if err := e.Send(context.Background(), "", "Hello", "bob@example.com", "<p>Hi</p>"); err != nil {
+		t.Fatal(err)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("Incorrect number of files, got: %v.", len(files))
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := msg.Header.Get("Subject"); actual != "Hello" {
+		t.Errorf("Incorrect subject, got: %v.", actual)
+	}
+}
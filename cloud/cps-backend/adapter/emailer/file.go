@@ -0,0 +1,39 @@
+package emailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dchest/uniuri"
+)
+
+type fileTransport struct {
+	directoryPath string
+}
+
+// NewFileTransport returns the development transport which writes every
+// message as an `.eml` file into the directory instead of sending it.
+func NewFileTransport(directoryPath string) Transport {
+	return &fileTransport{directoryPath: directoryPath}
+}
+
+func (t *fileTransport) Name() string {
+	return "file"
+}
+
+func (t *fileTransport) Deliver(ctx context.Context, m *Message) error {
+	data, err := m.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(t.directoryPath, 0o755); err != nil {
+		return err
+	}
+
+	// Sortable by the time they were sent.
+	name := fmt.Sprintf("%v-%v.eml", time.Now().UTC().Format("20060102T150405.000000000"), uniuri.NewLen(8))
+	return os.WriteFile(filepath.Join(t.directoryPath, name), data, 0o644)
+}
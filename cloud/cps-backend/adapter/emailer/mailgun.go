@@ -0,0 +1,38 @@
+package emailer
+
+import (
+	"context"
+	"time"
+
+	"github.com/mailgun/mailgun-go/v4"
+)
+
+type mailgunTransport struct {
+	Mailgun *mailgun.MailgunImpl
+}
+
+func NewMailgunTransport(domain, apiKey, apiBase string) Transport {
+	mg := mailgun.NewMailgun(domain, apiKey)
+	mg.SetAPIBase(apiBase) // Override to support our custom email requirements.
+	return &mailgunTransport{Mailgun: mg}
+}
+
+func (t *mailgunTransport) Name() string {
+	return "mailgun"
+}
+
+func (t *mailgunTransport) Deliver(ctx context.Context, m *Message) error {
+	message := t.Mailgun.NewMessage(m.Sender, m.Subject, m.Text, m.Recipients...)
+	if m.HTML != "" {
+		message.SetHtml(m.HTML)
+	}
+	for _, a := range m.Attachments {
+		message.AddBufferAttachment(a.Filename, a.Data)
+	}
+
+	// Send the message with a 10 second timeout
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+	_, _, err := t.Mailgun.Send(ctx, message)
+	return err
+}
@@ -0,0 +1,42 @@
+package emailer
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryTransport keeps the messages instead of sending them so tests and
+// local development can look at what would have been sent.
+type MemoryTransport struct {
+	mu       sync.Mutex
+	messages []*Message
+}
+
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{}
+}
+
+func (t *MemoryTransport) Name() string {
+	return "memory"
+}
+
+func (t *MemoryTransport) Deliver(ctx context.Context, m *Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.messages = append(t.messages, m)
+	return nil
+}
+
+// Messages returns the messages delivered so far, oldest first.
+func (t *MemoryTransport) Messages() []*Message {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*Message(nil), t.messages...)
+}
+
+// Reset forgets the messages delivered so far.
+func (t *MemoryTransport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.messages = nil
+}
@@ -0,0 +1,152 @@
+package emailer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// createPartFunc writes the headers of a part and returns where its body goes.
+type createPartFunc func(h textproto.MIMEHeader) (io.Writer, error)
+
+// Bytes returns the message in the RFC 5322 format used by SMTP and `.eml`
+// files. The HTML and text bodies are sent as alternatives of each other.
+func (m *Message) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	h := textproto.MIMEHeader{}
+	h.Set("From", m.Sender)
+	h.Set("To", strings.Join(m.Recipients, ", "))
+	h.Set("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	h.Set("Date", time.Now().Format(time.RFC1123Z))
+	h.Set("MIME-Version", "1.0")
+
+	// The top level headers are merged with the headers of the body.
+	create := func(ph textproto.MIMEHeader) (io.Writer, error) {
+		for k, v := range ph {
+			h[k] = v
+		}
+		writeHeader(&buf, h)
+		return &buf, nil
+	}
+
+	if len(m.Attachments) == 0 {
+		if err := m.writeBody(create); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	var mixed bytes.Buffer
+	mw := multipart.NewWriter(&mixed)
+	if err := m.writeBody(mw.CreatePart); err != nil {
+		return nil, err
+	}
+	for _, a := range m.Attachments {
+		if err := writeAttachment(mw, a); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	w, _ := create(textproto.MIMEHeader{"Content-Type": {"multipart/mixed; boundary=" + mw.Boundary()}})
+	if _, err := mixed.WriteTo(w); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (m *Message) writeBody(create createPartFunc) error {
+	if m.HTML == "" || m.Text == "" {
+		if m.HTML != "" {
+			return writeText(create, "text/html", m.HTML)
+		}
+		return writeText(create, "text/plain", m.Text)
+	}
+
+	// Order matters, clients show the last alternative they support.
+	var alternative bytes.Buffer
+	mw := multipart.NewWriter(&alternative)
+	if err := writeText(mw.CreatePart, "text/plain", m.Text); err != nil {
+		return err
+	}
+	if err := writeText(mw.CreatePart, "text/html", m.HTML); err != nil {
+		return err
+	}
+	if err := mw.Close(); err != nil {
+		return err
+	}
+	w, err := create(textproto.MIMEHeader{"Content-Type": {"multipart/alternative; boundary=" + mw.Boundary()}})
+	if err != nil {
+		return err
+	}
+	_, err = alternative.WriteTo(w)
+	return err
+}
+
+func writeText(create createPartFunc, contentType, body string) error {
+	w, err := create(textproto.MIMEHeader{
+		"Content-Type":              {contentType + "; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+func writeAttachment(mw *multipart.Writer, a *Attachment) error {
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(a.Filename))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename})},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Base64 lines must not be longer than 76 characters.
+	encoded := base64.StdEncoding.EncodeToString(a.Data)
+	for len(encoded) > 76 {
+		if _, err := fmt.Fprintf(w, "%s\r\n", encoded[:76]); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err = fmt.Fprintf(w, "%s\r\n", encoded)
+	return err
+}
+
+func writeHeader(buf *bytes.Buffer, h textproto.MIMEHeader) {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range h[k] {
+			fmt.Fprintf(buf, "%s: %s\r\n", k, v)
+		}
+	}
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,101 @@
+package emailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+type smtpTransport struct {
+	host     string
+	port     int
+	username string
+	password string
+	startTLS bool
+}
+
+// NewSMTPTransport returns the transport sending through a mail server, the
+// authentication is skipped if the username is empty.
+func NewSMTPTransport(host string, port int, username, password string, startTLS bool) Transport {
+	return &smtpTransport{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		startTLS: startTLS,
+	}
+}
+
+func (t *smtpTransport) Name() string {
+	return "smtp"
+}
+
+func (t *smtpTransport) Deliver(ctx context.Context, m *Message) error {
+	data, err := m.Bytes()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(t.host, strconv.Itoa(t.port)))
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	client, err := smtp.NewClient(conn, t.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if t.startTLS {
+		if err := client.StartTLS(&tls.Config{ServerName: t.host}); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	if t.username != "" {
+		// `smtp.PlainAuth` refuses to send the password unless the
+		// connection is encrypted or to localhost.
+		if err := client.Auth(smtp.PlainAuth("", t.username, t.password, t.host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	if err := client.Mail(address(m.Sender)); err != nil {
+		return err
+	}
+	for _, r := range m.Recipients {
+		if err := client.Rcpt(address(r)); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// address returns the bare address of `Name <address>` style values.
+func address(s string) string {
+	if a, err := mail.ParseAddress(s); err == nil {
+		return a.Address
+	}
+	return s
+}
@@ -0,0 +1,129 @@
+package stripe
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	stripe "github.com/stripe/stripe-go/v75"
+)
+
+// MockPaymentProcessor mocks PaymentProcessor so our controllers can be
+// tested without making calls to the remote payment processor.
+type MockPaymentProcessor struct {
+	mock.Mock
+}
+
+func (m *MockPaymentProcessor) GetName() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockPaymentProcessor) GetProducts() ([]PaymentProcessorProduct, error) {
+	args := m.Called()
+	return args.Get(0).([]PaymentProcessorProduct), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) GetWebhookSecretKey() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockPaymentProcessor) CreateCustomer(fullName, email, descr, shipName, shipPhone, shipCity, shipCountry, shipLine1, shipLine2, shipPostalCode, shipState, billCity, billCountry, billLine1, billLine2, billPostalCode, billState string) (*string, error) {
+	args := m.Called(fullName, email, descr, shipName, shipPhone, shipCity, shipCountry, shipLine1, shipLine2, shipPostalCode, shipState, billCity, billCountry, billLine1, billLine2, billPostalCode, billState)
+	return args.Get(0).(*string), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) UpdateCustomer(customerID, fullName, email, descr, shipName, shipPhone, shipCity, shipCountry, shipLine1, shipLine2, shipPostalCode, shipState, billCity, billCountry, billLine1, billLine2, billPostalCode, billState string) error {
+	args := m.Called(customerID, fullName, email, descr, shipName, shipPhone, shipCity, shipCountry, shipLine1, shipLine2, shipPostalCode, shipState, billCity, billCountry, billLine1, billLine2, billPostalCode, billState)
+	return args.Error(0)
+}
+
+func (m *MockPaymentProcessor) SetupNewCard(customerID string) (*string, error) {
+	args := m.Called(customerID)
+	return args.Get(0).(*string), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) CreateSubscriptionCheckoutSessionURL(domain, successURL, canceledURL, customerID, priceID string, metadata map[string]string, customerHasShippingAddress bool) (string, error) {
+	args := m.Called(domain, successURL, canceledURL, customerID, priceID, metadata, customerHasShippingAddress)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) CreateOneTimeCheckoutSessionURL(domain, successCallbackURL, canceledCallbackURL, customerID, priceID string, metadata map[string]string, customerHasShippingAddress bool) (string, error) {
+	args := m.Called(domain, successCallbackURL, canceledCallbackURL, customerID, priceID, metadata, customerHasShippingAddress)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) GetCheckoutSession(sessionID string) (*stripe.CheckoutSession, error) {
+	args := m.Called(sessionID)
+	return args.Get(0).(*stripe.CheckoutSession), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) GetCheckoutSessionLineItems(sessionID string) ([]*stripe.LineItem, error) {
+	args := m.Called(sessionID)
+	return args.Get(0).([]*stripe.LineItem), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) GetCustomer(customerID string) (*stripe.Customer, error) {
+	args := m.Called(customerID)
+	return args.Get(0).(*stripe.Customer), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) ListInvoicesByCustomerID(customerID string) ([]*stripe.Invoice, error) {
+	args := m.Called(customerID)
+	return args.Get(0).([]*stripe.Invoice), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) GetPaymentIntent(paymentIntentID string) (*stripe.PaymentIntent, error) {
+	args := m.Called(paymentIntentID)
+	return args.Get(0).(*stripe.PaymentIntent), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) GetLatestInvoiceByCustomerID(customerID string) (*stripe.Invoice, error) {
+	args := m.Called(customerID)
+	return args.Get(0).(*stripe.Invoice), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) GetPrice(priceID string) (*stripe.Price, error) {
+	args := m.Called(priceID)
+	return args.Get(0).(*stripe.Price), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) ListPaymentIntentsByCreatedRange(createdGTE, createdLT time.Time) ([]*stripe.PaymentIntent, error) {
+	args := m.Called(createdGTE, createdLT)
+	return args.Get(0).([]*stripe.PaymentIntent), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) GetSubscription(subscriptionID string) (*stripe.Subscription, error) {
+	args := m.Called(subscriptionID)
+	return args.Get(0).(*stripe.Subscription), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) CancelSubscription(subscriptionID string) (*stripe.Subscription, error) {
+	args := m.Called(subscriptionID)
+	return args.Get(0).(*stripe.Subscription), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) UpdateSubscriptionPrice(subscriptionID string, priceID string) (*stripe.Subscription, error) {
+	args := m.Called(subscriptionID, priceID)
+	return args.Get(0).(*stripe.Subscription), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) CreateProduct(name, description string, metadata map[string]string) (*stripe.Product, error) {
+	args := m.Called(name, description, metadata)
+	return args.Get(0).(*stripe.Product), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) UpdateProduct(productID, name, description, defaultPriceID string, active bool) (*stripe.Product, error) {
+	args := m.Called(productID, name, description, defaultPriceID, active)
+	return args.Get(0).(*stripe.Product), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) CreatePrice(productID, currency string, amount float64, recurringInterval string) (*stripe.Price, error) {
+	args := m.Called(productID, currency, amount, recurringInterval)
+	return args.Get(0).(*stripe.Price), args.Error(1)
+}
+
+func (m *MockPaymentProcessor) ArchivePrice(priceID string) error {
+	args := m.Called(priceID)
+	return args.Error(0)
+}
@@ -1,7 +1,11 @@
 package stripe
 
 import (
+	"fmt"
 	"log/slog"
+	"math"
+	"strings"
+	"time"
 
 	stripe "github.com/stripe/stripe-go/v75"
 	"github.com/stripe/stripe-go/v75/checkout/session"
@@ -9,7 +13,9 @@ import (
 	"github.com/stripe/stripe-go/v75/invoice"
 	"github.com/stripe/stripe-go/v75/paymentintent"
 	"github.com/stripe/stripe-go/v75/price"
+	"github.com/stripe/stripe-go/v75/product"
 	"github.com/stripe/stripe-go/v75/setupintent"
+	"github.com/stripe/stripe-go/v75/subscription"
 
 	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
@@ -61,6 +67,14 @@ type PaymentProcessor interface {
 	GetPaymentIntent(paymentIntentID string) (*stripe.PaymentIntent, error)
 	GetLatestInvoiceByCustomerID(customerID string) (*stripe.Invoice, error)
 	GetPrice(priceID string) (*stripe.Price, error)
+	ListPaymentIntentsByCreatedRange(createdGTE, createdLT time.Time) ([]*stripe.PaymentIntent, error)
+	GetSubscription(subscriptionID string) (*stripe.Subscription, error)
+	CancelSubscription(subscriptionID string) (*stripe.Subscription, error)
+	UpdateSubscriptionPrice(subscriptionID string, priceID string) (*stripe.Subscription, error)
+	CreateProduct(name, description string, metadata map[string]string) (*stripe.Product, error)
+	UpdateProduct(productID, name, description, defaultPriceID string, active bool) (*stripe.Product, error)
+	CreatePrice(productID, currency string, amount float64, recurringInterval string) (*stripe.Price, error)
+	ArchivePrice(priceID string) error
 }
 
 type stripePaymentProcessor struct {
@@ -339,8 +353,18 @@ func (pm *stripePaymentProcessor) createCheckoutSessionURL(
 	// THIS IS HOW WE ATTACH OUR METADATA TO OUR STRIPE SUBMISSION. THIS ALLOWS
 	// USE TO DEREFERENCE THE METADATA LATER IN WEBHOOKS. THIS IS IMPORTANT TO
 	// HOW OUR APP WORKS.
-	params.PaymentIntentData = &stripe.CheckoutSessionPaymentIntentDataParams{
-		Metadata: metadata,
+	//
+	// Subscriptions do not have a payment intent at checkout so we attach our
+	// metadata to the subscription instead; afterwards it appears on every
+	// subscription and invoice webhook.
+	if mode == stripe.CheckoutSessionModeSubscription {
+		params.SubscriptionData = &stripe.CheckoutSessionSubscriptionDataParams{
+			Metadata: metadata,
+		}
+	} else {
+		params.PaymentIntentData = &stripe.CheckoutSessionPaymentIntentDataParams{
+			Metadata: metadata,
+		}
 	}
 
 	// If customer used shipping address then our checkout will require the
@@ -440,3 +464,141 @@ func (pm *stripePaymentProcessor) GetLatestInvoiceByCustomerID(customerID string
 	}
 	return nil, nil
 }
+
+// ListPaymentIntentsByCreatedRange function will return all the payment
+// intents which were created by the payment processor inside the time window.
+func (pm *stripePaymentProcessor) ListPaymentIntentsByCreatedRange(createdGTE, createdLT time.Time) ([]*stripe.PaymentIntent, error) {
+	params := &stripe.PaymentIntentListParams{
+		CreatedRange: &stripe.RangeQueryParams{
+			GreaterThanOrEqual: createdGTE.Unix(),
+			LesserThan:         createdLT.Unix(),
+		},
+	}
+	i := paymentintent.List(params)
+
+	pis := []*stripe.PaymentIntent{}
+	for i.Next() {
+		pis = append(pis, i.PaymentIntent())
+	}
+	if err := i.Err(); err != nil {
+		return nil, err
+	}
+	return pis, nil
+}
+
+func (pm *stripePaymentProcessor) GetSubscription(subscriptionID string) (*stripe.Subscription, error) {
+	s, err := subscription.Get(subscriptionID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// CancelSubscription function will cancel the subscription at the end of the
+// current billing period so the customer keeps what they already paid for.
+func (pm *stripePaymentProcessor) CancelSubscription(subscriptionID string) (*stripe.Subscription, error) {
+	params := &stripe.SubscriptionParams{
+		CancelAtPeriodEnd: stripe.Bool(true),
+	}
+	s, err := subscription.Update(subscriptionID, params)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// UpdateSubscriptionPrice function will switch the subscription to the new
+// price and prorate the difference on the next invoice.
+func (pm *stripePaymentProcessor) UpdateSubscriptionPrice(subscriptionID string, priceID string) (*stripe.Subscription, error) {
+	s, err := subscription.Get(subscriptionID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.Items == nil || len(s.Items.Data) == 0 {
+		return nil, fmt.Errorf("subscription %v has no items", subscriptionID)
+	}
+
+	params := &stripe.SubscriptionParams{
+		CancelAtPeriodEnd: stripe.Bool(false),
+		ProrationBehavior: stripe.String("create_prorations"),
+		Items: []*stripe.SubscriptionItemsParams{
+			{
+				ID:    stripe.String(s.Items.Data[0].ID),
+				Price: stripe.String(priceID),
+			},
+		},
+	}
+	s, err = subscription.Update(subscriptionID, params)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// CreateProduct function will create the product in the payment processor so
+// we can attach prices to it. The `metadata` is returned to us in webhooks.
+func (pm *stripePaymentProcessor) CreateProduct(name, description string, metadata map[string]string) (*stripe.Product, error) {
+	params := &stripe.ProductParams{
+		Name: stripe.String(name),
+	}
+	if description != "" {
+		params.Description = stripe.String(description)
+	}
+	for k, v := range metadata {
+		params.AddMetadata(k, v)
+	}
+	p, err := product.New(params)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// UpdateProduct function will update the product in the payment processor,
+// setting an inactive product will prevent it from being purchased.
+func (pm *stripePaymentProcessor) UpdateProduct(productID, name, description, defaultPriceID string, active bool) (*stripe.Product, error) {
+	params := &stripe.ProductParams{
+		Name:        stripe.String(name),
+		Description: stripe.String(description),
+		Active:      stripe.Bool(active),
+	}
+	if defaultPriceID != "" {
+		params.DefaultPrice = stripe.String(defaultPriceID)
+	}
+	p, err := product.Update(productID, params)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// CreatePrice function will create a new price for the product. Leave the
+// `recurringInterval` empty for a one-time price, else use `day`, `week`,
+// `month` or `year` for a subscription price.
+func (pm *stripePaymentProcessor) CreatePrice(productID, currency string, amount float64, recurringInterval string) (*stripe.Price, error) {
+	params := &stripe.PriceParams{
+		Product:    stripe.String(productID),
+		Currency:   stripe.String(strings.ToLower(currency)),
+		UnitAmount: stripe.Int64(int64(math.Round(amount * 100))),
+	}
+	if recurringInterval != "" {
+		params.Recurring = &stripe.PriceRecurringParams{
+			Interval: stripe.String(recurringInterval),
+		}
+	}
+	p, err := price.New(params)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ArchivePrice function will deactivate the price. Prices cannot be modified
+// or deleted in the payment processor so we archive them when they change.
+func (pm *stripePaymentProcessor) ArchivePrice(priceID string) error {
+	params := &stripe.PriceParams{
+		Active: stripe.Bool(false),
+	}
+	_, err := price.Update(priceID, params)
+	return err
+}
@@ -15,6 +15,8 @@ import (
 	s_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
 	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/metrics"
 )
 
 // Pre-Screening Service.
@@ -62,6 +64,7 @@ type CBFFBuilderRequestDTO struct {
 	Signatures                         []*s_d.ComicSubmissionSignature `bson:"signatures" json:"signatures,omitempty"`
 	PrimaryLabelDetails                int8                            `bson:"primary_label_details" json:"primary_label_details"`
 	PrimaryLabelDetailsOther           string                          `bson:"primary_label_details_other" json:"primary_label_details_other"`
+	Locale                             string                          `bson:"locale" json:"locale"`
 }
 
 type CBFFBuilder interface {
@@ -91,8 +94,8 @@ func NewCBFFBuilder(cfg *c.Conf, logger *slog.Logger, uuidp uuid.Provider) CBFFB
 	}
 }
 
-func (bdr *cbffBuilder) GeneratePDF(r *CBFFBuilderRequestDTO) (*PDFBuilderResponseDTO, error) {
-	var err error
+func (bdr *cbffBuilder) GeneratePDF(r *CBFFBuilderRequestDTO) (_ *PDFBuilderResponseDTO, err error) {
+	defer metrics.ObservePDFGeneration("cbff", time.Now(), &err)
 	bdr.Logger.Debug("opening up template file", slog.String("file", bdr.PDFTemplateFilePath))
 
 	pdf := gopdf.GoPdf{}
@@ -103,11 +106,11 @@ func (bdr *cbffBuilder) GeneratePDF(r *CBFFBuilderRequestDTO) (*PDFBuilderRespon
 	// The `github.com/signintech/gopdf` library needs to access a `tff` file
 	// to utilize to render font family in our PDF. Therefore the following set
 	// of lines are going to populate the font family we will need to use,
-	err = pdf.AddTTFFont("roboto", "./static/roboto/Roboto-Regular.ttf")
+	err = pdf.AddTTFFont("roboto", robotoRegularFontPath)
 	if err != nil {
 		panic(err) // For developer purpose only.
 	}
-	err = pdf.AddTTFFont("roboto-bold", "./static/roboto/Roboto-Bold.ttf")
+	err = pdf.AddTTFFont("roboto-bold", robotoBoldFontPath)
 	if err != nil {
 		panic(err) // For developer purpose only.
 	}
@@ -518,7 +521,7 @@ func (bdr *cbffBuilder) generateContent(r *CBFFBuilderRequestDTO, pdf *gopdf.GoP
 		if r.KeyIssue == s_d.KeyIssueOther {
 			r.SpecialNotes = r.KeyIssueOther + " " + r.SpecialNotes
 		} else {
-			r.SpecialNotes = s_d.KeyIssueMap[int(r.KeyIssue)] + " " + r.KeyIssueDetail + ". " + r.SpecialNotes
+			r.SpecialNotes = i18n.T(r.Locale, s_d.KeyIssueMap[int(r.KeyIssue)]) + " " + r.KeyIssueDetail + ". " + r.SpecialNotes
 		}
 	}
 
@@ -15,6 +15,7 @@ import (
 	s_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
 	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/metrics"
 )
 
 type CCIMGBuilderRequestDTO struct {
@@ -50,6 +51,7 @@ type CCIMGBuilderRequestDTO struct {
 	Signatures                         []*s_d.ComicSubmissionSignature `bson:"signatures" json:"signatures,omitempty"`
 	PrimaryLabelDetails                int8                            `bson:"primary_label_details" json:"primary_label_details"`
 	PrimaryLabelDetailsOther           string                          `bson:"primary_label_details_other" json:"primary_label_details_other"`
+	Locale                             string                          `bson:"locale" json:"locale"`
 }
 
 // CCIMGBuilder interface for building the "CPS C-Capsule Indie Mint Gem" edition document.
@@ -80,8 +82,8 @@ func NewCCIMGBuilder(cfg *c.Conf, logger *slog.Logger, uuidp uuid.Provider) CCIM
 	}
 }
 
-func (bdr *ccimgBuilder) GeneratePDF(r *CCIMGBuilderRequestDTO) (*PDFBuilderResponseDTO, error) {
-	var err error
+func (bdr *ccimgBuilder) GeneratePDF(r *CCIMGBuilderRequestDTO) (_ *PDFBuilderResponseDTO, err error) {
+	defer metrics.ObservePDFGeneration("ccimg", time.Now(), &err)
 
 	// Open our PDF invoice template and create clone it for the PDF invoice we will be building with.
 	pdf := gofpdf.New("P", "mm", "A4", "")
@@ -127,7 +129,7 @@ func (bdr *ccimgBuilder) GeneratePDF(r *CCIMGBuilderRequestDTO) (*PDFBuilderResp
 
 	// ROW 1
 	pdf.SetXY(60, 60)
-	pdf.Cell(0, 0, "Volume:")
+	pdf.Cell(0, 0, translate(pdf, r.Locale, "Volume:"))
 	pdf.SetXY(81, 60)
 	// pdf.SetTextColor(178, 34, 34) // Set font color to firebrick red. (see: https://www.rapidtables.com/web/color/red-color.html)
 	pdf.Cell(0, 0, fmt.Sprintf("%v", r.IssueVol))
@@ -150,7 +152,7 @@ func (bdr *ccimgBuilder) GeneratePDF(r *CCIMGBuilderRequestDTO) (*PDFBuilderResp
 		// Do nothing.
 	}
 	pdf.SetXY(60, 66)
-	pdf.Cell(0, 0, "Date:")
+	pdf.Cell(0, 0, translate(pdf, r.Locale, "Date:"))
 	pdf.SetXY(75, 66)
 	// pdf.SetTextColor(178, 34, 34) // Set font color to firebrick red. (see: https://www.rapidtables.com/web/color/red-color.html)
 	pdf.Cell(0, 0, issueDate)
@@ -167,19 +169,19 @@ func (bdr *ccimgBuilder) GeneratePDF(r *CCIMGBuilderRequestDTO) (*PDFBuilderResp
 	case s_d.PrimaryLabelDetailsOther:
 		pdf.Cell(0, 0, r.PrimaryLabelDetailsOther)
 	case s_d.PrimaryLabelDetailsRegularEdition:
-		pdf.Cell(0, 0, "Regular Edition")
+		pdf.Cell(0, 0, translate(pdf, r.Locale, "Regular Edition"))
 	case s_d.PrimaryLabelDetailsDirectEdition:
-		pdf.Cell(0, 0, "Direct Edition")
+		pdf.Cell(0, 0, translate(pdf, r.Locale, "Direct Edition"))
 	case s_d.PrimaryLabelDetailsNewsstandEdition:
-		pdf.Cell(0, 0, "Newstand Edition")
+		pdf.Cell(0, 0, translate(pdf, r.Locale, "Newsstand Edition"))
 	case s_d.PrimaryLabelDetailsVariantCover:
-		pdf.Cell(0, 0, "Variant Cover")
+		pdf.Cell(0, 0, translate(pdf, r.Locale, "Variant Cover"))
 	case s_d.PrimaryLabelDetailsCanadianPriceVariant:
-		pdf.Cell(0, 0, "Canadian Price Variant")
+		pdf.Cell(0, 0, translate(pdf, r.Locale, "Canadian Price Variant"))
 	case s_d.PrimaryLabelDetailsFacsimile:
-		pdf.Cell(0, 0, "Facsimile")
+		pdf.Cell(0, 0, translate(pdf, r.Locale, "Facsimile"))
 	case s_d.PrimaryLabelDetailsReprint:
-		pdf.Cell(0, 0, "Reprint")
+		pdf.Cell(0, 0, translate(pdf, r.Locale, "Reprint"))
 	default:
 		return nil, fmt.Errorf("missing value for crease finding with %v", r.CreasesFinding)
 	}
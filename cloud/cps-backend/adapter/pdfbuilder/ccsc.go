@@ -17,6 +17,7 @@ import (
 	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/metrics"
 )
 
 // Signature Collection.
@@ -54,6 +55,7 @@ type CCSCBuilderRequestDTO struct {
 	Signatures                         []*s_d.ComicSubmissionSignature `bson:"signatures" json:"signatures,omitempty"`
 	PrimaryLabelDetails                int8                            `bson:"primary_label_details" json:"primary_label_details"`
 	PrimaryLabelDetailsOther           string                          `bson:"primary_label_details_other" json:"primary_label_details_other"`
+	Locale                             string                          `bson:"locale" json:"locale"`
 }
 
 // CCSCBuilder interface for building the "CPS C-Capsule Indie Mint Gem" edition document.
@@ -84,8 +86,8 @@ func NewCCSCBuilder(cfg *c.Conf, logger *slog.Logger, uuidp uuid.Provider) CCSCB
 	}
 }
 
-func (bdr *ccscBuilder) GeneratePDF(r *CCSCBuilderRequestDTO) (*PDFBuilderResponseDTO, error) {
-	var err error
+func (bdr *ccscBuilder) GeneratePDF(r *CCSCBuilderRequestDTO) (_ *PDFBuilderResponseDTO, err error) {
+	defer metrics.ObservePDFGeneration("ccsc", time.Now(), &err)
 
 	// Open our PDF invoice template and create clone it for the PDF invoice we will be building with.
 	pdf := gofpdf.New("P", "mm", "A4", "")
@@ -135,7 +137,7 @@ func (bdr *ccscBuilder) GeneratePDF(r *CCSCBuilderRequestDTO) (*PDFBuilderRespon
 
 	// ROW 1
 	pdf.SetXY(60, 60)
-	pdf.Cell(0, 0, "Volume:")
+	pdf.Cell(0, 0, translate(pdf, r.Locale, "Volume:"))
 	pdf.SetXY(81, 60)
 	pdf.SetTextColor(178, 34, 34) // Set font color to firebrick red. (see: https://www.rapidtables.com/web/color/red-color.html)
 	pdf.Cell(0, 0, fmt.Sprintf("%v", r.IssueVol))
@@ -158,7 +160,7 @@ func (bdr *ccscBuilder) GeneratePDF(r *CCSCBuilderRequestDTO) (*PDFBuilderRespon
 		// Do nothing.
 	}
 	pdf.SetXY(60, 66)
-	pdf.Cell(0, 0, "Date:")
+	pdf.Cell(0, 0, translate(pdf, r.Locale, "Date:"))
 	pdf.SetXY(75, 66)
 	pdf.SetTextColor(178, 34, 34) // Set font color to firebrick red. (see: https://www.rapidtables.com/web/color/red-color.html)
 	pdf.Cell(0, 0, issueDate)
@@ -175,19 +177,19 @@ func (bdr *ccscBuilder) GeneratePDF(r *CCSCBuilderRequestDTO) (*PDFBuilderRespon
 	case s_d.PrimaryLabelDetailsOther:
 		pdf.Cell(0, 0, r.PrimaryLabelDetailsOther)
 	case s_d.PrimaryLabelDetailsRegularEdition:
-		pdf.Cell(0, 0, "Regular Edition")
+		pdf.Cell(0, 0, translate(pdf, r.Locale, "Regular Edition"))
 	case s_d.PrimaryLabelDetailsDirectEdition:
-		pdf.Cell(0, 0, "Direct Edition")
+		pdf.Cell(0, 0, translate(pdf, r.Locale, "Direct Edition"))
 	case s_d.PrimaryLabelDetailsNewsstandEdition:
-		pdf.Cell(0, 0, "Newstand Edition")
+		pdf.Cell(0, 0, translate(pdf, r.Locale, "Newsstand Edition"))
 	case s_d.PrimaryLabelDetailsVariantCover:
-		pdf.Cell(0, 0, "Variant Cover")
+		pdf.Cell(0, 0, translate(pdf, r.Locale, "Variant Cover"))
 	case s_d.PrimaryLabelDetailsCanadianPriceVariant:
-		pdf.Cell(0, 0, "Canadian Price Variant")
+		pdf.Cell(0, 0, translate(pdf, r.Locale, "Canadian Price Variant"))
 	case s_d.PrimaryLabelDetailsFacsimile:
-		pdf.Cell(0, 0, "Facsimile")
+		pdf.Cell(0, 0, translate(pdf, r.Locale, "Facsimile"))
 	case s_d.PrimaryLabelDetailsReprint:
-		pdf.Cell(0, 0, "Reprint")
+		pdf.Cell(0, 0, translate(pdf, r.Locale, "Reprint"))
 	default:
 		return nil, fmt.Errorf("missing value for crease finding with %v", r.CreasesFinding)
 	}
@@ -266,7 +268,7 @@ func (bdr *ccscBuilder) GeneratePDF(r *CCSCBuilderRequestDTO) (*PDFBuilderRespon
 
 			pdf.SetFont("Helvetica", "", 10)
 			pdf.SetXY(22.5, 65)
-			pdf.Cell(0, 0, "Near Mint Plus")
+			pdf.Cell(0, 0, translate(pdf, r.Locale, "Near Mint Plus"))
 
 			pdf.SetFont("Helvetica", "B", 22) // Start subscript.
 			pdf.SetXY(41, 50)
@@ -287,7 +289,7 @@ func (bdr *ccscBuilder) GeneratePDF(r *CCSCBuilderRequestDTO) (*PDFBuilderRespon
 			case "gd", "GD":
 				// CASE 1 OF 2: One word description. (Ex: "Fine")
 				pdf.SetXY(29, 65)
-				pdf.Cell(0, 0, constants.SubmissionOverallLetterGrades[r.OverallLetterGrade])
+				pdf.Cell(0, 0, translate(pdf, r.Locale, constants.SubmissionOverallLetterGrades[r.OverallLetterGrade]))
 			case "vg", "VG":
 				fallthrough
 			case "vf", "VF":
@@ -295,7 +297,7 @@ func (bdr *ccscBuilder) GeneratePDF(r *CCSCBuilderRequestDTO) (*PDFBuilderRespon
 			case "nm", "NM":
 				// CASE 1 OF 2: Two word description. (Ex: "Very Fine")
 				pdf.SetXY(23, 65)
-				pdf.Cell(0, 0, constants.SubmissionOverallLetterGrades[r.OverallLetterGrade])
+				pdf.Cell(0, 0, translate(pdf, r.Locale, constants.SubmissionOverallLetterGrades[r.OverallLetterGrade]))
 			}
 		}
 
@@ -0,0 +1,281 @@
+package pdfbuilder
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/signintech/gopdf"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/metrics"
+)
+
+// STORE ACCOUNT INVOICE
+
+type InvoiceLineItemDTO struct {
+	CPSRN     string    `json:"cpsrn"`
+	Item      string    `json:"item"`
+	Service   string    `json:"service"`
+	Amount    float64   `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type InvoiceBuilderRequestDTO struct {
+	InvoiceNumber string                `json:"invoice_number"`
+	StoreName     string                `json:"store_name"`
+	PeriodStart   time.Time             `json:"period_start"`
+	PeriodEnd     time.Time             `json:"period_end"`
+	IssuedAt      time.Time             `json:"issued_at"`
+	DueAt         time.Time             `json:"due_at"`
+	Currency      string                `json:"currency"`
+	LineItems     []*InvoiceLineItemDTO `json:"line_items"`
+	AmountTotal   float64               `json:"amount_total"`
+	AmountPaid    float64               `json:"amount_paid"`
+	AmountDue     float64               `json:"amount_due"`
+}
+
+type InvoiceBuilder interface {
+	GeneratePDF(dto *InvoiceBuilderRequestDTO) (*PDFBuilderResponseDTO, error)
+}
+
+type invoiceBuilder struct {
+	DataDirectoryPath string
+	UUID              uuid.Provider
+	Logger            *slog.Logger
+}
+
+func NewInvoiceBuilder(cfg *c.Conf, logger *slog.Logger, uuidp uuid.Provider) InvoiceBuilder {
+	// DEVELOPERS NOTE:
+	// Unlike our other builders the invoice is drawn from scratch so there is
+	// no template file we need to verify exists.
+	logger.Debug("pdf builder for invoice initializing...")
+
+	return &invoiceBuilder{
+		DataDirectoryPath: cfg.PDFBuilder.DataDirectoryPath,
+		UUID:              uuidp,
+		Logger:            logger,
+	}
+}
+
+const (
+	invoicePageWidth    = 612 // `US Letter` in portrait orientation.
+	invoicePageHeight   = 792
+	invoiceMargin       = 50
+	invoiceRowHeight    = 16
+	invoiceColCPSRN     = 50
+	invoiceColDate      = 190
+	invoiceColItem      = 255
+	invoiceColService   = 430
+	invoiceColAmount    = 562 // Right aligned.
+	invoiceItemMaxChars = 32
+)
+
+func (bdr *invoiceBuilder) GeneratePDF(r *InvoiceBuilderRequestDTO) (_ *PDFBuilderResponseDTO, err error) {
+	defer metrics.ObservePDFGeneration("invoice", time.Now(), &err)
+	bdr.Logger.Debug("generating invoice", slog.String("invoice_number", r.InvoiceNumber))
+
+	pdf := gopdf.GoPdf{}
+	pdf.Start(gopdf.Config{Unit: gopdf.Unit_PT, PageSize: gopdf.Rect{W: invoicePageWidth, H: invoicePageHeight}})
+	pdf.AddPage()
+
+	// DEVELOPER NOTE:
+	// The `github.com/signintech/gopdf` library needs to access a `tff` file
+	// to utilize to render font family in our PDF.
+	err = pdf.AddTTFFont("arial-bold", arialBoldFontPath)
+	if err != nil {
+		return nil, err
+	}
+	err = pdf.AddTTFFont("arial", arialFontPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bdr.generateContent(r, &pdf); err != nil {
+		return nil, err
+	}
+
+	////
+	//// Generate the file and save it to the file.
+	////
+
+	fileName := fmt.Sprintf("%s.pdf", r.InvoiceNumber)
+	filePath := fmt.Sprintf("%s/%s", bdr.DataDirectoryPath, fileName)
+
+	err = pdf.WritePdf(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	////
+	//// Open the file and read all the binary data.
+	////
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	bin, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	////
+	//// Return the generate invoice.
+	////
+
+	return &PDFBuilderResponseDTO{
+		FileName: fileName,
+		FilePath: filePath,
+		Content:  bin,
+	}, err
+}
+
+func (bdr *invoiceBuilder) generateContent(r *InvoiceBuilderRequestDTO, pdf *gopdf.GoPdf) error {
+	//
+	// HEADER
+	//
+
+	pdf.SetFont("arial-bold", "", 22)
+	pdf.SetXY(invoiceMargin, invoiceMargin)
+	pdf.Cell(nil, "INVOICE")
+
+	pdf.SetFont("arial", "", 10)
+	pdf.SetXY(invoiceMargin, invoiceMargin+30)
+	pdf.Cell(nil, "Comic Book Pedigree Services (CPS)")
+
+	pdf.SetFont("arial-bold", "", 10)
+	pdf.SetXY(380, invoiceMargin)
+	pdf.Cell(nil, "Invoice #:")
+	pdf.SetXY(380, invoiceMargin+14)
+	pdf.Cell(nil, "Issued:")
+	pdf.SetXY(380, invoiceMargin+28)
+	pdf.Cell(nil, "Due:")
+	pdf.SetXY(380, invoiceMargin+42)
+	pdf.Cell(nil, "Period:")
+
+	pdf.SetFont("arial", "", 10)
+	pdf.SetXY(440, invoiceMargin)
+	pdf.Cell(nil, r.InvoiceNumber)
+	pdf.SetXY(440, invoiceMargin+14)
+	pdf.Cell(nil, r.IssuedAt.Format("2006-01-02"))
+	pdf.SetXY(440, invoiceMargin+28)
+	pdf.Cell(nil, r.DueAt.Format("2006-01-02"))
+	pdf.SetXY(440, invoiceMargin+42)
+	pdf.Cell(nil, fmt.Sprintf("%v - %v", r.PeriodStart.Format("Jan 2"), r.PeriodEnd.Add(-time.Second).Format("Jan 2, 2006")))
+
+	//
+	// BILL TO
+	//
+
+	pdf.SetFont("arial-bold", "", 10)
+	pdf.SetXY(invoiceMargin, invoiceMargin+80)
+	pdf.Cell(nil, "Bill To:")
+	pdf.SetFont("arial", "", 10)
+	pdf.SetXY(invoiceMargin, invoiceMargin+94)
+	pdf.Cell(nil, r.StoreName)
+
+	//
+	// LINE ITEMS
+	//
+
+	y := float64(invoiceMargin + 130)
+	y = bdr.drawTableHeader(pdf, y)
+
+	pdf.SetFont("arial", "", 9)
+	for _, li := range r.LineItems {
+		// Start a new page if we ran out of room, leaving space for the totals.
+		if y > invoicePageHeight-invoiceMargin-invoiceRowHeight {
+			pdf.AddPage()
+			y = bdr.drawTableHeader(pdf, invoiceMargin)
+			pdf.SetFont("arial", "", 9)
+		}
+
+		item := li.Item
+		if lines := splitText(item, invoiceItemMaxChars); len(lines) > 1 {
+			item = lines[0] + "..."
+		}
+
+		pdf.SetXY(invoiceColCPSRN, y)
+		pdf.Cell(nil, li.CPSRN)
+		pdf.SetXY(invoiceColDate, y)
+		pdf.Cell(nil, li.CreatedAt.Format("2006-01-02"))
+		pdf.SetXY(invoiceColItem, y)
+		pdf.Cell(nil, item)
+		pdf.SetXY(invoiceColService, y)
+		pdf.Cell(nil, li.Service)
+		bdr.cellRightAligned(pdf, invoiceColAmount, y, fmt.Sprintf("%.2f", li.Amount))
+		y += invoiceRowHeight
+	}
+
+	//
+	// TOTALS
+	//
+
+	if y > invoicePageHeight-invoiceMargin-(4*invoiceRowHeight) {
+		pdf.AddPage()
+		y = invoiceMargin
+	}
+	y += invoiceRowHeight / 2
+	pdf.SetLineWidth(0.5)
+	pdf.Line(380, y, invoicePageWidth-invoiceMargin, y)
+	y += invoiceRowHeight / 2
+
+	totals := []struct {
+		label  string
+		amount float64
+	}{
+		{"Total", r.AmountTotal},
+		{"Paid", r.AmountPaid},
+		{fmt.Sprintf("Amount Due (%v)", r.Currency), r.AmountDue},
+	}
+	for i, t := range totals {
+		if i == len(totals)-1 {
+			pdf.SetFont("arial-bold", "", 11)
+		} else {
+			pdf.SetFont("arial", "", 10)
+		}
+		pdf.SetXY(380, y)
+		pdf.Cell(nil, t.label)
+		bdr.cellRightAligned(pdf, invoiceColAmount, y, fmt.Sprintf("%.2f", t.amount))
+		y += invoiceRowHeight
+	}
+
+	pdf.SetFont("arial", "", 9)
+	pdf.SetXY(invoiceMargin, y+invoiceRowHeight)
+	pdf.Cell(nil, fmt.Sprintf("Please reference invoice %v with your payment.", r.InvoiceNumber))
+
+	return nil
+}
+
+func (bdr *invoiceBuilder) drawTableHeader(pdf *gopdf.GoPdf, y float64) float64 {
+	pdf.SetFont("arial-bold", "", 9)
+	pdf.SetXY(invoiceColCPSRN, y)
+	pdf.Cell(nil, "CPSRN")
+	pdf.SetXY(invoiceColDate, y)
+	pdf.Cell(nil, "Date")
+	pdf.SetXY(invoiceColItem, y)
+	pdf.Cell(nil, "Item")
+	pdf.SetXY(invoiceColService, y)
+	pdf.Cell(nil, "Service")
+	bdr.cellRightAligned(pdf, invoiceColAmount, y, "Amount")
+
+	y += invoiceRowHeight - 2
+	pdf.SetLineWidth(0.5)
+	pdf.Line(invoiceMargin, y, invoicePageWidth-invoiceMargin, y)
+	return y + 4
+}
+
+// cellRightAligned prints the text so that it ends at the `x` coordinate.
+func (bdr *invoiceBuilder) cellRightAligned(pdf *gopdf.GoPdf, x float64, y float64, text string) {
+	w, err := pdf.MeasureTextWidth(text)
+	if err != nil {
+		w = 0
+	}
+	pdf.SetXY(x-w, y)
+	pdf.Cell(nil, text)
+}
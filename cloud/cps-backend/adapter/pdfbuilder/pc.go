@@ -17,6 +17,8 @@ import (
 	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/metrics"
 )
 
 // CPS PEDIGREE COLLECTION
@@ -58,6 +60,7 @@ type PCBuilderRequestDTO struct {
 	Signatures                         []*s_d.ComicSubmissionSignature `bson:"signatures" json:"signatures,omitempty"`
 	PrimaryLabelDetails                int8                            `bson:"primary_label_details" json:"primary_label_details"`
 	PrimaryLabelDetailsOther           string                          `bson:"primary_label_details_other" json:"primary_label_details_other"`
+	Locale                             string                          `bson:"locale" json:"locale"`
 }
 
 type PCBuilder interface {
@@ -87,8 +90,8 @@ func NewPCBuilder(cfg *c.Conf, logger *slog.Logger, uuidp uuid.Provider) PCBuild
 	}
 }
 
-func (bdr *pcBuilder) GeneratePDF(r *PCBuilderRequestDTO) (*PDFBuilderResponseDTO, error) {
-	var err error
+func (bdr *pcBuilder) GeneratePDF(r *PCBuilderRequestDTO) (_ *PDFBuilderResponseDTO, err error) {
+	defer metrics.ObservePDFGeneration("pc", time.Now(), &err)
 	bdr.Logger.Debug("opening up template file", slog.String("file", bdr.PDFTemplateFilePath))
 
 	pdf := gopdf.GoPdf{}
@@ -107,11 +110,11 @@ func (bdr *pcBuilder) GeneratePDF(r *PCBuilderRequestDTO) (*PDFBuilderResponseDT
 	// if err != nil {
 	// 	panic(err) // For developer purpose only.
 	// }
-	err = pdf.AddTTFFont("arial-bold", "./static/arial/ARIALBD.TTF")
+	err = pdf.AddTTFFont("arial-bold", arialBoldFontPath)
 	if err != nil {
 		panic(err) // For developer purpose only.
 	}
-	err = pdf.AddTTFFont("arial", "./static/arial/ARIAL.TTF")
+	err = pdf.AddTTFFont("arial", arialFontPath)
 	if err != nil {
 		panic(err) // For developer purpose only.
 	}
@@ -247,7 +250,7 @@ func (bdr *pcBuilder) generateContent(r *PCBuilderRequestDTO, pdf *gopdf.GoPdf)
 	if r.KeyIssue == 1 {
 		pdf.Cell(nil, r.KeyIssueOther)
 	} else {
-		pdf.Cell(nil, fmt.Sprintf("%v %v", constants.SubmissionKeyIssue[r.KeyIssue], r.KeyIssueDetail))
+		pdf.Cell(nil, fmt.Sprintf("%v %v", i18n.T(r.Locale, constants.SubmissionKeyIssue[r.KeyIssue]), r.KeyIssueDetail))
 	}
 
 	// // DEVELOPERS NOTE: THIS IS ONLY HERE FOR REFERENCE PURPOSES (DELETE WHEN READY TO NO LONGER US)
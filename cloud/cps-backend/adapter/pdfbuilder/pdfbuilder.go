@@ -7,8 +7,38 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
 )
 
+// The fonts embedded into our PDFs.
+const (
+	robotoRegularFontPath = "./static/roboto/Roboto-Regular.ttf"
+	robotoBoldFontPath    = "./static/roboto/Roboto-Bold.ttf"
+	arialFontPath         = "./static/arial/ARIAL.TTF"
+	arialBoldFontPath     = "./static/arial/ARIALBD.TTF"
+)
+
+// RequiredFiles returns the templates and fonts the builders need to read to
+// generate the PDFs.
+func RequiredFiles(cfg *c.Conf) []string {
+	return []string{
+		cfg.PDFBuilder.CBFFTemplatePath,
+		cfg.PDFBuilder.PCTemplatePath,
+		cfg.PDFBuilder.CCIMGTemplatePath,
+		cfg.PDFBuilder.CCSCTemplatePath,
+		cfg.PDFBuilder.CCTemplatePath,
+		cfg.PDFBuilder.CCUGTemplatePath,
+		robotoRegularFontPath,
+		robotoBoldFontPath,
+		arialFontPath,
+		arialBoldFontPath,
+	}
+}
+
 // DownloadFile will download a url to a local file. It's efficient because it will
 // write as it downloads and not load the whole file into memory.
 func DownloadFile(filepath string, url string) error {
@@ -46,6 +76,12 @@ func cleanup(dataDirectoryPath string) {
 		})
 }
 
+// translate returns the text in the locale, encoded for the core fonts as they
+// only support the cp1252 code page and would garble accented characters.
+func translate(pdf *gofpdf.Fpdf, locale, text string) string {
+	return pdf.UnicodeTranslatorFromDescriptor("")(i18n.T(locale, text))
+}
+
 func splitText(text string, maxChars int) []string {
 	var lines []string
 	words := strings.Fields(text)
@@ -10,11 +10,12 @@ import (
 	"log/slog"
 
 	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/metrics"
 )
 
 func NewStorage(appCfg *c.Conf, logger *slog.Logger) *mongo.Client {
 	logger.Debug("storage initializing...")
-	client, err := mongo.Connect(context.TODO(), options.Client().ApplyURI(appCfg.DB.URI))
+	client, err := mongo.Connect(context.TODO(), options.Client().ApplyURI(appCfg.DB.URI).SetMonitor(metrics.NewCommandMonitor()))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -19,6 +19,7 @@ import (
 
 	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/metrics"
 )
 
 type S3Storager interface {
@@ -125,6 +126,7 @@ func (s *s3Storager) UploadContent(ctx context.Context, objectKey string, conten
 		Body:   bytes.NewReader(content),
 	})
 	if err != nil {
+		metrics.IncS3UploadFailure("content")
 		return err
 	}
 	return nil
@@ -141,6 +143,7 @@ func (s *s3Storager) UploadContentFromMulipart(ctx context.Context, objectKey st
 	// Perform the file upload to S3
 	_, err := s.S3Client.PutObject(ctx, params)
 	if err != nil {
+		metrics.IncS3UploadFailure("multipart")
 		return err
 	}
 	return nil
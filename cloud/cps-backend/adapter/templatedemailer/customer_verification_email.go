@@ -3,18 +3,17 @@ package templatedemailer
 import (
 	"bytes"
 	"context"
-	"path"
-	"text/template"
 
 	"log/slog"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
 )
 
-func (impl *templatedEmailer) SendCustomerVerificationEmail(email, verificationCode, firstName string) error {
+func (impl *templatedEmailer) SendCustomerVerificationEmail(ctx context.Context, locale string, email, verificationCode, firstName string) error {
 	impl.Logger.Debug("sending email verification email...")
 
 	// FOR TESTING PURPOSES ONLY.
-	fp := path.Join("templates", "customer_verification_email.html")
-	tmpl, err := template.ParseFiles(fp)
+	tmpl, err := impl.parseTemplate(locale, "customer_verification_email.html")
 	if err != nil {
 		impl.Logger.Error("customer verification parsing error", slog.Any("error", err))
 		return err
@@ -38,7 +37,7 @@ func (impl *templatedEmailer) SendCustomerVerificationEmail(email, verificationC
 	}
 	body := processed.String() // DEVELOPERS NOTE: Convert our long sequence of data into a string.
 
-	if err := impl.Emailer.Send(context.Background(), impl.Emailer.GetSenderEmail(), "Activate your CPS Retail Partner Account", email, body); err != nil {
+	if err := impl.send(ctx, i18n.T(locale, "Activate your CPS Retail Partner Account"), email, body); err != nil {
 		impl.Logger.Error("sending customer verification error", slog.Any("error", err))
 		return err
 	}
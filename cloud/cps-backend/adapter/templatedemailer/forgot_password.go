@@ -3,17 +3,15 @@ package templatedemailer
 import (
 	"bytes"
 	"context"
-	"path"
-	"text/template"
 
 	"log/slog"
-)
 
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
+)
 
-func (impl *templatedEmailer) SendForgotPasswordEmail(email, verificationCode, firstName string) error {
+func (impl *templatedEmailer) SendForgotPasswordEmail(ctx context.Context, locale string, email, verificationCode, firstName string) error {
 	// FOR TESTING PURPOSES ONLY.
-	fp := path.Join("templates", "forgot_password.html")
-	tmpl, err := template.ParseFiles(fp)
+	tmpl, err := impl.parseTemplate(locale, "forgot_password.html")
 	if err != nil {
 		impl.Logger.Error("parsing error", slog.Any("error", err))
 		return err
@@ -37,7 +35,7 @@ func (impl *templatedEmailer) SendForgotPasswordEmail(email, verificationCode, f
 	}
 	body := processed.String() // DEVELOPERS NOTE: Convert our long sequence of data into a string.
 
-	if err := impl.Emailer.Send(context.Background(), impl.Emailer.GetSenderEmail(), "Forgot Password", email, body); err != nil {
+	if err := impl.send(ctx, i18n.T(locale, "Forgot Password"), email, body); err != nil {
 		impl.Logger.Error("sending error", slog.Any("error", err))
 		return err
 	}
@@ -1,35 +1,62 @@
 package templatedemailer
 
 import (
+	"context"
 	"log/slog"
+	"os"
+	"path"
+	"text/template"
 
-	mg "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/emailer/mailgun"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/emailer"
+	outbox_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/outbox/datastore"
 
 	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
 )
 
 // TemplatedEmailer Is adapter for responsive HTML email templates sender.
+//
+// The emails are not sent right away, they are saved to the outbox using the
+// context so when called inside a transaction the email is only sent if the
+// transaction commits.
 type TemplatedEmailer interface {
 	GetBackendDomainName() string
 	GetFrontendDomainName() string
-	SendNewUserTemporaryPasswordEmail(email, firstName, temporaryPassword string) error
-	SendBusinessVerificationEmail(email, verificationCode, firstName string) error
-	SendCustomerVerificationEmail(email, verificationCode, firstName string) error
-	SendForgotPasswordEmail(email, verificationCode, firstName string) error
-	SendNewComicSubmissionEmailToStaff(staffEmails []string, submissionID string, storeName string, item string, cpsrn string, serviceTypeName string) error
-	SendNewComicSubmissionEmailToRetailers(retailerEmails []string, submissionID string, storeName string, item string, cpsrn string, serviceTypeName string) error
-	SendNewStoreEmailToStaff(staffEmails []string, storeID string) error
-	SendRetailerStoreActiveEmailToRetailers(retailerEmails []string, storeName string) error
+	TemplateNames() []string
+	Preview(name, locale string) (string, error)
+	SendNewUserTemporaryPasswordEmail(ctx context.Context, locale string, email, firstName, temporaryPassword string) error
+	SendUserAccountLockedEmail(ctx context.Context, locale string, email, firstName string, failedCount int, lockedUntil string) error
+	SendStaffInvitationEmail(ctx context.Context, locale string, email, firstName, inviterName, storeName, roleName, token, expiresAt string) error
+	SendEmailChangeVerificationEmail(ctx context.Context, locale string, email, firstName, verificationCode, expiresAt string) error
+	SendEmailChangeNoticeEmail(ctx context.Context, locale string, email, firstName, newEmail string) error
+	SendBusinessVerificationEmail(ctx context.Context, locale string, email, verificationCode, firstName string) error
+	SendCustomerVerificationEmail(ctx context.Context, locale string, email, verificationCode, firstName string) error
+	SendForgotPasswordEmail(ctx context.Context, locale string, email, verificationCode, firstName string) error
+	SendSubmissionStatusChangedEmail(ctx context.Context, locale string, email, firstName, submissionID, item, cpsrn, statusName, grade, unsubscribeToken string) error
+	SendNewComicSubmissionEmailToStaff(ctx context.Context, staff []Recipient, submissionID string, storeName string, item string, cpsrn string, serviceTypeName string) error
+	SendNewComicSubmissionEmailToRetailers(ctx context.Context, retailers []Recipient, submissionID string, storeName string, item string, cpsrn string, serviceTypeName string) error
+	SendNewStoreEmailToStaff(ctx context.Context, staff []Recipient, storeID string) error
+	SendRetailerStoreActiveEmailToRetailers(ctx context.Context, retailers []Recipient, storeName string) error
+	SendReconciliationReportEmailToStaff(ctx context.Context, staff []Recipient, reportID string, period string, chargeCount int64, submissionCount int64, missingReceiptCount int64, missingUserPurchaseCount int64, amountMismatchCount int64, orphanChargeCount int64, missingChargeCount int64, discrepancyCount int64) error
+	SendInvoiceIssuedEmailToRetailers(ctx context.Context, retailers []Recipient, invoiceID string, invoiceNumber string, storeName string, amountDue string, dueDate string) error
+	SendInvoiceOverdueReminderEmailToRetailers(ctx context.Context, retailers []Recipient, invoiceID string, invoiceNumber string, storeName string, amountDue string, dueDate string) error
+}
+
+// Recipient is someone we email in the language they picked.
+type Recipient struct {
+	Email  string
+	Locale string
 }
 
 type templatedEmailer struct {
 	UUID    uuid.Provider
 	Logger  *slog.Logger
-	Emailer mg.Emailer
+	Emailer emailer.Emailer
+	Outbox  outbox_s.OutboxMessageStorer
 }
 
-func NewTemplatedEmailer(cfg *c.Conf, logger *slog.Logger, uuidp uuid.Provider, emailer mg.Emailer) TemplatedEmailer {
+func NewTemplatedEmailer(cfg *c.Conf, logger *slog.Logger, uuidp uuid.Provider, emailer emailer.Emailer, outbox outbox_s.OutboxMessageStorer) TemplatedEmailer {
 	// Defensive code: Make sure we have access to the file before proceeding any further with the code.
 	logger.Debug("templated emailer initializing...")
 	logger.Debug("templated emailer initialized")
@@ -38,6 +65,7 @@ func NewTemplatedEmailer(cfg *c.Conf, logger *slog.Logger, uuidp uuid.Provider,
 		UUID:    uuidp,
 		Logger:  logger,
 		Emailer: emailer,
+		Outbox:  outbox,
 	}
 }
 
@@ -48,3 +76,60 @@ func (impl *templatedEmailer) GetBackendDomainName() string {
 func (impl *templatedEmailer) GetFrontendDomainName() string {
 	return impl.Emailer.GetFrontendDomainName()
 }
+
+// send queues the rendered email in the outbox to be delivered by the
+// dispatcher.
+func (impl *templatedEmailer) send(ctx context.Context, subject, recipient, body string) error {
+	return impl.sendMessage(ctx, &emailer.Message{
+		Recipients: []string{recipient},
+		Subject:    subject,
+		HTML:       body,
+	})
+}
+
+// sendMessage queues the email, with its plain text alternative and
+// attachments, in the outbox once per recipient so they do not see each
+// other's address.
+func (impl *templatedEmailer) sendMessage(ctx context.Context, m *emailer.Message) error {
+	sender := m.Sender
+	if sender == "" {
+		sender = impl.Emailer.GetSenderEmail()
+	}
+	var attachments []*outbox_s.OutboxAttachment
+	for _, a := range m.Attachments {
+		attachments = append(attachments, &outbox_s.OutboxAttachment{
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Data:        a.Data,
+		})
+	}
+	for _, recipient := range m.Recipients {
+		om := outbox_s.NewEmailMessage(&outbox_s.OutboxEmail{
+			Sender:      sender,
+			Recipient:   recipient,
+			Subject:     m.Subject,
+			Body:        m.HTML,
+			Text:        m.Text,
+			Attachments: attachments,
+		})
+		if err := impl.Outbox.Create(ctx, om); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseTemplate returns the template translated to the locale, the English
+// one in the `templates` directory is used when there is no translation.
+func (impl *templatedEmailer) parseTemplate(locale, name string) (*template.Template, error) {
+	if locale = i18n.Normalize(locale); locale != i18n.DefaultLocale {
+		fp := path.Join("templates", locale, name)
+		if _, err := os.Stat(fp); err == nil {
+			return template.ParseFiles(fp)
+		}
+		impl.Logger.Warn("email template not translated",
+			slog.String("name", name),
+			slog.String("locale", locale))
+	}
+	return template.ParseFiles(path.Join("templates", name))
+}
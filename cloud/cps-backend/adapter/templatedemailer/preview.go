@@ -0,0 +1,97 @@
+package templatedemailer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"log/slog"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
+)
+
+// TemplateNames returns the file name of every English template, the
+// translations share the same names.
+func (impl *templatedEmailer) TemplateNames() []string {
+	entries, err := os.ReadDir("templates")
+	if err != nil {
+		impl.Logger.Error("read templates directory error", slog.Any("error", err))
+		return []string{}
+	}
+	names := []string{}
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".html") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Preview renders the template in the locale with made up data so staff can
+// review the translations without having to trigger the email.
+func (impl *templatedEmailer) Preview(name, locale string) (string, error) {
+	found := false
+	for _, n := range impl.TemplateNames() {
+		found = found || n == name
+	}
+	if !found {
+		return "", fmt.Errorf("email template does not exist: %v", name)
+	}
+
+	tmpl, err := impl.parseTemplate(locale, name)
+	if err != nil {
+		impl.Logger.Error("parsing error", slog.Any("error", err))
+		return "", err
+	}
+	var processed bytes.Buffer
+	if err := tmpl.Execute(&processed, impl.previewData(locale)); err != nil {
+		impl.Logger.Error("template execution error", slog.Any("error", err))
+		return "", err
+	}
+	return processed.String(), nil
+}
+
+// previewData has a value for every field used by our templates.
+func (impl *templatedEmailer) previewData(locale string) map[string]any {
+	link := fmt.Sprintf("https://%v/preview", impl.Emailer.GetDomainName())
+	return map[string]any{
+		"AcceptLink":               link,
+		"AmountDue":                "$125.00 CAD",
+		"AmountMismatchCount":      1,
+		"CPSRN":                    "788346-26649-1-1000",
+		"ChargeCount":              42,
+		"ConfirmLink":              link,
+		"DetailLink":               link,
+		"DiscrepancyCount":         5,
+		"DueDate":                  "January 31, 2025",
+		"Email":                    "jane@example.com",
+		"ExpiresAt":                "January 31, 2025",
+		"FailedCount":              10,
+		"FirstName":                "Jane",
+		"Grade":                    "9.8",
+		"InviterName":              "John Doe",
+		"InvoiceNumber":            "INV-000123",
+		"Item":                     "Winter World, Vol. 1, Issue #1",
+		"LockedUntil":              "2025-01-31 15:04:05 EST",
+		"LoginURL":                 link,
+		"MissingChargeCount":       1,
+		"MissingReceiptCount":      1,
+		"MissingUserPurchaseCount": 1,
+		"NewEmail":                 "jane.doe@example.com",
+		"OrphanChargeCount":        1,
+		"Period":                   "2025-01-01 00:00 UTC to 2025-01-02 00:00 UTC",
+		"RegistryLink":             link,
+		"ResetLink":                link,
+		"RoleName":                 i18n.T(locale, "Retailer"),
+		"ServiceTypeName":          i18n.T(locale, "CPS Capsule"),
+		"StatusName":               i18n.T(locale, "Complete"),
+		"StoreName":                "Comic Book Store",
+		"SubmissionCount":          40,
+		"TemporaryPassword":        "Xy7pQ2mN",
+		"UnsubscribeLink":          link,
+		"VerificationLink":         link,
+	}
+}
@@ -0,0 +1,54 @@
+package templatedemailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"log/slog"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
+)
+
+func (impl *templatedEmailer) SendInvoiceIssuedEmailToRetailers(ctx context.Context, retailers []Recipient, invoiceID string, invoiceNumber string, storeName string, amountDue string, dueDate string) error {
+	impl.Logger.Debug("sending `Invoice Issued` email to retailer")
+
+	for _, r := range retailers {
+		// FOR TESTING PURPOSES ONLY.
+		tmpl, err := impl.parseTemplate(r.Locale, "retailer_invoice_issued.html")
+		if err != nil {
+			impl.Logger.Error("parsing error", slog.Any("error", err))
+			return err
+		}
+
+		var processed bytes.Buffer
+
+		// Render the HTML template with our data.
+		data := struct {
+			InvoiceNumber string
+			StoreName     string
+			AmountDue     string
+			DueDate       string
+			DetailLink    string
+		}{
+			InvoiceNumber: invoiceNumber,
+			StoreName:     storeName,
+			AmountDue:     amountDue,
+			DueDate:       dueDate,
+			DetailLink:    fmt.Sprintf("https://%v/invoice/%v", impl.Emailer.GetDomainName(), invoiceID),
+		}
+		if err := tmpl.Execute(&processed, data); err != nil {
+			impl.Logger.Error("template execution error", slog.Any("error", err))
+			return err
+		}
+		body := processed.String() // DEVELOPERS NOTE: Convert our long sequence of data into a string.
+
+		if err := impl.send(ctx, fmt.Sprintf(i18n.T(r.Locale, "Invoice %v from CPS"), invoiceNumber), r.Email, body); err != nil {
+			impl.Logger.Error("sending error", slog.Any("error", err))
+			return err
+		}
+		impl.Logger.Debug("sent `Invoice Issued` email to retailer",
+			slog.String("r.Email", r.Email))
+	}
+	return nil
+}
@@ -4,19 +4,18 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"path"
-	"text/template"
 
 	"log/slog"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
 )
 
-func (impl *templatedEmailer) SendRetailerStoreActiveEmailToRetailers(retailerEmails []string, storeName string) error {
+func (impl *templatedEmailer) SendRetailerStoreActiveEmailToRetailers(ctx context.Context, retailers []Recipient, storeName string) error {
 	impl.Logger.Debug("sending `Store Active` email to retailer")
 
-	for _, retailerEmail := range retailerEmails {
+	for _, r := range retailers {
 		// FOR TESTING PURPOSES ONLY.
-		fp := path.Join("templates", "retailer_store_active.html")
-		tmpl, err := template.ParseFiles(fp)
+		tmpl, err := impl.parseTemplate(r.Locale, "retailer_store_active.html")
 		if err != nil {
 			impl.Logger.Error("parsing error", slog.Any("error", err))
 			return err
@@ -38,12 +37,12 @@ func (impl *templatedEmailer) SendRetailerStoreActiveEmailToRetailers(retailerEm
 		}
 		body := processed.String() // DEVELOPERS NOTE: Convert our long sequence of data into a string.
 
-		if err := impl.Emailer.Send(context.Background(), impl.Emailer.GetSenderEmail(), "Your store is active", retailerEmail, body); err != nil {
+		if err := impl.send(ctx, i18n.T(r.Locale, "Your store is active"), r.Email, body); err != nil {
 			impl.Logger.Error("sending error", slog.Any("error", err))
 			return err
 		}
 		impl.Logger.Debug("sent `Store Active` email  to retailer",
-			slog.String("retailerEmail", retailerEmail))
+			slog.String("r.Email", r.Email))
 	}
 	return nil
 }
@@ -4,19 +4,18 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"path"
-	"text/template"
 
 	"log/slog"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
 )
 
-func (impl *templatedEmailer) SendNewComicSubmissionEmailToRetailers(retailerEmails []string, submissionID string, storeName string, item string, cpsrn string, serviceTypeName string) error {
+func (impl *templatedEmailer) SendNewComicSubmissionEmailToRetailers(ctx context.Context, retailers []Recipient, submissionID string, storeName string, item string, cpsrn string, serviceTypeName string) error {
 	impl.Logger.Debug("sending `Submitted to CPS` to retailer", slog.String("submissionID", submissionID))
 
-	for _, retailerEmail := range retailerEmails {
+	for _, r := range retailers {
 		// FOR TESTING PURPOSES ONLY.
-		fp := path.Join("templates", "retailer_submission_created.html")
-		tmpl, err := template.ParseFiles(fp)
+		tmpl, err := impl.parseTemplate(r.Locale, "retailer_submission_created.html")
 		if err != nil {
 			impl.Logger.Error("parsing error", slog.Any("error", err))
 			return err
@@ -35,7 +34,7 @@ func (impl *templatedEmailer) SendNewComicSubmissionEmailToRetailers(retailerEma
 			StoreName:       storeName,
 			Item:            item,
 			CPSRN:           cpsrn,
-			ServiceTypeName: serviceTypeName,
+			ServiceTypeName: i18n.T(r.Locale, serviceTypeName),
 			DetailLink:      fmt.Sprintf("https://%v/submission/%v", impl.Emailer.GetDomainName(), submissionID),
 		}
 		if err := tmpl.Execute(&processed, data); err != nil {
@@ -44,12 +43,12 @@ func (impl *templatedEmailer) SendNewComicSubmissionEmailToRetailers(retailerEma
 		}
 		body := processed.String() // DEVELOPERS NOTE: Convert our long sequence of data into a string.
 
-		if err := impl.Emailer.Send(context.Background(), impl.Emailer.GetSenderEmail(), "New Comic Submission", retailerEmail, body); err != nil {
+		if err := impl.send(ctx, i18n.T(r.Locale, "New Comic Submission"), r.Email, body); err != nil {
 			impl.Logger.Error("sending error", slog.Any("error", err))
 			return err
 		}
 		impl.Logger.Debug("sent `Submitted to CPS` email to retailer",
-			slog.String("retailerEmail", retailerEmail),
+			slog.String("r.Email", r.Email),
 			slog.Any("submissionID", submissionID))
 	}
 	return nil
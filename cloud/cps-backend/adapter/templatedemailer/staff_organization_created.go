@@ -4,19 +4,18 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"path"
-	"text/template"
 
 	"log/slog"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
 )
 
-func (impl *templatedEmailer) SendNewStoreEmailToStaff(staffEmails []string, storeID string) error {
+func (impl *templatedEmailer) SendNewStoreEmailToStaff(ctx context.Context, staff []Recipient, storeID string) error {
 	impl.Logger.Debug("sending `New Registration` to admin staff", slog.String("storeID", storeID))
 
-	for _, staffEmail := range staffEmails {
+	for _, r := range staff {
 		// FOR TESTING PURPOSES ONLY.
-		fp := path.Join("templates", "staff_store_created.html")
-		tmpl, err := template.ParseFiles(fp)
+		tmpl, err := impl.parseTemplate(r.Locale, "staff_store_created.html")
 		if err != nil {
 			impl.Logger.Error("parsing error", slog.Any("error", err))
 			return err
@@ -36,12 +35,12 @@ func (impl *templatedEmailer) SendNewStoreEmailToStaff(staffEmails []string, sto
 		}
 		body := processed.String() // DEVELOPERS NOTE: Convert our long sequence of data into a string.
 
-		if err := impl.Emailer.Send(context.Background(), impl.Emailer.GetSenderEmail(), "New CPS Retail Partner Application", staffEmail, body); err != nil {
+		if err := impl.send(ctx, i18n.T(r.Locale, "New CPS Retail Partner Application"), r.Email, body); err != nil {
 			impl.Logger.Error("sending error", slog.Any("error", err))
 			return err
 		}
 		impl.Logger.Debug("sent `New Registration` email",
-			slog.String("staffEmail", staffEmail),
+			slog.String("r.Email", r.Email),
 			slog.Any("storeID", storeID))
 	}
 	return nil
@@ -0,0 +1,77 @@
+package templatedemailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"log/slog"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
+)
+
+func (impl *templatedEmailer) SendReconciliationReportEmailToStaff(ctx context.Context, staff []Recipient, reportID string, period string, chargeCount int64, submissionCount int64, missingReceiptCount int64, missingUserPurchaseCount int64, amountMismatchCount int64, orphanChargeCount int64, missingChargeCount int64, discrepancyCount int64) error {
+	impl.Logger.Debug("sending `Payment Reconciliation Report` to admin staff", slog.String("reportID", reportID))
+
+	for _, r := range staff {
+		// FOR TESTING PURPOSES ONLY.
+		tmpl, err := impl.parseTemplate(r.Locale, "staff_reconciliation_report.html")
+		if err != nil {
+			impl.Logger.Error("parsing error",
+				slog.Any("error", err))
+			return err
+		}
+
+		var processed bytes.Buffer
+
+		// Render the HTML template with our data.
+		data := struct {
+			Period                   string
+			ChargeCount              int64
+			SubmissionCount          int64
+			MissingReceiptCount      int64
+			MissingUserPurchaseCount int64
+			AmountMismatchCount      int64
+			OrphanChargeCount        int64
+			MissingChargeCount       int64
+			DiscrepancyCount         int64
+			DetailLink               string
+		}{
+			Period:                   period,
+			ChargeCount:              chargeCount,
+			SubmissionCount:          submissionCount,
+			MissingReceiptCount:      missingReceiptCount,
+			MissingUserPurchaseCount: missingUserPurchaseCount,
+			AmountMismatchCount:      amountMismatchCount,
+			OrphanChargeCount:        orphanChargeCount,
+			MissingChargeCount:       missingChargeCount,
+			DiscrepancyCount:         discrepancyCount,
+			DetailLink:               fmt.Sprintf("https://%v/admin/reconciliation-report/%v", impl.Emailer.GetDomainName(), reportID),
+		}
+		if err := tmpl.Execute(&processed, data); err != nil {
+			impl.Logger.Error("template execution error",
+				slog.Any("data", data),
+				slog.Any("error", err),
+			)
+			return err
+		}
+		body := processed.String() // DEVELOPERS NOTE: Convert our long sequence of data into a string.
+
+		subject := i18n.T(r.Locale, "Payment Reconciliation Report")
+		if discrepancyCount > 0 {
+			subject = fmt.Sprintf(i18n.T(r.Locale, "Payment Reconciliation Report: %v discrepancies found"), discrepancyCount)
+		}
+
+		if err := impl.send(ctx, subject, r.Email, body); err != nil {
+			impl.Logger.Error("sending error",
+				slog.Any("r.Email", r.Email),
+				slog.Any("reportID", reportID),
+				slog.Any("error", err))
+			return err
+		}
+		impl.Logger.Debug("sent `Payment Reconciliation Report` email",
+			slog.String("r.Email", r.Email),
+			slog.Any("reportID", reportID))
+	}
+	return nil
+}
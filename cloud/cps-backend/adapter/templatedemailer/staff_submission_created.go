@@ -4,19 +4,18 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"path"
-	"text/template"
 
 	"log/slog"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
 )
 
-func (impl *templatedEmailer) SendNewComicSubmissionEmailToStaff(staffEmails []string, submissionID string, storeName string, item string, cpsrn string, serviceTypeName string) error {
+func (impl *templatedEmailer) SendNewComicSubmissionEmailToStaff(ctx context.Context, staff []Recipient, submissionID string, storeName string, item string, cpsrn string, serviceTypeName string) error {
 	impl.Logger.Debug("sending `New Comic Submission` to admin staff", slog.String("submissionID", submissionID))
 
-	for _, staffEmail := range staffEmails {
+	for _, r := range staff {
 		// FOR TESTING PURPOSES ONLY.
-		fp := path.Join("templates", "staff_submission_created.html")
-		tmpl, err := template.ParseFiles(fp)
+		tmpl, err := impl.parseTemplate(r.Locale, "staff_submission_created.html")
 		if err != nil {
 			impl.Logger.Error("parsing error",
 				slog.Any("error", err))
@@ -36,7 +35,7 @@ func (impl *templatedEmailer) SendNewComicSubmissionEmailToStaff(staffEmails []s
 			StoreName:       storeName,
 			Item:            item,
 			CPSRN:           cpsrn,
-			ServiceTypeName: serviceTypeName,
+			ServiceTypeName: i18n.T(r.Locale, serviceTypeName),
 			DetailLink:      fmt.Sprintf("https://%v/admin/submission/%v", impl.Emailer.GetDomainName(), submissionID),
 		}
 		if err := tmpl.Execute(&processed, data); err != nil {
@@ -52,15 +51,15 @@ func (impl *templatedEmailer) SendNewComicSubmissionEmailToStaff(staffEmails []s
 		}
 		body := processed.String() // DEVELOPERS NOTE: Convert our long sequence of data into a string.
 
-		if err := impl.Emailer.Send(context.Background(), impl.Emailer.GetSenderEmail(), "New Comic Submission", staffEmail, body); err != nil {
+		if err := impl.send(ctx, i18n.T(r.Locale, "New Comic Submission"), r.Email, body); err != nil {
 			impl.Logger.Error("sending error",
-				slog.Any("staffEmail", staffEmail),
+				slog.Any("r.Email", r.Email),
 				slog.Any("submissionID", submissionID),
 				slog.Any("error", err))
 			return err
 		}
 		impl.Logger.Debug("sent `New Comic Submission` email",
-			slog.String("staffEmail", staffEmail),
+			slog.String("r.Email", r.Email),
 			slog.Any("submissionID", submissionID))
 	}
 	return nil
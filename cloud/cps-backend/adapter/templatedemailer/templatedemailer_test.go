@@ -0,0 +1,122 @@
+package templatedemailer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/emailer"
+	outbox_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/outbox/datastore"
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+// memoryOutbox keeps the queued messages instead of saving them, the other
+// methods of the storer are not used.
+type memoryOutbox struct {
+	outbox_s.OutboxMessageStorer
+	messages []*outbox_s.OutboxMessage
+}
+
+func (o *memoryOutbox) Create(ctx context.Context, m *outbox_s.OutboxMessage) error {
+	o.messages = append(o.messages, m)
+	return nil
+}
+
+// newTestTemplatedEmailer returns the templated emailer queueing to an outbox
+// in memory and the emailer delivering to a memory transport, `dispatch`
+// delivers the queued messages like the outbox dispatcher does.
+func newTestTemplatedEmailer(t *testing.T) (*templatedEmailer, *emailer.MemoryTransport, func()) {
+	// The templates are loaded relative to the root of the project.
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir("../.."); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	cfg := &c.Conf{}
+	cfg.Emailer.SenderEmail = "noreply@cpsapp.ca"
+	cfg.AppServer.AppDomainName = "cpsapp.ca"
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	mt := emailer.NewMemoryTransport()
+	e := emailer.NewEmailerWithTransport(cfg, logger, nil, mt)
+	outbox := &memoryOutbox{}
+	te := NewTemplatedEmailer(cfg, logger, nil, e, outbox).(*templatedEmailer)
+
+	dispatch := func() {
+		for _, m := range outbox.messages {
+			if err := e.SendMessage(context.Background(), m.Email.Message()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		outbox.messages = nil
+	}
+	return te, mt, dispatch
+}
+
+func TestSendForgotPasswordEmail(t *testing.T) {
+	te, mt, dispatch := newTestTemplatedEmailer(t)
+
+	if err := te.SendForgotPasswordEmail(context.Background(), "en", "bob@example.com", "abc123", "Bob"); err != nil {
+		t.Fatal(err)
+	}
+	if len(mt.Messages()) != 0 {
+		t.Fatalf("Expected the email to wait in the outbox.")
+	}
+	dispatch()
+
+	messages := mt.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("Incorrect number of messages, got: %v, want: %v.", len(messages), 1)
+	}
+	m := messages[0]
+	if m.Sender != "noreply@cpsapp.ca" || len(m.Recipients) != 1 || m.Recipients[0] != "bob@example.com" {
+		t.Errorf("Incorrect sender or recipients, got: %v, %v.", m.Sender, m.Recipients)
+	}
+	if m.Subject != "Forgot Password" {
+		t.Errorf("Incorrect subject, got: %v.", m.Subject)
+	}
+	if !strings.Contains(m.HTML, "https://cpsapp.ca/password-reset?q=abc123") {
+		t.Errorf("Expected the reset link in the body.")
+	}
+}
+
+func TestSendMessageKeepsTextAndAttachments(t *testing.T) {
+	te, mt, dispatch := newTestTemplatedEmailer(t)
+
+	err := te.sendMessage(context.Background(), &emailer.Message{
+		Recipients: []string{"bob@example.com", "alice@example.com"},
+		Subject:    "Your invoice",
+		HTML:       "<p>Your invoice is attached.</p>",
+		Text:       "Your invoice is attached.",
+		Attachments: []*emailer.Attachment{
+			{Filename: "invoice.pdf", ContentType: "application/pdf", Data: []byte("%PDF")},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dispatch()
+
+	messages := mt.Messages()
+	if len(messages) != 2 {
+		t.Fatalf("Incorrect number of messages, got: %v, want: %v.", len(messages), 2)
+	}
+	for i, want := range []string{"bob@example.com", "alice@example.com"} {
+		m := messages[i]
+		if len(m.Recipients) != 1 || m.Recipients[0] != want {
+			t.Errorf("Incorrect recipients, got: %v, want: %v.", m.Recipients, want)
+		}
+		if m.Text != "Your invoice is attached." {
+			t.Errorf("Incorrect text, got: %v.", m.Text)
+		}
+		if len(m.Attachments) != 1 || m.Attachments[0].Filename != "invoice.pdf" || string(m.Attachments[0].Data) != "%PDF" {
+			t.Errorf("Incorrect attachments, got: %+v.", m.Attachments)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package templatedemailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"log/slog"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
+)
+
+func (impl *templatedEmailer) SendUserAccountLockedEmail(ctx context.Context, locale string, email, firstName string, failedCount int, lockedUntil string) error {
+	impl.Logger.Debug("sending user account locked email...")
+
+	// FOR TESTING PURPOSES ONLY.
+	tmpl, err := impl.parseTemplate(locale, "user_account_locked.html")
+	if err != nil {
+		impl.Logger.Error("parsing error", slog.Any("error", err))
+		return err
+	}
+
+	var processed bytes.Buffer
+
+	// Render the HTML template with our data.
+	data := struct {
+		FirstName   string
+		FailedCount int
+		LockedUntil string
+		ResetLink   string
+	}{
+		FirstName:   firstName,
+		FailedCount: failedCount,
+		LockedUntil: lockedUntil,
+		ResetLink:   fmt.Sprintf("https://%v/forgot-password", impl.Emailer.GetDomainName()),
+	}
+	if err := tmpl.Execute(&processed, data); err != nil {
+		impl.Logger.Error("template execution error", slog.Any("error", err))
+		return err
+	}
+	body := processed.String() // DEVELOPERS NOTE: Convert our long sequence of data into a string.
+
+	if err := impl.send(ctx, i18n.T(locale, "Your account was locked"), email, body); err != nil {
+		impl.Logger.Error("sending error", slog.Any("error", err))
+		return err
+	}
+	impl.Logger.Debug("user account locked email sent")
+	return nil
+}
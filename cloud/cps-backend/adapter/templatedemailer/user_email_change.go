@@ -0,0 +1,84 @@
+package templatedemailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+
+	"log/slog"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
+)
+
+func (impl *templatedEmailer) SendEmailChangeVerificationEmail(ctx context.Context, locale string, email, firstName, verificationCode, expiresAt string) error {
+	impl.Logger.Debug("sending email change verification email...")
+
+	// FOR TESTING PURPOSES ONLY.
+	tmpl, err := impl.parseTemplate(locale, "user_email_change_verification.html")
+	if err != nil {
+		impl.Logger.Error("parsing error", slog.Any("error", err))
+		return err
+	}
+
+	var processed bytes.Buffer
+
+	// Render the HTML template with our data.
+	data := struct {
+		FirstName   string
+		ExpiresAt   string
+		ConfirmLink string
+	}{
+		FirstName:   firstName,
+		ExpiresAt:   expiresAt,
+		ConfirmLink: fmt.Sprintf("https://%v/confirm-email-change?q=%v", impl.Emailer.GetDomainName(), url.QueryEscape(verificationCode)),
+	}
+	if err := tmpl.Execute(&processed, data); err != nil {
+		impl.Logger.Error("template execution error", slog.Any("error", err))
+		return err
+	}
+	body := processed.String() // DEVELOPERS NOTE: Convert our long sequence of data into a string.
+
+	if err := impl.send(ctx, i18n.T(locale, "Confirm your new email"), email, body); err != nil {
+		impl.Logger.Error("sending error", slog.Any("error", err))
+		return err
+	}
+	impl.Logger.Debug("email change verification email sent")
+	return nil
+}
+
+func (impl *templatedEmailer) SendEmailChangeNoticeEmail(ctx context.Context, locale string, email, firstName, newEmail string) error {
+	impl.Logger.Debug("sending email change notice email...")
+
+	// FOR TESTING PURPOSES ONLY.
+	tmpl, err := impl.parseTemplate(locale, "user_email_change_notice.html")
+	if err != nil {
+		impl.Logger.Error("parsing error", slog.Any("error", err))
+		return err
+	}
+
+	var processed bytes.Buffer
+
+	// Render the HTML template with our data.
+	data := struct {
+		FirstName string
+		NewEmail  string
+		ResetLink string
+	}{
+		FirstName: firstName,
+		NewEmail:  newEmail,
+		ResetLink: fmt.Sprintf("https://%v/forgot-password", impl.Emailer.GetDomainName()),
+	}
+	if err := tmpl.Execute(&processed, data); err != nil {
+		impl.Logger.Error("template execution error", slog.Any("error", err))
+		return err
+	}
+	body := processed.String() // DEVELOPERS NOTE: Convert our long sequence of data into a string.
+
+	if err := impl.send(ctx, i18n.T(locale, "Your email is being changed"), email, body); err != nil {
+		impl.Logger.Error("sending error", slog.Any("error", err))
+		return err
+	}
+	impl.Logger.Debug("email change notice email sent")
+	return nil
+}
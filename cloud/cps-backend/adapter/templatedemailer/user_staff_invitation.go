@@ -0,0 +1,55 @@
+package templatedemailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+
+	"log/slog"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
+)
+
+func (impl *templatedEmailer) SendStaffInvitationEmail(ctx context.Context, locale string, email, firstName, inviterName, storeName, roleName, token, expiresAt string) error {
+	impl.Logger.Debug("sending staff invitation email...")
+
+	// FOR TESTING PURPOSES ONLY.
+	tmpl, err := impl.parseTemplate(locale, "user_staff_invitation.html")
+	if err != nil {
+		impl.Logger.Error("parsing error", slog.Any("error", err))
+		return err
+	}
+
+	var processed bytes.Buffer
+
+	// Render the HTML template with our data.
+	data := struct {
+		FirstName   string
+		InviterName string
+		StoreName   string
+		RoleName    string
+		ExpiresAt   string
+		AcceptLink  string
+	}{
+		FirstName:   firstName,
+		InviterName: inviterName,
+		StoreName:   storeName,
+		RoleName:    i18n.T(locale, roleName),
+		ExpiresAt:   expiresAt,
+		AcceptLink:  fmt.Sprintf("https://%v/invitation?q=%v", impl.Emailer.GetDomainName(), url.QueryEscape(token)),
+	}
+	if err := tmpl.Execute(&processed, data); err != nil {
+		impl.Logger.Error("template execution error", slog.Any("error", err))
+		return err
+	}
+	body := processed.String() // DEVELOPERS NOTE: Convert our long sequence of data into a string.
+
+	subject := fmt.Sprintf(i18n.T(locale, "You have been invited to join %v"), storeName)
+	if err := impl.send(ctx, subject, email, body); err != nil {
+		impl.Logger.Error("sending error", slog.Any("error", err))
+		return err
+	}
+	impl.Logger.Debug("staff invitation email sent")
+	return nil
+}
@@ -0,0 +1,58 @@
+package templatedemailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"log/slog"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
+)
+
+func (impl *templatedEmailer) SendSubmissionStatusChangedEmail(ctx context.Context, locale string, email, firstName, submissionID, item, cpsrn, statusName, grade, unsubscribeToken string) error {
+	impl.Logger.Debug("sending submission status changed email...", slog.String("submissionID", submissionID))
+
+	// FOR TESTING PURPOSES ONLY.
+	tmpl, err := impl.parseTemplate(locale, "user_submission_status_changed.html")
+	if err != nil {
+		impl.Logger.Error("parsing error", slog.Any("error", err))
+		return err
+	}
+
+	var processed bytes.Buffer
+
+	// Render the HTML template with our data.
+	data := struct {
+		FirstName       string
+		Item            string
+		CPSRN           string
+		StatusName      string
+		Grade           string
+		DetailLink      string
+		RegistryLink    string
+		UnsubscribeLink string
+	}{
+		FirstName:       firstName,
+		Item:            item,
+		CPSRN:           cpsrn,
+		StatusName:      i18n.T(locale, statusName),
+		Grade:           grade,
+		DetailLink:      fmt.Sprintf("https://%v/submission/%v", impl.Emailer.GetDomainName(), submissionID),
+		RegistryLink:    fmt.Sprintf("https://%v/cpsrn?v=%v", impl.Emailer.GetFrontendDomainName(), cpsrn),
+		UnsubscribeLink: fmt.Sprintf("https://%v/unsubscribe?token=%v", impl.Emailer.GetFrontendDomainName(), unsubscribeToken),
+	}
+	if err := tmpl.Execute(&processed, data); err != nil {
+		impl.Logger.Error("template execution error", slog.Any("error", err))
+		return err
+	}
+	body := processed.String() // DEVELOPERS NOTE: Convert our long sequence of data into a string.
+
+	subject := fmt.Sprintf(i18n.T(locale, "Your submission is now %v"), i18n.T(locale, statusName))
+	if err := impl.send(ctx, subject, email, body); err != nil {
+		impl.Logger.Error("sending error", slog.Any("error", err))
+		return err
+	}
+	impl.Logger.Debug("submission status changed email sent", slog.String("submissionID", submissionID))
+	return nil
+}
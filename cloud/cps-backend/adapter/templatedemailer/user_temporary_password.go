@@ -3,18 +3,17 @@ package templatedemailer
 import (
 	"bytes"
 	"context"
-	"path"
-	"text/template"
 
 	"log/slog"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
 )
 
-func (impl *templatedEmailer) SendNewUserTemporaryPasswordEmail(email, firstName, temporaryPassword string) error {
+func (impl *templatedEmailer) SendNewUserTemporaryPasswordEmail(ctx context.Context, locale string, email, firstName, temporaryPassword string) error {
 	impl.Logger.Debug("sending new user temporary password email...")
 
 	// FOR TESTING PURPOSES ONLY.
-	fp := path.Join("templates", "user_temporary_password.html")
-	tmpl, err := template.ParseFiles(fp)
+	tmpl, err := impl.parseTemplate(locale, "user_temporary_password.html")
 	if err != nil {
 		impl.Logger.Error("parsing error", slog.Any("error", err))
 		return err
@@ -40,7 +39,7 @@ func (impl *templatedEmailer) SendNewUserTemporaryPasswordEmail(email, firstName
 	}
 	body := processed.String() // DEVELOPERS NOTE: Convert our long sequence of data into a string.
 
-	if err := impl.Emailer.Send(context.Background(), impl.Emailer.GetSenderEmail(), "Welcome to your new account", email, body); err != nil {
+	if err := impl.send(ctx, i18n.T(locale, "Welcome to your new account"), email, body); err != nil {
 		impl.Logger.Error("sending error", slog.Any("error", err))
 		return err
 	}
@@ -0,0 +1,89 @@
+package webhookpublisher
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	webhook_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/datastore"
+	whd_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhookdelivery/datastore"
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+// Publisher queues the events of a store for the webhooks of the store.
+//
+// The events are not sent right away, the deliveries are saved using the
+// context so when called inside a transaction the event is only sent if the
+// transaction commits.
+type Publisher interface {
+	Publish(ctx context.Context, storeID primitive.ObjectID, event string, data any) error
+}
+
+// Payload is the body of every request we send to the webhooks.
+type Payload struct {
+	ID        primitive.ObjectID `json:"id"`
+	Event     string             `json:"event"`
+	CreatedAt time.Time          `json:"created_at"`
+	Data      any                `json:"data"`
+}
+
+type publisher struct {
+	Logger                *slog.Logger
+	WebhookStorer         webhook_s.WebhookStorer
+	WebhookDeliveryStorer whd_s.WebhookDeliveryStorer
+}
+
+func NewPublisher(cfg *c.Conf, logger *slog.Logger, wh_storer webhook_s.WebhookStorer, whd_storer whd_s.WebhookDeliveryStorer) Publisher {
+	logger.Debug("webhook publisher initializing...")
+	logger.Debug("webhook publisher initialized")
+
+	return &publisher{
+		Logger:                logger,
+		WebhookStorer:         wh_storer,
+		WebhookDeliveryStorer: whd_storer,
+	}
+}
+
+func (impl *publisher) Publish(ctx context.Context, storeID primitive.ObjectID, event string, data any) error {
+	if storeID.IsZero() {
+		return nil
+	}
+	webhooks, err := impl.WebhookStorer.ListActiveByStoreIDAndEvent(ctx, storeID, event)
+	if err != nil {
+		impl.Logger.Error("database list active webhooks error", slog.Any("error", err))
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	// Every webhook is sent the same event so partners with more than one
+	// endpoint can tell it is the same event.
+	payload := &Payload{
+		ID:        primitive.NewObjectID(),
+		Event:     event,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		impl.Logger.Error("failed marshalling webhook payload", slog.Any("error", err))
+		return err
+	}
+
+	for _, wh := range webhooks {
+		d := whd_s.NewDelivery(wh.ID, wh.StoreID, payload.ID, event, wh.URL, string(body))
+		if err := impl.WebhookDeliveryStorer.Create(ctx, d); err != nil {
+			impl.Logger.Error("database create webhook delivery error", slog.Any("error", err))
+			return err
+		}
+		impl.Logger.Debug("webhook delivery queued",
+			slog.Any("webhook_id", wh.ID),
+			slog.Any("webhook_delivery_id", d.ID),
+			slog.String("event", event))
+	}
+	return nil
+}
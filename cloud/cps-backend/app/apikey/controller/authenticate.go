@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"log/slog"
+	"time"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/apikey/datastore"
+	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+func (impl *APIKeyControllerImpl) Authenticate(ctx context.Context, key string) (*user_s.User, *domain.APIKey, error) {
+	id, secret, ok := parseKey(key)
+	if !ok {
+		impl.Logger.Warn("malformed api key")
+		return nil, nil, nil
+	}
+
+	// The record is looked up on every request so revoking a key takes
+	// effect right away.
+	m, err := impl.APIKeyStorer.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if m == nil {
+		impl.Logger.Warn("api key not found", slog.String("api_key_id", id.Hex()))
+		return nil, nil, nil
+	}
+	if !m.ExpiresAt.IsZero() && time.Now().After(m.ExpiresAt) {
+		impl.Logger.Warn("api key expired", slog.String("api_key_id", id.Hex()))
+		return nil, nil, nil
+	}
+
+	digest := sha256.Sum256([]byte(key))
+	cached, ok := impl.verified.Load(m.ID)
+	if !ok || subtle.ConstantTimeCompare(cached.([]byte), digest[:]) != 1 {
+		match, err := impl.Password.ComparePasswordAndHash(secret, m.SecretHash)
+		if err != nil {
+			impl.Logger.Error("api key compare error", slog.Any("error", err))
+			return nil, nil, err
+		}
+		if !match {
+			impl.Logger.Warn("api key secret does not match", slog.String("api_key_id", id.Hex()))
+			return nil, nil, nil
+		}
+		impl.verified.Store(m.ID, digest[:])
+	}
+
+	// The owner must still be active store staff of the store the key was
+	// created for, otherwise the key stops working.
+	u, err := impl.UserStorer.GetByID(ctx, m.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if u == nil || u.Status != user_s.UserStatusActive || u.StoreID != m.StoreID {
+		impl.Logger.Warn("api key owner is not allowed", slog.String("api_key_id", id.Hex()))
+		return nil, nil, nil
+	}
+	if r := permission.GetRole(u.Role); r == nil || r.Scope != permission.ScopeStore {
+		impl.Logger.Warn("api key owner is not store staff", slog.String("api_key_id", id.Hex()))
+		return nil, nil, nil
+	}
+
+	// Keep track of when the key was last used, we throttle the writes so
+	// we do not update the database on every request.
+	if time.Since(m.LastUsedAt) > time.Minute {
+		m.LastUsedAt = time.Now()
+		m.LastUsedIPAddress, _ = ctx.Value(constants.SessionIPAddress).(string)
+		if err := impl.APIKeyStorer.UpdateByID(ctx, m); err != nil {
+			impl.Logger.Error("api key update error", slog.Any("error", err))
+		}
+	}
+	return u, m, nil
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/apikey/datastore"
+	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/password"
+)
+
+// APIKeyController Interface for the personal api keys business logic controller.
+type APIKeyController interface {
+	Create(ctx context.Context, req *APIKeyCreateRequestIDO) (*APIKeyCreateResponseIDO, error)
+	ListByFilter(ctx context.Context, f *domain.APIKeyListFilter) ([]*domain.APIKey, error)
+	DeleteByID(ctx context.Context, id primitive.ObjectID) error
+	// Authenticate returns the owner of the key and the key, or nil if the
+	// key is not valid.
+	Authenticate(ctx context.Context, key string) (*user_s.User, *domain.APIKey, error)
+}
+
+type APIKeyControllerImpl struct {
+	Config       *config.Conf
+	Logger       *slog.Logger
+	Password     password.Provider
+	DbClient     *mongo.Client
+	UserStorer   user_s.UserStorer
+	APIKeyStorer domain.APIKeyStorer
+
+	// verified remembers the digest of the last key which matched the
+	// stored hash of each api key so we do not run the slow password hash
+	// comparison on every request.
+	verified sync.Map
+}
+
+func NewController(
+	appCfg *config.Conf,
+	loggerp *slog.Logger,
+	passwordp password.Provider,
+	client *mongo.Client,
+	usr_storer user_s.UserStorer,
+	key_storer domain.APIKeyStorer,
+) APIKeyController {
+	loggerp.Debug("api key controller initialization started...")
+	s := &APIKeyControllerImpl{
+		Config:       appCfg,
+		Logger:       loggerp,
+		Password:     passwordp,
+		DbClient:     client,
+		UserStorer:   usr_storer,
+		APIKeyStorer: key_storer,
+	}
+	s.Logger.Debug("api key controller initialized")
+	return s
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/apikey/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+// ungrantablePermissions may never be given to an api key so a leaked key
+// cannot be used to take over accounts or to send our events to another
+// server through a webhook.
+var ungrantablePermissions = []permission.Permission{
+	permission.UserManage,
+	permission.UserManageStore,
+	permission.UserAssignRole,
+	permission.SessionManage,
+	permission.WebhookManage,
+}
+
+type APIKeyCreateRequestIDO struct {
+	Name string `json:"name"`
+	// Permissions only limit the endpoints which check a permission, a key
+	// without any can still read everything the user can see without one,
+	// like the customers and submissions of their own store.
+	Permissions []permission.Permission `json:"permissions"`
+	ReadOnly    bool                    `json:"read_only"`
+	ExpiresAt   time.Time               `json:"expires_at,omitempty"`
+}
+
+// APIKeyCreateResponseIDO includes the full key, it is only ever returned
+// once so the user must copy it right away.
+type APIKeyCreateResponseIDO struct {
+	APIKey *domain.APIKey `json:"api_key"`
+	Key    string         `json:"key"`
+}
+
+func validateCreateRequest(ctx context.Context, dirtyData *APIKeyCreateRequestIDO) error {
+	e := make(map[string]string)
+
+	if dirtyData.Name == "" {
+		e["name"] = "missing value"
+	}
+	if len(dirtyData.Name) > 255 {
+		e["name"] = "too long"
+	}
+	role, _ := ctx.Value(constants.SessionUserRole).(int8)
+	for _, p := range dirtyData.Permissions {
+		if slices.Contains(ungrantablePermissions, p) || !permission.Has(role, p) {
+			e["permissions"] = "cannot grant " + string(p)
+			break
+		}
+	}
+	if !dirtyData.ExpiresAt.IsZero() && dirtyData.ExpiresAt.Before(time.Now()) {
+		e["expires_at"] = "must be in the future"
+	}
+
+	if len(e) != 0 {
+		return httperror.NewForBadRequest(&e)
+	}
+	return nil
+}
+
+// checkNotAPIKey returns a `403 Forbidden` error if the request was made
+// with an api key, keys may not be used to manage other keys.
+func checkNotAPIKey(ctx context.Context) error {
+	if keyID, ok := ctx.Value(constants.SessionAPIKeyID).(primitive.ObjectID); ok && !keyID.IsZero() {
+		return httperror.NewForForbiddenWithSingleField("message", "api keys cannot manage api keys")
+	}
+	return nil
+}
+
+func (impl *APIKeyControllerImpl) Create(ctx context.Context, req *APIKeyCreateRequestIDO) (*APIKeyCreateResponseIDO, error) {
+	if err := checkNotAPIKey(ctx); err != nil {
+		return nil, err
+	}
+
+	// Keys are for the integrations of a store and are limited to the data
+	// of the owner's store.
+	if permission.Scope(ctx) != permission.ScopeStore {
+		return nil, httperror.NewForForbiddenWithSingleField("message", "only store staff may create api keys")
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if err := validateCreateRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		impl.Logger.Error("secret generation error", slog.Any("error", err))
+		return nil, err
+	}
+	secretHash, err := impl.Password.GenerateHashFromPassword(secret)
+	if err != nil {
+		impl.Logger.Error("hashing error", slog.Any("error", err))
+		return nil, err
+	}
+
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	userName, _ := ctx.Value(constants.SessionUserName).(string)
+	storeID, _ := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+	storeName, _ := ctx.Value(constants.SessionUserStoreName).(string)
+
+	m := &domain.APIKey{
+		ID:                  primitive.NewObjectID(),
+		UserID:              userID,
+		UserName:            userName,
+		StoreID:             storeID,
+		StoreName:           storeName,
+		Name:                req.Name,
+		Hint:                secret[len(secret)-4:],
+		SecretHash:          secretHash,
+		SecretHashAlgorithm: impl.Password.AlgorithmName(),
+		Permissions:         req.Permissions,
+		ReadOnly:            req.ReadOnly,
+		ExpiresAt:           req.ExpiresAt,
+		CreatedAt:           time.Now(),
+	}
+	if m.Permissions == nil {
+		m.Permissions = []permission.Permission{}
+	}
+	if err := impl.APIKeyStorer.Create(ctx, m); err != nil {
+		return nil, err
+	}
+	impl.Logger.Info("api key created",
+		slog.String("api_key_id", m.ID.Hex()),
+		slog.String("user_id", userID.Hex()))
+
+	return &APIKeyCreateResponseIDO{
+		APIKey: m,
+		Key:    formatKey(m.ID, secret),
+	}, nil
+}
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+// DeleteByID revokes the key, it stops working right away.
+func (impl *APIKeyControllerImpl) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
+	if err := checkNotAPIKey(ctx); err != nil {
+		return err
+	}
+
+	m, err := impl.APIKeyStorer.GetByID(ctx, id)
+	if err != nil {
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return err
+	}
+	if m == nil {
+		return httperror.NewForBadRequestWithSingleField("id", "api key does not exist")
+	}
+
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	storeID, _ := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+	switch {
+	case m.UserID == userID:
+	case permission.Can(ctx, permission.UserManage):
+	case permission.Can(ctx, permission.UserManageStore) && m.StoreID == storeID:
+	default:
+		return httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+
+	if err := impl.APIKeyStorer.DeleteByID(ctx, id); err != nil {
+		return err
+	}
+	impl.verified.Delete(id)
+	impl.Logger.Info("api key revoked", slog.String("api_key_id", id.Hex()))
+	return nil
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// keyPrefix makes our keys easy to recognize, for example by secret scanners.
+const keyPrefix = "cps"
+
+// newSecret returns the random part of a new key.
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// formatKey returns the key given to the user which is made of the id of the
+// record, used to look it up, and the secret.
+func formatKey(id primitive.ObjectID, secret string) string {
+	return keyPrefix + "_" + id.Hex() + "_" + secret
+}
+
+// parseKey returns the id and secret of the key or false if it is malformed.
+func parseKey(key string) (primitive.ObjectID, string, bool) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != keyPrefix || parts[2] == "" {
+		return primitive.NilObjectID, "", false
+	}
+	id, err := primitive.ObjectIDFromHex(parts[1])
+	if err != nil {
+		return primitive.NilObjectID, "", false
+	}
+	return id, parts[2], true
+}
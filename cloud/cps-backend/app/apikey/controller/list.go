@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/apikey/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+func (impl *APIKeyControllerImpl) ListByFilter(ctx context.Context, f *domain.APIKeyListFilter) ([]*domain.APIKey, error) {
+	if err := checkNotAPIKey(ctx); err != nil {
+		return nil, err
+	}
+
+	// Apply filtering based on ownership and role. Store managers may audit
+	// the keys of their store and everyone else only sees their own keys.
+	switch {
+	case permission.Can(ctx, permission.UserManage):
+	case permission.Can(ctx, permission.UserManageStore):
+		f.StoreID, _ = ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+	default:
+		f.UserID, _ = ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+		f.StoreID = primitive.NilObjectID
+	}
+
+	res, err := impl.APIKeyStorer.ListByFilter(ctx, f)
+	if err != nil {
+		impl.Logger.Error("database list by filter error", slog.Any("error", err))
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,25 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (impl APIKeyStorerImpl) Create(ctx context.Context, m *APIKey) error {
+	if m.ID == primitive.NilObjectID {
+		m.ID = primitive.NewObjectID()
+		impl.Logger.Warn("database insert api key not included id value, created id now.", slog.Any("id", m.ID))
+	}
+
+	_, err := impl.Collection.InsertOne(ctx, m)
+
+	// check for errors in the insertion
+	if err != nil {
+		impl.Logger.Error("database insert error", slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+// APIKey is a long lived credential a retailer creates for their point of
+// sale or inventory system. It acts on behalf of the user who created it and
+// is limited to the permissions picked when it was created. Only the hash of
+// the secret is stored, the full key is shown once on creation.
+type APIKey struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	UserName  string             `bson:"user_name" json:"user_name"`
+	StoreID   primitive.ObjectID `bson:"store_id" json:"store_id"`
+	StoreName string             `bson:"store_name" json:"store_name"`
+	Name      string             `bson:"name" json:"name"`
+	// Hint is the last few characters of the key so users can tell their keys apart.
+	Hint                string `bson:"hint" json:"hint"`
+	SecretHash          string `bson:"secret_hash" json:"-"`
+	SecretHashAlgorithm string `bson:"secret_hash_algorithm" json:"-"`
+	// Permissions the key is limited to on the endpoints which check one,
+	// the endpoints for the user's own data like the customers and
+	// submissions of their store do not so every key can read them.
+	Permissions []permission.Permission `bson:"permissions" json:"permissions"`
+	// ReadOnly keys may only make `GET` requests.
+	ReadOnly bool `bson:"read_only" json:"read_only"`
+	// ExpiresAt is optional, a zero value never expires.
+	ExpiresAt         time.Time `bson:"expires_at" json:"expires_at"`
+	LastUsedAt        time.Time `bson:"last_used_at" json:"last_used_at"`
+	LastUsedIPAddress string    `bson:"last_used_ip_address" json:"last_used_ip_address"`
+	CreatedAt         time.Time `bson:"created_at" json:"created_at"`
+}
+
+// APIKeyListFilter filters the keys by the user or the store, zero values
+// are ignored.
+type APIKeyListFilter struct {
+	UserID  primitive.ObjectID
+	StoreID primitive.ObjectID
+}
+
+// APIKeyStorer Interface for api key.
+type APIKeyStorer interface {
+	Create(ctx context.Context, m *APIKey) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*APIKey, error)
+	UpdateByID(ctx context.Context, m *APIKey) error
+	ListByFilter(ctx context.Context, f *APIKeyListFilter) ([]*APIKey, error)
+	DeleteByID(ctx context.Context, id primitive.ObjectID) error
+	// DeleteAllByUserID deletes every key of the user and returns the number deleted.
+	DeleteAllByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error)
+}
+
+type APIKeyStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) APIKeyStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("api_keys")
+
+	// The following few lines of code will create the index for our app for
+	// this colleciton.
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "store_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &APIKeyStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
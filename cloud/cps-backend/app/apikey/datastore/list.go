@@ -0,0 +1,36 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ListByFilter returns the keys with the newest first. Users only have a
+// handful of keys so we do not paginate.
+func (impl APIKeyStorerImpl) ListByFilter(ctx context.Context, f *APIKeyListFilter) ([]*APIKey, error) {
+	filter := bson.M{}
+	if !f.UserID.IsZero() {
+		filter["user_id"] = f.UserID
+	}
+	if !f.StoreID.IsZero() {
+		filter["store_id"] = f.StoreID
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := impl.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		impl.Logger.Error("database list by filter error", slog.Any("error", err))
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results = []*APIKey{}
+	if err := cursor.All(ctx, &results); err != nil {
+		impl.Logger.Error("database list by filter decode error", slog.Any("error", err))
+		return nil, err
+	}
+	return results, nil
+}
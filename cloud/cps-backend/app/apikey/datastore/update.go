@@ -0,0 +1,25 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (impl APIKeyStorerImpl) UpdateByID(ctx context.Context, m *APIKey) error {
+	filter := bson.M{"_id": m.ID}
+
+	update := bson.M{
+		"$set": m,
+	}
+
+	// execute the UpdateOne() function to update the first matching document
+	_, err := impl.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		impl.Logger.Error("database update by id error", slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
@@ -8,7 +8,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
-	mg "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/emailer/mailgun"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/emailer"
 	s3_storage "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/storage/s3"
 	attachment_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/attachment/datastore"
 	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/attachment/datastore"
@@ -35,7 +35,7 @@ type AttachmentControllerImpl struct {
 	UUID                  uuid.Provider
 	S3                    s3_storage.S3Storager
 	DbClient              *mongo.Client
-	Emailer               mg.Emailer
+	Emailer               emailer.Emailer
 	AttachmentStorer      attachment_s.AttachmentStorer
 	UserStorer            user_s.UserStorer
 	ComicSubmissionStorer comicsub_s.ComicSubmissionStorer
@@ -46,7 +46,7 @@ func NewController(
 	loggerp *slog.Logger,
 	uuidp uuid.Provider,
 	s3 s3_storage.S3Storager,
-	emailer mg.Emailer,
+	emailer emailer.Emailer,
 	client *mongo.Client,
 	org_storer attachment_s.AttachmentStorer,
 	usr_storer user_s.UserStorer,
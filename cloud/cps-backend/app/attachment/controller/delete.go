@@ -9,9 +9,9 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 
 	org_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/attachment/datastore"
-	user_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (impl *AttachmentControllerImpl) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
@@ -34,7 +34,7 @@ func (impl *AttachmentControllerImpl) DeleteByID(ctx context.Context, id primiti
 		userRole := sessCtx.Value(constants.SessionUserRole).(int8)
 
 		// Apply protection based on ownership and role.
-		if userRole != user_d.UserRoleRoot && userRole != user_d.UserRoleRetailer {
+		if !permission.Can(sessCtx, permission.AttachmentDelete) {
 			impl.Logger.Error("authenticated user is not staff role error",
 				slog.Any("role", userRole),
 				slog.Any("userID", userID))
@@ -97,7 +97,7 @@ func (impl *AttachmentControllerImpl) PermanentlyDeleteByID(ctx context.Context,
 		userRole := sessCtx.Value(constants.SessionUserRole).(int8)
 
 		// Apply protection based on ownership and role.
-		if userRole != user_d.UserRoleRoot && userRole != user_d.UserRoleRetailer {
+		if !permission.Can(sessCtx, permission.AttachmentDelete) {
 			impl.Logger.Error("authenticated user is not staff role error",
 				slog.Any("role", userRole),
 				slog.Any("userID", userID))
@@ -5,9 +5,9 @@ import (
 	"time"
 
 	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/attachment/datastore"
-	user_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"log/slog"
 )
@@ -16,10 +16,9 @@ func (c *AttachmentControllerImpl) ListByFilter(ctx context.Context, f *domain.A
 	// Extract from our session the following data.
 	orgID := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
 	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
-	userRole := ctx.Value(constants.SessionUserRole).(int8)
 
 	// Apply protection based on ownership and role.
-	if userRole != user_d.UserRoleRoot {
+	if !permission.Can(ctx, permission.AttachmentViewAll) {
 		f.StoreID = orgID // Force store tenancy restrictions.
 	}
 
@@ -50,7 +49,7 @@ func (c *AttachmentControllerImpl) ListAsSelectOptionByFilter(ctx context.Contex
 	userRole := ctx.Value(constants.SessionUserRole).(int8)
 
 	// Apply protection based on ownership and role.
-	if userRole != user_d.UserRoleRoot {
+	if !permission.Can(ctx, permission.AttachmentViewAll) {
 		c.Logger.Error("authenticated user is not staff role error",
 			slog.Any("role", userRole),
 			slog.Any("userID", userID))
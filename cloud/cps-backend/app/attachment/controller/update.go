@@ -12,9 +12,9 @@ import (
 
 	a_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/attachment/datastore"
 	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/attachment/datastore"
-	user_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 type AttachmentUpdateRequestIDO struct {
@@ -94,7 +94,7 @@ func (impl *AttachmentControllerImpl) UpdateByID(ctx context.Context, req *Attac
 		userName, _ := sessCtx.Value(constants.SessionUserName).(string)
 
 		// If user is not administrator nor belongs to the attachment then error.
-		if userRole != user_d.UserRoleRoot && os.StoreID != userStoreID {
+		if !permission.Can(sessCtx, permission.AttachmentViewAll) && os.StoreID != userStoreID {
 			impl.Logger.Error("authenticated user is not staff role nor belongs to the attachment error",
 				slog.Any("userRole", userRole),
 				slog.Any("userStoreID", userStoreID))
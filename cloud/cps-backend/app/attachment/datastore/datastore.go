@@ -57,6 +57,8 @@ type AttachmentStorer interface {
 	Create(ctx context.Context, m *Attachment) error
 	GetByID(ctx context.Context, id primitive.ObjectID) (*Attachment, error)
 	UpdateByID(ctx context.Context, m *Attachment) error
+	UpdateStoreByStoreID(ctx context.Context, storeID primitive.ObjectID, storeName, storeTimezone string) (int64, error)
+	UpdateUserNameByUserID(ctx context.Context, userID primitive.ObjectID, userName string) (int64, error)
 	ListByFilter(ctx context.Context, m *AttachmentPaginationListFilter) (*AttachmentPaginationListResult, error)
 	ListAsSelectOptionByFilter(ctx context.Context, f *AttachmentPaginationListFilter) ([]*AttachmentAsSelectOption, error)
 	DeleteByID(ctx context.Context, id primitive.ObjectID) error
@@ -7,16 +7,20 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
-	mg "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/emailer/mailgun" // TODO: Remove
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/pdfbuilder"
 	s3_storage "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/storage/s3"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/webhookpublisher"
 	submission_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
 	credit_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/credit/datastore"
+	invoice_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/datastore"
+	notification_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/datastore"
+	offer_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
 	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/cpsrn"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/jwt"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/kmutex"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/password"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
@@ -45,6 +49,7 @@ type ComicSubmissionControllerImpl struct {
 	UUID                  uuid.Provider
 	S3                    s3_storage.S3Storager
 	Password              password.Provider
+	JWT                   jwt.Provider
 	CPSRN                 cpsrn.Provider
 	CBFFBuilder           pdfbuilder.CBFFBuilder
 	PCBuilder             pdfbuilder.PCBuilder
@@ -52,14 +57,17 @@ type ComicSubmissionControllerImpl struct {
 	CCSCBuilder           pdfbuilder.CCSCBuilder
 	CCBuilder             pdfbuilder.CCBuilder
 	CCUGBuilder           pdfbuilder.CCUGBuilder
-	Emailer               mg.Emailer // TODO: Remove
 	TemplatedEmailer      templatedemailer.TemplatedEmailer
+	WebhookPublisher      webhookpublisher.Publisher
 	Kmutex                kmutex.Provider
 	DbClient              *mongo.Client
 	UserStorer            user_s.UserStorer
 	ComicSubmissionStorer submission_s.ComicSubmissionStorer
 	StoreStorer           store_s.StoreStorer
 	CreditStorer          credit_s.CreditStorer
+	OfferStorer           offer_s.OfferStorer
+	InvoiceStorer         invoice_s.InvoiceStorer
+	NotificationStorer    notification_s.NotificationStorer
 }
 
 func NewController(
@@ -68,6 +76,7 @@ func NewController(
 	uuidp uuid.Provider,
 	s3 s3_storage.S3Storager,
 	passwordp password.Provider,
+	jwtp jwt.Provider,
 	kmux kmutex.Provider,
 	cpsrnP cpsrn.Provider,
 	cbffb pdfbuilder.CBFFBuilder,
@@ -76,13 +85,16 @@ func NewController(
 	ccsc pdfbuilder.CCSCBuilder,
 	cc pdfbuilder.CCBuilder,
 	ccug pdfbuilder.CCUGBuilder,
-	emailer mg.Emailer, // TODO: Remove
 	client *mongo.Client,
 	te templatedemailer.TemplatedEmailer,
+	whp webhookpublisher.Publisher,
 	usr_storer user_s.UserStorer,
 	sub_storer submission_s.ComicSubmissionStorer,
 	org_storer store_s.StoreStorer,
 	credit_storer credit_s.CreditStorer,
+	offer_storer offer_s.OfferStorer,
+	inv_storer invoice_s.InvoiceStorer,
+	n_storer notification_s.NotificationStorer,
 ) ComicSubmissionController {
 	loggerp.Debug("submission controller initialization started...")
 
@@ -309,6 +321,7 @@ func NewController(
 		UUID:                  uuidp,
 		S3:                    s3,
 		Password:              passwordp,
+		JWT:                   jwtp,
 		Kmutex:                kmux,
 		CPSRN:                 cpsrnP,
 		CBFFBuilder:           cbffb,
@@ -317,13 +330,16 @@ func NewController(
 		CCSCBuilder:           ccsc,
 		CCBuilder:             cc,
 		CCUGBuilder:           ccug,
-		Emailer:               emailer, // TODO: Remove
 		TemplatedEmailer:      te,
+		WebhookPublisher:      whp,
 		DbClient:              client,
 		UserStorer:            usr_storer,
 		ComicSubmissionStorer: sub_storer,
 		StoreStorer:           org_storer,
 		CreditStorer:          credit_storer,
+		OfferStorer:           offer_storer,
+		InvoiceStorer:         inv_storer,
+		NotificationStorer:    n_storer,
 	}
 	s.Logger.Debug("submission controller initialized")
 	return s
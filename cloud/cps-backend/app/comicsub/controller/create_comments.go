@@ -62,6 +62,11 @@ func (impl *ComicSubmissionControllerImpl) CreateComment(ctx context.Context, su
 			return nil, err
 		}
 
+		if err := impl.notifyCommentCreated(sessCtx, s, comment); err != nil {
+			impl.Logger.Error("notify comment created error", slog.Any("error", err))
+			// Do not return error, just keep it in the server logs.
+		}
+
 		return s, nil
 	}
 
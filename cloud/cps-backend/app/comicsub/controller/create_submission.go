@@ -14,8 +14,12 @@ import (
 	s_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
 	submission_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
 	credit_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/credit/datastore"
-	u_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
+	webhook_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/metrics"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 // ComicSubmissionCreateRequestIDO represents the user submitted data into our
@@ -163,6 +167,10 @@ func (impl *ComicSubmissionControllerImpl) Create(ctx context.Context, req *Comi
 		// userLastName, _ := sessCtx.Value(constants.SessionUserLastName).(string)
 		userID, _ := sessCtx.Value(constants.SessionUserID).(primitive.ObjectID)
 
+		if err := permission.Require(sessCtx, permission.SubmissionCreate); err != nil {
+			return nil, err
+		}
+
 		m := comicSubmissionFromCreate(req) // Convert into our data-structure.
 
 		// Variable used to keep track of the current logged in user.
@@ -180,14 +188,13 @@ func (impl *ComicSubmissionControllerImpl) Create(ctx context.Context, req *Comi
 		// Every submission creation is dependent on the `role` of the logged in
 		// user in our system; however, the root administrator has the ability to
 		// assign whatever store you want.
-		switch userRole {
-
-		case u_d.UserRoleRoot:
+		switch {
+		case permission.Can(sessCtx, permission.SubmissionAssignStore):
 			impl.Logger.Debug("admin picking custom store")
-		case u_d.UserRoleRetailer:
+		case permission.Scope(sessCtx) == permission.ScopeStore:
 			impl.Logger.Debug("retailer assigning their store (auto-assigning `store_id`)")
 			m.StoreID = sessCtx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
-		case u_d.UserRoleCustomer:
+		case permission.Scope(sessCtx) == permission.ScopeCustomer:
 			impl.Logger.Debug("customer picking custom store (auto-assigning `store_id`)")
 
 			// Force the following fields for logged in customer accounts.
@@ -197,7 +204,7 @@ func (impl *ComicSubmissionControllerImpl) Create(ctx context.Context, req *Comi
 			m.CustomerLastName = loggedInUser.LastName
 		default:
 			impl.Logger.Error("unsupported role", slog.Any("role", userRole))
-			return nil, fmt.Errorf("unsupported role via: %v", userRole)
+			return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
 		}
 
 		// Lookup the store.
@@ -271,9 +278,7 @@ func (impl *ComicSubmissionControllerImpl) Create(ctx context.Context, req *Comi
 		//          from us.
 		//
 
-		switch userRole {
-		case u_d.UserRoleRetailer, u_d.UserRoleCustomer:
-
+		if permission.Scope(sessCtx) != permission.ScopeSystem {
 			// STEP 1: Credits.
 
 			// The following code will lookup to see if the retailer user has a
@@ -307,7 +312,19 @@ func (impl *ComicSubmissionControllerImpl) Create(ctx context.Context, req *Comi
 					slog.Any("comicSubmissionID", m.ID))
 			}
 
-			// STEP 2: Pre-Screening
+			// STEP 2: Store Account.
+
+			// If no credit was available and the store was granted account
+			// billing by our staff then we bill the submission to the store's
+			// monthly invoice instead of having the retailer pay by card.
+			if credit == nil && permission.Scope(sessCtx) == permission.ScopeStore && org.PaymentMode == store_s.PaymentModeStoreAccount {
+				if err := impl.accrueToStoreInvoice(sessCtx, org, m); err != nil {
+					impl.Logger.Error("accrue to store invoice error", slog.Any("error", err))
+					return nil, err
+				}
+			}
+
+			// STEP 3: Pre-Screening
 
 			// If a retailer makes a submission for `pre-screening` submission
 			// then we need to change the status specific to this case of `completed
@@ -366,8 +383,7 @@ func (impl *ComicSubmissionControllerImpl) Create(ctx context.Context, req *Comi
 		//            print it themeselves!
 		//
 
-		switch userRole {
-		case u_d.UserRoleRetailer, u_d.UserRoleCustomer:
+		if !permission.Can(sessCtx, permission.SubmissionViewLabel) {
 			m.LabelObjectKey = "[hidden]"
 			m.LabelObjectURL = "[hidden]"
 			m.LabelObjectURLExpiry = time.Now()
@@ -380,12 +396,17 @@ func (impl *ComicSubmissionControllerImpl) Create(ctx context.Context, req *Comi
 		// The following code will send the email notifications to the correct
 		// CPS staff individuals if the submission is NOT CBFF.
 		if m.ServiceType != domain.ServiceTypePreScreening {
-			if err := impl.sendNewComicSubmissionEmails(m); err != nil {
+			if err := impl.sendNewComicSubmissionEmails(sessCtx, m); err != nil {
 				impl.Logger.Error("database update error", slog.Any("error", err))
 				// Do not return error, just keep it in the server logs.
 			}
 		}
 
+		if err := impl.WebhookPublisher.Publish(sessCtx, m.StoreID, webhook_s.EventSubmissionCreated, newSubmissionWebhookData(m, 0)); err != nil {
+			impl.Logger.Error("publish submission created error", slog.Any("error", err))
+			// Do not return error, just keep it in the server logs.
+		}
+
 		// return nil, httperror.NewForBadRequestWithSingleField("message", "halted by programmer") // For debugging purposes only.
 
 		return m, nil
@@ -399,5 +420,7 @@ func (impl *ComicSubmissionControllerImpl) Create(ctx context.Context, req *Comi
 		return nil, err
 	}
 
-	return res.(*submission_s.ComicSubmission), nil
+	m := res.(*submission_s.ComicSubmission)
+	metrics.IncSubmissionCreated(m.ServiceType)
+	return m, nil
 }
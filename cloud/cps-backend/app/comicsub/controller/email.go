@@ -6,10 +6,11 @@ import (
 
 	"log/slog"
 
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
 	s_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
 )
 
-func (impl *ComicSubmissionControllerImpl) sendNewComicSubmissionEmails(m *s_d.ComicSubmission) error {
+func (impl *ComicSubmissionControllerImpl) sendNewComicSubmissionEmails(ctx context.Context, m *s_d.ComicSubmission) error {
 	//
 	// ROOT
 	//
@@ -17,7 +18,7 @@ func (impl *ComicSubmissionControllerImpl) sendNewComicSubmissionEmails(m *s_d.C
 	impl.Logger.Debug("sending to root staff",
 		slog.Any("submission-id", m.ID))
 
-	response, err := impl.UserStorer.ListAllRootStaff(context.Background())
+	response, err := impl.UserStorer.ListAllRootStaff(ctx)
 	if err != nil {
 		impl.Logger.Error("database list all staff error",
 			slog.Any("submission-id", m.ID),
@@ -25,14 +26,14 @@ func (impl *ComicSubmissionControllerImpl) sendNewComicSubmissionEmails(m *s_d.C
 		return err
 	}
 
-	emails := []string{}
+	recipients := []templatedemailer.Recipient{}
 	for _, u := range response.Results {
-		emails = append(emails, u.Email)
+		recipients = append(recipients, templatedemailer.Recipient{Email: u.Email, Locale: u.Locale})
 	}
 
 	serviceTypeName, _ := s_d.ServiceTypeMap[m.ServiceType] // Get the service type description to utilize in our email.
 
-	if err := impl.TemplatedEmailer.SendNewComicSubmissionEmailToStaff(emails, m.ID.Hex(), m.StoreName, fmt.Sprintf("%v, Vol. %v, Issue #%v", m.SeriesTitle, m.IssueVol, m.IssueNo), m.CPSRN, serviceTypeName); err != nil {
+	if err := impl.TemplatedEmailer.SendNewComicSubmissionEmailToStaff(ctx, recipients, m.ID.Hex(), m.StoreName, fmt.Sprintf("%v, Vol. %v, Issue #%v", m.SeriesTitle, m.IssueVol, m.IssueNo), m.CPSRN, serviceTypeName); err != nil {
 		impl.Logger.Error("send comic submission email to staff error",
 			slog.Any("submission-id", m.ID),
 			slog.Any("error", err))
@@ -47,18 +48,18 @@ func (impl *ComicSubmissionControllerImpl) sendNewComicSubmissionEmails(m *s_d.C
 		slog.Any("submission-id", m.ID),
 		slog.Any("store-id", m.StoreID))
 
-	response, err = impl.UserStorer.ListAllRetailerStaffForStoreID(context.Background(), m.StoreID)
+	response, err = impl.UserStorer.ListAllRetailerStaffForStoreID(ctx, m.StoreID)
 	if err != nil {
 		impl.Logger.Error("database list all retailer staff for store id error", slog.Any("error", err))
 		return err
 	}
 
-	emails = []string{} // Reset emails list from above
+	recipients = []templatedemailer.Recipient{} // Reset recipients list from above
 	for _, u := range response.Results {
-		emails = append(emails, u.Email)
+		recipients = append(recipients, templatedemailer.Recipient{Email: u.Email, Locale: u.Locale})
 	}
 
-	if err := impl.TemplatedEmailer.SendNewComicSubmissionEmailToRetailers(emails, m.ID.Hex(), m.StoreName, fmt.Sprintf("%v, Vol. %v, Issue #%v", m.SeriesTitle, m.IssueVol, m.IssueNo), m.CPSRN, serviceTypeName); err != nil {
+	if err := impl.TemplatedEmailer.SendNewComicSubmissionEmailToRetailers(ctx, recipients, m.ID.Hex(), m.StoreName, fmt.Sprintf("%v, Vol. %v, Issue #%v", m.SeriesTitle, m.IssueVol, m.IssueNo), m.CPSRN, serviceTypeName); err != nil {
 		impl.Logger.Error("database list all retailer error",
 			slog.Any("submission-id", m.ID),
 			slog.Any("error", err))
@@ -10,16 +10,14 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
-	u_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (c *ComicSubmissionControllerImpl) GetByID(ctx context.Context, id primitive.ObjectID) (*domain.ComicSubmission, error) {
-	// DEVELOPERS NOTE:
-	// Every submission creation is dependent on the `role` of the logged in
-	// user in our system so we need to extract it right away.
-	userRole := ctx.Value(constants.SessionUserRole).(int8)
+	// Extract from our session the following data.
+	userStoreID, _ := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
 
 	// Retrieve from our database the record for the specific id.
 	m, err := c.ComicSubmissionStorer.GetByID(ctx, id)
@@ -31,6 +29,10 @@ func (c *ComicSubmissionControllerImpl) GetByID(ctx context.Context, id primitiv
 		c.Logger.Warn("submission does not exist for id lookup validation error", slog.Any("id", id))
 		return nil, httperror.NewForBadRequestWithSingleField("message", fmt.Sprintf("registry entry does not exist for id: %v", id.Hex()))
 	}
+	if !permission.Can(ctx, permission.SubmissionViewAll) && m.StoreID != userStoreID {
+		c.Logger.Warn("submission belongs to another store", slog.Any("id", id))
+		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
 
 	// Variable controls if we need to update the record before we return it.
 	var hasUpdate bool = false
@@ -93,8 +95,7 @@ func (c *ComicSubmissionControllerImpl) GetByID(ctx context.Context, id primitiv
 	//            print it themeselves!
 	//
 
-	switch userRole {
-	case u_d.UserRoleRetailer, u_d.UserRoleCustomer:
+	if !permission.Can(ctx, permission.SubmissionViewLabel) {
 		m.LabelObjectKey = "[hidden]"
 		m.LabelObjectURL = "[hidden]"
 		m.LabelObjectURLExpiry = time.Now()
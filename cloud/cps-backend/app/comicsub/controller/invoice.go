@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	s_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
+	invoice_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/datastore"
+	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
+)
+
+// accrueToStoreInvoice function bills the comic submission to the store's
+// open invoice for the current billing period, opening a new invoice if the
+// store does not have one yet.
+func (impl *ComicSubmissionControllerImpl) accrueToStoreInvoice(sessCtx mongo.SessionContext, org *store_s.Store, m *s_d.ComicSubmission) error {
+	offer, err := impl.OfferStorer.GetByServiceType(sessCtx, m.ServiceType)
+	if err != nil {
+		impl.Logger.Error("database get offer by service type error", slog.Any("error", err))
+		return err
+	}
+	if offer == nil {
+		impl.Logger.Debug("no offer for service type, nothing to bill",
+			slog.Any("service_type", m.ServiceType))
+		return nil
+	}
+
+	// Bill in the store's currency, though an invoice never mixes currencies.
+	price := offer.PriceForCurrency(org.Currency)
+	if price.Amount <= 0 {
+		impl.Logger.Debug("no priced offer for service type, nothing to bill",
+			slog.Any("service_type", m.ServiceType),
+			slog.String("currency", price.Currency))
+		return nil
+	}
+
+	// The billing period is the calendar month in UTC.
+	now := time.Now().UTC()
+	periodStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	id := primitive.NewObjectID()
+	inv, err := impl.InvoiceStorer.GetOrCreateOpen(sessCtx, &invoice_s.Invoice{
+		ID:               id,
+		InvoiceNumber:    fmt.Sprintf("%v-%v", periodStart.Format("200601"), strings.ToUpper(id.Hex()[18:])),
+		StoreID:          org.ID,
+		StoreName:        org.Name,
+		Status:           invoice_s.StatusOpen,
+		PeriodStart:      periodStart,
+		PeriodEnd:        periodStart.AddDate(0, 1, 0),
+		LineItems:        []*invoice_s.InvoiceLineItem{},
+		Currency:         price.Currency,
+		Payments:         []*invoice_s.InvoicePayment{},
+		PaymentTermsDays: org.PaymentTermsDays,
+		CreatedAt:        now,
+		ModifiedAt:       now,
+	})
+	if err != nil {
+		impl.Logger.Error("database get or create open invoice error", slog.Any("error", err))
+		return err
+	}
+
+	// The store's currency may have changed since the invoice was opened.
+	if inv.Currency != price.Currency {
+		price = offer.PriceForCurrency(inv.Currency)
+		if price.Amount <= 0 {
+			impl.Logger.Debug("no priced offer for service type in invoice currency, nothing to bill",
+				slog.Any("service_type", m.ServiceType),
+				slog.String("currency", inv.Currency))
+			return nil
+		}
+	}
+
+	inv.StoreName = org.Name
+	inv.LineItems = append(inv.LineItems, &invoice_s.InvoiceLineItem{
+		ComicSubmissionID: m.ID,
+		CPSRN:             m.CPSRN,
+		Item:              m.Item,
+		ServiceType:       m.ServiceType,
+		Amount:            price.Amount,
+		CreatedAt:         now,
+	})
+	inv.AmountTotal += price.Amount
+	inv.AmountDue = inv.AmountTotal - inv.AmountPaid
+	inv.ModifiedAt = now
+
+	if err := impl.InvoiceStorer.UpdateByID(sessCtx, inv); err != nil {
+		impl.Logger.Error("database save invoice error", slog.Any("error", err))
+		return err
+	}
+
+	// Keep a record in the comic submission that it was billed to the store
+	// account so the retailer partner does not need to purchase.
+	m.InvoiceID = inv.ID
+	m.AmountSubtotal = price.Amount
+	m.AmountTotal = price.Amount
+
+	impl.Logger.Debug("billed submission to store account",
+		slog.Any("invoice_id", inv.ID),
+		slog.Any("comic_submission_id", m.ID))
+	return nil
+}
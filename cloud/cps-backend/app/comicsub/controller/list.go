@@ -7,8 +7,8 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	submission_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
-	user_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (c *ComicSubmissionControllerImpl) ListByFilter(ctx context.Context, f *submission_s.ComicSubmissionPaginationListFilter) (*submission_s.ComicSubmissionPaginationListResult, error) {
@@ -18,7 +18,7 @@ func (c *ComicSubmissionControllerImpl) ListByFilter(ctx context.Context, f *sub
 	userRole := ctx.Value(constants.SessionUserRole).(int8)
 
 	// Apply filtering based on tenancy if the user is not a system administrator.
-	if userRole != user_d.UserRoleRoot {
+	if !permission.Can(ctx, permission.SubmissionViewAll) {
 		f.StoreID = storeID
 		c.Logger.Debug("applying security policy to filters",
 			slog.Any("store_id", storeID),
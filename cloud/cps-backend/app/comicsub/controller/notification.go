@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"log/slog"
+
+	s_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
+	notification_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/datastore"
+	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
+)
+
+// statusNotificationRule is who gets notified when a submission moves into
+// the status.
+type statusNotificationRule struct {
+	Customer  bool
+	Retailer  bool
+	WithGrade bool
+}
+
+// statusNotificationRules are the status changes we tell people about, the
+// statuses which are not listed are internal to our workflow.
+var statusNotificationRules = map[int8]statusNotificationRule{
+	s_d.StatusReceived:                 {Customer: true, Retailer: true},
+	s_d.StatusInProcess:                {Customer: true},
+	s_d.StatusComplete:                 {Customer: true, Retailer: true, WithGrade: true},
+	s_d.StatusShipped:                  {Customer: true, Retailer: true, WithGrade: true},
+	s_d.StatusCompletedByRetailPartner: {Customer: true, WithGrade: true},
+}
+
+// notifyStatusChanged notifies the customer and the retailer staff of the
+// submission in the app if its status changed into one of our notification
+// rules, and emails everyone who did not unsubscribe.
+func (impl *ComicSubmissionControllerImpl) notifyStatusChanged(ctx context.Context, m *s_d.ComicSubmission, previousStatus int8) error {
+	if m.Status == previousStatus {
+		return nil
+	}
+	rule, ok := statusNotificationRules[m.Status]
+	if !ok {
+		return nil
+	}
+
+	recipients := []*user_s.User{}
+	if rule.Customer && !m.CustomerID.IsZero() {
+		u, err := impl.UserStorer.GetByID(ctx, m.CustomerID)
+		if err != nil {
+			impl.Logger.Error("database get by id error", slog.Any("error", err))
+			return err
+		}
+		if u != nil {
+			recipients = append(recipients, u)
+		}
+	}
+	if rule.Retailer {
+		res, err := impl.UserStorer.ListAllRetailerStaffForStoreID(ctx, m.StoreID)
+		if err != nil {
+			impl.Logger.Error("database list all retailer staff for store id error", slog.Any("error", err))
+			return err
+		}
+		recipients = append(recipients, res.Results...)
+	}
+
+	item := fmt.Sprintf("%v, Vol. %v, Issue #%v", m.SeriesTitle, m.IssueVol, m.IssueNo)
+	for _, u := range recipients {
+		n := notification_s.NewNotification(
+			u.ID,
+			notification_s.TypeSubmissionStatusChanged,
+			fmt.Sprintf(i18n.T(u.Locale, "Your submission is now %v"), i18n.T(u.Locale, s_d.StatusNameMap[m.Status])),
+			item,
+			submissionLink(u, m.ID, ""),
+			m.ID)
+		if err := impl.NotificationStorer.Create(ctx, n); err != nil {
+			impl.Logger.Error("database create notification error", slog.Any("error", err))
+			return err
+		}
+
+		if !u.IsSubscribedTo(user_s.NotificationSubmissionStatusChanged) {
+			impl.Logger.Debug("skipping unsubscribed user", slog.Any("user_id", u.ID))
+			continue
+		}
+		token, err := impl.JWT.GenerateUnsubscribeToken(u.ID.Hex(), user_s.NotificationSubmissionStatusChanged)
+		if err != nil {
+			impl.Logger.Error("generate unsubscribe token error", slog.Any("error", err))
+			return err
+		}
+		var grade string
+		if rule.WithGrade {
+			grade = gradeDisplayName(m, u.Locale)
+		}
+		if err := impl.TemplatedEmailer.SendSubmissionStatusChangedEmail(ctx, u.Locale, u.Email, u.FirstName, m.ID.Hex(), item, m.CPSRN, s_d.StatusNameMap[m.Status], grade, token); err != nil {
+			impl.Logger.Error("send submission status changed email error",
+				slog.Any("submission-id", m.ID),
+				slog.Any("error", err))
+			return err
+		}
+	}
+	return nil
+}
+
+// submissionLink returns the frontend path of the submission for the user,
+// customers have their own section in the frontend.
+func submissionLink(u *user_s.User, submissionID primitive.ObjectID, suffix string) string {
+	if u.Role == user_s.UserRoleCustomer {
+		return fmt.Sprintf("/c/submissions/comic/%v%v", submissionID.Hex(), suffix)
+	}
+	return fmt.Sprintf("/submissions/comic/%v%v", submissionID.Hex(), suffix)
+}
+
+// gradeDisplayName returns the grade of the submission the way it is printed
+// on the label, or an empty string if it was not graded.
+func gradeDisplayName(m *s_d.ComicSubmission, locale string) string {
+	switch m.GradingScale {
+	case s_d.GradingScaleLetter:
+		if m.IsOverallLetterGradeNearMintPlus {
+			return fmt.Sprintf("NM+ (%v)", i18n.T(locale, "Near Mint Plus"))
+		}
+		if m.OverallLetterGrade == "" {
+			return ""
+		}
+		return fmt.Sprintf("%v (%v)", strings.ToUpper(m.OverallLetterGrade), i18n.T(locale, constants.SubmissionOverallLetterGrades[m.OverallLetterGrade]))
+	case s_d.GradingScaleNumber:
+		return fmt.Sprintf("%.1f", m.OverallNumberGrade)
+	case s_d.GradingScaleCPSPercentage:
+		return fmt.Sprintf("%v%%", m.CpsPercentageGrade)
+	}
+	return ""
+}
+
+// notifyCommentCreated notifies the customer and the retailer staff of the
+// submission about the new comment, except for the person who wrote it.
+func (impl *ComicSubmissionControllerImpl) notifyCommentCreated(ctx context.Context, m *s_d.ComicSubmission, comment *s_d.ComicSubmissionComment) error {
+	res, err := impl.UserStorer.ListAllRetailerStaffForStoreID(ctx, m.StoreID)
+	if err != nil {
+		impl.Logger.Error("database list all retailer staff for store id error", slog.Any("error", err))
+		return err
+	}
+	recipients := res.Results
+	if !m.CustomerID.IsZero() {
+		u, err := impl.UserStorer.GetByID(ctx, m.CustomerID)
+		if err != nil {
+			impl.Logger.Error("database get by id error", slog.Any("error", err))
+			return err
+		}
+		if u != nil {
+			recipients = append(recipients, u)
+		}
+	}
+
+	for _, u := range recipients {
+		if u.ID == comment.CreatedByUserID {
+			continue
+		}
+		n := notification_s.NewNotification(
+			u.ID,
+			notification_s.TypeSubmissionComment,
+			fmt.Sprintf(i18n.T(u.Locale, "%v commented on your submission"), comment.CreatedByName),
+			comment.Content,
+			submissionLink(u, m.ID, "/comments"),
+			m.ID)
+		if err := impl.NotificationStorer.Create(ctx, n); err != nil {
+			impl.Logger.Error("database create notification error", slog.Any("error", err))
+			return err
+		}
+	}
+	return nil
+}
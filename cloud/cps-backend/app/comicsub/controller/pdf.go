@@ -15,6 +15,17 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// storeLocale returns the locale of the submission's store, falling back to
+// the default locale if the store could not be looked up.
+func (c *ComicSubmissionControllerImpl) storeLocale(sessCtx mongo.SessionContext, m *s_d.ComicSubmission) string {
+	s, err := c.StoreStorer.GetByID(sessCtx, m.StoreID)
+	if err != nil || s == nil {
+		c.Logger.Warn("failed looking up store locale", slog.Any("store_id", m.StoreID), slog.Any("err", err))
+		return ""
+	}
+	return s.Locale
+}
+
 func (c *ComicSubmissionControllerImpl) generateLabelPDF(sessCtx mongo.SessionContext, m *s_d.ComicSubmission) (*pdfbuilder.PDFBuilderResponseDTO, error) {
 	// Look up the publisher names and get the correct display name or get the other.
 	var publisherNameDisplay string = constants.SubmissionPublisherNames[m.PublisherName]
@@ -22,6 +33,9 @@ func (c *ComicSubmissionControllerImpl) generateLabelPDF(sessCtx mongo.SessionCo
 		publisherNameDisplay = m.PublisherNameOther
 	}
 
+	// The labels are printed in the language of the store.
+	locale := c.storeLocale(sessCtx, m)
+
 	switch m.ServiceType {
 	case s_d.ServiceTypePreScreening:
 		return c.generateFindingsFormPDF(sessCtx, m)
@@ -32,6 +46,7 @@ func (c *ComicSubmissionControllerImpl) generateLabelPDF(sessCtx mongo.SessionCo
 		r := &pdfbuilder.PCBuilderRequestDTO{
 			CPSRN:                              m.CPSRN,
 			Filename:                           fmt.Sprintf("%v.pdf", m.ID.Hex()),
+			Locale:                             locale,
 			SubmissionDate:                     time.Now(),
 			SeriesTitle:                        m.SeriesTitle,
 			IssueVol:                           m.IssueVol,
@@ -81,6 +96,7 @@ func (c *ComicSubmissionControllerImpl) generateLabelPDF(sessCtx mongo.SessionCo
 		r := &pdfbuilder.CCBuilderRequestDTO{
 			CPSRN:                            m.CPSRN,
 			Filename:                         fmt.Sprintf("%v.pdf", m.ID.Hex()),
+			Locale:                           locale,
 			SeriesTitle:                      m.SeriesTitle,
 			IssueVol:                         m.IssueVol,
 			IssueNo:                          m.IssueNo,
@@ -119,6 +135,7 @@ func (c *ComicSubmissionControllerImpl) generateLabelPDF(sessCtx mongo.SessionCo
 		r := &pdfbuilder.CCSCBuilderRequestDTO{
 			CPSRN:                            m.CPSRN,
 			Filename:                         fmt.Sprintf("%v.pdf", m.ID.Hex()),
+			Locale:                           locale,
 			SeriesTitle:                      m.SeriesTitle,
 			IssueVol:                         m.IssueVol,
 			IssueNo:                          m.IssueNo,
@@ -157,6 +174,7 @@ func (c *ComicSubmissionControllerImpl) generateLabelPDF(sessCtx mongo.SessionCo
 		r := &pdfbuilder.CCIMGBuilderRequestDTO{
 			CPSRN:                            m.CPSRN,
 			Filename:                         fmt.Sprintf("%v.pdf", m.ID.Hex()),
+			Locale:                           locale,
 			SeriesTitle:                      m.SeriesTitle,
 			IssueVol:                         m.IssueVol,
 			IssueNo:                          m.IssueNo,
@@ -195,6 +213,7 @@ func (c *ComicSubmissionControllerImpl) generateLabelPDF(sessCtx mongo.SessionCo
 		r := &pdfbuilder.CCUGBuilderRequestDTO{
 			CPSRN:                            m.CPSRN,
 			Filename:                         fmt.Sprintf("%v.pdf", m.ID.Hex()),
+			Locale:                           locale,
 			SeriesTitle:                      m.SeriesTitle,
 			IssueVol:                         m.IssueVol,
 			IssueNo:                          m.IssueNo,
@@ -240,12 +259,16 @@ func (c *ComicSubmissionControllerImpl) generateFindingsFormPDF(sessCtx mongo.Se
 		publisherNameDisplay = m.PublisherNameOther
 	}
 
+	// The labels are printed in the language of the store.
+	locale := c.storeLocale(sessCtx, m)
+
 	c.Logger.Debug("beginning to generate `pre-screening` pdf")
 	// The next following lines of code will create the PDF file gnerator
 	// request to be submitted into our PDF file generator to generate the data.
 	r := &pdfbuilder.CBFFBuilderRequestDTO{
 		CPSRN:                              m.CPSRN,
 		Filename:                           fmt.Sprintf("%v.pdf", m.ID.Hex()),
+		Locale:                             locale,
 		SubmissionDate:                     time.Now(),
 		SeriesTitle:                        m.SeriesTitle,
 		IssueVol:                           m.IssueVol,
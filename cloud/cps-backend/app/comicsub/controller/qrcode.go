@@ -31,7 +31,7 @@ func (c *ComicSubmissionControllerImpl) GetQRCodePNGImageOfRegisteryURLByCPSRN(c
 	}
 
 	// Create our payload.
-	payload := fmt.Sprintf("https://%s/cpsrn?v=%s", c.Emailer.GetFrontendDomainName(), cpsrn)
+	payload := fmt.Sprintf("https://%s/cpsrn?v=%s", c.TemplatedEmailer.GetFrontendDomainName(), cpsrn)
 
 	// Generate the QR code for the specific URL and return the `png` binary
 	// file bytes.
@@ -11,9 +11,11 @@ import (
 
 	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
 	s_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
-	u_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/metrics"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 type ComicSubmissionUpdateRequestIDO struct {
@@ -134,6 +136,10 @@ func (impl *ComicSubmissionControllerImpl) UpdateByID(ctx context.Context, req *
 	}
 	defer session.EndSession(ctx)
 
+	// Keep track of the status before the update so we can count the change
+	// once the transaction was committed.
+	var previousStatus int8
+
 	// Define a transaction function with a series of operations
 	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
 		// DEVELOPERS NOTE:
@@ -144,6 +150,10 @@ func (impl *ComicSubmissionControllerImpl) UpdateByID(ctx context.Context, req *
 		// userLastName, _ := sessCtx.Value(constants.SessionUserLastName).(string)
 		userID, _ := sessCtx.Value(constants.SessionUserID).(primitive.ObjectID)
 
+		if err := permission.RequireAny(sessCtx, permission.SubmissionUpdate, permission.SubmissionSetStatus); err != nil {
+			return nil, err
+		}
+
 		ns := comicSubmissionFromModify(req) // Convert into our data-structure.
 
 		//
@@ -160,6 +170,33 @@ func (impl *ComicSubmissionControllerImpl) UpdateByID(ctx context.Context, req *
 			impl.Logger.Warn("submission does not exist error", slog.Any("id", req.ID))
 			return nil, httperror.NewForBadRequestWithSingleField("id", fmt.Sprintf("submission does not exist for ID: %v", req.ID))
 		}
+		if userStoreID, _ := sessCtx.Value(constants.SessionUserStoreID).(primitive.ObjectID); !permission.Can(sessCtx, permission.SubmissionViewAll) && os.StoreID != userStoreID {
+			return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+		}
+		previousStatus = os.Status
+
+		// Staff who may only move the submission along the workflow do not
+		// get to touch anything else.
+		if !permission.Can(sessCtx, permission.SubmissionUpdate) {
+			os.Status = ns.Status
+			os.ModifiedAt = time.Now()
+			os.ModifiedByUserID = userID
+			os.ModifiedByUserRole = userRole
+			if err := impl.ComicSubmissionStorer.UpdateByID(sessCtx, os); err != nil {
+				impl.Logger.Error("database update by id error", slog.Any("error", err))
+				return nil, err
+			}
+
+			if err := impl.notifyStatusChanged(sessCtx, os, previousStatus); err != nil {
+				impl.Logger.Error("notify status changed error", slog.Any("error", err))
+				// Do not return error, just keep it in the server logs.
+			}
+			if err := impl.publishStatusChanged(sessCtx, os, previousStatus); err != nil {
+				impl.Logger.Error("publish status changed error", slog.Any("error", err))
+				// Do not return error, just keep it in the server logs.
+			}
+			return os, nil
+		}
 
 		// Variable used to keep track of the current logged in user.
 		loggedInUser, err := impl.UserStorer.GetByID(sessCtx, userID)
@@ -176,38 +213,19 @@ func (impl *ComicSubmissionControllerImpl) UpdateByID(ctx context.Context, req *
 		// Set store.
 		//
 
-		// DEVELOPERS NOTE:
-		// Every submission creation is dependent on the `role` of the logged in
-		// user in our system; however, the root administrator has the ability to
-		// assign whatever store you want.
-		switch userRole {
-		case u_d.UserRoleRoot:
-			impl.Logger.Debug("admin picking custom store")
-		case u_d.UserRoleRetailer:
-			impl.Logger.Debug("retailer assigning their store (auto-assigning `store_id`)")
-			os.StoreID = sessCtx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
-		case u_d.UserRoleCustomer:
-			impl.Logger.Debug("customer picking custom store (auto-assigning `store_id`)")
-
-			// Force the following fields for logged in customer accounts.
-			os.StoreID = loggedInUser.StoreID
-			os.CustomerID = loggedInUser.ID
-			os.CustomerFirstName = loggedInUser.FirstName
-			os.CustomerLastName = loggedInUser.LastName
-		default:
-			impl.Logger.Error("unsupported role", slog.Any("role", userRole))
-			return nil, fmt.Errorf("unsupported role via: %v", userRole)
+		if err := impl.assignSubmissionStore(sessCtx, os, ns, loggedInUser); err != nil {
+			return nil, err
 		}
 
 		// Lookup the store.
-		org, err := impl.StoreStorer.GetByID(sessCtx, ns.StoreID)
+		org, err := impl.StoreStorer.GetByID(sessCtx, os.StoreID)
 		if err != nil {
 			impl.Logger.Error("database get by id error", slog.Any("error", err))
 			return nil, err
 		}
 		if org == nil {
-			impl.Logger.Error("database get by id does not exist", slog.Any("store id", ns.StoreID))
-			return nil, fmt.Errorf("does not exist for store id: %v", ns.StoreID)
+			impl.Logger.Error("database get by id does not exist", slog.Any("store id", os.StoreID))
+			return nil, fmt.Errorf("does not exist for store id: %v", os.StoreID)
 		}
 
 		// Lookup the store owner.
@@ -265,11 +283,13 @@ func (impl *ComicSubmissionControllerImpl) UpdateByID(ctx context.Context, req *
 		os.SpineFinding = ns.SpineFinding
 		os.CoverFinding = ns.CoverFinding
 		os.ShowsSignsOfTamperingOrRestoration = ns.ShowsSignsOfTamperingOrRestoration
-		os.GradingScale = ns.GradingScale
-		os.OverallLetterGrade = ns.OverallLetterGrade
-		os.IsOverallLetterGradeNearMintPlus = ns.IsOverallLetterGradeNearMintPlus
-		os.OverallNumberGrade = ns.OverallNumberGrade
-		os.CpsPercentageGrade = ns.CpsPercentageGrade
+		if permission.Can(sessCtx, permission.SubmissionGrade) {
+			os.GradingScale = ns.GradingScale
+			os.OverallLetterGrade = ns.OverallLetterGrade
+			os.IsOverallLetterGradeNearMintPlus = ns.IsOverallLetterGradeNearMintPlus
+			os.OverallNumberGrade = ns.OverallNumberGrade
+			os.CpsPercentageGrade = ns.CpsPercentageGrade
+		}
 		// os.UserFirstName = ns.UserFirstName     // NO NEED TO CHANGE AFTER FACT.
 		// os.UserLastName = ns.UserLastName       // NO NEED TO CHANGE AFTER FACT.
 		// os.UserStoreName = ns.UserStoreName // NO NEED TO CHANGE AFTER FACT.
@@ -283,8 +303,7 @@ func (impl *ComicSubmissionControllerImpl) UpdateByID(ctx context.Context, req *
 
 		// DEVELOPERS NOTE:
 		// Enforce status protection based on user roles.
-		switch userRole {
-		case u_d.UserRoleRoot:
+		if permission.Can(sessCtx, permission.SubmissionSetStatus) {
 			os.Status = ns.Status
 		}
 
@@ -369,14 +388,22 @@ func (impl *ComicSubmissionControllerImpl) UpdateByID(ctx context.Context, req *
 			return nil, err
 		}
 
+		if err := impl.notifyStatusChanged(sessCtx, os, previousStatus); err != nil {
+			impl.Logger.Error("notify status changed error", slog.Any("error", err))
+			// Do not return error, just keep it in the server logs.
+		}
+		if err := impl.publishStatusChanged(sessCtx, os, previousStatus); err != nil {
+			impl.Logger.Error("publish status changed error", slog.Any("error", err))
+			// Do not return error, just keep it in the server logs.
+		}
+
 		//
 		// Security - Censor label data if the logged in user is retailer. We do
 		//            this because if the retailer gets our label then they can
 		//            print it themeselves!
 		//
 
-		switch userRole {
-		case u_d.UserRoleRetailer, u_d.UserRoleCustomer:
+		if !permission.Can(sessCtx, permission.SubmissionViewLabel) {
 			os.LabelObjectKey = "[hidden]"
 			os.LabelObjectURL = "[hidden]"
 			os.LabelObjectURLExpiry = time.Now()
@@ -393,5 +420,44 @@ func (impl *ComicSubmissionControllerImpl) UpdateByID(ctx context.Context, req *
 		return nil, err
 	}
 
-	return res.(*domain.ComicSubmission), nil
+	m := res.(*domain.ComicSubmission)
+	if m.Status != previousStatus {
+		metrics.IncSubmissionStatusChange(m.Status)
+	}
+	return m, nil
+}
+
+// assignSubmissionStore sets the store of the submission being updated based
+// on the role of the logged in user.
+//
+// DEVELOPERS NOTE:
+// Every submission update is dependent on the `role` of the logged in user in
+// our system; however, the root administrator has the ability to assign
+// whatever store you want.
+func (impl *ComicSubmissionControllerImpl) assignSubmissionStore(ctx context.Context, os, ns *domain.ComicSubmission, loggedInUser *user_s.User) error {
+	switch {
+	case permission.Can(ctx, permission.SubmissionAssignStore):
+		impl.Logger.Debug("admin picking custom store")
+		os.StoreID = ns.StoreID
+	case permission.Scope(ctx) == permission.ScopeSystem:
+		// Our own staff, like the graders, work on the submission of the
+		// store which sent it in and may not move it to another store.
+		impl.Logger.Debug("staff keeping the store of the submission")
+	case permission.Scope(ctx) == permission.ScopeStore:
+		impl.Logger.Debug("retailer assigning their store (auto-assigning `store_id`)")
+		os.StoreID = ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+	case permission.Scope(ctx) == permission.ScopeCustomer:
+		impl.Logger.Debug("customer picking custom store (auto-assigning `store_id`)")
+
+		// Force the following fields for logged in customer accounts.
+		os.StoreID = loggedInUser.StoreID
+		os.CustomerID = loggedInUser.ID
+		os.CustomerFirstName = loggedInUser.FirstName
+		os.CustomerLastName = loggedInUser.LastName
+	default:
+		userRole, _ := ctx.Value(constants.SessionUserRole).(int8)
+		impl.Logger.Error("unsupported role", slog.Any("role", userRole))
+		return httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+	return nil
 }
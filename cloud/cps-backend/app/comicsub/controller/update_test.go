@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
+	u_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+func newTestController() *ComicSubmissionControllerImpl {
+	return &ComicSubmissionControllerImpl{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+}
+
+func TestUpdateGradeAsGrader(t *testing.T) {
+	impl := newTestController()
+	ctx := context.WithValue(context.Background(), constants.SessionUserRole, int8(u_d.UserRoleGrader))
+	if err := permission.Require(ctx, permission.SubmissionUpdate); err != nil {
+		t.Fatalf("expected grader to update submissions, got %v", err)
+	}
+	if err := permission.Require(ctx, permission.SubmissionGrade); err != nil {
+		t.Fatalf("expected grader to grade submissions, got %v", err)
+	}
+
+	storeID := primitive.NewObjectID()
+	customerID := primitive.NewObjectID()
+	os := &domain.ComicSubmission{StoreID: storeID, CustomerID: customerID}
+	ns := comicSubmissionFromModify(&ComicSubmissionUpdateRequestIDO{
+		StoreID:            primitive.NewObjectID(),
+		OverallLetterGrade: "vf",
+		OverallNumberGrade: 8,
+	})
+	loggedInUser := &u_d.User{ID: primitive.NewObjectID(), Role: u_d.UserRoleGrader}
+
+	if err := impl.assignSubmissionStore(ctx, os, ns, loggedInUser); err != nil {
+		t.Fatalf("expected grader to update the submission, got %v", err)
+	}
+	if os.StoreID != storeID || os.CustomerID != customerID {
+		t.Errorf("expected grader to keep the store and customer, got store %v and customer %v", os.StoreID, os.CustomerID)
+	}
+	if ns.OverallLetterGrade != "vf" || ns.OverallNumberGrade != 8 {
+		t.Errorf("expected the grade to be kept, got %v %v", ns.OverallLetterGrade, ns.OverallNumberGrade)
+	}
+}
+
+func TestAssignSubmissionStoreAsRetailer(t *testing.T) {
+	impl := newTestController()
+	userStoreID := primitive.NewObjectID()
+	ctx := context.WithValue(context.Background(), constants.SessionUserRole, int8(u_d.UserRoleRetailer))
+	ctx = context.WithValue(ctx, constants.SessionUserStoreID, userStoreID)
+
+	os := &domain.ComicSubmission{StoreID: userStoreID}
+	ns := &domain.ComicSubmission{StoreID: primitive.NewObjectID()}
+	if err := impl.assignSubmissionStore(ctx, os, ns, &u_d.User{}); err != nil {
+		t.Fatal(err)
+	}
+	if os.StoreID != userStoreID {
+		t.Errorf("expected retailer to keep their own store, got %v", os.StoreID)
+	}
+}
+
+func TestAssignSubmissionStoreUnknownRole(t *testing.T) {
+	impl := newTestController()
+	ctx := context.WithValue(context.Background(), constants.SessionUserRole, int8(0))
+	if err := impl.assignSubmissionStore(ctx, &domain.ComicSubmission{}, &domain.ComicSubmission{}, &u_d.User{}); err == nil {
+		t.Error("expected unknown role to be forbidden")
+	}
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	s_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
+	webhook_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/datastore"
+)
+
+// SubmissionWebhookData is the data of the submission events we send to the
+// webhooks of the store. The label and findings form are left out on
+// purpose, retailers only get those through the app.
+type SubmissionWebhookData struct {
+	ID             primitive.ObjectID `json:"id"`
+	CPSRN          string             `json:"cpsrn"`
+	StoreID        primitive.ObjectID `json:"store_id"`
+	CustomerID     primitive.ObjectID `json:"customer_id,omitempty"`
+	ServiceType    int8               `json:"service_type"`
+	SeriesTitle    string             `json:"series_title"`
+	IssueVol       string             `json:"issue_vol"`
+	IssueNo        string             `json:"issue_no"`
+	IssueCoverYear int64              `json:"issue_cover_year"`
+	Status         int8               `json:"status"`
+	StatusName     string             `json:"status_name"`
+	// PreviousStatus is only set on the `submission.status_changed` event.
+	PreviousStatus                   int8      `json:"previous_status,omitempty"`
+	GradingScale                     int8      `json:"grading_scale,omitempty"`
+	OverallLetterGrade               string    `json:"overall_letter_grade,omitempty"`
+	IsOverallLetterGradeNearMintPlus bool      `json:"is_overall_letter_grade_near_mint_plus,omitempty"`
+	OverallNumberGrade               float64   `json:"overall_number_grade,omitempty"`
+	CpsPercentageGrade               float64   `json:"cps_percentage_grade,omitempty"`
+	Grade                            string    `json:"grade,omitempty"`
+	CreatedAt                        time.Time `json:"created_at"`
+	ModifiedAt                       time.Time `json:"modified_at"`
+}
+
+func newSubmissionWebhookData(m *s_d.ComicSubmission, previousStatus int8) *SubmissionWebhookData {
+	return &SubmissionWebhookData{
+		ID:                               m.ID,
+		CPSRN:                            m.CPSRN,
+		StoreID:                          m.StoreID,
+		CustomerID:                       m.CustomerID,
+		ServiceType:                      m.ServiceType,
+		SeriesTitle:                      m.SeriesTitle,
+		IssueVol:                         m.IssueVol,
+		IssueNo:                          m.IssueNo,
+		IssueCoverYear:                   m.IssueCoverYear,
+		Status:                           m.Status,
+		StatusName:                       s_d.StatusNameMap[m.Status],
+		PreviousStatus:                   previousStatus,
+		GradingScale:                     m.GradingScale,
+		OverallLetterGrade:               m.OverallLetterGrade,
+		IsOverallLetterGradeNearMintPlus: m.IsOverallLetterGradeNearMintPlus,
+		OverallNumberGrade:               m.OverallNumberGrade,
+		CpsPercentageGrade:               m.CpsPercentageGrade,
+		Grade:                            gradeDisplayName(m, ""),
+		CreatedAt:                        m.CreatedAt,
+		ModifiedAt:                       m.ModifiedAt,
+	}
+}
+
+// publishStatusChanged queues the status change of the submission for the
+// webhooks of the store, along with the `submission.completed` event if the
+// submission was graded.
+func (impl *ComicSubmissionControllerImpl) publishStatusChanged(ctx context.Context, m *s_d.ComicSubmission, previousStatus int8) error {
+	if m.Status == previousStatus {
+		return nil
+	}
+	data := newSubmissionWebhookData(m, previousStatus)
+	if err := impl.WebhookPublisher.Publish(ctx, m.StoreID, webhook_s.EventSubmissionStatusChanged, data); err != nil {
+		return err
+	}
+	if m.Status == s_d.StatusComplete || m.Status == s_d.StatusCompletedByRetailPartner {
+		return impl.WebhookPublisher.Publish(ctx, m.StoreID, webhook_s.EventSubmissionCompleted, data)
+	}
+	return nil
+}
@@ -39,6 +39,18 @@ var KeyIssueMap = map[int]string{
 	1:  "Other",
 }
 
+// StatusNameMap is the name of the statuses we tell the customer and retailer
+// about, see `StatusWaiting`.
+var StatusNameMap = map[int8]string{
+	StatusWaiting:                  "Waiting",
+	StatusReceived:                 "Received",
+	StatusPending:                  "Pending",
+	StatusInProcess:                "In Process",
+	StatusComplete:                 "Complete",
+	StatusShipped:                  "Shipped",
+	StatusCompletedByRetailPartner: "Completed by Retail Partner",
+}
+
 var ServiceTypeMap = map[int8]string{
 	1: "Pre-Screening Service",
 	2: "CPS Pedigree Service",
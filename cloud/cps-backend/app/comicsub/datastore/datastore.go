@@ -127,6 +127,8 @@ type ComicSubmission struct {
 	LabelObjectURLExpiry        time.Time `bson:"label_object_url_expiry" json:"label_object_url_expiry"`
 	// CreditID stores the unique ID from the `Credit` table of the credit used to purchase this comic submission.
 	CreditID primitive.ObjectID `bson:"credit_id,omitempty" json:"credit_id,omitempty"`
+	// InvoiceID stores the unique ID from the `Invoice` table if this comic submission was billed to the store account.
+	InvoiceID primitive.ObjectID `bson:"invoice_id,omitempty" json:"invoice_id,omitempty"`
 	// PaymentProcessorName represents the name of the payment processor we used in the purchase.
 	PaymentProcessor int8 `bson:"payment_processor" json:"payment_processor"`
 	// PaymentProcessorPaymentIntentID represent the unique id returned by the payment processor that this comic book submisison was successfully purchased by the customer. If
@@ -215,9 +217,13 @@ type ComicSubmissionStorer interface {
 	GetByCPSRN(ctx context.Context, cpsrn string) (*ComicSubmission, error)
 	GetByPaymentProcessorPurchaseID(ctx context.Context, paymentProcessorPurchaseID string) (*ComicSubmission, error)
 	UpdateByID(ctx context.Context, m *ComicSubmission) error
+	UpdateStoreByStoreID(ctx context.Context, storeID primitive.ObjectID, storeName, storeTimezone string) (int64, error)
+	UpdateInspectorNameByInspectorID(ctx context.Context, inspectorID primitive.ObjectID, firstName, lastName string) (int64, error)
+	UpdateCustomerNameByCustomerID(ctx context.Context, customerID primitive.ObjectID, firstName, lastName string) (int64, error)
 	ListByFilter(ctx context.Context, f *ComicSubmissionPaginationListFilter) (*ComicSubmissionPaginationListResult, error)
 	ListAsSelectOptionByFilter(ctx context.Context, f *ComicSubmissionPaginationListFilter) ([]*ComicSubmissionAsSelectOption, error)
 	DeleteByID(ctx context.Context, id primitive.ObjectID) error
+	ListAllPurchasedBetween(ctx context.Context, purchasedAtGTE time.Time, purchasedAtLT time.Time) ([]*ComicSubmission, error)
 	CountAll(ctx context.Context) (int64, error)
 	CountByFilter(ctx context.Context, f *ComicSubmissionPaginationListFilter) (int64, error)
 	// //TODO: Add more...
@@ -105,3 +105,31 @@ func (impl ComicSubmissionStorerImpl) ListByFilter(ctx context.Context, f *Comic
 		HasNextPage: hasNextPage,
 	}, nil
 }
+
+// ListAllPurchasedBetween returns every comic submission which was purchased
+// through the payment processor within the time window.
+func (impl ComicSubmissionStorerImpl) ListAllPurchasedBetween(ctx context.Context, purchasedAtGTE time.Time, purchasedAtLT time.Time) ([]*ComicSubmission, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"payment_processor_purchase_id": bson.M{"$ne": ""},
+		"payment_processor_purchased_at": bson.M{
+			"$gte": purchasedAtGTE,
+			"$lt":  purchasedAtLT,
+		},
+	}
+
+	cursor, err := impl.Collection.Find(ctx, filter)
+	if err != nil {
+		impl.Logger.Error("database list all purchased between error", slog.Any("error", err))
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	results := []*ComicSubmission{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
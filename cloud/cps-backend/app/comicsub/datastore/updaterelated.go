@@ -0,0 +1,61 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UpdateStoreByStoreID updates the store details saved on every submission of
+// the store.
+func (impl ComicSubmissionStorerImpl) UpdateStoreByStoreID(ctx context.Context, storeID primitive.ObjectID, storeName, storeTimezone string) (int64, error) {
+	filter := bson.M{"store_id": storeID}
+	update := bson.M{"$set": bson.M{
+		"store_name":     storeName,
+		"store_timezone": storeTimezone,
+		"modified_at":    time.Now(),
+	}}
+	result, err := impl.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		impl.Logger.Error("database update many error", slog.Any("error", err))
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// UpdateInspectorNameByInspectorID updates the inspector name saved on every
+// submission they inspected.
+func (impl ComicSubmissionStorerImpl) UpdateInspectorNameByInspectorID(ctx context.Context, inspectorID primitive.ObjectID, firstName, lastName string) (int64, error) {
+	filter := bson.M{"inspector_id": inspectorID}
+	update := bson.M{"$set": bson.M{
+		"inspector_first_name": firstName,
+		"inspector_last_name":  lastName,
+		"modified_at":          time.Now(),
+	}}
+	result, err := impl.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		impl.Logger.Error("database update many error", slog.Any("error", err))
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// UpdateCustomerNameByCustomerID updates the customer name saved on every
+// submission of the customer.
+func (impl ComicSubmissionStorerImpl) UpdateCustomerNameByCustomerID(ctx context.Context, customerID primitive.ObjectID, firstName, lastName string) (int64, error) {
+	filter := bson.M{"customer_id": customerID}
+	update := bson.M{"$set": bson.M{
+		"customer_first_name": firstName,
+		"customer_last_name":  lastName,
+		"modified_at":         time.Now(),
+	}}
+	result, err := impl.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		impl.Logger.Error("database update many error", slog.Any("error", err))
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
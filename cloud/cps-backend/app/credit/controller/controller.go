@@ -7,7 +7,9 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/webhookpublisher"
 	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/credit/datastore"
+	notification_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/datastore"
 	offer_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
 	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
@@ -26,14 +28,16 @@ type CreditController interface {
 }
 
 type CreditControllerImpl struct {
-	Config       *config.Conf
-	Logger       *slog.Logger
-	UUID         uuid.Provider
-	DbClient     *mongo.Client
-	StoreStorer  store_s.StoreStorer
-	CreditStorer domain.CreditStorer
-	UserStorer   user_s.UserStorer
-	OfferStorer  offer_s.OfferStorer
+	Config             *config.Conf
+	Logger             *slog.Logger
+	UUID               uuid.Provider
+	DbClient           *mongo.Client
+	WebhookPublisher   webhookpublisher.Publisher
+	StoreStorer        store_s.StoreStorer
+	CreditStorer       domain.CreditStorer
+	UserStorer         user_s.UserStorer
+	OfferStorer        offer_s.OfferStorer
+	NotificationStorer notification_s.NotificationStorer
 }
 
 func NewController(
@@ -41,21 +45,25 @@ func NewController(
 	loggerp *slog.Logger,
 	uuidp uuid.Provider,
 	client *mongo.Client,
+	whp webhookpublisher.Publisher,
 	org_storer store_s.StoreStorer,
 	credit_storer domain.CreditStorer,
 	usr_storer user_s.UserStorer,
 	offer_storer offer_s.OfferStorer,
+	n_storer notification_s.NotificationStorer,
 ) CreditController {
 	loggerp.Debug("store controller initialization started...")
 	s := &CreditControllerImpl{
-		Config:       appCfg,
-		Logger:       loggerp,
-		UUID:         uuidp,
-		DbClient:     client,
-		StoreStorer:  org_storer,
-		CreditStorer: credit_storer,
-		UserStorer:   usr_storer,
-		OfferStorer:  offer_storer,
+		Config:             appCfg,
+		Logger:             loggerp,
+		UUID:               uuidp,
+		DbClient:           client,
+		WebhookPublisher:   whp,
+		StoreStorer:        org_storer,
+		CreditStorer:       credit_storer,
+		UserStorer:         usr_storer,
+		OfferStorer:        offer_storer,
+		NotificationStorer: n_storer,
 	}
 	s.Logger.Debug("store controller initialized")
 	return s
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -9,9 +10,12 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 
 	s_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/credit/datastore"
-	u_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
-	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	notification_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/datastore"
+	webhook_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/metrics"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 type CreditCreateRequest struct {
@@ -21,6 +25,18 @@ type CreditCreateRequest struct {
 	NumberOfCredits  int                `bson:"number_of_credits" json:"number_of_credits"`
 }
 
+// CreditWebhookData is the data of the `credit.granted` event we send to
+// the webhooks of the store.
+type CreditWebhookData struct {
+	UserID           primitive.ObjectID `json:"user_id"`
+	StoreID          primitive.ObjectID `json:"store_id"`
+	OfferID          primitive.ObjectID `json:"offer_id"`
+	OfferName        string             `json:"offer_name"`
+	OfferServiceType int8               `json:"offer_service_type"`
+	BusinessFunction int8               `json:"business_function"`
+	NumberOfCredits  int                `json:"number_of_credits"`
+}
+
 func (impl *CreditControllerImpl) Create(ctx context.Context, req *CreditCreateRequest) error {
 	////
 	//// Start the transaction.
@@ -37,17 +53,13 @@ func (impl *CreditControllerImpl) Create(ctx context.Context, req *CreditCreateR
 	// Define a transaction function with a series of operations
 	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
 		// Extract from our session the following data.
-		urole := sessCtx.Value(constants.SessionUserRole).(int8)
 		// uid := sessCtx.Value(constants.SessionUserID).(primitive.ObjectID)
 		// uname := sessCtx.Value(constants.SessionUserName).(string)
 		// oid := sessCtx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
 		// oname := sessCtx.Value(constants.SessionUserStoreName).(string)
 
-		switch urole { // Security.
-		case u_d.UserRoleRoot:
-			impl.Logger.Debug("access granted")
-		default:
-			return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+		if err := permission.Require(sessCtx, permission.CreditGrant); err != nil { // Security.
+			return nil, err
 		}
 
 		// Get the user.
@@ -103,6 +115,32 @@ func (impl *CreditControllerImpl) Create(ctx context.Context, req *CreditCreateR
 			}
 		}
 
+		n := notification_s.NewNotification(
+			user.ID,
+			notification_s.TypeCreditsGranted,
+			i18n.T(user.Locale, "You have received credits"),
+			fmt.Sprintf(i18n.T(user.Locale, "%v credits were added for %v."), req.NumberOfCredits, offer.Name),
+			fmt.Sprintf("/store/%v/credits", user.StoreID.Hex()),
+			user.ID)
+		if err := impl.NotificationStorer.Create(sessCtx, n); err != nil {
+			impl.Logger.Error("database create notification error", slog.Any("error", err))
+			return nil, err
+		}
+
+		data := &CreditWebhookData{
+			UserID:           user.ID,
+			StoreID:          user.StoreID,
+			OfferID:          offer.ID,
+			OfferName:        offer.Name,
+			OfferServiceType: offer.ServiceType,
+			BusinessFunction: req.BusinessFunction,
+			NumberOfCredits:  req.NumberOfCredits,
+		}
+		if err := impl.WebhookPublisher.Publish(sessCtx, user.StoreID, webhook_s.EventCreditGranted, data); err != nil {
+			impl.Logger.Error("publish credit granted error", slog.Any("error", err))
+			return nil, err
+		}
+
 		return nil, nil
 	}
 
@@ -113,5 +151,6 @@ func (impl *CreditControllerImpl) Create(ctx context.Context, req *CreditCreateR
 		return err
 	}
 
+	metrics.AddCreditsGranted(req.BusinessFunction, req.NumberOfCredits)
 	return nil
 }
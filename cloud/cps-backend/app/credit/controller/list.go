@@ -8,18 +8,17 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/credit/datastore"
-	u_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (c *CreditControllerImpl) ListByFilter(ctx context.Context, f *domain.CreditPaginationListFilter) (*domain.CreditPaginationListResult, error) {
 	// // Extract from our session the following data.
 	// userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
 	userOID := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
-	userRole := ctx.Value(constants.SessionUserRole).(int8)
 	//
 	// Apply filtering based on ownership and role.
-	if userRole != u_s.UserRoleRoot {
+	if !permission.Can(ctx, permission.CreditViewAll) {
 		f.StoreID = userOID
 	}
 
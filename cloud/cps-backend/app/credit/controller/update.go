@@ -10,9 +10,9 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 
 	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/credit/datastore"
-	u_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (impl *CreditControllerImpl) UpdateByID(ctx context.Context, ns *domain.Credit) (*domain.Credit, error) {
@@ -31,18 +31,14 @@ func (impl *CreditControllerImpl) UpdateByID(ctx context.Context, ns *domain.Cre
 	// Define a transaction function with a series of operations
 	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
 		// Extract from our session the following data.
-		urole := sessCtx.Value(constants.SessionUserRole).(int8)
 		// uid := sessCtx.Value(constants.SessionUserID).(primitive.ObjectID)
 		// uname := sessCtx.Value(constants.SessionUserName).(string)
 		oid := sessCtx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
 		oname := sessCtx.Value(constants.SessionUserStoreName).(string)
 		otz := sessCtx.Value(constants.SessionUserStoreTimezone).(string)
 
-		switch urole { // Security.
-		case u_d.UserRoleRoot:
-			impl.Logger.Debug("access granted")
-		default:
-			return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+		if err := permission.Require(sessCtx, permission.CreditGrant); err != nil { // Security.
+			return nil, err
 		}
 
 		// Fetch the original store.
@@ -20,3 +20,13 @@ func (impl CreditStorerImpl) CheckIfExistsByNameInOrgBranch(ctx context.Context,
 	}
 	return count >= 1, nil
 }
+
+func (impl CreditStorerImpl) CheckIfExistsByPaymentProcessorInvoiceID(ctx context.Context, paymentProcessorInvoiceID string) (bool, error) {
+	filter := bson.M{"payment_processor_invoice_id": paymentProcessorInvoiceID}
+	count, err := impl.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		impl.Logger.Error("database check if exists by payment processor invoice id error", slog.Any("error", err))
+		return false, err
+	}
+	return count >= 1, nil
+}
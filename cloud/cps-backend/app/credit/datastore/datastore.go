@@ -36,6 +36,8 @@ type Credit struct {
 	CreatedAt                  time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
 	ModifiedAt                 time.Time          `bson:"modified_at,omitempty" json:"modified_at,omitempty"`
 	ClaimedByComicSubmissionID primitive.ObjectID `bson:"claimed_by_comic_submission_id" json:"claimed_by_comic_submission_id"`
+	// PaymentProcessorInvoiceID is the subscription invoice which paid for this credit, if any.
+	PaymentProcessorInvoiceID string `bson:"payment_processor_invoice_id,omitempty" json:"payment_processor_invoice_id,omitempty"`
 }
 
 type CreditListFilter struct {
@@ -68,7 +70,10 @@ type CreditStorer interface {
 	GetByName(ctx context.Context, name string) (*Credit, error)
 	GetByPaymentProcessorCreditID(ctx context.Context, paymentProcessorCreditID string) (*Credit, error)
 	GetNextAvailable(ctx context.Context, userID primitive.ObjectID, serviceType int8) (*Credit, error)
+	CheckIfExistsByPaymentProcessorInvoiceID(ctx context.Context, paymentProcessorInvoiceID string) (bool, error)
 	UpdateByID(ctx context.Context, m *Credit) error
+	UpdateStoreByStoreID(ctx context.Context, storeID primitive.ObjectID, storeName, storeTimezone string) (int64, error)
+	UpdateUserNameByUserID(ctx context.Context, userID primitive.ObjectID, userName, userLexicalName string) (int64, error)
 	ListByFilter(ctx context.Context, m *CreditPaginationListFilter) (*CreditPaginationListResult, error)
 	ListAsSelectOptionByFilter(ctx context.Context, f *CreditPaginationListFilter) ([]*CreditAsSelectOption, error)
 	DeleteByID(ctx context.Context, id primitive.ObjectID) error
@@ -0,0 +1,44 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UpdateStoreByStoreID updates the store details saved on every record of the
+// store.
+func (impl CreditStorerImpl) UpdateStoreByStoreID(ctx context.Context, storeID primitive.ObjectID, storeName, storeTimezone string) (int64, error) {
+	filter := bson.M{"store_id": storeID}
+	update := bson.M{"$set": bson.M{
+		"store_name":     storeName,
+		"store_timezone": storeTimezone,
+		"modified_at":    time.Now(),
+	}}
+	result, err := impl.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		impl.Logger.Error("database update many error", slog.Any("error", err))
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// UpdateUserNameByUserID updates the user name saved on every record of the
+// user.
+func (impl CreditStorerImpl) UpdateUserNameByUserID(ctx context.Context, userID primitive.ObjectID, userName, userLexicalName string) (int64, error) {
+	filter := bson.M{"user_id": userID}
+	update := bson.M{"$set": bson.M{
+		"user_name":         userName,
+		"user_lexical_name": userLexicalName,
+		"modified_at":       time.Now(),
+	}}
+	result, err := impl.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		impl.Logger.Error("database update many error", slog.Any("error", err))
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
@@ -12,7 +12,7 @@ import (
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/pdfbuilder"
 	s3_storage "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/storage/s3"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
-	submission_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
+	sj_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/datastore"
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/password"
@@ -32,17 +32,17 @@ type CustomerController interface {
 }
 
 type CustomerControllerImpl struct {
-	Config                *config.Conf
-	Logger                *slog.Logger
-	UUID                  uuid.Provider
-	S3                    s3_storage.S3Storager
-	Password              password.Provider
-	PaymentProcessor      pm.PaymentProcessor
-	CBFFBuilder           pdfbuilder.CBFFBuilder
-	DbClient              *mongo.Client
-	UserStorer            user_s.UserStorer
-	ComicSubmissionStorer submission_s.ComicSubmissionStorer
-	TemplatedEmailer      templatedemailer.TemplatedEmailer
+	Config           *config.Conf
+	Logger           *slog.Logger
+	UUID             uuid.Provider
+	S3               s3_storage.S3Storager
+	Password         password.Provider
+	PaymentProcessor pm.PaymentProcessor
+	CBFFBuilder      pdfbuilder.CBFFBuilder
+	DbClient         *mongo.Client
+	UserStorer       user_s.UserStorer
+	SyncJobStorer    sj_s.SyncJobStorer
+	TemplatedEmailer templatedemailer.TemplatedEmailer
 }
 
 func NewController(
@@ -56,20 +56,20 @@ func NewController(
 	temailer templatedemailer.TemplatedEmailer,
 	client *mongo.Client,
 	u_storer user_s.UserStorer,
-	sub_storer submission_s.ComicSubmissionStorer,
+	sj_storer sj_s.SyncJobStorer,
 ) CustomerController {
 	s := &CustomerControllerImpl{
-		Config:                appCfg,
-		Logger:                loggerp,
-		UUID:                  uuidp,
-		S3:                    s3,
-		Password:              passwordp,
-		PaymentProcessor:      paymentProcessor,
-		CBFFBuilder:           cbffb,
-		TemplatedEmailer:      temailer,
-		DbClient:              client,
-		UserStorer:            u_storer,
-		ComicSubmissionStorer: sub_storer,
+		Config:           appCfg,
+		Logger:           loggerp,
+		UUID:             uuidp,
+		S3:               s3,
+		Password:         passwordp,
+		PaymentProcessor: paymentProcessor,
+		CBFFBuilder:      cbffb,
+		TemplatedEmailer: temailer,
+		DbClient:         client,
+		UserStorer:       u_storer,
+		SyncJobStorer:    sj_storer,
 	}
 	s.Logger.Debug("customer controller initialization started...")
 	s.Logger.Debug("customer controller initialized")
@@ -20,6 +20,7 @@ type CustomerCreateRequestIDO struct {
 	FirstName                                       string `json:"first_name"`
 	LastName                                        string `json:"last_name"`
 	Email                                           string `json:"email"`
+	Locale                                          string `json:"locale,omitempty"`
 	Phone                                           string `json:"phone,omitempty"`
 	Country                                         string `json:"country,omitempty"`
 	Region                                          string `json:"region,omitempty"`
@@ -56,6 +57,7 @@ func (impl *CustomerControllerImpl) userFromCreateRequest(requestData *CustomerC
 		FirstName:                             requestData.FirstName,
 		LastName:                              requestData.LastName,
 		Email:                                 requestData.Email,
+		Locale:                                requestData.Locale,
 		Phone:                                 requestData.Phone,
 		Country:                               requestData.Country,
 		Region:                                requestData.Region,
@@ -175,7 +177,7 @@ func (impl *CustomerControllerImpl) Create(ctx context.Context, requestData *Cus
 		}
 
 		// Send email to user of the new password.
-		if err := impl.TemplatedEmailer.SendNewUserTemporaryPasswordEmail(m.Email, m.FirstName, temporaryPassword); err != nil {
+		if err := impl.TemplatedEmailer.SendNewUserTemporaryPasswordEmail(sessCtx, m.Locale, m.Email, m.FirstName, temporaryPassword); err != nil {
 			impl.Logger.Error("failed sending verification email with error", slog.Any("err", err))
 			return nil, err
 		}
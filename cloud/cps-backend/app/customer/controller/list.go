@@ -9,15 +9,15 @@ import (
 
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (c *CustomerControllerImpl) ListByFilter(ctx context.Context, f *user_s.UserPaginationListFilter) (*user_s.UserPaginationListResult, error) {
 	// // Extract from our session the following data.
 	storeID := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
-	userRole := ctx.Value(constants.SessionUserRole).(int8)
 
 	// Apply filtering based on ownership and role.
-	if userRole == user_s.UserRoleRetailer {
+	if !permission.Can(ctx, permission.CustomerViewAll) {
 		f.StoreID = storeID
 	}
 
@@ -11,6 +11,7 @@ import (
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (impl *CustomerControllerImpl) CreateComment(ctx context.Context, customerID primitive.ObjectID, content string) (*user_s.User, error) {
@@ -97,7 +98,6 @@ func (impl *CustomerControllerImpl) Star(ctx context.Context, id primitive.Objec
 		userID, _ := sessCtx.Value(constants.SessionUserID).(primitive.ObjectID)
 		userName, _ := sessCtx.Value(constants.SessionUserName).(string)
 		userStoreID, _ := sessCtx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
-		userRole := sessCtx.Value(constants.SessionUserRole).(int8)
 
 		// Lookup the user in our database, else return a `400 Bad Request` error.
 		ou, err := impl.UserStorer.GetByID(sessCtx, id)
@@ -111,7 +111,7 @@ func (impl *CustomerControllerImpl) Star(ctx context.Context, id primitive.Objec
 		}
 
 		// Apply filtering based on ownership and role.
-		if userRole != user_s.UserRoleRoot {
+		if !permission.Can(sessCtx, permission.CustomerViewAll) {
 			if userStoreID != ou.StoreID {
 				impl.Logger.Error("permission error",
 					slog.Any("userStoreID", userStoreID),
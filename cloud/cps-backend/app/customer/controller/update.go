@@ -3,12 +3,14 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"log/slog"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	sj_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/datastore"
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
@@ -47,14 +49,20 @@ func (impl *CustomerControllerImpl) UpdateByID(ctx context.Context, nu *user_s.U
 			return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
 		}
 
+		// The email can only be changed by the user confirming the new
+		// address, otherwise the password reset channel could be taken over.
+		if nu.Email != "" && !strings.EqualFold(nu.Email, ou.Email) {
+			return nil, httperror.NewForBadRequestWithSingleField("email", "can only be changed by the user from their profile")
+		}
+
 		ou.StoreID = orgID
 		ou.StoreName = orgName
 		ou.FirstName = nu.FirstName
 		ou.LastName = nu.LastName
 		ou.Name = fmt.Sprintf("%s %s", nu.FirstName, nu.LastName)
 		ou.LexicalName = fmt.Sprintf("%s, %s", nu.LastName, nu.FirstName)
-		ou.Email = nu.Email
 		ou.Phone = nu.Phone
+		ou.Locale = nu.Locale
 		ou.Country = nu.Country
 		ou.Region = nu.Region
 		ou.City = nu.City
@@ -88,6 +96,12 @@ func (impl *CustomerControllerImpl) UpdateByID(ctx context.Context, nu *user_s.U
 			return nil, err
 		}
 
+		// Queue copying the customer names onto their related records.
+		if err := impl.SyncJobStorer.Create(sessCtx, sj_s.NewSyncJob(sj_s.TypeUser, ou.ID)); err != nil {
+			impl.Logger.Error("sync job create error", slog.Any("error", err))
+			return nil, err
+		}
+
 		// Defensive Code: In case user does not have an account with the payment processor.
 		if ou.PaymentProcessorCustomerID != "" {
 			err = impl.PaymentProcessor.UpdateCustomer(
@@ -123,15 +137,5 @@ func (impl *CustomerControllerImpl) UpdateByID(ctx context.Context, nu *user_s.U
 
 	usr := res.(*user_s.User)
 
-	////
-	//// Update related (in background)
-	////
-
-	go func(usr *user_s.User) {
-		if err := impl.updateRelatedComicsInBackground(usr); err != nil {
-			impl.Logger.Error("update related comics failed", slog.Any("error", err))
-		}
-	}(usr)
-
 	return usr, nil
 }
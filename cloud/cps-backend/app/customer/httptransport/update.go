@@ -7,6 +7,7 @@ import (
 
 	usr_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -97,6 +98,10 @@ func ValidateUpdateRequest(dirtyData *usr_s.User) error {
 		e["has_regularly_attended_comic_cons_or_collectible_shows"] = "missing value"
 	}
 
+	if dirtyData.Locale != "" && !i18n.IsSupported(dirtyData.Locale) {
+		e["locale"] = "unsupported value"
+	}
+
 	if len(e) != 0 {
 		return httperror.NewForBadRequest(&e)
 	}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+// EmailTemplateController Interface for reviewing the translated email
+// templates.
+type EmailTemplateController interface {
+	List(ctx context.Context) ([]string, error)
+	Preview(ctx context.Context, name string, locale string) (string, error)
+}
+
+type EmailTemplateControllerImpl struct {
+	Config           *config.Conf
+	Logger           *slog.Logger
+	TemplatedEmailer templatedemailer.TemplatedEmailer
+}
+
+func NewController(
+	appCfg *config.Conf,
+	loggerp *slog.Logger,
+	temailer templatedemailer.TemplatedEmailer,
+) EmailTemplateController {
+	loggerp.Debug("email template controller initialization started...")
+	s := &EmailTemplateControllerImpl{
+		Config:           appCfg,
+		Logger:           loggerp,
+		TemplatedEmailer: temailer,
+	}
+	s.Logger.Debug("email template controller initialized")
+	return s
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"log/slog"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+func (c *EmailTemplateControllerImpl) List(ctx context.Context) ([]string, error) {
+	if !permission.Can(ctx, permission.EmailTemplatePreview) {
+		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+	return c.TemplatedEmailer.TemplateNames(), nil
+}
+
+func (c *EmailTemplateControllerImpl) Preview(ctx context.Context, name string, locale string) (string, error) {
+	if !permission.Can(ctx, permission.EmailTemplatePreview) {
+		return "", httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+	if locale == "" {
+		locale = i18n.DefaultLocale
+	}
+	if !i18n.IsSupported(locale) {
+		return "", httperror.NewForBadRequestWithSingleField("locale", "unsupported value")
+	}
+
+	found := false
+	for _, n := range c.TemplatedEmailer.TemplateNames() {
+		found = found || n == name
+	}
+	if !found {
+		return "", httperror.NewForSingleField(http.StatusNotFound, "name", "email template does not exist")
+	}
+
+	html, err := c.TemplatedEmailer.Preview(name, locale)
+	if err != nil {
+		c.Logger.Error("preview error", slog.String("name", name), slog.String("locale", locale), slog.Any("error", err))
+		return "", err
+	}
+	return html, nil
+}
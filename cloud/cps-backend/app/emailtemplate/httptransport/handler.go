@@ -0,0 +1,21 @@
+package httptransport
+
+import (
+	"log/slog"
+
+	emailtemplate_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/emailtemplate/controller"
+)
+
+// Handler Creates http request handler
+type Handler struct {
+	Logger     *slog.Logger
+	Controller emailtemplate_c.EmailTemplateController
+}
+
+// NewHandler Constructor
+func NewHandler(loggerp *slog.Logger, c emailtemplate_c.EmailTemplateController) *Handler {
+	return &Handler{
+		Logger:     loggerp,
+		Controller: c,
+	}
+}
@@ -0,0 +1,23 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	names, err := h.Controller.List(ctx)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(&names); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,21 @@
+package httptransport
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+func (h *Handler) Preview(w http.ResponseWriter, r *http.Request, name string) {
+	ctx := r.Context()
+
+	html, err := h.Controller.Preview(ctx, name, r.URL.Query().Get("locale"))
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	io.WriteString(w, html)
+}
@@ -3,25 +3,33 @@ package controller
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"log"
 	"log/slog"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/cache/mongodbcache"
 	pm "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/paymentprocessor/stripe"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
 	gateway_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/datastore"
+	la_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/loginattempt/datastore"
+	prt_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/passwordreset/datastore"
+	ses_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/session/datastore"
 	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
+	sj_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/datastore"
 	u_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	wa_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webauthn/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/jwt"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/kmutex"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/oidc"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/password"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/webauthn"
 )
 
 type GatewayController interface {
@@ -43,21 +51,45 @@ type GatewayController interface {
 	ValidateOTP(ctx context.Context, req *ValidateTokenRequestIDO) (*ValidateTokenResponseIDO, error)
 	DisableOTP(ctx context.Context) (*u_d.User, error)
 	RecoveryOTP(ctx context.Context, req *RecoveryRequestIDO) (*gateway_s.LoginResponseIDO, error)
+	RegenerateOTPRecoveryCodes(ctx context.Context) (*OTPRecoveryCodesResponseIDO, error)
+	WebAuthnRegisterBegin(ctx context.Context) (*WebAuthnBeginResponseIDO, error)
+	WebAuthnRegisterFinish(ctx context.Context, req *WebAuthnRegisterFinishRequestIDO) (*wa_s.WebAuthnCredential, error)
+	WebAuthnListCredentials(ctx context.Context) ([]*wa_s.WebAuthnCredential, error)
+	WebAuthnDeleteCredential(ctx context.Context, id primitive.ObjectID) error
+	WebAuthnValidateBegin(ctx context.Context) (*WebAuthnBeginResponseIDO, error)
+	WebAuthnValidateFinish(ctx context.Context, req *WebAuthnAssertionRequestIDO) (*ValidateTokenResponseIDO, error)
+	WebAuthnLoginBegin(ctx context.Context, email string) (*WebAuthnBeginResponseIDO, error)
+	WebAuthnLoginFinish(ctx context.Context, req *WebAuthnAssertionRequestIDO) (*gateway_s.LoginResponseIDO, error)
+	OIDCLoginBegin(ctx context.Context) (*OIDCLoginBeginResponseIDO, error)
+	OIDCLoginFinish(ctx context.Context, req *OIDCLoginFinishRequestIDO) (*gateway_s.LoginResponseIDO, error)
+	ProfileChangeEmail(ctx context.Context, req *ProfileChangeEmailRequestIDO) error
+	ProfileCancelEmailChange(ctx context.Context) error
+	ConfirmEmailChange(ctx context.Context, code string) error
+	Unsubscribe(ctx context.Context, token string) error
+	StreamTicket(ctx context.Context) (*StreamTicketResponseIDO, error)
+	RedeemStreamTicket(ctx context.Context, ticket string) (string, error)
 }
 
 type GatewayControllerImpl struct {
-	Config           *config.Conf
-	Logger           *slog.Logger
-	UUID             uuid.Provider
-	JWT              jwt.Provider
-	Kmutex           kmutex.Provider
-	Password         password.Provider
-	Cache            mongodbcache.Cacher
-	DbClient         *mongo.Client
-	TemplatedEmailer templatedemailer.TemplatedEmailer
-	PaymentProcessor pm.PaymentProcessor
-	UserStorer       user_s.UserStorer
-	StoreStorer      store_s.StoreStorer
+	Config              *config.Conf
+	Logger              *slog.Logger
+	UUID                uuid.Provider
+	JWT                 jwt.Provider
+	Kmutex              kmutex.Provider
+	Password            password.Provider
+	WebAuthn            webauthn.Provider
+	OIDC                oidc.Provider
+	Cache               mongodbcache.Cacher
+	DbClient            *mongo.Client
+	TemplatedEmailer    templatedemailer.TemplatedEmailer
+	PaymentProcessor    pm.PaymentProcessor
+	UserStorer          user_s.UserStorer
+	StoreStorer         store_s.StoreStorer
+	SessionStorer       ses_s.SessionStorer
+	LoginAttemptStorer  la_s.LoginAttemptStorer
+	WebAuthnStorer      wa_s.WebAuthnCredentialStorer
+	PasswordResetStorer prt_s.PasswordResetTokenStorer
+	SyncJobStorer       sj_s.SyncJobStorer
 }
 
 func NewController(
@@ -67,26 +99,40 @@ func NewController(
 	jwtp jwt.Provider,
 	kmx kmutex.Provider,
 	passwordp password.Provider,
+	webauthnp webauthn.Provider,
+	oidcp oidc.Provider,
 	cache mongodbcache.Cacher,
 	client *mongo.Client,
 	te templatedemailer.TemplatedEmailer,
 	paymentProcessor pm.PaymentProcessor,
 	usr_storer user_s.UserStorer,
 	org_storer store_s.StoreStorer,
+	ses_storer ses_s.SessionStorer,
+	la_storer la_s.LoginAttemptStorer,
+	wa_storer wa_s.WebAuthnCredentialStorer,
+	prt_storer prt_s.PasswordResetTokenStorer,
+	sj_storer sj_s.SyncJobStorer,
 ) GatewayController {
 	s := &GatewayControllerImpl{
-		Config:           appCfg,
-		Logger:           loggerp,
-		UUID:             uuidp,
-		JWT:              jwtp,
-		Kmutex:           kmx,
-		Password:         passwordp,
-		Cache:            cache,
-		DbClient:         client,
-		TemplatedEmailer: te,
-		PaymentProcessor: paymentProcessor,
-		UserStorer:       usr_storer,
-		StoreStorer:      org_storer,
+		Config:              appCfg,
+		Logger:              loggerp,
+		UUID:                uuidp,
+		JWT:                 jwtp,
+		Kmutex:              kmx,
+		Password:            passwordp,
+		WebAuthn:            webauthnp,
+		OIDC:                oidcp,
+		Cache:               cache,
+		DbClient:            client,
+		TemplatedEmailer:    te,
+		PaymentProcessor:    paymentProcessor,
+		UserStorer:          usr_storer,
+		StoreStorer:         org_storer,
+		SessionStorer:       ses_storer,
+		LoginAttemptStorer:  la_storer,
+		WebAuthnStorer:      wa_storer,
+		PasswordResetStorer: prt_storer,
+		SyncJobStorer:       sj_storer,
 	}
 	s.Logger.Debug("gateway controller initialization started...")
 
@@ -103,13 +149,23 @@ func NewController(
 func (impl *GatewayControllerImpl) GetUserBySessionID(ctx context.Context, sessionID string) (*user_s.User, error) {
 	impl.Logger.Debug("gateway controller initialization started...")
 
-	userBytes, err := impl.Cache.Get(ctx, sessionID)
+	// The session record is the authority on whether the user is still
+	// logged in, it gets deleted when the session was revoked.
+	ses, err := impl.SessionStorer.GetBySessionID(ctx, sessionID)
 	if err != nil {
 		return nil, err
 	}
-	if userBytes == nil {
-		impl.Logger.Warn("record not found")
-		return nil, errors.New("record not found")
+	// A revoked or expired session returns no user so the middleware
+	// responds with `401` and the client signs in again.
+	if ses == nil {
+		impl.Logger.Warn("session not found")
+		return nil, nil
+	}
+
+	userBytes, err := impl.Cache.Get(ctx, sessionID)
+	if err != nil || userBytes == nil {
+		impl.Logger.Warn("session user not found in cache", slog.Any("err", err))
+		return nil, nil
 	}
 	var user user_s.User
 	err = json.Unmarshal(userBytes, &user)
@@ -118,6 +174,18 @@ func (impl *GatewayControllerImpl) GetUserBySessionID(ctx context.Context, sessi
 		return nil, err
 	}
 
+	// Keep track of when the session was last used, we throttle the writes
+	// so we do not update the database on every request.
+	if time.Since(ses.LastSeenAt) > time.Minute {
+		ses.LastSeenAt = time.Now()
+		if ipAddress, _ := ctx.Value(constants.SessionIPAddress).(string); ipAddress != "" {
+			ses.IPAddress = ipAddress
+		}
+		if err := impl.SessionStorer.UpdateByID(ctx, ses); err != nil {
+			impl.Logger.Error("session update error", slog.Any("err", err))
+		}
+	}
+
 	impl.Logger.Debug("gateway controller initialized")
 	return &user, nil
 }
@@ -0,0 +1,260 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	u_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+// emailChangeExpiry is how long the link sent to the new email works.
+const emailChangeExpiry = 24 * time.Hour
+
+type ProfileChangeEmailRequestIDO struct {
+	Email string `json:"email"`
+	// Password is asked again so a stolen session alone cannot take over
+	// the account's password reset channel.
+	Password string `json:"password"`
+}
+
+type EmailChangeConfirmRequestIDO struct {
+	Code string `json:"code"`
+}
+
+func validateProfileChangeEmailRequest(req *ProfileChangeEmailRequestIDO) error {
+	e := make(map[string]string)
+
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	if req.Email == "" {
+		e["email"] = "missing value"
+	} else if len(req.Email) > 255 {
+		e["email"] = "too long"
+	} else if _, err := mail.ParseAddress(req.Email); err != nil {
+		e["email"] = "invalid email"
+	}
+	if req.Password == "" {
+		e["password"] = "missing value"
+	}
+
+	if len(e) != 0 {
+		return httperror.NewForBadRequest(&e)
+	}
+	return nil
+}
+
+// ProfileChangeEmail starts changing the email of the logged in user, the
+// email only changes once the user confirms the link we send to it.
+func (impl *GatewayControllerImpl) ProfileChangeEmail(ctx context.Context, req *ProfileChangeEmailRequestIDO) error {
+	if err := validateProfileChangeEmailRequest(req); err != nil {
+		return err
+	}
+
+	u, err := impl.getSessionUserWithSecondFactor(ctx)
+	if err != nil {
+		return err
+	}
+	if passwordMatch, _ := impl.Password.ComparePasswordAndHash(req.Password, u.PasswordHash); !passwordMatch {
+		impl.Logger.Warn("password check validation error", slog.String("user_id", u.ID.Hex()))
+		return httperror.NewForBadRequestWithSingleField("password", "does not match with record")
+	}
+	if req.Email == u.Email {
+		return httperror.NewForBadRequestWithSingleField("email", "is already your email")
+	}
+	exists, err := impl.UserStorer.CheckIfExistsByEmail(ctx, req.Email)
+	if err != nil {
+		impl.Logger.Error("database error", slog.Any("err", err))
+		return err
+	}
+	if exists {
+		return httperror.NewForBadRequestWithSingleField("email", "email is not unique")
+	}
+
+	u.PendingEmail = req.Email
+	u.EmailChangeCode = impl.UUID.NewUUID()
+	u.EmailChangeExpiry = time.Now().Add(emailChangeExpiry)
+	u.ModifiedAt = time.Now()
+	if err := impl.UserStorer.UpdateByID(ctx, u); err != nil {
+		impl.Logger.Error("user update by id error", slog.Any("error", err))
+		return err
+	}
+	if err := impl.saveSessionUser(ctx, u); err != nil {
+		return err
+	}
+
+	expiresAt := u.EmailChangeExpiry.Format("2006-01-02 15:04 MST")
+	if err := impl.TemplatedEmailer.SendEmailChangeVerificationEmail(ctx, u.Locale, u.PendingEmail, u.FirstName, u.EmailChangeCode, expiresAt); err != nil {
+		impl.Logger.Error("failed sending email change verification email", slog.Any("err", err))
+		return err
+	}
+	if err := impl.TemplatedEmailer.SendEmailChangeNoticeEmail(ctx, u.Locale, u.Email, u.FirstName, u.PendingEmail); err != nil {
+		// Do not fail as the new address already got its link.
+		impl.Logger.Error("failed sending email change notice email", slog.Any("err", err))
+	}
+	impl.Logger.Info("email change requested", slog.String("user_id", u.ID.Hex()))
+	return nil
+}
+
+// ProfileCancelEmailChange cancels the pending email change of the logged in user.
+func (impl *GatewayControllerImpl) ProfileCancelEmailChange(ctx context.Context) error {
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	u, err := impl.UserStorer.GetByID(ctx, userID)
+	if err != nil {
+		impl.Logger.Error("database error", slog.Any("err", err))
+		return err
+	}
+	if u == nil {
+		return httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+	u.PendingEmail = ""
+	u.EmailChangeCode = ""
+	u.EmailChangeExpiry = time.Time{}
+	u.ModifiedAt = time.Now()
+	if err := impl.UserStorer.UpdateByID(ctx, u); err != nil {
+		impl.Logger.Error("user update by id error", slog.Any("error", err))
+		return err
+	}
+	return impl.saveSessionUser(ctx, u)
+}
+
+// ConfirmEmailChange swaps in the new email of the user who the code was
+// sent to. The link may be opened on any device so no session is needed.
+func (impl *GatewayControllerImpl) ConfirmEmailChange(ctx context.Context, code string) error {
+	if code == "" {
+		return httperror.NewForBadRequestWithSingleField("code", "missing value")
+	}
+	impl.Kmutex.Lock(code)
+	defer func() {
+		impl.Kmutex.Unlock(code)
+	}()
+
+	////
+	//// Start the transaction.
+	////
+
+	session, err := impl.DbClient.StartSession()
+	if err != nil {
+		impl.Logger.Error("start session error",
+			slog.Any("error", err))
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// Define a transaction function with a series of operations
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		u, err := impl.UserStorer.GetByEmailChangeCode(sessCtx, code)
+		if err != nil {
+			impl.Logger.Error("database error", slog.Any("err", err))
+			return nil, err
+		}
+		if u == nil || u.PendingEmail == "" || time.Now().After(u.EmailChangeExpiry) {
+			impl.Logger.Warn("email change code does not exist or expired")
+			return nil, httperror.NewForBadRequestWithSingleField("code", "expired or invalid")
+		}
+
+		// Someone may have signed up with the email in the meantime.
+		exists, err := impl.UserStorer.CheckIfExistsByEmail(sessCtx, u.PendingEmail)
+		if err != nil {
+			impl.Logger.Error("database error", slog.Any("err", err))
+			return nil, err
+		}
+		if exists {
+			return nil, httperror.NewForBadRequestWithSingleField("email", "email is not unique")
+		}
+
+		oldEmail := u.Email
+		u.Email = u.PendingEmail
+		u.WasEmailVerified = true
+		u.PendingEmail = ""
+		u.EmailChangeCode = ""
+		u.EmailChangeExpiry = time.Time{}
+		u.ModifiedAt = time.Now()
+		if err := impl.UserStorer.UpdateByID(sessCtx, u); err != nil {
+			impl.Logger.Error("user update by id error", slog.Any("error", err))
+			return nil, err
+		}
+
+		// Keep the payment processor's copy of the customer in sync.
+		if r := permission.GetRole(u.Role); r != nil && r.Scope != permission.ScopeSystem && u.PaymentProcessorCustomerID != "" {
+			if "Stripe, Inc." == impl.PaymentProcessor.GetName() {
+				err = impl.PaymentProcessor.UpdateCustomer(
+					u.PaymentProcessorCustomerID,
+					fmt.Sprintf("%s %s", u.FirstName, u.LastName),
+					u.Email,
+					"", // description...
+					fmt.Sprintf("%s %s Shipping Address", u.FirstName, u.LastName),
+					u.Phone,
+					u.ShippingCity, u.ShippingCountry, u.ShippingAddressLine1, u.ShippingAddressLine2, u.ShippingPostalCode, u.ShippingRegion, // Shipping
+					u.City, u.Country, u.AddressLine1, u.AddressLine2, u.PostalCode, u.Region, // Billing
+				)
+				if err != nil {
+					impl.Logger.Error("updated customer from payment processor error", slog.Any("error", err))
+					return nil, err
+				}
+			}
+		}
+
+		impl.Logger.Info("email changed",
+			slog.String("user_id", u.ID.Hex()),
+			slog.String("old_email", oldEmail))
+		return u, nil
+	}
+
+	// Start a transaction
+	res, err := session.WithTransaction(ctx, transactionFunc)
+	if err != nil {
+		impl.Logger.Error("session failed error",
+			slog.Any("error", err))
+		return err
+	}
+
+	// The logged in sessions keep a copy of the user.
+	impl.refreshSessionUsers(ctx, res.(*u_s.User))
+	return nil
+}
+
+// refreshSessionUsers updates the copy of the user kept by every one of their
+// logged in sessions. Each session keeps its own second factor state as the
+// user record says nothing about how the other sessions signed in.
+func (impl *GatewayControllerImpl) refreshSessionUsers(ctx context.Context, u *u_s.User) {
+	sessions, err := impl.SessionStorer.ListByUserID(ctx, u.ID)
+	if err != nil {
+		impl.Logger.Error("list sessions error", slog.Any("error", err))
+		return
+	}
+	for _, ses := range sessions {
+		expiry := time.Until(ses.ExpiresAt)
+		if expiry <= 0 {
+			continue
+		}
+		cached, err := impl.Cache.Get(ctx, ses.SessionID)
+		if err != nil || cached == nil {
+			continue // Session expired from the cache, nothing to refresh.
+		}
+		var prev u_s.User
+		if err := json.Unmarshal(cached, &prev); err != nil {
+			impl.Logger.Error("unmarshalling failed", slog.Any("err", err))
+			continue
+		}
+		su := *u
+		su.OTPValidated = prev.OTPValidated
+		uBin, err := json.Marshal(&su)
+		if err != nil {
+			impl.Logger.Error("marshalling error", slog.Any("err", err))
+			continue
+		}
+		if err := impl.Cache.SetWithExpiry(ctx, ses.SessionID, uBin, expiry); err != nil {
+			impl.Logger.Error("cache set with expiry error", slog.Any("err", err))
+		}
+	}
+}
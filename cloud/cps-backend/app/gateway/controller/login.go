@@ -7,12 +7,20 @@ import (
 	"strings"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
 	gateway_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/datastore"
+	la_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/loginattempt/datastore"
+	ses_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/session/datastore"
 	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
 	u_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
 )
 
+// errInvalidLogin is returned for every kind of bad credentials.
+var errInvalidLogin = httperror.NewForBadRequestWithSingleField("message", "invalid email or password")
+
 func (impl *GatewayControllerImpl) Login(ctx context.Context, email, password string) (*gateway_s.LoginResponseIDO, error) {
 	// Defensive Code: For security purposes we need to perform some sanitization on the inputs.
 	email = strings.ToLower(email)
@@ -23,7 +31,17 @@ func (impl *GatewayControllerImpl) Login(ctx context.Context, email, password st
 	password = strings.ReplaceAll(password, "\t", "")
 	password = strings.TrimSpace(password)
 
+	// Stop brute force attacks by limiting the failed attempts for the email
+	// and the ip address.
+	accountKey := la_s.AccountKey(email)
+	attemptKeys := loginAttemptKeys(ctx, accountKey)
+	if err := impl.checkLoginAttempts(ctx, attemptKeys); err != nil {
+		return nil, err
+	}
+
 	// Lookup the user in our database, else return a `400 Bad Request` error.
+	// Please note we return the same error whether the email or the password
+	// was wrong so we do not reveal which emails have an account.
 	u, err := impl.UserStorer.GetByEmail(ctx, email)
 	if err != nil {
 		impl.Logger.Error("database error", slog.Any("err", err))
@@ -31,56 +49,80 @@ func (impl *GatewayControllerImpl) Login(ctx context.Context, email, password st
 	}
 	if u == nil {
 		impl.Logger.Warn("user does not exist validation error")
-		return nil, httperror.NewForBadRequestWithSingleField("email", "does not exist")
+
+		// Hash anyways so the response time does not reveal the email has no account.
+		_, _ = impl.Password.GenerateHashFromPassword(password)
+
+		impl.recordLoginFailure(ctx, attemptKeys, nil)
+		return nil, errInvalidLogin
+	}
+
+	// Verify the inputted password and hashed password match.
+	passwordMatch, _ := impl.Password.ComparePasswordAndHash(password, u.PasswordHash)
+	if passwordMatch == false {
+		impl.Logger.Warn("password check validation error")
+		impl.recordLoginFailure(ctx, attemptKeys, u)
+		return nil, errInvalidLogin
+	}
+	impl.resetLoginAttempts(ctx, accountKey)
+
+	if err := impl.checkCanLogin(ctx, u); err != nil {
+		return nil, err
 	}
 
+	if err := impl.requireSecondFactor(ctx, u); err != nil {
+		return nil, err
+	}
+
+	return impl.loginWithUser(ctx, u)
+}
+
+// requireSecondFactor enforces 2FA for the new login if the user enabled it.
+func (impl *GatewayControllerImpl) requireSecondFactor(ctx context.Context, u *u_s.User) error {
+	if u.OTPEnabled || u.WebAuthnEnabled || impl.isWebAuthnRequired(u) {
+		// We need to reset the `otp_validated` status to be false to force
+		// the user to use their `totp authenticator` application or their
+		// security key.
+		u.OTPValidated = false
+		u.ModifiedAt = time.Now()
+		if err := impl.UserStorer.UpdateByID(ctx, u); err != nil {
+			impl.Logger.Error("failed updating user during login", slog.Any("err", err))
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCanLogin returns an error if the store of the user or the user's
+// email does not allow signing in yet.
+func (impl *GatewayControllerImpl) checkCanLogin(ctx context.Context, u *u_s.User) error {
 	// Lookup the store and check to see if it's active or not, if not active then return the specific requests.
 	o, err := impl.StoreStorer.GetByID(ctx, u.StoreID)
 	if err != nil {
 		impl.Logger.Error("database error", slog.Any("err", err))
-		return nil, err
+		return err
 	}
 	switch o.Status {
 	case store_s.StorePendingStatus:
 		impl.Logger.Warn("user does not exist validation error")
-		return nil, httperror.NewForLockedWithSingleField("message", "Your application is pending approval")
+		return httperror.NewForLockedWithSingleField("message", "Your application is pending approval")
 	case store_s.StoreRejectedStatus:
 		impl.Logger.Warn("user does not exist validation error")
-		return nil, httperror.NewForLockedWithSingleField("message", "Your application has been rejected")
+		return httperror.NewForLockedWithSingleField("message", "Your application has been rejected")
 	case store_s.StoreErrorStatus:
 		impl.Logger.Warn("user does not exist validation error")
-		return nil, httperror.NewForBadRequestWithSingleField("message", "problem with your store found, please contact administrator")
+		return httperror.NewForBadRequestWithSingleField("message", "problem with your store found, please contact administrator")
 	case store_s.StoreArchivedStatus:
 		impl.Logger.Warn("user does not exist validation error")
-		return nil, httperror.NewForGoneWithSingleField("message", "store does not exist")
-	}
-
-	// Verify the inputted password and hashed password match.
-	passwordMatch, _ := impl.Password.ComparePasswordAndHash(password, u.PasswordHash)
-	if passwordMatch == false {
-		impl.Logger.Warn("password check validation error")
-		return nil, httperror.NewForBadRequestWithSingleField("password", "password do not match with record")
+		return httperror.NewForGoneWithSingleField("message", "store does not exist")
 	}
 
 	// Enforce the verification code of the email.
 	if u.WasEmailVerified == false {
 		impl.Logger.Warn("email verification validation error", slog.Any("u", u))
-		return nil, httperror.NewForBadRequestWithSingleField("email", "was not verified")
+		return httperror.NewForBadRequestWithSingleField("email", "was not verified")
 	}
-
-	// Enforce 2FA if enabled.
-	if u.OTPEnabled {
-		// We need to reset the `otp_validated` status to be false to force
-		// the user to use their `totp authenticator` application.
-		u.OTPValidated = false
-		u.ModifiedAt = time.Now()
-		if err := impl.UserStorer.UpdateByID(ctx, u); err != nil {
-			impl.Logger.Error("failed updating user during login", slog.Any("err", err))
-			return nil, err
-		}
-	}
-
-	return impl.loginWithUser(ctx, u)
+	return nil
 }
 
 func (impl *GatewayControllerImpl) loginWithUser(ctx context.Context, u *u_s.User) (*gateway_s.LoginResponseIDO, error) {
@@ -91,8 +133,8 @@ func (impl *GatewayControllerImpl) loginWithUser(ctx context.Context, u *u_s.Use
 	}
 
 	// Set expiry duration.
-	atExpiry := 24 * time.Hour
-	rtExpiry := 14 * 24 * time.Hour
+	atExpiry := impl.Config.AppServer.AccessTokenExpiry
+	rtExpiry := impl.Config.AppServer.RefreshTokenExpiry
 
 	// Start our session using an access and refresh token.
 	sessionUUID := impl.UUID.NewUUID()
@@ -103,8 +145,36 @@ func (impl *GatewayControllerImpl) loginWithUser(ctx context.Context, u *u_s.Use
 		return nil, err
 	}
 
+	// Record the session so the user can see and revoke their logged in devices.
+	ipAddress, _ := ctx.Value(constants.SessionIPAddress).(string)
+	userAgent, _ := ctx.Value(constants.SessionUserAgent).(string)
+	ses := &ses_s.Session{
+		ID:             primitive.NewObjectID(),
+		SessionID:      sessionUUID,
+		RefreshTokenID: impl.UUID.NewUUID(),
+		UserID:         u.ID,
+		UserName:       u.Name,
+		IPAddress:      ipAddress,
+		UserAgent:      userAgent,
+		CreatedAt:      time.Now(),
+		LastSeenAt:     time.Now(),
+		RefreshedAt:    time.Now(),
+		ExpiresAt:      time.Now().Add(rtExpiry),
+	}
+	if err := impl.SessionStorer.Create(ctx, ses); err != nil {
+		impl.Logger.Error("session create error", slog.Any("err", err))
+		return nil, err
+	}
+
+	// Signing in proves the user remembers their password so any forgot
+	// password links they requested should stop working.
+	if err := impl.PasswordResetStorer.DeleteAllByUserID(ctx, u.ID); err != nil {
+		impl.Logger.Error("delete password reset tokens error", slog.Any("err", err))
+		return nil, err
+	}
+
 	// Generate our JWT token.
-	accessToken, accessTokenExpiry, refreshToken, refreshTokenExpiry, err := impl.JWT.GenerateJWTTokenPair(sessionUUID, atExpiry, rtExpiry)
+	accessToken, accessTokenExpiry, refreshToken, refreshTokenExpiry, err := impl.JWT.GenerateJWTTokenPairWithRefreshTokenID(sessionUUID, ses.RefreshTokenID, atExpiry, rtExpiry)
 	if err != nil {
 		impl.Logger.Error("jwt generate pairs error", slog.Any("err", err))
 		return nil, err
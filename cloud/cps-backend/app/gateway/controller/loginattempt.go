@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	la_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/loginattempt/datastore"
+	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+const (
+	// loginAttemptWindow is how long a failed attempt is remembered for.
+	loginAttemptWindow = 15 * time.Minute
+	// loginFreeAttempts is how many failures are allowed before we start
+	// making the caller wait between attempts.
+	loginFreeAttempts = 3
+	// loginMaxDelay is the longest we make the caller wait between attempts.
+	loginMaxDelay = 60 * time.Second
+	// loginMaxAccountAttempts is how many failures lock the account.
+	loginMaxAccountAttempts = 10
+	// loginMaxIPAttempts is how many failures lock the ip address, this is
+	// higher as many users may share the same ip address.
+	loginMaxIPAttempts = 50
+	// loginLockoutDuration is how long the lock lasts.
+	loginLockoutDuration = 15 * time.Minute
+)
+
+// loginAttemptKeys returns the keys which are tracked for the account key
+// and the ip address of the request.
+func loginAttemptKeys(ctx context.Context, accountKey string) []string {
+	keys := []string{accountKey}
+	if ipAddress, _ := ctx.Value(constants.SessionIPAddress).(string); ipAddress != "" {
+		keys = append(keys, la_s.IPKey(ipAddress))
+	}
+	return keys
+}
+
+// checkLoginAttempts returns a `429 Too Many Requests` error if any of the
+// keys are locked or the caller must wait before trying again.
+func (impl *GatewayControllerImpl) checkLoginAttempts(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		m, err := impl.LoginAttemptStorer.GetByKey(ctx, key)
+		if err != nil {
+			impl.Logger.Error("database get login attempt error", slog.Any("err", err))
+			return err
+		}
+		if m == nil {
+			continue
+		}
+		if time.Now().Before(m.LockedUntil) || time.Now().Before(m.NextAttemptAt) {
+			impl.Logger.Warn("login attempt blocked",
+				slog.String("key", key),
+				slog.Int("failed_count", m.FailedCount),
+				slog.Time("next_attempt_at", m.NextAttemptAt),
+				slog.Time("locked_until", m.LockedUntil))
+			return httperror.NewForSingleField(http.StatusTooManyRequests, "message", "too many failed attempts, please try again later")
+		}
+	}
+	return nil
+}
+
+// recordLoginFailure increments the failed attempts of the keys and applies
+// the delays and locks. The user is optional and is emailed when their
+// account gets locked.
+func (impl *GatewayControllerImpl) recordLoginFailure(ctx context.Context, keys []string, u *user_s.User) {
+	for _, key := range keys {
+		impl.Kmutex.Lockf("login-attempt-%v", key)
+		m, err := impl.recordLoginFailureForKey(ctx, key)
+		impl.Kmutex.Unlockf("login-attempt-%v", key)
+		if err != nil {
+			impl.Logger.Error("failed recording login attempt", slog.String("key", key), slog.Any("err", err))
+			continue
+		}
+
+		// Let the user know the moment their account gets locked.
+		if u != nil && m.Kind != la_s.LoginAttemptKindIP && m.FailedCount == loginMaxAccountAttempts {
+			lockedUntil := m.LockedUntil.Format("2006-01-02 15:04:05 MST")
+			if err := impl.TemplatedEmailer.SendUserAccountLockedEmail(ctx, u.Locale, u.Email, u.FirstName, m.FailedCount, lockedUntil); err != nil {
+				impl.Logger.Error("failed sending account locked email", slog.Any("err", err))
+			}
+		}
+	}
+}
+
+func (impl *GatewayControllerImpl) recordLoginFailureForKey(ctx context.Context, key string) (*la_s.LoginAttempt, error) {
+	m, err := impl.LoginAttemptStorer.GetByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil || time.Since(m.LastFailedAt) > loginAttemptWindow {
+		// The keys are prefixed with the kind, see `la_s.AccountKey`.
+		kind, _, _ := strings.Cut(key, ":")
+		id := primitive.NewObjectID()
+		if m != nil {
+			id = m.ID
+		}
+		m = &la_s.LoginAttempt{ID: id, Key: key, Kind: kind}
+	}
+
+	now := time.Now()
+	m.FailedCount++
+	m.LastFailedAt = now
+
+	// Progressive delay: double the wait with every failure past the free ones.
+	if m.FailedCount > loginFreeAttempts {
+		delay := time.Duration(math.Pow(2, float64(m.FailedCount-loginFreeAttempts))) * time.Second
+		if delay > loginMaxDelay {
+			delay = loginMaxDelay
+		}
+		m.NextAttemptAt = now.Add(delay)
+	}
+
+	// Temporary lockout.
+	maxAttempts := loginMaxAccountAttempts
+	if m.Kind == la_s.LoginAttemptKindIP {
+		maxAttempts = loginMaxIPAttempts
+	}
+	if m.FailedCount >= maxAttempts {
+		m.LockedUntil = now.Add(loginLockoutDuration)
+		impl.Logger.Warn("login attempts locked",
+			slog.String("key", key),
+			slog.Int("failed_count", m.FailedCount),
+			slog.Time("locked_until", m.LockedUntil))
+	}
+
+	m.ExpiresAt = now.Add(loginAttemptWindow)
+	if m.LockedUntil.After(m.ExpiresAt) {
+		m.ExpiresAt = m.LockedUntil
+	}
+	if err := impl.LoginAttemptStorer.Upsert(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// resetLoginAttempts forgets the failed attempts of the key after a
+// successful attempt.
+func (impl *GatewayControllerImpl) resetLoginAttempts(ctx context.Context, key string) {
+	if err := impl.LoginAttemptStorer.DeleteByKey(ctx, key); err != nil {
+		impl.Logger.Error("failed resetting login attempts", slog.String("key", key), slog.Any("err", err))
+	}
+}
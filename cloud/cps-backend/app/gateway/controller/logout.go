@@ -16,6 +16,10 @@ func (impl *GatewayControllerImpl) Logout(ctx context.Context) error {
 		impl.Logger.Error("cache delete error", slog.Any("err", err))
 		return err
 	}
+	if err := impl.SessionStorer.DeleteBySessionID(ctx, sessionID); err != nil {
+		impl.Logger.Error("session delete error", slog.Any("err", err))
+		return err
+	}
 
 	return nil
 }
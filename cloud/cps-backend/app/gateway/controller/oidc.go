@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/dchest/uniuri"
+
+	gateway_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/oidc"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+// oidcLoginTimeout is how long the user has to sign in at the identity provider.
+const oidcLoginTimeout = 10 * time.Minute
+
+// oidcLoginState is kept in the cache while the user is at the identity provider.
+type oidcLoginState struct {
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+type OIDCLoginBeginResponseIDO struct {
+	// AuthorizationURL is where the frontend must send the user to sign in.
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+type OIDCLoginFinishRequestIDO struct {
+	// Code and State are the query parameters the identity provider added
+	// when it sent the user back to our frontend.
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
+
+// errSSOLogin is returned for every kind of rejected single sign-on so we do
+// not reveal which emails have an account.
+var errSSOLogin = httperror.NewForBadRequestWithSingleField("message", "your account cannot sign in with single sign-on")
+
+// OIDCLoginBegin starts signing in with our single sign-on identity provider.
+func (impl *GatewayControllerImpl) OIDCLoginBegin(ctx context.Context) (*OIDCLoginBeginResponseIDO, error) {
+	if !impl.OIDC.IsEnabled() {
+		return nil, httperror.NewForBadRequestWithSingleField("message", "single sign-on is not enabled")
+	}
+
+	verifier, challenge, err := impl.OIDC.NewPKCE()
+	if err != nil {
+		impl.Logger.Error("pkce generation error", slog.Any("err", err))
+		return nil, err
+	}
+	state := uniuri.NewLen(32)
+	nonce := uniuri.NewLen(32)
+
+	sBin, err := json.Marshal(&oidcLoginState{Nonce: nonce, CodeVerifier: verifier})
+	if err != nil {
+		impl.Logger.Error("marshalling error", slog.Any("err", err))
+		return nil, err
+	}
+	if err := impl.Cache.SetWithExpiry(ctx, "oidc:"+state, sBin, oidcLoginTimeout); err != nil {
+		impl.Logger.Error("cache set with expiry error", slog.Any("err", err))
+		return nil, err
+	}
+
+	authURL, err := impl.OIDC.AuthCodeURL(ctx, state, nonce, challenge)
+	if err != nil {
+		impl.Logger.Error("oidc authorization url error", slog.Any("err", err))
+		return nil, err
+	}
+	return &OIDCLoginBeginResponseIDO{AuthorizationURL: authURL}, nil
+}
+
+// OIDCLoginFinish signs in the user the identity provider vouched for. The
+// account must already exist, it gets linked to the identity provider the
+// first time by its verified email.
+func (impl *GatewayControllerImpl) OIDCLoginFinish(ctx context.Context, req *OIDCLoginFinishRequestIDO) (*gateway_s.LoginResponseIDO, error) {
+	if !impl.OIDC.IsEnabled() {
+		return nil, httperror.NewForBadRequestWithSingleField("message", "single sign-on is not enabled")
+	}
+	if req.Code == "" || req.State == "" {
+		return nil, httperror.NewForBadRequestWithSingleField("message", "missing code or state")
+	}
+
+	// The state can only be used once and must have been issued by us.
+	errExpired := httperror.NewForBadRequestWithSingleField("state", "expired or invalid")
+	sBin, err := impl.Cache.Get(ctx, "oidc:"+req.State)
+	if err != nil {
+		impl.Logger.Error("cache get error", slog.Any("err", err))
+		return nil, err
+	}
+	if sBin == nil {
+		return nil, errExpired
+	}
+	if err := impl.Cache.Delete(ctx, "oidc:"+req.State); err != nil {
+		impl.Logger.Error("cache delete error", slog.Any("err", err))
+		return nil, err
+	}
+	var state oidcLoginState
+	if err := json.Unmarshal(sBin, &state); err != nil {
+		return nil, errExpired
+	}
+
+	claims, err := impl.OIDC.Exchange(ctx, req.Code, state.CodeVerifier)
+	if err != nil {
+		impl.Logger.Warn("oidc exchange failed", slog.Any("err", err))
+		if errors.Is(err, oidc.ErrInvalidToken) {
+			return nil, httperror.NewForBadRequestWithSingleField("message", "single sign-on failed, please try again")
+		}
+		return nil, err
+	}
+	if claims.Nonce != state.Nonce {
+		impl.Logger.Warn("oidc nonce mismatch")
+		return nil, httperror.NewForBadRequestWithSingleField("message", "single sign-on failed, please try again")
+	}
+
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+	if email == "" || !claims.EmailVerified {
+		impl.Logger.Warn("oidc email missing or not verified", slog.String("sub", claims.Subject))
+		return nil, errSSOLogin
+	}
+	if !impl.OIDC.IsAllowedEmail(email) {
+		impl.Logger.Warn("oidc email domain not allowed", slog.String("email", email))
+		return nil, errSSOLogin
+	}
+
+	u, err := impl.UserStorer.GetByEmail(ctx, email)
+	if err != nil {
+		impl.Logger.Error("database error", slog.Any("err", err))
+		return nil, err
+	}
+	if u == nil {
+		impl.Logger.Warn("oidc user does not exist", slog.String("email", email))
+		return nil, errSSOLogin
+	}
+
+	// Single sign-on is only for our staff and the staff of our stores.
+	if r := permission.GetRole(u.Role); r == nil || r.Scope == permission.ScopeCustomer {
+		impl.Logger.Warn("oidc user is not staff", slog.String("user_id", u.ID.Hex()))
+		return nil, errSSOLogin
+	}
+
+	// Once linked only the same identity may sign in, in case the email gets
+	// reassigned to someone else at the identity provider.
+	if u.OIDCSubject != "" && u.OIDCSubject != claims.Subject {
+		impl.Logger.Warn("oidc subject does not match linked subject", slog.String("user_id", u.ID.Hex()))
+		return nil, errSSOLogin
+	}
+
+	if err := impl.checkCanLogin(ctx, u); err != nil {
+		return nil, err
+	}
+
+	if u.OIDCSubject == "" {
+		u.OIDCSubject = claims.Subject
+		u.ModifiedAt = time.Now()
+		if err := impl.UserStorer.UpdateByID(ctx, u); err != nil {
+			impl.Logger.Error("failed updating user during login", slog.Any("err", err))
+			return nil, err
+		}
+		impl.Logger.Info("linked user to single sign-on", slog.String("user_id", u.ID.Hex()))
+	}
+
+	if err := impl.requireSecondFactor(ctx, u); err != nil {
+		return nil, err
+	}
+
+	return impl.loginWithUser(ctx, u)
+}
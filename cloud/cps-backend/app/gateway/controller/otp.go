@@ -7,13 +7,13 @@ import (
 	"log/slog"
 	"time"
 
-	"github.com/dchest/uniuri"
 	"github.com/pquerna/otp/totp"
 	"github.com/skip2/go-qrcode"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
 	gateway_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/datastore"
+	la_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/loginattempt/datastore"
 	u_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
@@ -99,7 +99,7 @@ func (impl *GatewayControllerImpl) GenerateOTP(ctx context.Context) (*OTPGenerat
 				impl.Logger.Error("marshalling error", slog.Any("err", err))
 				return nil, err
 			}
-			atExpiry := 14 * 24 * time.Hour
+			atExpiry := impl.Config.AppServer.RefreshTokenExpiry
 			err = impl.Cache.SetWithExpiry(sessCtx, sessionID, uBin, atExpiry)
 			if err != nil {
 				impl.Logger.Error("cache set with expiry error", slog.Any("err", err))
@@ -165,8 +165,8 @@ type VerificationTokenRequestIDO struct {
 }
 
 type VerificationTokenResponseIDO struct {
-	User          *u_d.User `json:"user"`
-	OTPBackupCode string    `json:"otp_backup_code"`
+	User             *u_d.User `json:"user"`
+	OTPRecoveryCodes []string  `json:"otp_recovery_codes"`
 }
 
 // VerifyOTP function verifies provided token from the third-party authenticator app. The purpose of this function is to finish the otp setup.
@@ -175,9 +175,9 @@ func (impl *GatewayControllerImpl) VerifyOTP(ctx context.Context, req *Verificat
 	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
 	sessionID, _ := ctx.Value(constants.SessionID).(string)
 
-	// Variable used to store 2FA backup code when the OTP was successfully
-	// verified in this function.
-	var otpBackupCode string
+	// Variable used to store the 2FA recovery codes when the OTP was
+	// successfully verified in this function.
+	var otpRecoveryCodes []string
 
 	////
 	//// Start the transaction.
@@ -244,26 +244,14 @@ func (impl *GatewayControllerImpl) VerifyOTP(ctx context.Context, req *Verificat
 		u.ModifiedAt = time.Now()
 
 		//
-		// STEP 4: Generate 2FA backup code then hash it.
+		// STEP 4: Generate 2FA recovery codes then hash them.
 		//
 
-		// Generate a random string of 20 characters (UUIDLen) length and store
-		// it in our variable `otpBackupCode` which we will return later.
-		otpBackupCode = fmt.Sprintf("%v%v%v",
-			uniuri.NewLen(uniuri.UUIDLen),
-			uniuri.NewLen(uniuri.UUIDLen),
-			uniuri.NewLen(uniuri.UUIDLen))
-
-		// Hash our backup code so it's saved in our database protected.
-		otpBackupCodeHash, err := impl.Password.GenerateHashFromPassword(otpBackupCode)
+		otpRecoveryCodes, err = impl.newOTPRecoveryCodes(u)
 		if err != nil {
-			impl.Logger.Error("hashing error", slog.Any("error", err))
 			return nil, err
 		}
 
-		u.OTPBackupCodeHash = otpBackupCodeHash
-		u.OTPBackupCodeHashAlgorithm = impl.Password.AlgorithmName()
-
 		//
 		// STEP 5: Save to database.
 		//
@@ -282,7 +270,7 @@ func (impl *GatewayControllerImpl) VerifyOTP(ctx context.Context, req *Verificat
 			impl.Logger.Error("marshalling error", slog.Any("err", err))
 			return nil, err
 		}
-		atExpiry := 14 * 24 * time.Hour
+		atExpiry := impl.Config.AppServer.RefreshTokenExpiry
 		err = impl.Cache.SetWithExpiry(sessCtx, sessionID, uBin, atExpiry)
 		if err != nil {
 			impl.Logger.Error("cache set with expiry error", slog.Any("err", err))
@@ -301,8 +289,8 @@ func (impl *GatewayControllerImpl) VerifyOTP(ctx context.Context, req *Verificat
 	}
 
 	res := &VerificationTokenResponseIDO{
-		User:          u.(*u_d.User),
-		OTPBackupCode: otpBackupCode, // Return the non-hashed value.
+		User:             u.(*u_d.User),
+		OTPRecoveryCodes: otpRecoveryCodes, // Return the non-hashed values.
 	}
 
 	return res, nil
@@ -322,6 +310,13 @@ func (impl *GatewayControllerImpl) ValidateOTP(ctx context.Context, req *Validat
 	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
 	sessionID, _ := ctx.Value(constants.SessionID).(string)
 
+	// Stop brute force attacks against the 6 digit codes.
+	otpKey := la_s.OTPKey(userID)
+	attemptKeys := loginAttemptKeys(ctx, otpKey)
+	if err := impl.checkLoginAttempts(ctx, attemptKeys); err != nil {
+		return nil, err
+	}
+
 	////
 	//// Start the transaction.
 	////
@@ -351,6 +346,10 @@ func (impl *GatewayControllerImpl) ValidateOTP(ctx context.Context, req *Validat
 			impl.Logger.Warn("user did not run generate otp")
 			return nil, httperror.NewForBadRequestWithSingleField("message", "you did not setup two-factor authentication")
 		}
+		if impl.isWebAuthnRequired(u) && u.WebAuthnEnabled {
+			impl.Logger.Warn("totp not allowed by security key policy", slog.String("user_id", userID.Hex()))
+			return nil, httperror.NewForBadRequestWithSingleField("message", "you must use your hardware security key")
+		}
 
 		//
 		// STEP 1: Validate the inputted totp code.
@@ -363,8 +362,8 @@ func (impl *GatewayControllerImpl) ValidateOTP(ctx context.Context, req *Validat
 			//
 
 			impl.Logger.Warn("totp verification failed or expired",
-				slog.String("token", req.Token),
-				slog.String("otp_secret", u.OTPSecret))
+				slog.String("user_id", userID.Hex()))
+			impl.recordLoginFailure(ctx, attemptKeys, u)
 			return nil, httperror.NewForBadRequestWithSingleField("token", "expired or invalid")
 		}
 
@@ -392,7 +391,7 @@ func (impl *GatewayControllerImpl) ValidateOTP(ctx context.Context, req *Validat
 			impl.Logger.Error("marshalling error", slog.Any("err", err))
 			return nil, err
 		}
-		atExpiry := 14 * 24 * time.Hour
+		atExpiry := impl.Config.AppServer.RefreshTokenExpiry
 		err = impl.Cache.SetWithExpiry(sessCtx, sessionID, uBin, atExpiry)
 		if err != nil {
 			impl.Logger.Error("cache set with expiry error", slog.Any("err", err))
@@ -410,6 +409,8 @@ func (impl *GatewayControllerImpl) ValidateOTP(ctx context.Context, req *Validat
 		return nil, err
 	}
 
+	impl.resetLoginAttempts(ctx, otpKey)
+
 	res := &ValidateTokenResponseIDO{
 		User: u.(*u_d.User),
 	}
@@ -458,6 +459,9 @@ func (impl *GatewayControllerImpl) DisableOTP(ctx context.Context) (*u_d.User, e
 		u.OTPValidated = false
 		u.OTPSecret = ""
 		u.OTPAuthURL = ""
+		if !u.WebAuthnEnabled {
+			clearOTPRecoveryCodes(u)
+		}
 		u.ModifiedAt = time.Now()
 		if err := impl.UserStorer.UpdateByID(sessCtx, u); err != nil {
 			impl.Logger.Error("failed updating user", slog.Any("err", err))
@@ -473,7 +477,7 @@ func (impl *GatewayControllerImpl) DisableOTP(ctx context.Context) (*u_d.User, e
 			impl.Logger.Error("marshalling error", slog.Any("err", err))
 			return nil, err
 		}
-		atExpiry := 14 * 24 * time.Hour
+		atExpiry := impl.Config.AppServer.RefreshTokenExpiry
 		err = impl.Cache.SetWithExpiry(sessCtx, sessionID, uBin, atExpiry)
 		if err != nil {
 			impl.Logger.Error("cache set with expiry error", slog.Any("err", err))
@@ -498,18 +502,25 @@ type RecoveryRequestIDO struct {
 	BackupCode string `json:"backup_code"`
 }
 
-// RecoveryOTP function verifies provided `backup code` for the logged in user and disable 2FA plus log user in if successfully verified.
+// RecoveryOTP function verifies one of the single-use recovery codes of the logged in user instead of their 2FA token and logs the user in if successfully verified. The code is used up but 2FA stays enabled.
 func (impl *GatewayControllerImpl) RecoveryOTP(ctx context.Context, req *RecoveryRequestIDO) (*gateway_s.LoginResponseIDO, error) {
 	// Extract from our session the following data.
 	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
 
-	// Validate the inputted totp code.
+	// Validate the inputted recovery code.
 	if req.BackupCode == "" {
 		impl.Logger.Warn("user did not include backup code",
 			slog.String("user_id", userID.Hex()))
 		return nil, httperror.NewForBadRequestWithSingleField("backup_code", "missing value")
 	}
 
+	// Stop brute force attacks against the recovery codes.
+	otpKey := la_s.OTPKey(userID)
+	attemptKeys := loginAttemptKeys(ctx, otpKey)
+	if err := impl.checkLoginAttempts(ctx, attemptKeys); err != nil {
+		return nil, err
+	}
+
 	////
 	//// Start the transaction.
 	////
@@ -538,38 +549,24 @@ func (impl *GatewayControllerImpl) RecoveryOTP(ctx context.Context, req *Recover
 				slog.String("user_id", userID.Hex()))
 			return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
 		}
-		if u.OTPBackupCodeHash == "" || u.OTPBackupCodeHashAlgorithm == "" {
-			impl.Logger.Error("user did not run generate backup code",
+		if u.OTPRecoveryCodesRemaining <= 0 && u.OTPBackupCodeHash == "" {
+			impl.Logger.Warn("user has no recovery codes left",
 				slog.String("user_id", userID.Hex()),
 				slog.String("user_email", u.Email),
-				slog.String("hash", u.OTPBackupCodeHash),
-				slog.String("algorithm", u.OTPBackupCodeHashAlgorithm),
 			)
-			return nil, httperror.NewForBadRequestWithSingleField("message", "you did not setup a 2fa backup code")
+			return nil, httperror.NewForBadRequestWithSingleField("message", "you have no recovery codes left, please contact administrator")
 		}
 
 		//
-		// Validate backup code.
+		// Validate and use up the recovery code.
 		//
 
-		// Make sure we are using the correct hashing algorithm.
-		if impl.Password.AlgorithmName() != u.OTPBackupCodeHashAlgorithm {
-			impl.Logger.Warn("backup code encoded in different hashing algorithm",
-				slog.String("given_algorithm", u.OTPBackupCodeHashAlgorithm),
-				slog.String("system_algorithm", impl.Password.AlgorithmName()),
-				slog.String("user_email", u.Email),
-				slog.String("user_id", u.ID.Hex()),
-			)
-			return nil, httperror.NewForBadRequestWithSingleField("message", "please contact administrator")
-		}
-
-		// Verify the inputted password and hashed password match.
-		otpBackupCodedMatch, _ := impl.Password.ComparePasswordAndHash(req.BackupCode, u.OTPBackupCodeHash)
-		if otpBackupCodedMatch == false {
-			impl.Logger.Warn("backup code check validation error",
+		if !impl.useOTPRecoveryCode(u, req.BackupCode) {
+			impl.Logger.Warn("recovery code check validation error",
 				slog.String("user_id", userID.Hex()),
 				slog.String("user_email", u.Email),
 			)
+			impl.recordLoginFailure(ctx, attemptKeys, u)
 			return nil, httperror.NewForBadRequestWithSingleField("backup_code", "does do not match with record")
 		}
 
@@ -577,12 +574,10 @@ func (impl *GatewayControllerImpl) RecoveryOTP(ctx context.Context, req *Recover
 		// Update the user's profile.
 		//
 
+		// The recovery code counts as the second factor for this login.
+		u.OTPValidated = true
+
 		// Keep track of when user's account changes.
-		u.OTPEnabled = false
-		u.OTPVerified = false
-		u.OTPValidated = false
-		u.OTPSecret = ""
-		u.OTPAuthURL = ""
 		u.ModifiedAt = time.Now()
 		if err := impl.UserStorer.UpdateByID(sessCtx, u); err != nil {
 			impl.Logger.Error("failed updating user",
@@ -591,8 +586,9 @@ func (impl *GatewayControllerImpl) RecoveryOTP(ctx context.Context, req *Recover
 			return nil, err
 		}
 
-		impl.Logger.Debug("successfully reset 2fa via backup code recovery",
-			slog.String("user_id", userID.Hex()))
+		impl.Logger.Info("logged in via 2fa recovery code",
+			slog.String("user_id", userID.Hex()),
+			slog.Int("remaining", u.OTPRecoveryCodesRemaining))
 
 		return u, nil
 	}
@@ -605,6 +601,7 @@ func (impl *GatewayControllerImpl) RecoveryOTP(ctx context.Context, req *Recover
 			slog.Any("error", err))
 		return nil, err
 	}
+	impl.resetLoginAttempts(ctx, otpKey)
 
 	// Login the user.
 	return impl.loginWithUser(ctx, u.(*u_d.User))
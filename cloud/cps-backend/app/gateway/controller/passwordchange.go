@@ -92,6 +92,13 @@ func (impl *GatewayControllerImpl) ProfileChangePassword(ctx context.Context, re
 			impl.Logger.Error("user update by id error", slog.Any("error", err))
 			return nil, err
 		}
+
+		// Log the user out of all their other devices.
+		sessionID, _ := sessCtx.Value(constants.SessionID).(string)
+		if _, err := impl.SessionStorer.DeleteAllByUserID(sessCtx, u.ID, sessionID); err != nil {
+			impl.Logger.Error("delete sessions error", slog.Any("error", err))
+			return nil, err
+		}
 		return nil, nil
 	}
 
@@ -3,14 +3,29 @@ package controller
 import (
 	"context"
 	"strings"
+	"time"
 
 	"log/slog"
 
-	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/dchest/uniuri"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+
+	prt_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/passwordreset/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 )
 
+// passwordResetTokenLength is the number of characters of the token we email,
+// long enough that it cannot be guessed within the token lifetime.
+const passwordResetTokenLength = 48
+
+// ForgotPassword emails the user a link to reset their password. For security
+// purposes the result is the same whether or not the email exists so this
+// cannot be used to find out who has an account.
 func (impl *GatewayControllerImpl) ForgotPassword(ctx context.Context, email string) error {
+	// Defensive Code: For security purposes we need to remove all whitespaces from the email and lower the characters.
+	email = strings.ToLower(strings.TrimSpace(email))
+
 	////
 	//// Start the transaction.
 	////
@@ -25,29 +40,47 @@ func (impl *GatewayControllerImpl) ForgotPassword(ctx context.Context, email str
 
 	// Define a transaction function with a series of operations
 	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
-		// Defensive Code: For security purposes we need to remove all whitespaces from the email and lower the characters.
-		email = strings.ToLower(email)
-
-		// Lookup the user in our database, else return a `400 Bad Request` error.
+		// Lookup the user in our database.
 		u, err := impl.UserStorer.GetByEmail(sessCtx, email)
 		if err != nil {
 			impl.Logger.Error("database error", slog.Any("err", err))
 			return nil, err
 		}
 		if u == nil {
-			impl.Logger.Warn("user does not exist validation error", slog.String("email", email))
-			return nil, httperror.NewForBadRequestWithSingleField("email", "does not exist")
+			impl.Logger.Warn("forgot password requested for email which does not exist", slog.String("email", email))
+			return nil, nil
+		}
+
+		// Only the latest requested link works.
+		if err := impl.PasswordResetStorer.DeleteAllByUserID(sessCtx, u.ID); err != nil {
+			impl.Logger.Error("delete password reset tokens error", slog.Any("err", err))
+			return nil, err
 		}
 
-		// Generate unique token and save it to the user record.
-		u.EmailVerificationCode = impl.UUID.NewUUID()
-		if err := impl.UserStorer.UpdateByID(sessCtx, u); err != nil {
-			impl.Logger.Warn("user update by id failed", slog.Any("error", err))
+		// Generate the token we email, we only save the hash of it so a leaked
+		// database cannot be used to reset passwords.
+		t := uniuri.NewLen(passwordResetTokenLength)
+		ipAddress, _ := ctx.Value(constants.SessionIPAddress).(string)
+		prt := &prt_s.PasswordResetToken{
+			ID:        primitive.NewObjectID(),
+			UserID:    u.ID,
+			TokenHash: prt_s.HashToken(t),
+			IPAddress: ipAddress,
+			CreatedAt: time.Now(),
+			ExpiresAt: time.Now().Add(impl.Config.AppServer.PasswordResetTokenExpiry),
+		}
+		if err := impl.PasswordResetStorer.Create(sessCtx, prt); err != nil {
+			impl.Logger.Error("create password reset token error", slog.Any("err", err))
 			return nil, err
 		}
 
-		// Send password reset email.
-		return impl.TemplatedEmailer.SendForgotPasswordEmail(email, u.EmailVerificationCode, u.FirstName), nil
+		// Queue the email in the same transaction, a mail outage will not fail
+		// the request as the outbox dispatcher retries the delivery.
+		if err := impl.TemplatedEmailer.SendForgotPasswordEmail(sessCtx, u.Locale, email, t, u.FirstName); err != nil {
+			impl.Logger.Error("send forgot password email error", slog.Any("err", err))
+			return nil, err
+		}
+		return nil, nil
 	}
 
 	// Start a transaction
@@ -6,8 +6,11 @@ import (
 
 	"log/slog"
 
-	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
 	"go.mongodb.org/mongo-driver/mongo"
+
+	la_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/loginattempt/datastore"
+	prt_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/passwordreset/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
 )
 
 func (impl *GatewayControllerImpl) PasswordReset(ctx context.Context, code string, password string) error {
@@ -23,20 +26,36 @@ func (impl *GatewayControllerImpl) PasswordReset(ctx context.Context, code strin
 	}
 	defer session.EndSession(ctx)
 
+	var email string
+
 	// Define a transaction function with a series of operations
 	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
-		// Lookup the user in our database, else return a `400 Bad Request` error.
-		u, err := impl.UserStorer.GetByVerificationCode(sessCtx, code)
+		// Lookup the token in our database, else return a `400 Bad Request` error.
+		prt, err := impl.PasswordResetStorer.GetByTokenHash(sessCtx, prt_s.HashToken(code))
+		if err != nil {
+			impl.Logger.Error("database error", slog.Any("err", err))
+			return nil, err
+		}
+		if prt == nil || time.Now().After(prt.ExpiresAt) {
+			impl.Logger.Warn("password reset token expired or invalid")
+			return nil, httperror.NewForBadRequestWithSingleField("code", "expired or invalid")
+		}
+
+		u, err := impl.UserStorer.GetByID(sessCtx, prt.UserID)
 		if err != nil {
 			impl.Logger.Error("database error", slog.Any("err", err))
 			return nil, err
 		}
 		if u == nil {
 			impl.Logger.Warn("user does not exist validation error")
-			return nil, httperror.NewForBadRequestWithSingleField("code", "does not exist")
+			return nil, httperror.NewForBadRequestWithSingleField("code", "expired or invalid")
 		}
 
-		//TODO: Handle expiry dates.
+		// The token is single use, remove it and any others the user requested.
+		if err := impl.PasswordResetStorer.DeleteAllByUserID(sessCtx, u.ID); err != nil {
+			impl.Logger.Error("delete password reset tokens error", slog.Any("err", err))
+			return nil, err
+		}
 
 		passwordHash, err := impl.Password.GenerateHashFromPassword(password)
 		if err != nil {
@@ -46,8 +65,6 @@ func (impl *GatewayControllerImpl) PasswordReset(ctx context.Context, code strin
 
 		u.PasswordHash = passwordHash
 		u.PasswordHashAlgorithm = impl.Password.AlgorithmName()
-		u.EmailVerificationCode = "" // Remove email active code so it cannot be used agian.
-		u.EmailVerificationExpiry = time.Now()
 		u.ModifiedAt = time.Now()
 
 		if err := impl.UserStorer.UpdateByID(sessCtx, u); err != nil {
@@ -55,6 +72,14 @@ func (impl *GatewayControllerImpl) PasswordReset(ctx context.Context, code strin
 			return nil, err
 		}
 
+		// Log the user out of all their devices as the old password may have
+		// been compromised.
+		if _, err := impl.SessionStorer.DeleteAllByUserID(sessCtx, u.ID, ""); err != nil {
+			impl.Logger.Error("delete sessions error", slog.Any("err", err))
+			return nil, err
+		}
+
+		email = u.Email
 		return nil, nil
 	}
 
@@ -65,5 +90,9 @@ func (impl *GatewayControllerImpl) PasswordReset(ctx context.Context, code strin
 		return err
 	}
 
+	// The user proved they own the email so let them sign in again if they
+	// were locked out.
+	impl.resetLoginAttempts(ctx, la_s.AccountKey(email))
+
 	return nil
 }
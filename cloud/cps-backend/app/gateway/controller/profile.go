@@ -9,9 +9,11 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	sj_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/datastore"
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (impl *GatewayControllerImpl) Profile(ctx context.Context) (*user_s.User, error) {
@@ -60,12 +62,18 @@ func (impl *GatewayControllerImpl) ProfileUpdate(ctx context.Context, nu *user_s
 			return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
 		}
 
+		// The email can only be changed by confirming the new address.
+		if nu.Email != "" && nu.Email != ou.Email {
+			return nil, httperror.NewForBadRequestWithSingleField("email", "please use the change email request to change your email")
+		}
+
 		ou.FirstName = nu.FirstName
 		ou.LastName = nu.LastName
 		ou.Name = fmt.Sprintf("%s %s", nu.FirstName, nu.LastName)
 		ou.LexicalName = fmt.Sprintf("%s, %s", nu.LastName, nu.FirstName)
-		ou.Email = nu.Email
 		ou.Phone = nu.Phone
+		ou.Locale = nu.Locale
+		ou.UnsubscribedNotifications = nu.UnsubscribedNotifications
 		ou.Country = nu.Country
 		ou.Region = nu.Region
 		ou.City = nu.City
@@ -90,7 +98,15 @@ func (impl *GatewayControllerImpl) ProfileUpdate(ctx context.Context, nu *user_s
 			return nil, err
 		}
 
-		if ou.Role != user_s.UserRoleRoot {
+		// Queue copying the user names onto their related records, this is
+		// saved in the same transaction so the change cannot be lost.
+		if err := impl.SyncJobStorer.Create(sessCtx, sj_s.NewSyncJob(sj_s.TypeUser, ou.ID)); err != nil {
+			impl.Logger.Error("sync job create error", slog.Any("error", err))
+			return nil, err
+		}
+
+		// Only store staff and customers are billed through the payment processor.
+		if r := permission.GetRole(ou.Role); r != nil && r.Scope != permission.ScopeSystem {
 			if "Stripe, Inc." == impl.PaymentProcessor.GetName() {
 				err = impl.PaymentProcessor.UpdateCustomer(
 					ou.PaymentProcessorCustomerID,
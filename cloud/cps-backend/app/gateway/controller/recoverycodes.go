@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/dchest/uniuri"
+
+	u_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+const (
+	// otpRecoveryCodeCount is how many recovery codes the user gets at a time.
+	otpRecoveryCodeCount = 10
+
+	// otpRecoveryCodeLength is the number of characters of each code, not
+	// counting the dash we add for readability.
+	otpRecoveryCodeLength = 10
+)
+
+// otpRecoveryCodeChars excludes characters which are easy to misread.
+var otpRecoveryCodeChars = []byte("abcdefghjkmnpqrstuvwxyz23456789")
+
+type OTPRecoveryCodesResponseIDO struct {
+	// RecoveryCodes are only ever shown once, we only keep their hashes.
+	RecoveryCodes []string `json:"otp_recovery_codes"`
+}
+
+// normalizeOTPRecoveryCode lets the user type the code with any case and
+// with or without the dash.
+func normalizeOTPRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	code = strings.ReplaceAll(code, " ", "")
+	return code
+}
+
+// newOTPRecoveryCodes replaces all the recovery codes of the user and returns
+// the plain codes so they can be shown to the user.
+func (impl *GatewayControllerImpl) newOTPRecoveryCodes(u *u_d.User) ([]string, error) {
+	codes := make([]string, 0, otpRecoveryCodeCount)
+	hashes := make([]*u_d.OTPRecoveryCode, 0, otpRecoveryCodeCount)
+	for i := 0; i < otpRecoveryCodeCount; i++ {
+		code := uniuri.NewLenChars(otpRecoveryCodeLength, otpRecoveryCodeChars)
+		hash, err := impl.Password.GenerateHashFromPassword(code)
+		if err != nil {
+			impl.Logger.Error("hashing error", slog.Any("error", err))
+			return nil, err
+		}
+		hashes = append(hashes, &u_d.OTPRecoveryCode{
+			Hash:          hash,
+			HashAlgorithm: impl.Password.AlgorithmName(),
+		})
+		half := otpRecoveryCodeLength / 2
+		codes = append(codes, fmt.Sprintf("%s-%s", code[:half], code[half:]))
+	}
+	u.OTPRecoveryCodes = hashes
+	u.OTPRecoveryCodesRemaining = len(hashes)
+
+	// The new codes replace the old single backup code.
+	u.OTPBackupCodeHash = ""
+	u.OTPBackupCodeHashAlgorithm = ""
+	return codes, nil
+}
+
+// clearOTPRecoveryCodes removes the recovery codes once the user has no
+// second factor left.
+func clearOTPRecoveryCodes(u *u_d.User) {
+	u.OTPRecoveryCodes = nil
+	u.OTPRecoveryCodesRemaining = 0
+	u.OTPBackupCodeHash = ""
+	u.OTPBackupCodeHashAlgorithm = ""
+}
+
+// useOTPRecoveryCode marks the matching unused recovery code as used and
+// returns true, else returns false.
+func (impl *GatewayControllerImpl) useOTPRecoveryCode(u *u_d.User, code string) bool {
+	normalized := normalizeOTPRecoveryCode(code)
+	for _, rc := range u.OTPRecoveryCodes {
+		if !rc.UsedAt.IsZero() || rc.HashAlgorithm != impl.Password.AlgorithmName() {
+			continue
+		}
+		if match, _ := impl.Password.ComparePasswordAndHash(normalized, rc.Hash); match {
+			rc.UsedAt = time.Now()
+			u.OTPRecoveryCodesRemaining--
+			return true
+		}
+	}
+
+	// Users who enabled 2FA before recovery codes existed have one backup code.
+	if u.OTPBackupCodeHash != "" && u.OTPBackupCodeHashAlgorithm == impl.Password.AlgorithmName() {
+		if match, _ := impl.Password.ComparePasswordAndHash(code, u.OTPBackupCodeHash); match {
+			u.OTPBackupCodeHash = ""
+			u.OTPBackupCodeHashAlgorithm = ""
+			return true
+		}
+	}
+	return false
+}
+
+// RegenerateOTPRecoveryCodes replaces the recovery codes of the logged in
+// user, the old codes stop working.
+func (impl *GatewayControllerImpl) RegenerateOTPRecoveryCodes(ctx context.Context) (*OTPRecoveryCodesResponseIDO, error) {
+	u, err := impl.getSessionUserWithSecondFactor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !u.OTPEnabled && !u.WebAuthnEnabled {
+		return nil, httperror.NewForBadRequestWithSingleField("message", "you did not setup two-factor authentication")
+	}
+
+	codes, err := impl.newOTPRecoveryCodes(u)
+	if err != nil {
+		return nil, err
+	}
+	u.ModifiedAt = time.Now()
+	if err := impl.UserStorer.UpdateByID(ctx, u); err != nil {
+		impl.Logger.Error("failed updating user", slog.Any("err", err))
+		return nil, err
+	}
+	if err := impl.saveSessionUser(ctx, u); err != nil {
+		return nil, err
+	}
+	impl.Logger.Info("regenerated otp recovery codes", slog.String("user_id", u.ID.Hex()))
+	return &OTPRecoveryCodesResponseIDO{RecoveryCodes: codes}, nil
+}
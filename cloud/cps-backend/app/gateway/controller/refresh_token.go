@@ -9,20 +9,65 @@ import (
 	"log/slog"
 
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 )
 
 func (impl *GatewayControllerImpl) RefreshToken(ctx context.Context, value string) (*user_s.User, string, time.Time, string, time.Time, error) {
 	////
-	//// Extract the `sessionID` so we can process it.
+	//// Extract the `sessionID` and refresh token id so we can process it.
 	////
 
-	sessionID, err := impl.JWT.ProcessJWTToken(value)
+	sessionID, refreshTokenID, err := impl.JWT.ProcessJWTRefreshToken(value)
 	if err != nil {
 		impl.Logger.Warn("process jwt refresh token does not exist", slog.String("value", value))
 		err := errors.New("jwt refresh token failed")
 		return nil, "", time.Now(), "", time.Now(), err
 	}
 
+	// Only allow one refresh per session at a time so the same refresh
+	// token cannot be consumed twice.
+	impl.Kmutex.Lockf("refresh-token-%v", sessionID)
+	defer impl.Kmutex.Unlockf("refresh-token-%v", sessionID)
+
+	////
+	//// Lookup the session, if it was revoked then the refresh token is no
+	//// longer valid.
+	////
+
+	ses, err := impl.SessionStorer.GetBySessionID(ctx, sessionID)
+	if err != nil {
+		impl.Logger.Error("database get session error", slog.Any("err", err))
+		return nil, "", time.Now(), "", time.Now(), err
+	}
+	if ses == nil {
+		impl.Logger.Warn("session does not exist", slog.String("session_id", sessionID))
+		err := errors.New("jwt refresh token failed")
+		return nil, "", time.Now(), "", time.Now(), err
+	}
+
+	////
+	//// Reuse detection: every refresh consumes the refresh token, if an
+	//// already consumed refresh token is presented then either the user or
+	//// an attacker holds a stolen copy so we revoke the whole session.
+	////
+
+	if refreshTokenID == "" || refreshTokenID != ses.RefreshTokenID {
+		impl.Logger.Warn("refresh token reuse detected, revoking session",
+			slog.String("session_id", sessionID),
+			slog.Any("user_id", ses.UserID),
+			slog.Any("ip_address", ctx.Value(constants.SessionIPAddress)))
+		if err := impl.SessionStorer.DeleteBySessionID(ctx, sessionID); err != nil {
+			impl.Logger.Error("session delete error", slog.Any("err", err))
+			return nil, "", time.Now(), "", time.Now(), err
+		}
+		if err := impl.Cache.Delete(ctx, sessionID); err != nil {
+			impl.Logger.Error("cache delete error", slog.Any("err", err))
+			return nil, "", time.Now(), "", time.Now(), err
+		}
+		err := errors.New("jwt refresh token failed")
+		return nil, "", time.Now(), "", time.Now(), err
+	}
+
 	////
 	//// Lookup in our in-memory the user record for the `sessionID` or error.
 	////
@@ -32,6 +77,11 @@ func (impl *GatewayControllerImpl) RefreshToken(ctx context.Context, value strin
 		impl.Logger.Error("in-memory set error", slog.Any("err", err))
 		return nil, "", time.Now(), "", time.Now(), err
 	}
+	if uBin == nil {
+		impl.Logger.Warn("in-memory session does not exist", slog.String("session_id", sessionID))
+		err := errors.New("jwt refresh token failed")
+		return nil, "", time.Now(), "", time.Now(), err
+	}
 
 	var u *user_s.User
 	err = json.Unmarshal(uBin, &u)
@@ -45,20 +95,29 @@ func (impl *GatewayControllerImpl) RefreshToken(ctx context.Context, value strin
 	////
 
 	// Set expiry duration.
-	atExpiry := 24 * time.Hour
-	rtExpiry := 14 * 24 * time.Hour
-
-	// Start our session using an access and refresh token.
-	newSessionUUID := impl.UUID.NewUUID()
+	atExpiry := impl.Config.AppServer.AccessTokenExpiry
+	rtExpiry := impl.Config.AppServer.RefreshTokenExpiry
 
-	err = impl.Cache.SetWithExpiry(ctx, newSessionUUID, uBin, rtExpiry)
+	// Extend our session, we keep the same session uuid so the session
+	// record the user sees for this device stays the same.
+	err = impl.Cache.SetWithExpiry(ctx, sessionID, uBin, rtExpiry)
 	if err != nil {
 		impl.Logger.Error("cache set with expiry error", slog.Any("err", err))
 		return nil, "", time.Now(), "", time.Now(), err
 	}
 
+	// Rotate the refresh token, from now on only the new one is accepted.
+	ses.RefreshTokenID = impl.UUID.NewUUID()
+	ses.RefreshedAt = time.Now()
+	ses.LastSeenAt = time.Now()
+	ses.ExpiresAt = time.Now().Add(rtExpiry)
+	if err := impl.SessionStorer.UpdateByID(ctx, ses); err != nil {
+		impl.Logger.Error("session update error", slog.Any("err", err))
+		return nil, "", time.Now(), "", time.Now(), err
+	}
+
 	// Generate our JWT token.
-	accessToken, accessTokenExpiry, refreshToken, refreshTokenExpiry, err := impl.JWT.GenerateJWTTokenPair(newSessionUUID, atExpiry, rtExpiry)
+	accessToken, accessTokenExpiry, refreshToken, refreshTokenExpiry, err := impl.JWT.GenerateJWTTokenPairWithRefreshTokenID(sessionID, ses.RefreshTokenID, atExpiry, rtExpiry)
 	if err != nil {
 		impl.Logger.Error("jwt generate pairs error", slog.Any("err", err))
 		return nil, "", time.Now(), "", time.Now(), err
@@ -15,6 +15,7 @@ import (
 	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
 )
 
 func validateRegisterBusinessRequest(dirtyData *gateway_s.RegisterBusinessRequestIDO) error {
@@ -136,6 +137,10 @@ func validateRegisterBusinessRequest(dirtyData *gateway_s.RegisterBusinessReques
 		}
 	}
 
+	if dirtyData.Locale != "" && !i18n.IsSupported(dirtyData.Locale) {
+		e["locale"] = "unsupported value"
+	}
+
 	if len(e) != 0 {
 		return httperror.NewForBadRequest(&e)
 	}
@@ -201,7 +206,7 @@ func (impl *GatewayControllerImpl) RegisterBusiness(ctx context.Context, req *ga
 		}
 
 		// Send our verification email.
-		if err := impl.TemplatedEmailer.SendBusinessVerificationEmail(u.Email, u.EmailVerificationCode, u.FirstName); err != nil {
+		if err := impl.TemplatedEmailer.SendBusinessVerificationEmail(sessCtx, u.Locale, u.Email, u.EmailVerificationCode, u.FirstName); err != nil {
 			impl.Logger.Error("failed sending verification email with error", slog.Any("err", err))
 			return nil, err
 		}
@@ -252,9 +257,10 @@ func (impl *GatewayControllerImpl) createBusinessUserForRequest(sessCtx mongo.Se
 		Name:                       fmt.Sprintf("%s %s", req.FirstName, req.LastName),
 		LexicalName:                fmt.Sprintf("%s, %s", req.LastName, req.FirstName),
 		Email:                      req.Email,
+		Locale:                     req.Locale,
 		PasswordHash:               passwordHash,
 		PasswordHashAlgorithm:      impl.Password.AlgorithmName(),
-		Role:                       user_s.UserRoleRetailer,
+		Role:                       user_s.UserRoleStoreManager,
 		Phone:                      req.Phone,
 		Country:                    req.Country,
 		Region:                     req.Region,
@@ -326,6 +332,7 @@ func (impl *GatewayControllerImpl) createStoreForUser(sessCtx mongo.SessionConte
 		Status:                       store_s.StorePendingStatus,
 		Level:                        1, // Default
 		Timezone:                     req.Timezone,
+		Locale:                       req.Locale,
 	}
 	err := impl.StoreStorer.Create(sessCtx, o)
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	gateway_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/datastore"
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
 )
 
 func (impl *GatewayControllerImpl) validateRegisterCustomerRequest(ctx context.Context, dirtyData *gateway_s.RegisterCustomerRequestIDO) error {
@@ -129,6 +130,10 @@ func (impl *GatewayControllerImpl) validateRegisterCustomerRequest(ctx context.C
 		e["has_regularly_attended_comic_cons_or_collectible_shows"] = "missing value"
 	}
 
+	if dirtyData.Locale != "" && !i18n.IsSupported(dirtyData.Locale) {
+		e["locale"] = "unsupported value"
+	}
+
 	if len(e) != 0 {
 		return httperror.NewForBadRequest(&e)
 	}
@@ -193,6 +198,9 @@ func (impl *GatewayControllerImpl) RegisterCustomer(ctx context.Context, req *ga
 		u.StoreName = s.Name
 		u.StoreLevel = s.Level
 		u.StoreTimezone = s.Timezone
+		if u.Locale == "" {
+			u.Locale = s.Locale
+		}
 		// u.StoreTimezone = req.Timezone
 		u.ModifiedAt = time.Now()
 		if err := impl.UserStorer.UpdateByID(sessCtx, u); err != nil {
@@ -201,7 +209,7 @@ func (impl *GatewayControllerImpl) RegisterCustomer(ctx context.Context, req *ga
 		}
 
 		// Send our verification email.
-		if err := impl.TemplatedEmailer.SendCustomerVerificationEmail(u.Email, u.EmailVerificationCode, u.FirstName); err != nil {
+		if err := impl.TemplatedEmailer.SendCustomerVerificationEmail(sessCtx, u.Locale, u.Email, u.EmailVerificationCode, u.FirstName); err != nil {
 			impl.Logger.Error("failed sending verification email with error", slog.Any("err", err))
 			return nil, err
 		}
@@ -254,6 +262,7 @@ func (impl *GatewayControllerImpl) createCustomerUserForRequest(sessCtx mongo.Se
 		Name:                                  fmt.Sprintf("%s %s", req.FirstName, req.LastName),
 		LexicalName:                           fmt.Sprintf("%s, %s", req.LastName, req.FirstName),
 		Email:                                 req.Email,
+		Locale:                                req.Locale,
 		PasswordHash:                          passwordHash,
 		PasswordHashAlgorithm:                 impl.Password.AlgorithmName(),
 		Role:                                  user_s.UserRoleCustomer,
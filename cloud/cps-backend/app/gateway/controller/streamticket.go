@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dchest/uniuri"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+// streamTicketTimeout is how long the client has to open the notification
+// stream with the ticket.
+const streamTicketTimeout = 30 * time.Second
+
+type StreamTicketResponseIDO struct {
+	// Ticket goes in the `ticket` url parameter of the notification stream,
+	// it works once and only for the stream.
+	Ticket string `json:"ticket"`
+}
+
+// StreamTicket issues a ticket for the session of the logged in user so the
+// browser `EventSource`, which cannot set headers, can open the notification
+// stream without putting the access token in the url.
+func (impl *GatewayControllerImpl) StreamTicket(ctx context.Context) (*StreamTicketResponseIDO, error) {
+	// Api keys have no session so they cannot open the stream.
+	sessionID, _ := ctx.Value(constants.SessionID).(string)
+	if sessionID == "" {
+		return nil, httperror.NewForForbiddenWithSingleField("message", "only logged in users can open the notification stream")
+	}
+
+	ticket := uniuri.NewLen(32)
+	if err := impl.Cache.SetWithExpiry(ctx, "stream:"+ticket, []byte(sessionID), streamTicketTimeout); err != nil {
+		impl.Logger.Error("cache set with expiry error", slog.Any("err", err))
+		return nil, err
+	}
+	return &StreamTicketResponseIDO{Ticket: ticket}, nil
+}
+
+// RedeemStreamTicket returns the session the ticket was issued for and
+// deletes it so it cannot be used again. An unknown or expired ticket
+// returns an empty session ID.
+func (impl *GatewayControllerImpl) RedeemStreamTicket(ctx context.Context, ticket string) (string, error) {
+	sBin, err := impl.Cache.Get(ctx, "stream:"+ticket)
+	if err != nil || sBin == nil {
+		impl.Logger.Warn("stream ticket not found", slog.Any("err", err))
+		return "", nil
+	}
+	if err := impl.Cache.Delete(ctx, "stream:"+ticket); err != nil {
+		impl.Logger.Error("cache delete error", slog.Any("err", err))
+		return "", err
+	}
+	return string(sBin), nil
+}
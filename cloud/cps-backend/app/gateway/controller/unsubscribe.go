@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+type UnsubscribeRequestIDO struct {
+	Token string `json:"token"`
+}
+
+// Unsubscribe opts the user out of the notification of the unsubscribe link
+// we emailed them, the link works without the user having to log in.
+func (impl *GatewayControllerImpl) Unsubscribe(ctx context.Context, token string) error {
+	if token == "" {
+		return httperror.NewForBadRequestWithSingleField("token", "missing value")
+	}
+	userIDHex, notification, err := impl.JWT.ProcessUnsubscribeToken(token)
+	if err != nil {
+		impl.Logger.Warn("unsubscribe token error", slog.Any("error", err))
+		return httperror.NewForBadRequestWithSingleField("token", "invalid")
+	}
+	userID, err := primitive.ObjectIDFromHex(userIDHex)
+	if err != nil {
+		return httperror.NewForBadRequestWithSingleField("token", "invalid")
+	}
+
+	u, err := impl.UserStorer.GetByID(ctx, userID)
+	if err != nil {
+		impl.Logger.Error("database error", slog.Any("err", err))
+		return err
+	}
+	if u == nil {
+		return httperror.NewForBadRequestWithSingleField("token", "invalid")
+	}
+
+	// Clicking the link twice is fine.
+	if !u.IsSubscribedTo(notification) {
+		return nil
+	}
+	u.UnsubscribedNotifications = append(u.UnsubscribedNotifications, notification)
+	u.ModifiedAt = time.Now()
+	if err := impl.UserStorer.UpdateByID(ctx, u); err != nil {
+		impl.Logger.Error("database update error", slog.Any("err", err))
+		return err
+	}
+	impl.Logger.Debug("user unsubscribed",
+		slog.Any("user_id", u.ID),
+		slog.String("notification", notification))
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/mongo"
 
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
 	gateway_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/datastore"
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
@@ -60,7 +61,7 @@ func (impl *GatewayControllerImpl) Verify(ctx context.Context, code string) (*ga
 		//
 
 		switch u.Role {
-		case user_s.UserRoleRetailer:
+		case user_s.UserRoleRetailer, user_s.UserRoleStoreManager:
 			{
 				o, err := impl.StoreStorer.GetByID(sessCtx, u.StoreID)
 				if err != nil {
@@ -81,11 +82,11 @@ func (impl *GatewayControllerImpl) Verify(ctx context.Context, code string) (*ga
 						impl.Logger.Error("database error", slog.Any("err", err))
 						return nil, err
 					}
-					var emails []string
+					var recipients []templatedemailer.Recipient
 					for _, rootUser := range res.Results {
-						emails = append(emails, rootUser.Email)
+						recipients = append(recipients, templatedemailer.Recipient{Email: rootUser.Email, Locale: rootUser.Locale})
 					}
-					if err := impl.TemplatedEmailer.SendNewStoreEmailToStaff(emails, u.StoreID.Hex()); err != nil {
+					if err := impl.TemplatedEmailer.SendNewStoreEmailToStaff(sessCtx, recipients, u.StoreID.Hex()); err != nil {
 						impl.Logger.Error("failed sending verification email with error", slog.Any("err", err))
 						return nil, err
 					}
@@ -0,0 +1,660 @@
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	gateway_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/datastore"
+	la_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/loginattempt/datastore"
+	u_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	wa_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webauthn/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/webauthn"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+const (
+	webAuthnCeremonyRegister = "register"
+	webAuthnCeremonyValidate = "validate"
+	webAuthnCeremonyLogin    = "login"
+
+	// webAuthnCeremonyTimeout is how long the user has to use their key.
+	webAuthnCeremonyTimeout = 5 * time.Minute
+)
+
+// webAuthnCeremony is kept in the cache between the begin and finish calls.
+type webAuthnCeremony struct {
+	Kind      string             `json:"kind"`
+	Challenge string             `json:"challenge"`
+	UserID    primitive.ObjectID `json:"user_id"`
+}
+
+type webAuthnRelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type webAuthnUser struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type webAuthnCredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+type webAuthnCredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type webAuthnAuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// WebAuthnCreationOptions is given to `navigator.credentials.create()`, the
+// binary values are base64url encoded.
+type WebAuthnCreationOptions struct {
+	Challenge              string                         `json:"challenge"`
+	RP                     webAuthnRelyingParty           `json:"rp"`
+	User                   webAuthnUser                   `json:"user"`
+	PubKeyCredParams       []webAuthnCredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                          `json:"timeout"`
+	ExcludeCredentials     []webAuthnCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection webAuthnAuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                         `json:"attestation"`
+}
+
+// WebAuthnRequestOptions is given to `navigator.credentials.get()`, the
+// binary values are base64url encoded.
+type WebAuthnRequestOptions struct {
+	Challenge        string                         `json:"challenge"`
+	RPID             string                         `json:"rpId"`
+	Timeout          int64                          `json:"timeout"`
+	AllowCredentials []webAuthnCredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                         `json:"userVerification"`
+}
+
+type WebAuthnBeginResponseIDO struct {
+	// CeremonyID must be sent back with the finish request.
+	CeremonyID string      `json:"ceremony_id"`
+	PublicKey  interface{} `json:"public_key"`
+}
+
+// WebAuthnRegisterFinishRequestIDO carries the `AuthenticatorAttestationResponse`
+// with the binary values base64url encoded.
+type WebAuthnRegisterFinishRequestIDO struct {
+	CeremonyID        string `json:"ceremony_id"`
+	Name              string `json:"name"`
+	ClientDataJSON    string `json:"client_data_json"`
+	AttestationObject string `json:"attestation_object"`
+}
+
+// WebAuthnAssertionRequestIDO carries the `AuthenticatorAssertionResponse`
+// with the binary values base64url encoded.
+type WebAuthnAssertionRequestIDO struct {
+	CeremonyID        string `json:"ceremony_id"`
+	CredentialID      string `json:"credential_id"`
+	ClientDataJSON    string `json:"client_data_json"`
+	AuthenticatorData string `json:"authenticator_data"`
+	Signature         string `json:"signature"`
+}
+
+// isWebAuthnRequired returns true if our policy forces the user to sign in
+// with a security key.
+func (impl *GatewayControllerImpl) isWebAuthnRequired(u *u_s.User) bool {
+	return impl.Config.AppServer.WebAuthnRequiredForRoot && u.Role == u_s.UserRoleRoot
+}
+
+// decodeWebAuthnBinary decodes the base64url values sent by the browser,
+// with or without padding.
+func decodeWebAuthnBinary(field, value string) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil || len(b) == 0 {
+		return nil, httperror.NewForBadRequestWithSingleField(field, "invalid value")
+	}
+	return b, nil
+}
+
+func (impl *GatewayControllerImpl) beginWebAuthnCeremony(ctx context.Context, kind string, userID primitive.ObjectID) (string, string, error) {
+	challenge, err := impl.WebAuthn.NewChallenge()
+	if err != nil {
+		impl.Logger.Error("challenge generation error", slog.Any("err", err))
+		return "", "", err
+	}
+	cBin, err := json.Marshal(&webAuthnCeremony{Kind: kind, Challenge: challenge, UserID: userID})
+	if err != nil {
+		impl.Logger.Error("marshalling error", slog.Any("err", err))
+		return "", "", err
+	}
+	ceremonyID := impl.UUID.NewUUID()
+	if err := impl.Cache.SetWithExpiry(ctx, "webauthn:"+ceremonyID, cBin, webAuthnCeremonyTimeout); err != nil {
+		impl.Logger.Error("cache set with expiry error", slog.Any("err", err))
+		return "", "", err
+	}
+	return ceremonyID, challenge, nil
+}
+
+// finishWebAuthnCeremony returns the ceremony and deletes it so it can only
+// be finished once.
+func (impl *GatewayControllerImpl) finishWebAuthnCeremony(ctx context.Context, kind string, ceremonyID string) (*webAuthnCeremony, error) {
+	errExpired := httperror.NewForBadRequestWithSingleField("ceremony_id", "expired or invalid")
+	if ceremonyID == "" {
+		return nil, errExpired
+	}
+	key := "webauthn:" + ceremonyID
+	cBin, err := impl.Cache.Get(ctx, key)
+	if err != nil {
+		impl.Logger.Error("cache get error", slog.Any("err", err))
+		return nil, err
+	}
+	if cBin == nil {
+		return nil, errExpired
+	}
+	if err := impl.Cache.Delete(ctx, key); err != nil {
+		impl.Logger.Error("cache delete error", slog.Any("err", err))
+		return nil, err
+	}
+	var c webAuthnCeremony
+	if err := json.Unmarshal(cBin, &c); err != nil || c.Kind != kind {
+		return nil, errExpired
+	}
+	return &c, nil
+}
+
+// saveSessionUser updates the user in the cache of the current session.
+func (impl *GatewayControllerImpl) saveSessionUser(ctx context.Context, u *u_s.User) error {
+	sessionID, _ := ctx.Value(constants.SessionID).(string)
+	uBin, err := json.Marshal(u)
+	if err != nil {
+		impl.Logger.Error("marshalling error", slog.Any("err", err))
+		return err
+	}
+	if err := impl.Cache.SetWithExpiry(ctx, sessionID, uBin, impl.Config.AppServer.RefreshTokenExpiry); err != nil {
+		impl.Logger.Error("cache set with expiry error", slog.Any("err", err))
+		return err
+	}
+	return nil
+}
+
+// getSessionUserWithSecondFactor returns the logged in user and makes sure
+// the session finished 2FA, so a stolen password alone cannot change the
+// security keys.
+func (impl *GatewayControllerImpl) getSessionUserWithSecondFactor(ctx context.Context) (*u_s.User, error) {
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	u, err := impl.UserStorer.GetByID(ctx, userID)
+	if err != nil {
+		impl.Logger.Error("failed getting session user", slog.Any("err", err))
+		return nil, err
+	}
+	if u == nil {
+		impl.Logger.Warn("user does not exist validation error")
+		return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+
+	// The user record is shared by every session of the user, so the second
+	// factor must be checked on the current session else a password-only
+	// session passes once the real user finished 2FA somewhere else.
+	sessUser, _ := ctx.Value(constants.SessionUser).(*u_s.User)
+	if (u.OTPEnabled || u.WebAuthnEnabled) && (sessUser == nil || !sessUser.OTPValidated) {
+		return nil, httperror.NewForForbiddenWithSingleField("message", "please complete two-factor authentication first")
+	}
+	return u, nil
+}
+
+func credentialDescriptors(creds []*wa_s.WebAuthnCredential) []webAuthnCredentialDescriptor {
+	res := make([]webAuthnCredentialDescriptor, 0, len(creds))
+	for _, c := range creds {
+		res = append(res, webAuthnCredentialDescriptor{
+			Type: "public-key",
+			ID:   base64.RawURLEncoding.EncodeToString(c.CredentialID),
+		})
+	}
+	return res
+}
+
+// WebAuthnRegisterBegin starts registering a new security key for the logged in user.
+func (impl *GatewayControllerImpl) WebAuthnRegisterBegin(ctx context.Context) (*WebAuthnBeginResponseIDO, error) {
+	u, err := impl.getSessionUserWithSecondFactor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := impl.WebAuthnStorer.ListByUserID(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	ceremonyID, challenge, err := impl.beginWebAuthnCeremony(ctx, webAuthnCeremonyRegister, u.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make([]webAuthnCredentialParameter, 0, len(webauthn.SupportedAlgorithms))
+	for _, alg := range webauthn.SupportedAlgorithms {
+		params = append(params, webAuthnCredentialParameter{Type: "public-key", Alg: alg})
+	}
+	return &WebAuthnBeginResponseIDO{
+		CeremonyID: ceremonyID,
+		PublicKey: &WebAuthnCreationOptions{
+			Challenge: challenge,
+			RP: webAuthnRelyingParty{
+				ID:   impl.WebAuthn.RelyingPartyID(),
+				Name: impl.WebAuthn.RelyingPartyName(),
+			},
+			User: webAuthnUser{
+				// The user handle must not contain personal information.
+				ID:          base64.RawURLEncoding.EncodeToString(u.ID[:]),
+				Name:        u.Email,
+				DisplayName: u.Name,
+			},
+			PubKeyCredParams:   params,
+			Timeout:            webAuthnCeremonyTimeout.Milliseconds(),
+			ExcludeCredentials: credentialDescriptors(creds),
+			AuthenticatorSelection: webAuthnAuthenticatorSelection{
+				ResidentKey:      "preferred",
+				UserVerification: "preferred",
+			},
+			Attestation: "none",
+		},
+	}, nil
+}
+
+// WebAuthnRegisterFinish verifies and saves the new security key.
+func (impl *GatewayControllerImpl) WebAuthnRegisterFinish(ctx context.Context, req *WebAuthnRegisterFinishRequestIDO) (*wa_s.WebAuthnCredential, error) {
+	e := make(map[string]string)
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		e["name"] = "missing value"
+	}
+	if len(req.Name) > 100 {
+		e["name"] = "too long"
+	}
+	if len(e) != 0 {
+		return nil, httperror.NewForBadRequest(&e)
+	}
+	clientDataJSON, err := decodeWebAuthnBinary("client_data_json", req.ClientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+	attestationObject, err := decodeWebAuthnBinary("attestation_object", req.AttestationObject)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := impl.getSessionUserWithSecondFactor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c, err := impl.finishWebAuthnCeremony(ctx, webAuthnCeremonyRegister, req.CeremonyID)
+	if err != nil {
+		return nil, err
+	}
+	if c.UserID != u.ID {
+		return nil, httperror.NewForBadRequestWithSingleField("ceremony_id", "expired or invalid")
+	}
+
+	cred, err := impl.WebAuthn.VerifyRegistration(c.Challenge, clientDataJSON, attestationObject)
+	if err != nil {
+		impl.Logger.Warn("webauthn registration failed", slog.Any("err", err))
+		if errors.Is(err, webauthn.ErrVerification) {
+			return nil, httperror.NewForBadRequestWithSingleField("message", "security key could not be verified")
+		}
+		return nil, err
+	}
+
+	////
+	//// Start the transaction.
+	////
+
+	session, err := impl.DbClient.StartSession()
+	if err != nil {
+		impl.Logger.Error("start session error",
+			slog.Any("error", err))
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// Define a transaction function with a series of operations
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		existing, err := impl.WebAuthnStorer.GetByCredentialID(sessCtx, cred.ID)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, httperror.NewForBadRequestWithSingleField("message", "security key is already registered")
+		}
+
+		m := &wa_s.WebAuthnCredential{
+			ID:           primitive.NewObjectID(),
+			UserID:       u.ID,
+			Name:         req.Name,
+			CredentialID: cred.ID,
+			PublicKey:    cred.PublicKey,
+			Algorithm:    cred.Algorithm,
+			SignCount:    cred.SignCount,
+			AAGUID:       cred.AAGUID,
+			UserVerified: cred.UserVerified,
+			CreatedAt:    time.Now(),
+		}
+		if err := impl.WebAuthnStorer.Create(sessCtx, m); err != nil {
+			return nil, err
+		}
+
+		// The key was just proven to be in the user's possession so this
+		// session counts as having finished 2FA.
+		u.WebAuthnEnabled = true
+		u.OTPValidated = true
+		u.ModifiedAt = time.Now()
+		if err := impl.UserStorer.UpdateByID(sessCtx, u); err != nil {
+			impl.Logger.Error("failed updating user", slog.Any("err", err))
+			return nil, err
+		}
+		if err := impl.saveSessionUser(sessCtx, u); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+
+	// Start a transaction
+	res, err := session.WithTransaction(ctx, transactionFunc)
+	if err != nil {
+		impl.Logger.Error("session failed error",
+			slog.Any("error", err))
+		return nil, err
+	}
+	impl.Logger.Info("webauthn credential registered", slog.String("user_id", u.ID.Hex()))
+	return res.(*wa_s.WebAuthnCredential), nil
+}
+
+// WebAuthnListCredentials returns the security keys of the logged in user.
+func (impl *GatewayControllerImpl) WebAuthnListCredentials(ctx context.Context) ([]*wa_s.WebAuthnCredential, error) {
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	return impl.WebAuthnStorer.ListByUserID(ctx, userID)
+}
+
+// WebAuthnDeleteCredential removes a security key of the logged in user.
+func (impl *GatewayControllerImpl) WebAuthnDeleteCredential(ctx context.Context, id primitive.ObjectID) error {
+	u, err := impl.getSessionUserWithSecondFactor(ctx)
+	if err != nil {
+		return err
+	}
+
+	////
+	//// Start the transaction.
+	////
+
+	session, err := impl.DbClient.StartSession()
+	if err != nil {
+		impl.Logger.Error("start session error",
+			slog.Any("error", err))
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// Define a transaction function with a series of operations
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		m, err := impl.WebAuthnStorer.GetByID(sessCtx, id)
+		if err != nil {
+			return nil, err
+		}
+		if m == nil || m.UserID != u.ID {
+			return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
+		}
+		if err := impl.WebAuthnStorer.DeleteByID(sessCtx, id); err != nil {
+			return nil, err
+		}
+
+		remaining, err := impl.WebAuthnStorer.ListByUserID(sessCtx, u.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(remaining) == 0 {
+			u.WebAuthnEnabled = false
+			if !u.OTPEnabled {
+				clearOTPRecoveryCodes(u)
+			}
+			u.ModifiedAt = time.Now()
+			if err := impl.UserStorer.UpdateByID(sessCtx, u); err != nil {
+				impl.Logger.Error("failed updating user", slog.Any("err", err))
+				return nil, err
+			}
+			if err := impl.saveSessionUser(sessCtx, u); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
+	// Start a transaction
+	if _, err := session.WithTransaction(ctx, transactionFunc); err != nil {
+		impl.Logger.Error("session failed error",
+			slog.Any("error", err))
+		return err
+	}
+	impl.Logger.Info("webauthn credential removed", slog.String("user_id", u.ID.Hex()))
+	return nil
+}
+
+// WebAuthnValidateBegin starts using a security key as the second factor of
+// the current login.
+func (impl *GatewayControllerImpl) WebAuthnValidateBegin(ctx context.Context) (*WebAuthnBeginResponseIDO, error) {
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	creds, err := impl.WebAuthnStorer.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(creds) == 0 {
+		return nil, httperror.NewForBadRequestWithSingleField("message", "you did not register a security key")
+	}
+	ceremonyID, challenge, err := impl.beginWebAuthnCeremony(ctx, webAuthnCeremonyValidate, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &WebAuthnBeginResponseIDO{
+		CeremonyID: ceremonyID,
+		PublicKey: &WebAuthnRequestOptions{
+			Challenge:        challenge,
+			RPID:             impl.WebAuthn.RelyingPartyID(),
+			Timeout:          webAuthnCeremonyTimeout.Milliseconds(),
+			AllowCredentials: credentialDescriptors(creds),
+			UserVerification: "preferred",
+		},
+	}, nil
+}
+
+// WebAuthnValidateFinish verifies the security key and marks the current
+// login as having finished 2FA.
+func (impl *GatewayControllerImpl) WebAuthnValidateFinish(ctx context.Context, req *WebAuthnAssertionRequestIDO) (*ValidateTokenResponseIDO, error) {
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+
+	// Stop brute force attacks, we share the limit with the totp codes.
+	attemptKeys := loginAttemptKeys(ctx, la_s.OTPKey(userID))
+	if err := impl.checkLoginAttempts(ctx, attemptKeys); err != nil {
+		return nil, err
+	}
+
+	c, err := impl.finishWebAuthnCeremony(ctx, webAuthnCeremonyValidate, req.CeremonyID)
+	if err != nil {
+		return nil, err
+	}
+	if c.UserID != userID {
+		return nil, httperror.NewForBadRequestWithSingleField("ceremony_id", "expired or invalid")
+	}
+
+	u, err := impl.UserStorer.GetByID(ctx, userID)
+	if err != nil {
+		impl.Logger.Error("failed getting session user", slog.Any("err", err))
+		return nil, err
+	}
+	if u == nil {
+		impl.Logger.Warn("user does not exist validation error")
+		return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+
+	if _, err := impl.verifyWebAuthnAssertion(ctx, c, req, u, false, attemptKeys); err != nil {
+		return nil, err
+	}
+	impl.resetLoginAttempts(ctx, la_s.OTPKey(userID))
+
+	u.OTPValidated = true
+	u.ModifiedAt = time.Now()
+	if err := impl.UserStorer.UpdateByID(ctx, u); err != nil {
+		impl.Logger.Error("failed updating user", slog.Any("err", err))
+		return nil, err
+	}
+	if err := impl.saveSessionUser(ctx, u); err != nil {
+		return nil, err
+	}
+	return &ValidateTokenResponseIDO{User: u}, nil
+}
+
+// WebAuthnLoginBegin starts signing in with a passkey instead of a
+// password. The email is optional, without it the browser offers the
+// passkeys it knows for our site.
+func (impl *GatewayControllerImpl) WebAuthnLoginBegin(ctx context.Context, email string) (*WebAuthnBeginResponseIDO, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	// We do not reveal whether the email has an account, unknown emails get
+	// the same response as accounts without keys.
+	allow := []webAuthnCredentialDescriptor{}
+	if email != "" {
+		u, err := impl.UserStorer.GetByEmail(ctx, email)
+		if err != nil {
+			impl.Logger.Error("database error", slog.Any("err", err))
+			return nil, err
+		}
+		if u != nil {
+			creds, err := impl.WebAuthnStorer.ListByUserID(ctx, u.ID)
+			if err != nil {
+				return nil, err
+			}
+			allow = credentialDescriptors(creds)
+		}
+	}
+
+	ceremonyID, challenge, err := impl.beginWebAuthnCeremony(ctx, webAuthnCeremonyLogin, primitive.NilObjectID)
+	if err != nil {
+		return nil, err
+	}
+	return &WebAuthnBeginResponseIDO{
+		CeremonyID: ceremonyID,
+		PublicKey: &WebAuthnRequestOptions{
+			Challenge:        challenge,
+			RPID:             impl.WebAuthn.RelyingPartyID(),
+			Timeout:          webAuthnCeremonyTimeout.Milliseconds(),
+			AllowCredentials: allow,
+			UserVerification: "required",
+		},
+	}, nil
+}
+
+// WebAuthnLoginFinish signs in with a passkey. The authenticator must have
+// verified the user with a pin or biometric so the passkey counts as both
+// factors.
+func (impl *GatewayControllerImpl) WebAuthnLoginFinish(ctx context.Context, req *WebAuthnAssertionRequestIDO) (*gateway_s.LoginResponseIDO, error) {
+	c, err := impl.finishWebAuthnCeremony(ctx, webAuthnCeremonyLogin, req.CeremonyID)
+	if err != nil {
+		return nil, err
+	}
+	credentialID, err := decodeWebAuthnBinary("credential_id", req.CredentialID)
+	if err != nil {
+		return nil, err
+	}
+	cred, err := impl.WebAuthnStorer.GetByCredentialID(ctx, credentialID)
+	if err != nil {
+		return nil, err
+	}
+	if cred == nil {
+		impl.Logger.Warn("webauthn credential does not exist")
+		return nil, errInvalidLogin
+	}
+	u, err := impl.UserStorer.GetByID(ctx, cred.UserID)
+	if err != nil {
+		impl.Logger.Error("database error", slog.Any("err", err))
+		return nil, err
+	}
+	if u == nil {
+		impl.Logger.Warn("user does not exist validation error")
+		return nil, errInvalidLogin
+	}
+
+	accountKey := la_s.AccountKey(u.Email)
+	attemptKeys := loginAttemptKeys(ctx, accountKey)
+	if err := impl.checkLoginAttempts(ctx, attemptKeys); err != nil {
+		return nil, err
+	}
+	if _, err := impl.verifyWebAuthnAssertion(ctx, c, req, u, true, attemptKeys); err != nil {
+		return nil, err
+	}
+	impl.resetLoginAttempts(ctx, accountKey)
+
+	if err := impl.checkCanLogin(ctx, u); err != nil {
+		return nil, err
+	}
+
+	u.OTPValidated = true
+	u.ModifiedAt = time.Now()
+	if err := impl.UserStorer.UpdateByID(ctx, u); err != nil {
+		impl.Logger.Error("failed updating user during login", slog.Any("err", err))
+		return nil, err
+	}
+	return impl.loginWithUser(ctx, u)
+}
+
+// verifyWebAuthnAssertion verifies the signature of one of the user's
+// security keys and records the use of the key.
+func (impl *GatewayControllerImpl) verifyWebAuthnAssertion(ctx context.Context, c *webAuthnCeremony, req *WebAuthnAssertionRequestIDO, u *u_s.User, requireUserVerification bool, attemptKeys []string) (*wa_s.WebAuthnCredential, error) {
+	credentialID, err := decodeWebAuthnBinary("credential_id", req.CredentialID)
+	if err != nil {
+		return nil, err
+	}
+	clientDataJSON, err := decodeWebAuthnBinary("client_data_json", req.ClientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+	authenticatorData, err := decodeWebAuthnBinary("authenticator_data", req.AuthenticatorData)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := decodeWebAuthnBinary("signature", req.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	cred, err := impl.WebAuthnStorer.GetByCredentialID(ctx, credentialID)
+	if err != nil {
+		return nil, err
+	}
+	if cred == nil || cred.UserID != u.ID {
+		impl.Logger.Warn("webauthn credential does not belong to user", slog.String("user_id", u.ID.Hex()))
+		impl.recordLoginFailure(ctx, attemptKeys, u)
+		return nil, httperror.NewForBadRequestWithSingleField("message", "security key could not be verified")
+	}
+
+	a, err := impl.WebAuthn.VerifyAssertion(c.Challenge, cred.PublicKey, cred.SignCount, clientDataJSON, authenticatorData, signature, requireUserVerification)
+	if err != nil {
+		impl.Logger.Warn("webauthn assertion failed", slog.String("user_id", u.ID.Hex()), slog.Any("err", err))
+		if errors.Is(err, webauthn.ErrVerification) {
+			impl.recordLoginFailure(ctx, attemptKeys, u)
+			return nil, httperror.NewForBadRequestWithSingleField("message", "security key could not be verified")
+		}
+		return nil, err
+	}
+
+	cred.SignCount = a.SignCount
+	cred.LastUsedAt = time.Now()
+	if err := impl.WebAuthnStorer.UpdateByID(ctx, cred); err != nil {
+		return nil, err
+	}
+	return cred, nil
+}
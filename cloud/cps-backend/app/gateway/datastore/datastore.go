@@ -50,6 +50,7 @@ type RegisterBusinessRequestIDO struct {
 	OtherGradingServiceName      string `bson:"other_grading_service_name" json:"other_grading_service_name"`
 	RequestWelcomePackage        int8   `bson:"request_welcome_package" json:"request_welcome_package"`
 	Timezone                     string `bson:"timezone" json:"timezone"`
+	Locale                       string `bson:"locale" json:"locale,omitempty"`
 }
 
 type RegisterBusinessResponseIDO struct {
@@ -64,6 +65,7 @@ type RegisterCustomerRequestIDO struct {
 	FirstName                                       string             `json:"first_name"`
 	LastName                                        string             `json:"last_name"`
 	Email                                           string             `json:"email"`
+	Locale                                          string             `json:"locale,omitempty"`
 	Password                                        string             `json:"password"`
 	PasswordRepeated                                string             `json:"password_repeated"`
 	Phone                                           string             `json:"phone,omitempty"`
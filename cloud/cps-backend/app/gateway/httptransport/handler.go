@@ -1,9 +1,14 @@
 package httptransport
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"log/slog"
+	"net/http"
 
 	gateway_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/controller"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
 )
 
 // Handler Creates http request handler
@@ -19,3 +24,23 @@ func NewHandler(loggerp *slog.Logger, c gateway_c.GatewayController) *Handler {
 		Controller: c,
 	}
 }
+
+// decodeRequest decodes the JSON body of the request into `requestData`.
+func (h *Handler) decodeRequest(r *http.Request, requestData interface{}) error {
+	defer r.Body.Close()
+
+	var rawJSON bytes.Buffer
+	teeReader := io.TeeReader(r.Body, &rawJSON) // TeeReader allows you to read the JSON and capture it
+
+	// Read the JSON string and convert it into our golang stuct else we need
+	// to send a `400 Bad Request` errror message back to the client,
+	err := json.NewDecoder(teeReader).Decode(requestData) // [1]
+	if err != nil {
+		h.Logger.Error("decoding error",
+			slog.Any("err", err),
+			slog.String("json", rawJSON.String()),
+		)
+		return httperror.NewForSingleField(http.StatusBadRequest, "non_field_error", "payload structure is wrong")
+	}
+	return nil
+}
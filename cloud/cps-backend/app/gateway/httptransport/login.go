@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strings"
 
+	gateway_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/controller"
 	gateway_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
 )
@@ -91,3 +92,35 @@ func MarshalLoginResponse(responseData *gateway_s.LoginResponseIDO, w http.Respo
 		return
 	}
 }
+
+func (h *Handler) OIDCLoginBegin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	res, err := h.Controller.OIDCLoginBegin(ctx)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) OIDCLoginFinish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req gateway_c.OIDCLoginFinishRequestIDO
+	if err := h.decodeRequest(r, &req); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	res, err := h.Controller.OIDCLoginFinish(ctx, &req)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	MarshalLoginResponse(res, w)
+}
@@ -175,3 +175,18 @@ func (h *Handler) RecoveryOTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func (h *Handler) RegenerateOTPRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	res, err := h.Controller.RegenerateOTPRecoveryCodes(ctx)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -3,11 +3,15 @@ package httptransport
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
+	gateway_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/controller"
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
 )
 
 func (h *Handler) Profile(w http.ResponseWriter, r *http.Request) {
@@ -31,6 +35,7 @@ type ProfileUpdateRequestIDO struct {
 	FirstName                 string `bson:"first_name" json:"first_name"`
 	LastName                  string `bson:"last_name" json:"last_name"`
 	Email                     string `json:"email"`
+	Locale                    string `json:"locale,omitempty"`
 	Phone                     string `bson:"phone,omitempty" json:"phone,omitempty"`
 	Country                   string `bson:"country,omitempty" json:"country,omitempty"`
 	Region                    string `bson:"region,omitempty" json:"region,omitempty"`
@@ -50,6 +55,9 @@ type ProfileUpdateRequestIDO struct {
 	ShippingPostalCode        string `bson:"shipping_postal_code" json:"shipping_postal_code,omitempty"`
 	ShippingAddressLine1      string `bson:"shipping_address_line1" json:"shipping_address_line1,omitempty"`
 	ShippingAddressLine2      string `bson:"shipping_address_line2" json:"shipping_address_line2,omitempty"`
+	// UnsubscribedNotifications are the notification emails the user does
+	// not want, see `user_s.Notifications`.
+	UnsubscribedNotifications []string `json:"unsubscribed_notifications"`
 }
 
 func UnmarshalProfileUpdateRequest(ctx context.Context, r *http.Request) (*user_s.User, error) {
@@ -79,6 +87,7 @@ func UnmarshalProfileUpdateRequest(ctx context.Context, r *http.Request) (*user_
 		FirstName:                 requestData.FirstName,
 		LastName:                  requestData.LastName,
 		Email:                     requestData.Email,
+		Locale:                    requestData.Locale,
 		Phone:                     requestData.Phone,
 		Country:                   requestData.Country,
 		Region:                    requestData.Region,
@@ -98,6 +107,7 @@ func UnmarshalProfileUpdateRequest(ctx context.Context, r *http.Request) (*user_
 		ShippingPostalCode:        requestData.ShippingPostalCode,
 		ShippingAddressLine1:      requestData.ShippingAddressLine1,
 		ShippingAddressLine2:      requestData.ShippingAddressLine2,
+		UnsubscribedNotifications: requestData.UnsubscribedNotifications,
 	}, nil
 }
 
@@ -160,6 +170,15 @@ func ValidateProfileUpdateRequest(dirtyData *ProfileUpdateRequestIDO) error {
 		}
 	}
 
+	if dirtyData.Locale != "" && !i18n.IsSupported(dirtyData.Locale) {
+		e["locale"] = "unsupported value"
+	}
+	for _, n := range dirtyData.UnsubscribedNotifications {
+		if !slices.Contains(user_s.Notifications, n) {
+			e["unsubscribed_notifications"] = fmt.Sprintf("unsupported value: %v", n)
+		}
+	}
+
 	if len(e) != 0 {
 		return httperror.NewForBadRequest(&e)
 	}
@@ -183,3 +202,63 @@ func (h *Handler) ProfileUpdate(w http.ResponseWriter, r *http.Request) {
 	// Get the request
 	h.Profile(w, r)
 }
+
+func (h *Handler) ProfileChangeEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req gateway_c.ProfileChangeEmailRequestIDO
+	if err := h.decodeRequest(r, &req); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := h.Controller.ProfileChangeEmail(ctx, &req); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	h.Profile(w, r)
+}
+
+func (h *Handler) ProfileCancelEmailChange(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := h.Controller.ProfileCancelEmailChange(ctx); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	h.Profile(w, r)
+}
+
+func (h *Handler) ConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req gateway_c.EmailChangeConfirmRequestIDO
+	if err := h.decodeRequest(r, &req); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := h.Controller.ConfirmEmailChange(ctx, req.Code); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req gateway_c.UnsubscribeRequestIDO
+	if err := h.decodeRequest(r, &req); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := h.Controller.Unsubscribe(ctx, req.Token); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
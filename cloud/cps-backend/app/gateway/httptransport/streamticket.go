@@ -0,0 +1,21 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+func (h *Handler) StreamTicket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	res, err := h.Controller.StreamTicket(ctx)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+}
@@ -9,8 +9,3 @@ func (h *Handler) Version(w http.ResponseWriter, r *http.Request) {
 	result := "v1.0"
 	w.Write([]byte(result))
 }
-
-// Returns the status of the system.
-func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	w.Write([]byte("OK")) // DEVELOPERS NOTE: In future expand this when SaaSifying product.
-}
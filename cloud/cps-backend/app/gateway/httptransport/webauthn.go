@@ -0,0 +1,148 @@
+package httptransport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	gateway_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/controller"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+type WebAuthnLoginBeginRequestIDO struct {
+	Email string `json:"email"`
+}
+
+func (h *Handler) unmarshalWebAuthnAssertionRequest(ctx context.Context, r *http.Request) (*gateway_c.WebAuthnAssertionRequestIDO, error) {
+	var requestData gateway_c.WebAuthnAssertionRequestIDO
+	if err := h.decodeRequest(r, &requestData); err != nil {
+		return nil, err
+	}
+	return &requestData, nil
+}
+
+func encodeWebAuthnResponse(w http.ResponseWriter, res interface{}) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) WebAuthnRegisterBegin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	res, err := h.Controller.WebAuthnRegisterBegin(ctx)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	encodeWebAuthnResponse(w, res)
+}
+
+func (h *Handler) WebAuthnRegisterFinish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req gateway_c.WebAuthnRegisterFinishRequestIDO
+	if err := h.decodeRequest(r, &req); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	res, err := h.Controller.WebAuthnRegisterFinish(ctx, &req)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	encodeWebAuthnResponse(w, res)
+}
+
+func (h *Handler) WebAuthnListCredentials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	res, err := h.Controller.WebAuthnListCredentials(ctx)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	encodeWebAuthnResponse(w, res)
+}
+
+func (h *Handler) WebAuthnDeleteCredential(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("id", "invalid format"))
+		return
+	}
+	if err := h.Controller.WebAuthnDeleteCredential(ctx, objectID); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) WebAuthnValidateBegin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	res, err := h.Controller.WebAuthnValidateBegin(ctx)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	encodeWebAuthnResponse(w, res)
+}
+
+func (h *Handler) WebAuthnValidateFinish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := h.unmarshalWebAuthnAssertionRequest(ctx, r)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	res, err := h.Controller.WebAuthnValidateFinish(ctx, req)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	encodeWebAuthnResponse(w, res)
+}
+
+func (h *Handler) WebAuthnLoginBegin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req WebAuthnLoginBeginRequestIDO
+	if err := h.decodeRequest(r, &req); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	res, err := h.Controller.WebAuthnLoginBegin(ctx, req.Email)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	encodeWebAuthnResponse(w, res)
+}
+
+func (h *Handler) WebAuthnLoginFinish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := h.unmarshalWebAuthnAssertionRequest(ctx, r)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	res, err := h.Controller.WebAuthnLoginFinish(ctx, req)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	MarshalLoginResponse(res, w)
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	s3_storage "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/storage/s3"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+// HealthController Interface for reporting whether the server can do its job
+// to the orchestrator.
+type HealthController interface {
+	Readiness(ctx context.Context) *ReadinessResponseIDO
+}
+
+type HealthControllerImpl struct {
+	Config   *config.Conf
+	Logger   *slog.Logger
+	DbClient *mongo.Client
+	S3       s3_storage.S3Storager
+}
+
+func NewController(
+	appCfg *config.Conf,
+	loggerp *slog.Logger,
+	client *mongo.Client,
+	s3 s3_storage.S3Storager,
+) HealthController {
+	loggerp.Debug("health controller initialization started...")
+	s := &HealthControllerImpl{
+		Config:   appCfg,
+		Logger:   loggerp,
+		DbClient: client,
+		S3:       s3,
+	}
+	s.Logger.Debug("health controller initialized")
+	return s
+}
@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/pdfbuilder"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// checkTimeout is how long a dependency has to answer before we consider it
+// down, keep it below the timeout of the orchestrator's probe.
+const checkTimeout = 3 * time.Second
+
+type CheckResultIDO struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type ReadinessResponseIDO struct {
+	Status string                     `json:"status"`
+	Checks map[string]*CheckResultIDO `json:"checks"`
+}
+
+// Readiness checks every dependency we need to serve requests concurrently
+// and reports which ones are down.
+func (impl *HealthControllerImpl) Readiness(ctx context.Context) *ReadinessResponseIDO {
+	checks := map[string]func(ctx context.Context) error{
+		"mongodb":       impl.checkMongoDB,
+		"s3":            impl.checkS3,
+		"pdf_templates": impl.checkPDFFiles,
+	}
+
+	res := &ReadinessResponseIDO{
+		Status: StatusUp,
+		Checks: make(map[string]*CheckResultIDO, len(checks)),
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check func(ctx context.Context) error) {
+			defer wg.Done()
+			result := impl.run(ctx, name, check)
+			mu.Lock()
+			defer mu.Unlock()
+			res.Checks[name] = result
+			if result.Status != StatusUp {
+				res.Status = StatusDown
+			}
+		}(name, check)
+	}
+	wg.Wait()
+	return res
+}
+
+func (impl *HealthControllerImpl) run(ctx context.Context, name string, check func(ctx context.Context) error) *CheckResultIDO {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := &CheckResultIDO{
+		Status:    StatusUp,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		impl.Logger.Error("readiness check failed", slog.String("check", name), slog.Any("error", err))
+		result.Status = StatusDown
+		result.Error = "unavailable"
+
+		// The endpoint is public so only give away the details to developers.
+		if impl.Config.AppServer.IsDeveloperMode {
+			result.Error = err.Error()
+		}
+	}
+	return result
+}
+
+func (impl *HealthControllerImpl) checkMongoDB(ctx context.Context) error {
+	return impl.DbClient.Ping(ctx, readpref.Primary())
+}
+
+func (impl *HealthControllerImpl) checkS3(ctx context.Context) error {
+	exists, err := impl.S3.BucketExists(ctx, impl.Config.AWS.BucketName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("bucket %v does not exist", impl.Config.AWS.BucketName)
+	}
+	return nil
+}
+
+// checkPDFFiles makes sure every template and font the PDF builders need is
+// readable, a missing file would only show up once a submission is graded.
+func (impl *HealthControllerImpl) checkPDFFiles(ctx context.Context) error {
+	var errs []error
+	for _, path := range pdfbuilder.RequiredFiles(impl.Config) {
+		f, err := os.Open(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		info, err := f.Stat()
+		f.Close()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if info.IsDir() {
+			errs = append(errs, fmt.Errorf("%v is a directory", path))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,33 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	health_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/health/controller"
+)
+
+// Liveness tells the orchestrator the process is running, it does not check
+// any dependency so a database outage does not get every pod restarted.
+func (h *Handler) Liveness(w http.ResponseWriter, r *http.Request) {
+	res := map[string]string{"status": health_c.StatusUp}
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// Readiness tells the orchestrator whether to route traffic to this pod by
+// checking every dependency, it responds with `503` if any of them is down.
+func (h *Handler) Readiness(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	res := h.Controller.Readiness(ctx)
+	if res.Status != health_c.StatusUp {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/datastore"
+	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+type InvitationAcceptRequestIDO struct {
+	Token            string `json:"token"`
+	FirstName        string `json:"first_name"`
+	LastName         string `json:"last_name"`
+	Phone            string `json:"phone"`
+	Password         string `json:"password"`
+	PasswordRepeated string `json:"password_repeated"`
+	AgreeTOS         bool   `json:"agree_tos"`
+}
+
+func validateAcceptRequest(dirtyData *InvitationAcceptRequestIDO) error {
+	e := make(map[string]string)
+
+	if dirtyData.Token == "" {
+		e["token"] = "missing value"
+	}
+	if dirtyData.FirstName == "" {
+		e["first_name"] = "missing value"
+	}
+	if dirtyData.LastName == "" {
+		e["last_name"] = "missing value"
+	}
+	if dirtyData.Password == "" {
+		e["password"] = "missing value"
+	}
+	if len(dirtyData.Password) > 255 {
+		e["password"] = "too long"
+	}
+	if dirtyData.PasswordRepeated == "" {
+		e["password_repeated"] = "missing value"
+	}
+	if dirtyData.PasswordRepeated != dirtyData.Password {
+		e["password"] = "does not match"
+		e["password_repeated"] = "does not match"
+	}
+	if dirtyData.AgreeTOS == false {
+		e["agree_tos"] = "you must agree to the terms before proceeding"
+	}
+
+	if len(e) != 0 {
+		return httperror.NewForBadRequest(&e)
+	}
+	return nil
+}
+
+// GetByToken returns the pending invitation for the invite link token so
+// the accept page can show who is inviting and to which store.
+func (impl *InvitationControllerImpl) GetByToken(ctx context.Context, token string) (*domain.Invitation, error) {
+	tokenID, ok := impl.verifyToken(token)
+	if !ok {
+		return nil, httperror.NewForBadRequestWithSingleField("token", "invitation is invalid or has expired")
+	}
+	m, err := impl.InvitationStorer.GetByTokenID(ctx, tokenID)
+	if err != nil {
+		impl.Logger.Error("database get by token id error", slog.Any("error", err))
+		return nil, err
+	}
+	if m == nil || m.Status != domain.InvitationStatusPending || time.Now().After(m.ExpiresAt) {
+		return nil, httperror.NewForBadRequestWithSingleField("token", "invitation is invalid or has expired")
+	}
+	return m, nil
+}
+
+// Accept creates the account of the invited staff member with the password
+// they picked and attaches it to the store they were invited to.
+func (impl *InvitationControllerImpl) Accept(ctx context.Context, req *InvitationAcceptRequestIDO) error {
+	// Defensive Code: For security purposes we need to remove all whitespaces from the password.
+	req.Password = strings.ReplaceAll(req.Password, " ", "")
+	req.PasswordRepeated = strings.ReplaceAll(req.PasswordRepeated, " ", "")
+	if err := validateAcceptRequest(req); err != nil {
+		return err
+	}
+
+	////
+	//// Start the transaction.
+	////
+
+	session, err := impl.DbClient.StartSession()
+	if err != nil {
+		impl.Logger.Error("start session error",
+			slog.Any("error", err))
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// Define a transaction function with a series of operations
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		m, err := impl.GetByToken(sessCtx, req.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		// The email may have been registered since the invitation was sent.
+		u, err := impl.UserStorer.GetByEmail(sessCtx, m.Email)
+		if err != nil {
+			impl.Logger.Error("database get by email error", slog.Any("error", err))
+			return nil, err
+		}
+		if u != nil {
+			return nil, httperror.NewForBadRequestWithSingleField("email", "already has an account")
+		}
+
+		s, err := impl.StoreStorer.GetByID(sessCtx, m.StoreID)
+		if err != nil {
+			impl.Logger.Error("database get by id error", slog.Any("error", err))
+			return nil, err
+		}
+		if s == nil {
+			return nil, httperror.NewForBadRequestWithSingleField("token", "invitation is invalid or has expired")
+		}
+
+		passwordHash, err := impl.Password.GenerateHashFromPassword(req.Password)
+		if err != nil {
+			impl.Logger.Error("hashing error", slog.Any("error", err))
+			return nil, err
+		}
+
+		name := fmt.Sprintf("%s %s", req.FirstName, req.LastName)
+
+		// Create an account with our payment processor.
+		var paymentProcessorCustomerID string
+		if "Stripe, Inc." == impl.PaymentProcessor.GetName() {
+			cid, err := impl.PaymentProcessor.CreateCustomer(
+				name,
+				m.Email,
+				"", // description...
+				fmt.Sprintf("%s Shipping Address", name),
+				req.Phone,
+				"", "", "", "", "", "", // Shipping
+				"", "", "", "", "", "", // Billing
+			)
+			if err != nil {
+				impl.Logger.Error("creating customer from payment processor error", slog.Any("error", err))
+				return nil, err
+			}
+			paymentProcessorCustomerID = *cid
+		}
+
+		userID := primitive.NewObjectID()
+		u = &user_s.User{
+			ID:                         userID,
+			StoreID:                    s.ID,
+			StoreName:                  s.Name,
+			StoreLevel:                 s.Level,
+			StoreTimezone:              s.Timezone,
+			FirstName:                  req.FirstName,
+			LastName:                   req.LastName,
+			Name:                       name,
+			LexicalName:                fmt.Sprintf("%s, %s", req.LastName, req.FirstName),
+			Email:                      m.Email,
+			Locale:                     m.Locale,
+			Phone:                      req.Phone,
+			PasswordHash:               passwordHash,
+			PasswordHashAlgorithm:      impl.Password.AlgorithmName(),
+			Role:                       m.Role,
+			AgreeTOS:                   req.AgreeTOS,
+			CreatedByUserID:            m.CreatedByUserID,
+			CreatedAt:                  time.Now(),
+			CreatedByName:              m.CreatedByName,
+			ModifiedByUserID:           userID,
+			ModifiedAt:                 time.Now(),
+			ModifiedByName:             name,
+			WasEmailVerified:           true, // Receiving the invite proves the email.
+			Status:                     user_s.UserStatusActive,
+			PaymentProcessorName:       impl.PaymentProcessor.GetName(),
+			PaymentProcessorCustomerID: paymentProcessorCustomerID,
+		}
+		if err := impl.UserStorer.Create(sessCtx, u); err != nil {
+			impl.Logger.Error("database create error", slog.Any("error", err))
+			return nil, err
+		}
+
+		m.Status = domain.InvitationStatusAccepted
+		m.AcceptedAt = time.Now()
+		m.AcceptedUserID = u.ID
+		m.ModifiedAt = time.Now()
+		m.ModifiedByUserID = u.ID
+		m.ModifiedByName = u.Name
+		if err := impl.InvitationStorer.UpdateByID(sessCtx, m); err != nil {
+			return nil, err
+		}
+
+		impl.Logger.Info("invitation accepted",
+			slog.String("invitation_id", m.ID.Hex()),
+			slog.String("user_id", u.ID.Hex()),
+			slog.String("store_id", s.ID.Hex()))
+		return nil, nil
+	}
+
+	// Start a transaction
+	if _, err := session.WithTransaction(ctx, transactionFunc); err != nil {
+		impl.Logger.Error("session failed error",
+			slog.Any("error", err))
+		return err
+	}
+	return nil
+}
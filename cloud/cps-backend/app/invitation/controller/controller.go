@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	pm "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/paymentprocessor/stripe"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/datastore"
+	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
+	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/password"
+)
+
+// InvitationController Interface for the store staff invitations business logic controller.
+type InvitationController interface {
+	Create(ctx context.Context, req *InvitationCreateRequestIDO) (*domain.Invitation, error)
+	ListByFilter(ctx context.Context, f *domain.InvitationListFilter) ([]*domain.Invitation, error)
+	ResendByID(ctx context.Context, id primitive.ObjectID) (*domain.Invitation, error)
+	RevokeByID(ctx context.Context, id primitive.ObjectID) error
+	GetByToken(ctx context.Context, token string) (*domain.Invitation, error)
+	Accept(ctx context.Context, req *InvitationAcceptRequestIDO) error
+}
+
+type InvitationControllerImpl struct {
+	Config           *config.Conf
+	Logger           *slog.Logger
+	Password         password.Provider
+	DbClient         *mongo.Client
+	InvitationStorer domain.InvitationStorer
+	UserStorer       user_s.UserStorer
+	StoreStorer      store_s.StoreStorer
+	TemplatedEmailer templatedemailer.TemplatedEmailer
+	PaymentProcessor pm.PaymentProcessor
+}
+
+func NewController(
+	appCfg *config.Conf,
+	loggerp *slog.Logger,
+	passwordp password.Provider,
+	client *mongo.Client,
+	inv_storer domain.InvitationStorer,
+	usr_storer user_s.UserStorer,
+	org_storer store_s.StoreStorer,
+	temailer templatedemailer.TemplatedEmailer,
+	paymentProcessor pm.PaymentProcessor,
+) InvitationController {
+	loggerp.Debug("invitation controller initialization started...")
+	s := &InvitationControllerImpl{
+		Config:           appCfg,
+		Logger:           loggerp,
+		Password:         passwordp,
+		DbClient:         client,
+		InvitationStorer: inv_storer,
+		UserStorer:       usr_storer,
+		StoreStorer:      org_storer,
+		TemplatedEmailer: temailer,
+		PaymentProcessor: paymentProcessor,
+	}
+	s.Logger.Debug("invitation controller initialized")
+	return s
+}
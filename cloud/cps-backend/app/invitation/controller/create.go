@@ -0,0 +1,206 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/datastore"
+	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+// invitationExpiry is how long an invite link may be used after it was sent.
+const invitationExpiry = 7 * 24 * time.Hour
+
+type InvitationCreateRequestIDO struct {
+	// StoreID is only used by staff who manage every store, everyone else
+	// invites to their own store.
+	StoreID   primitive.ObjectID `json:"store_id,omitempty"`
+	Email     string             `json:"email"`
+	FirstName string             `json:"first_name"`
+	LastName  string             `json:"last_name"`
+	Role      int8               `json:"role"`
+	// Locale is the language of the invitation, defaults to the store's.
+	Locale string `json:"locale,omitempty"`
+}
+
+func (impl *InvitationControllerImpl) validateCreateRequest(dirtyData *InvitationCreateRequestIDO) error {
+	e := make(map[string]string)
+
+	if dirtyData.Email == "" {
+		e["email"] = "missing value"
+	}
+	if len(dirtyData.Email) > 255 {
+		e["email"] = "too long"
+	}
+	if dirtyData.FirstName == "" {
+		e["first_name"] = "missing value"
+	}
+	if dirtyData.LastName == "" {
+		e["last_name"] = "missing value"
+	}
+	if dirtyData.Role == 0 {
+		e["role"] = "missing value"
+	} else if r := permission.GetRole(dirtyData.Role); r == nil || r.Scope != permission.ScopeStore {
+		e["role"] = "is not a store role"
+	}
+
+	if dirtyData.Locale != "" && !i18n.IsSupported(dirtyData.Locale) {
+		e["locale"] = "unsupported value"
+	}
+
+	if len(e) != 0 {
+		return httperror.NewForBadRequest(&e)
+	}
+	return nil
+}
+
+// checkCanManageInvitations returns a `403 Forbidden` error if the logged in
+// user is not allowed to manage the invitations of the store.
+func (impl *InvitationControllerImpl) checkCanManageInvitations(ctx context.Context, storeID primitive.ObjectID) error {
+	if permission.Can(ctx, permission.UserManage) {
+		return nil
+	}
+	userStoreID, _ := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+	if permission.Can(ctx, permission.UserManageStore) && storeID == userStoreID {
+		return nil
+	}
+	return httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+}
+
+func (impl *InvitationControllerImpl) Create(ctx context.Context, req *InvitationCreateRequestIDO) (*domain.Invitation, error) {
+	if err := permission.RequireAny(ctx, permission.UserManage, permission.UserManageStore); err != nil {
+		return nil, err
+	}
+	if !permission.Can(ctx, permission.UserManage) {
+		req.StoreID, _ = ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+	}
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	if err := impl.validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	if req.StoreID.IsZero() {
+		return nil, httperror.NewForBadRequestWithSingleField("store_id", "missing value")
+	}
+
+	////
+	//// Start the transaction.
+	////
+
+	session, err := impl.DbClient.StartSession()
+	if err != nil {
+		impl.Logger.Error("start session error",
+			slog.Any("error", err))
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// Define a transaction function with a series of operations
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		userID, _ := sessCtx.Value(constants.SessionUserID).(primitive.ObjectID)
+		userName, _ := sessCtx.Value(constants.SessionUserName).(string)
+
+		u, err := impl.UserStorer.GetByEmail(sessCtx, req.Email)
+		if err != nil {
+			impl.Logger.Error("database get by email error", slog.Any("error", err))
+			return nil, err
+		}
+		if u != nil {
+			return nil, httperror.NewForBadRequestWithSingleField("email", "already has an account")
+		}
+
+		existing, err := impl.InvitationStorer.GetPendingByStoreIDAndEmail(sessCtx, req.StoreID, req.Email)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, httperror.NewForBadRequestWithSingleField("email", "already invited, please resend the invitation instead")
+		}
+
+		s, err := impl.StoreStorer.GetByID(sessCtx, req.StoreID)
+		if err != nil {
+			impl.Logger.Error("database get by id error", slog.Any("error", err))
+			return nil, err
+		}
+		if s == nil || s.Status == store_s.StoreArchivedStatus {
+			return nil, httperror.NewForBadRequestWithSingleField("store_id", "store does not exist")
+		}
+
+		tokenID, err := newTokenID()
+		if err != nil {
+			impl.Logger.Error("token generation error", slog.Any("error", err))
+			return nil, err
+		}
+		locale := req.Locale
+		if locale == "" {
+			locale = s.Locale
+		}
+		m := &domain.Invitation{
+			ID:               primitive.NewObjectID(),
+			StoreID:          s.ID,
+			StoreName:        s.Name,
+			Email:            req.Email,
+			FirstName:        req.FirstName,
+			LastName:         req.LastName,
+			Locale:           locale,
+			Role:             req.Role,
+			Status:           domain.InvitationStatusPending,
+			TokenID:          tokenID,
+			ExpiresAt:        time.Now().Add(invitationExpiry),
+			SentAt:           time.Now(),
+			SentCount:        1,
+			CreatedAt:        time.Now(),
+			CreatedByUserID:  userID,
+			CreatedByName:    userName,
+			ModifiedAt:       time.Now(),
+			ModifiedByUserID: userID,
+			ModifiedByName:   userName,
+		}
+		if err := impl.InvitationStorer.Create(sessCtx, m); err != nil {
+			return nil, err
+		}
+		if err := impl.sendInvitationEmail(sessCtx, m); err != nil {
+			impl.Logger.Error("failed sending invitation email", slog.Any("error", err))
+			return nil, err
+		}
+		impl.Logger.Debug("invitation created",
+			slog.String("invitation_id", m.ID.Hex()),
+			slog.String("store_id", m.StoreID.Hex()))
+		return m, nil
+	}
+
+	// Start a transaction
+	res, err := session.WithTransaction(ctx, transactionFunc)
+	if err != nil {
+		impl.Logger.Error("session failed error",
+			slog.Any("error", err))
+		return nil, err
+	}
+	return res.(*domain.Invitation), nil
+}
+
+func (impl *InvitationControllerImpl) sendInvitationEmail(ctx context.Context, m *domain.Invitation) error {
+	var roleName string
+	if r := permission.GetRole(m.Role); r != nil {
+		roleName = r.Name
+	}
+	return impl.TemplatedEmailer.SendStaffInvitationEmail(
+		ctx,
+		m.Locale,
+		m.Email,
+		m.FirstName,
+		m.ModifiedByName,
+		m.StoreName,
+		roleName,
+		impl.signToken(m.TokenID),
+		m.ExpiresAt.Format("January 2, 2006"),
+	)
+}
@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+func (impl *InvitationControllerImpl) ListByFilter(ctx context.Context, f *domain.InvitationListFilter) ([]*domain.Invitation, error) {
+	// Apply filtering based on ownership and role.
+	if !permission.Can(ctx, permission.UserManage) {
+		if err := permission.Require(ctx, permission.UserManageStore); err != nil {
+			return nil, err
+		}
+		f.StoreID, _ = ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+	}
+
+	impl.Logger.Debug("listing using filter options:",
+		slog.Any("StoreID", f.StoreID),
+		slog.Any("Status", f.Status))
+
+	res, err := impl.InvitationStorer.ListByFilter(ctx, f)
+	if err != nil {
+		impl.Logger.Error("database list by filter error", slog.Any("error", err))
+		return nil, err
+	}
+	return res, nil
+}
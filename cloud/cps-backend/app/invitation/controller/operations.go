@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+// getPendingForChange returns the pending invitation if the logged in user
+// is allowed to change it.
+func (impl *InvitationControllerImpl) getPendingForChange(ctx context.Context, id primitive.ObjectID) (*domain.Invitation, error) {
+	m, err := impl.InvitationStorer.GetByID(ctx, id)
+	if err != nil {
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if m == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "invitation does not exist")
+	}
+	if err := impl.checkCanManageInvitations(ctx, m.StoreID); err != nil {
+		return nil, err
+	}
+	if m.Status != domain.InvitationStatusPending {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "invitation is no longer pending")
+	}
+	return m, nil
+}
+
+// ResendByID sends the invitation again with a new link and expiry, the
+// previously sent link stops working.
+func (impl *InvitationControllerImpl) ResendByID(ctx context.Context, id primitive.ObjectID) (*domain.Invitation, error) {
+	session, err := impl.DbClient.StartSession()
+	if err != nil {
+		impl.Logger.Error("start session error",
+			slog.Any("error", err))
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// Define a transaction function with a series of operations
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		m, err := impl.getPendingForChange(sessCtx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		tokenID, err := newTokenID()
+		if err != nil {
+			impl.Logger.Error("token generation error", slog.Any("error", err))
+			return nil, err
+		}
+		m.TokenID = tokenID
+		m.ExpiresAt = time.Now().Add(invitationExpiry)
+		m.SentAt = time.Now()
+		m.SentCount++
+		m.ModifiedAt = time.Now()
+		m.ModifiedByUserID, _ = sessCtx.Value(constants.SessionUserID).(primitive.ObjectID)
+		m.ModifiedByName, _ = sessCtx.Value(constants.SessionUserName).(string)
+		if err := impl.InvitationStorer.UpdateByID(sessCtx, m); err != nil {
+			return nil, err
+		}
+		if err := impl.sendInvitationEmail(sessCtx, m); err != nil {
+			impl.Logger.Error("failed sending invitation email", slog.Any("error", err))
+			return nil, err
+		}
+		return m, nil
+	}
+
+	// Start a transaction
+	res, err := session.WithTransaction(ctx, transactionFunc)
+	if err != nil {
+		impl.Logger.Error("session failed error",
+			slog.Any("error", err))
+		return nil, err
+	}
+	return res.(*domain.Invitation), nil
+}
+
+// RevokeByID stops the invitation from being accepted.
+func (impl *InvitationControllerImpl) RevokeByID(ctx context.Context, id primitive.ObjectID) error {
+	m, err := impl.getPendingForChange(ctx, id)
+	if err != nil {
+		return err
+	}
+	m.Status = domain.InvitationStatusRevoked
+	m.ModifiedAt = time.Now()
+	m.ModifiedByUserID, _ = ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	m.ModifiedByName, _ = ctx.Value(constants.SessionUserName).(string)
+	if err := impl.InvitationStorer.UpdateByID(ctx, m); err != nil {
+		return err
+	}
+	impl.Logger.Debug("invitation revoked", slog.String("invitation_id", m.ID.Hex()))
+	return nil
+}
@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// newTokenID returns a new random token id for an invitation.
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// signToken returns the invite link token which is the token id signed with
+// our secret, so knowing a token id alone is not enough to accept.
+func (impl *InvitationControllerImpl) signToken(tokenID string) string {
+	mac := hmac.New(sha256.New, impl.Config.AppServer.HMACSecret)
+	mac.Write([]byte(tokenID))
+	return tokenID + "." + hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyToken returns the token id of the invite link token or false if
+// the signature does not match.
+func (impl *InvitationControllerImpl) verifyToken(token string) (string, bool) {
+	tokenID, _, ok := strings.Cut(token, ".")
+	if !ok || tokenID == "" {
+		return "", false
+	}
+	return tokenID, hmac.Equal([]byte(impl.signToken(tokenID)), []byte(token))
+}
@@ -0,0 +1,101 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+const (
+	InvitationStatusPending  = 1
+	InvitationStatusAccepted = 2
+	InvitationStatusRevoked  = 3
+)
+
+// Invitation represents a person invited by email to join a store as staff.
+// The user account is only created once the invitation is accepted.
+type Invitation struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	StoreID   primitive.ObjectID `bson:"store_id" json:"store_id"`
+	StoreName string             `bson:"store_name" json:"store_name"`
+	Email     string             `bson:"email" json:"email"`
+	FirstName string             `bson:"first_name" json:"first_name"`
+	LastName  string             `bson:"last_name" json:"last_name"`
+	Locale    string             `bson:"locale" json:"locale,omitempty"`
+	Role      int8               `bson:"role" json:"role"`
+	Status    int8               `bson:"status" json:"status"`
+	// TokenID is the random part of the signed invite link, it is replaced
+	// every time the invitation is sent so older links stop working.
+	TokenID          string             `bson:"token_id" json:"-"`
+	ExpiresAt        time.Time          `bson:"expires_at" json:"expires_at"`
+	SentAt           time.Time          `bson:"sent_at" json:"sent_at"`
+	SentCount        int                `bson:"sent_count" json:"sent_count"`
+	AcceptedAt       time.Time          `bson:"accepted_at,omitempty" json:"accepted_at,omitempty"`
+	AcceptedUserID   primitive.ObjectID `bson:"accepted_user_id,omitempty" json:"accepted_user_id,omitempty"`
+	CreatedAt        time.Time          `bson:"created_at" json:"created_at"`
+	CreatedByUserID  primitive.ObjectID `bson:"created_by_user_id" json:"created_by_user_id"`
+	CreatedByName    string             `bson:"created_by_name" json:"created_by_name"`
+	ModifiedAt       time.Time          `bson:"modified_at" json:"modified_at"`
+	ModifiedByUserID primitive.ObjectID `bson:"modified_by_user_id" json:"modified_by_user_id"`
+	ModifiedByName   string             `bson:"modified_by_name" json:"modified_by_name"`
+}
+
+// InvitationListFilter filters the invitations of a store, a zero value
+// `Status` returns every invitation.
+type InvitationListFilter struct {
+	StoreID primitive.ObjectID
+	Status  int8
+}
+
+// InvitationStorer Interface for invitation.
+type InvitationStorer interface {
+	Create(ctx context.Context, m *Invitation) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*Invitation, error)
+	GetByTokenID(ctx context.Context, tokenID string) (*Invitation, error)
+	GetPendingByStoreIDAndEmail(ctx context.Context, storeID primitive.ObjectID, email string) (*Invitation, error)
+	UpdateByID(ctx context.Context, m *Invitation) error
+	ListByFilter(ctx context.Context, f *InvitationListFilter) ([]*Invitation, error)
+}
+
+type InvitationStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) InvitationStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("invitations")
+
+	// The following few lines of code will create the index for our app for
+	// this colleciton.
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "token_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "store_id", Value: 1}, {Key: "status", Value: 1}, {Key: "email", Value: 1}},
+		},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &InvitationStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
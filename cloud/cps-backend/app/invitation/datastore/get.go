@@ -0,0 +1,62 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (impl InvitationStorerImpl) GetByID(ctx context.Context, id primitive.ObjectID) (*Invitation, error) {
+	filter := bson.M{"_id": id}
+
+	var result Invitation
+	err := impl.Collection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// This error means your query did not match any documents.
+			return nil, nil
+		}
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (impl InvitationStorerImpl) GetByTokenID(ctx context.Context, tokenID string) (*Invitation, error) {
+	filter := bson.M{"token_id": tokenID}
+
+	var result Invitation
+	err := impl.Collection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// This error means your query did not match any documents.
+			return nil, nil
+		}
+		impl.Logger.Error("database get by token id error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (impl InvitationStorerImpl) GetPendingByStoreIDAndEmail(ctx context.Context, storeID primitive.ObjectID, email string) (*Invitation, error) {
+	filter := bson.M{
+		"store_id": storeID,
+		"email":    email,
+		"status":   InvitationStatusPending,
+	}
+
+	var result Invitation
+	err := impl.Collection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// This error means your query did not match any documents.
+			return nil, nil
+		}
+		impl.Logger.Error("database get pending by store id and email error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
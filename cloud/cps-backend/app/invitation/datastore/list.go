@@ -0,0 +1,36 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ListByFilter returns the invitations with the most recent first. A store
+// only has a handful of invitations so we do not paginate.
+func (impl InvitationStorerImpl) ListByFilter(ctx context.Context, f *InvitationListFilter) ([]*Invitation, error) {
+	filter := bson.M{}
+	if !f.StoreID.IsZero() {
+		filter["store_id"] = f.StoreID
+	}
+	if f.Status != 0 {
+		filter["status"] = f.Status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := impl.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		impl.Logger.Error("database list by filter error", slog.Any("error", err))
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results = []*Invitation{}
+	if err := cursor.All(ctx, &results); err != nil {
+		impl.Logger.Error("database list by filter decode error", slog.Any("error", err))
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,55 @@
+package httptransport
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	invitation_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/controller"
+	invitation_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+func UnmarshalCreateRequest(ctx context.Context, r *http.Request) (*invitation_c.InvitationCreateRequestIDO, error) {
+	// Initialize our array which will store all the results from the remote server.
+	var requestData invitation_c.InvitationCreateRequestIDO
+
+	defer r.Body.Close()
+
+	// Read the JSON string and convert it into our golang stuct else we need
+	// to send a `400 Bad Request` errror message back to the client,
+	err := json.NewDecoder(r.Body).Decode(&requestData) // [1]
+	if err != nil {
+		log.Println("UnmarshalCreateRequest | NewDecoder/Decode | err:", err)
+		return nil, httperror.NewForSingleField(http.StatusBadRequest, "non_field_error", "payload structure is wrong")
+	}
+
+	return &requestData, nil
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := UnmarshalCreateRequest(ctx, r)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	m, err := h.Controller.Create(ctx, req)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	MarshalCreateResponse(m, w)
+}
+
+func MarshalCreateResponse(res *invitation_s.Invitation, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
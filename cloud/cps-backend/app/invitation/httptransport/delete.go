@@ -0,0 +1,25 @@
+package httptransport
+
+import (
+	"net/http"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeleteByID revokes the invitation, the record is kept for auditing.
+func (h *Handler) DeleteByID(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := h.Controller.RevokeByID(ctx, objectID); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,21 @@
+package httptransport
+
+import (
+	"log/slog"
+
+	invitation_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/controller"
+)
+
+// Handler Creates http request handler
+type Handler struct {
+	Logger     *slog.Logger
+	Controller invitation_c.InvitationController
+}
+
+// NewHandler Constructor
+func NewHandler(loggerp *slog.Logger, c invitation_c.InvitationController) *Handler {
+	return &Handler{
+		Logger:     loggerp,
+		Controller: c,
+	}
+}
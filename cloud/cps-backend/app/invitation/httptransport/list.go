@@ -0,0 +1,56 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	invitation_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	f := &invitation_s.InvitationListFilter{}
+
+	// Here is where you extract url parameters.
+	query := r.URL.Query()
+
+	storeIDStr := query.Get("store_id")
+	if storeIDStr != "" {
+		storeID, err := primitive.ObjectIDFromHex(storeIDStr)
+		if err != nil {
+			httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("store_id", "invalid value"))
+			return
+		}
+		f.StoreID = storeID
+	}
+
+	statusStr := query.Get("status")
+	if statusStr != "" {
+		status, err := strconv.ParseInt(statusStr, 10, 8)
+		if err != nil {
+			httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("status", "invalid value"))
+			return
+		}
+		f.Status = int8(status)
+	}
+
+	m, err := h.Controller.ListByFilter(ctx, f)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalListResponse(m, w)
+}
+
+func MarshalListResponse(res []*invitation_s.Invitation, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,61 @@
+package httptransport
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	invitation_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+type ResendRequest struct {
+	InvitationID primitive.ObjectID `json:"invitation_id"`
+}
+
+func UnmarshalOperationResendRequest(ctx context.Context, r *http.Request) (*ResendRequest, error) {
+	// Initialize our array which will store all the results from the remote server.
+	var requestData ResendRequest
+
+	defer r.Body.Close()
+
+	// Read the JSON string and convert it into our golang stuct else we need
+	// to send a `400 Bad Request` errror message back to the client,
+	err := json.NewDecoder(r.Body).Decode(&requestData) // [1]
+	if err != nil {
+		log.Println("UnmarshalOperationResendRequest | NewDecoder/Decode | err:", err)
+		return nil, httperror.NewForSingleField(http.StatusBadRequest, "non_field_error", "payload structure is wrong")
+	}
+	if requestData.InvitationID.IsZero() {
+		return nil, httperror.NewForBadRequestWithSingleField("invitation_id", "missing value")
+	}
+	return &requestData, nil
+}
+
+// OperationResend sends the invitation again with a new link.
+func (h *Handler) OperationResend(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := UnmarshalOperationResendRequest(ctx, r)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	m, err := h.Controller.ResendByID(ctx, req.InvitationID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	MarshalOperationResendResponse(m, w)
+}
+
+func MarshalOperationResendResponse(res *invitation_s.Invitation, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
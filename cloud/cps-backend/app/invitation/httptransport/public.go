@@ -0,0 +1,81 @@
+package httptransport
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	invitation_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/controller"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+// PublicInvitationResponse is what an anonymous visitor of the invite link
+// is allowed to see about the invitation.
+type PublicInvitationResponse struct {
+	StoreName     string    `json:"store_name"`
+	Email         string    `json:"email"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	Role          int8      `json:"role"`
+	CreatedByName string    `json:"created_by_name"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+func (h *Handler) GetByToken(w http.ResponseWriter, r *http.Request, token string) {
+	ctx := r.Context()
+
+	m, err := h.Controller.GetByToken(ctx, token)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	res := &PublicInvitationResponse{
+		StoreName:     m.StoreName,
+		Email:         m.Email,
+		FirstName:     m.FirstName,
+		LastName:      m.LastName,
+		Role:          m.Role,
+		CreatedByName: m.CreatedByName,
+		ExpiresAt:     m.ExpiresAt,
+	}
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func UnmarshalAcceptRequest(ctx context.Context, r *http.Request) (*invitation_c.InvitationAcceptRequestIDO, error) {
+	// Initialize our array which will store all the results from the remote server.
+	var requestData invitation_c.InvitationAcceptRequestIDO
+
+	defer r.Body.Close()
+
+	// Read the JSON string and convert it into our golang stuct else we need
+	// to send a `400 Bad Request` errror message back to the client,
+	err := json.NewDecoder(r.Body).Decode(&requestData) // [1]
+	if err != nil {
+		log.Println("UnmarshalAcceptRequest | NewDecoder/Decode | err:", err)
+		return nil, httperror.NewForSingleField(http.StatusBadRequest, "non_field_error", "payload structure is wrong")
+	}
+
+	return &requestData, nil
+}
+
+func (h *Handler) Accept(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := UnmarshalAcceptRequest(ctx, r)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := h.Controller.Accept(ctx, req); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/pdfbuilder"
+	s3_storage "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/storage/s3"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/datastore"
+	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
+	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/kmutex"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
+)
+
+// InvoiceController Interface for store account invoicing business logic controller.
+type InvoiceController interface {
+	GetByID(ctx context.Context, id primitive.ObjectID) (*domain.Invoice, error)
+	ListByFilter(ctx context.Context, f *domain.InvoicePaginationListFilter) (*domain.InvoicePaginationListResult, error)
+	IssueByID(ctx context.Context, id primitive.ObjectID) (*domain.Invoice, error)
+	CreatePayment(ctx context.Context, req *InvoicePaymentCreateRequest) (*domain.Invoice, error)
+	IssueEndedPeriods(ctx context.Context) error
+	SendOverdueReminders(ctx context.Context) error
+}
+
+type InvoiceControllerImpl struct {
+	Config           *config.Conf
+	Logger           *slog.Logger
+	UUID             uuid.Provider
+	S3               s3_storage.S3Storager
+	Kmutex           kmutex.Provider
+	InvoiceBuilder   pdfbuilder.InvoiceBuilder
+	TemplatedEmailer templatedemailer.TemplatedEmailer
+	DbClient         *mongo.Client
+	UserStorer       user_s.UserStorer
+	StoreStorer      store_s.StoreStorer
+	InvoiceStorer    domain.InvoiceStorer
+}
+
+func NewController(
+	appCfg *config.Conf,
+	loggerp *slog.Logger,
+	uuidp uuid.Provider,
+	s3 s3_storage.S3Storager,
+	kmux kmutex.Provider,
+	ib pdfbuilder.InvoiceBuilder,
+	te templatedemailer.TemplatedEmailer,
+	client *mongo.Client,
+	usr_storer user_s.UserStorer,
+	org_storer store_s.StoreStorer,
+	inv_storer domain.InvoiceStorer,
+) InvoiceController {
+	loggerp.Debug("invoice controller initialization started...")
+	s := &InvoiceControllerImpl{
+		Config:           appCfg,
+		Logger:           loggerp,
+		UUID:             uuidp,
+		S3:               s3,
+		Kmutex:           kmux,
+		InvoiceBuilder:   ib,
+		TemplatedEmailer: te,
+		DbClient:         client,
+		UserStorer:       usr_storer,
+		StoreStorer:      org_storer,
+		InvoiceStorer:    inv_storer,
+	}
+	s.Logger.Debug("invoice controller initialized")
+	return s
+}
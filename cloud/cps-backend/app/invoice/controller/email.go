@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/datastore"
+)
+
+func (impl *InvoiceControllerImpl) sendInvoiceEmails(ctx context.Context, m *domain.Invoice, isOverdue bool) error {
+	impl.Logger.Debug("sending to retailer staff",
+		slog.Any("invoice_id", m.ID),
+		slog.Bool("is_overdue", isOverdue))
+
+	res, err := impl.UserStorer.ListAllRetailerStaffForStoreID(ctx, m.StoreID)
+	if err != nil {
+		impl.Logger.Error("database list all retailer staff error",
+			slog.Any("invoice_id", m.ID),
+			slog.Any("error", err))
+		return err
+	}
+
+	recipients := []templatedemailer.Recipient{}
+	for _, u := range res.Results {
+		recipients = append(recipients, templatedemailer.Recipient{Email: u.Email, Locale: u.Locale})
+	}
+
+	amountDue := fmt.Sprintf("$%.2f %v", m.AmountDue, strings.ToUpper(m.Currency))
+	dueDate := m.DueAt.Format("January 2, 2006")
+
+	if isOverdue {
+		return impl.TemplatedEmailer.SendInvoiceOverdueReminderEmailToRetailers(ctx, recipients, m.ID.Hex(), m.InvoiceNumber, m.StoreName, amountDue, dueDate)
+	}
+	return impl.TemplatedEmailer.SendInvoiceIssuedEmailToRetailers(ctx, recipients, m.ID.Hex(), m.InvoiceNumber, m.StoreName, amountDue, dueDate)
+}
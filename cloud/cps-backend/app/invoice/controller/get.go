@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+func (c *InvoiceControllerImpl) GetByID(ctx context.Context, id primitive.ObjectID) (*domain.Invoice, error) {
+	userStoreID := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+
+	// Retrieve from our database the record for the specific id.
+	m, err := c.InvoiceStorer.GetByID(ctx, id)
+	if err != nil {
+		c.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if m == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "invoice does not exist")
+	}
+
+	switch { // Security.
+	case permission.Can(ctx, permission.InvoiceViewAll):
+		c.Logger.Debug("access granted")
+	case permission.Scope(ctx) == permission.ScopeStore:
+		if m.StoreID != userStoreID {
+			return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+		}
+	default:
+		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+
+	// The following will generate a pre-signed URL so user can download the
+	// invoice if the previous pre-signed URL has expired.
+	if m.FileObjectKey != "" && time.Now().After(m.FileObjectURLExpiry) {
+		expiryDate := time.Now().Add(time.Minute * 15)
+		downloadableURL, err := c.S3.GetDownloadablePresignedURL(ctx, m.FileObjectKey, time.Minute*15)
+		if err != nil {
+			c.Logger.Warn("s3 presign error", slog.Any("error", err))
+			// Do not return an error, simply continue this function as there might
+			// be a case were the file was removed on the s3 bucket by ourselves
+			// or some other reason.
+			return m, nil
+		}
+		m.FileObjectURL = downloadableURL
+		m.FileObjectURLExpiry = expiryDate
+		if err := c.InvoiceStorer.UpdateByID(ctx, m); err != nil {
+			c.Logger.Error("database update by id error", slog.Any("error", err))
+			return nil, err
+		}
+	}
+	return m, err
+}
@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/pdfbuilder"
+	s_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/datastore"
+	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+// IssueByID function closes the open invoice before the end of the billing
+// period and sends it to the store.
+func (impl *InvoiceControllerImpl) IssueByID(ctx context.Context, id primitive.ObjectID) (*domain.Invoice, error) {
+	if err := permission.Require(ctx, permission.InvoiceManage); err != nil { // Security.
+		return nil, err
+	}
+
+	m, err := impl.InvoiceStorer.GetByID(ctx, id)
+	if err != nil {
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if m == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "invoice does not exist")
+	}
+	if m.Status != domain.StatusOpen {
+		return nil, httperror.NewForBadRequestWithSingleField("message", "invoice was already issued")
+	}
+
+	if err := impl.issue(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// IssueEndedPeriods function issues every open invoice whose billing period
+// has ended and is intended to be executed by the scheduler.
+func (impl *InvoiceControllerImpl) IssueEndedPeriods(ctx context.Context) error {
+	invoices, err := impl.InvoiceStorer.ListAllOpenEndedBefore(ctx, time.Now())
+	if err != nil {
+		impl.Logger.Error("database list all open ended before error", slog.Any("error", err))
+		return err
+	}
+	impl.Logger.Debug("issuing invoices for ended periods", slog.Int("count", len(invoices)))
+
+	// DEVELOPERS NOTE:
+	// We continue on error so one bad invoice does not block the rest of the
+	// stores from being invoiced; the next run will retry the failed ones.
+	var lastErr error
+	for _, m := range invoices {
+		if err := impl.issue(ctx, m); err != nil {
+			impl.Logger.Error("issue invoice error",
+				slog.Any("invoice_id", m.ID),
+				slog.Any("error", err))
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func (impl *InvoiceControllerImpl) issue(ctx context.Context, m *domain.Invoice) error {
+	impl.Kmutex.Lockf("invoice-store-%v", m.StoreID.Hex())
+	defer impl.Kmutex.Unlockf("invoice-store-%v", m.StoreID.Hex())
+
+	store, err := impl.StoreStorer.GetByID(ctx, m.StoreID)
+	if err != nil {
+		impl.Logger.Error("database get store by id error", slog.Any("error", err))
+		return err
+	}
+	if store == nil {
+		return fmt.Errorf("store does not exist for id: %v", m.StoreID.Hex())
+	}
+	terms := store.PaymentTermsDays
+	if terms <= 0 {
+		terms = store_s.DefaultPaymentTermsDays
+	}
+
+	m.StoreName = store.Name
+	m.PaymentTermsDays = terms
+	m.IssuedAt = time.Now()
+	m.DueAt = m.IssuedAt.AddDate(0, 0, int(terms))
+	m.AmountDue = m.AmountTotal - m.AmountPaid
+	m.Status = domain.StatusSent
+	if m.AmountDue <= 0 {
+		m.Status = domain.StatusPaid
+		m.PaidAt = m.IssuedAt
+	}
+	m.ModifiedAt = time.Now()
+
+	objectKey, objectURL, objectURLExpiry, err := impl.generateAndUploadPDF(ctx, m)
+	if err != nil {
+		impl.Logger.Error("generate and upload invoice pdf error", slog.Any("error", err))
+		return err
+	}
+	m.FileObjectKey = objectKey
+	m.FileObjectURL = objectURL
+	m.FileObjectURLExpiry = objectURLExpiry
+
+	if err := impl.InvoiceStorer.UpdateByID(ctx, m); err != nil {
+		impl.Logger.Error("database update by id error", slog.Any("error", err))
+		return err
+	}
+
+	if m.Status == domain.StatusSent {
+		if err := impl.sendInvoiceEmails(ctx, m, false); err != nil {
+			// Do not return error, just keep it in the server logs.
+			impl.Logger.Error("send invoice issued emails error", slog.Any("error", err))
+		}
+	}
+
+	impl.Logger.Debug("issued invoice",
+		slog.Any("invoice_id", m.ID),
+		slog.String("invoice_number", m.InvoiceNumber))
+	return nil
+}
+
+func (impl *InvoiceControllerImpl) generateAndUploadPDF(ctx context.Context, m *domain.Invoice) (string, string, time.Time, error) {
+	r := &pdfbuilder.InvoiceBuilderRequestDTO{
+		InvoiceNumber: m.InvoiceNumber,
+		StoreName:     m.StoreName,
+		PeriodStart:   m.PeriodStart,
+		PeriodEnd:     m.PeriodEnd,
+		IssuedAt:      m.IssuedAt,
+		DueAt:         m.DueAt,
+		Currency:      strings.ToUpper(m.Currency),
+		LineItems:     make([]*pdfbuilder.InvoiceLineItemDTO, 0, len(m.LineItems)),
+		AmountTotal:   m.AmountTotal,
+		AmountPaid:    m.AmountPaid,
+		AmountDue:     m.AmountDue,
+	}
+	for _, li := range m.LineItems {
+		r.LineItems = append(r.LineItems, &pdfbuilder.InvoiceLineItemDTO{
+			CPSRN:     li.CPSRN,
+			Item:      li.Item,
+			Service:   s_d.ServiceTypeMap[li.ServiceType],
+			Amount:    li.Amount,
+			CreatedAt: li.CreatedAt,
+		})
+	}
+
+	pdfResponse, err := impl.InvoiceBuilder.GeneratePDF(r)
+	if err != nil {
+		impl.Logger.Error("pdf generation error", slog.Any("error", err))
+		return "", "", time.Now(), err
+	}
+
+	// The next few lines will upload our PDF to our remote storage. Once the
+	// file is saved remotely, we will have a connection to it through a "key"
+	// unique reference to the uploaded file.
+	path := fmt.Sprintf("invoices/%v", pdfResponse.FileName)
+
+	impl.Logger.Debug("S3 will upload...",
+		slog.String("path", path))
+
+	if err := impl.S3.UploadContent(ctx, path, pdfResponse.Content); err != nil {
+		impl.Logger.Error("s3 upload error", slog.Any("error", err))
+		return "", "", time.Now(), err
+	}
+
+	impl.Logger.Debug("S3 uploaded with success",
+		slog.String("path", path))
+
+	// The following will generate a pre-signed URL so user can download the file.
+	expiryDate := time.Now().Add(time.Minute * 15)
+	downloadableURL, err := impl.S3.GetDownloadablePresignedURL(ctx, path, time.Minute*15)
+	if err != nil {
+		impl.Logger.Error("s3 presign error", slog.Any("error", err))
+		return "", "", time.Now(), err
+	}
+
+	// Removing local file from the directory and don't do anything if we have errors.
+	if err := os.Remove(pdfResponse.FilePath); err != nil {
+		impl.Logger.Warn("removing local file error", slog.Any("error", err))
+		// Just continue even if we get an error...
+	}
+
+	return path, downloadableURL, expiryDate, nil
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"context"
+
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+func (c *InvoiceControllerImpl) ListByFilter(ctx context.Context, f *domain.InvoicePaginationListFilter) (*domain.InvoicePaginationListResult, error) {
+	switch { // Security.
+	case permission.Can(ctx, permission.InvoiceViewAll):
+		c.Logger.Debug("access granted")
+	case permission.Scope(ctx) == permission.ScopeStore:
+		// Retailers are only allowed to see the invoices of their store.
+		f.StoreID = ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+	default:
+		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+
+	c.Logger.Debug("listing using filter options:",
+		slog.Any("StoreID", f.StoreID),
+		slog.Any("Status", f.Status),
+		slog.Any("Cursor", f.Cursor),
+		slog.Int64("PageSize", f.PageSize),
+		slog.String("SortField", f.SortField),
+		slog.Int("SortOrder", int(f.SortOrder)))
+
+	m, err := c.InvoiceStorer.ListByFilter(ctx, f)
+	if err != nil {
+		c.Logger.Error("database list by filter error", slog.Any("error", err))
+		return nil, err
+	}
+	return m, err
+}
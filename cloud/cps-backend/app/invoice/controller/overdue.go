@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/datastore"
+)
+
+// overdueReminderInterval is how long we wait between reminders so we do not
+// flood the retail partner's inbox.
+const overdueReminderInterval = 7 * 24 * time.Hour
+
+// SendOverdueReminders function marks every unpaid invoice past its due date
+// as overdue and reminds the store, it is intended to be executed by the
+// scheduler.
+func (impl *InvoiceControllerImpl) SendOverdueReminders(ctx context.Context) error {
+	now := time.Now()
+	invoices, err := impl.InvoiceStorer.ListAllUnpaidDueBefore(ctx, now)
+	if err != nil {
+		impl.Logger.Error("database list all unpaid due before error", slog.Any("error", err))
+		return err
+	}
+	impl.Logger.Debug("processing overdue invoices", slog.Int("count", len(invoices)))
+
+	var errs []error
+	for _, m := range invoices {
+		if !isReminderDue(m, now) {
+			continue
+		}
+
+		m.Status = domain.StatusOverdue
+		m.LastReminderSentAt = now
+		m.ReminderCount++
+		m.ModifiedAt = now
+		if err := impl.InvoiceStorer.UpdateByID(ctx, m); err != nil {
+			// Keep going so one broken invoice does not stop the other
+			// stores from being reminded, it is retried tomorrow.
+			impl.Logger.Error("database update by id error",
+				slog.Any("invoice_id", m.ID),
+				slog.Any("error", err))
+			errs = append(errs, err)
+			continue
+		}
+
+		if err := impl.sendInvoiceEmails(ctx, m, true); err != nil {
+			// Do not return error, just keep it in the server logs.
+			impl.Logger.Error("send invoice overdue emails error",
+				slog.Any("invoice_id", m.ID),
+				slog.Any("error", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isReminderDue returns true if the store was never reminded about the
+// unpaid invoice or the last reminder was sent long enough ago.
+func isReminderDue(m *domain.Invoice, now time.Time) bool {
+	if m.Status != domain.StatusOverdue || m.LastReminderSentAt.IsZero() {
+		return true
+	}
+	return now.Sub(m.LastReminderSentAt) >= overdueReminderInterval
+}
@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+type InvoicePaymentCreateRequest struct {
+	InvoiceID primitive.ObjectID `json:"invoice_id"`
+	Amount    float64            `json:"amount"`
+	Method    int8               `json:"method"`
+	Reference string             `json:"reference"`
+	Notes     string             `json:"notes"`
+	PaidAt    time.Time          `json:"paid_at"`
+}
+
+func (impl *InvoiceControllerImpl) validateCreatePaymentRequest(req *InvoicePaymentCreateRequest) error {
+	e := make(map[string]string)
+	if req.InvoiceID.IsZero() {
+		e["invoice_id"] = "missing value"
+	}
+	if req.Amount <= 0 {
+		e["amount"] = "must be greater then zero"
+	}
+	switch req.Method {
+	case domain.PaymentMethodCheque, domain.PaymentMethodBankTransfer, domain.PaymentMethodCash, domain.PaymentMethodOther:
+	case 0:
+		e["method"] = "missing value"
+	default:
+		e["method"] = "unsupported value"
+	}
+	if req.Method == domain.PaymentMethodOther && req.Notes == "" {
+		e["notes"] = "missing value"
+	}
+	if len(e) != 0 {
+		return httperror.NewForBadRequest(&e)
+	}
+	return nil
+}
+
+// CreatePayment function records a payment the store made outside of our
+// payment processor, for example by cheque, against an issued invoice.
+func (impl *InvoiceControllerImpl) CreatePayment(ctx context.Context, req *InvoicePaymentCreateRequest) (*domain.Invoice, error) {
+	// Extract from our session the following data.
+	uid := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	uname := ctx.Value(constants.SessionUserName).(string)
+
+	if err := permission.Require(ctx, permission.InvoiceManage); err != nil { // Security.
+		return nil, err
+	}
+
+	if err := impl.validateCreatePaymentRequest(req); err != nil {
+		return nil, err
+	}
+
+	////
+	//// Start the transaction.
+	////
+
+	session, err := impl.DbClient.StartSession()
+	if err != nil {
+		impl.Logger.Error("start session error",
+			slog.Any("error", err))
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// Define a transaction function with a series of operations
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		m, err := impl.InvoiceStorer.GetByID(sessCtx, req.InvoiceID)
+		if err != nil {
+			impl.Logger.Error("database get by id error", slog.Any("error", err))
+			return nil, err
+		}
+		if m == nil {
+			return nil, httperror.NewForBadRequestWithSingleField("invoice_id", "invoice does not exist")
+		}
+
+		switch m.Status {
+		case domain.StatusSent, domain.StatusOverdue:
+			impl.Logger.Debug("invoice accepts payments")
+		case domain.StatusOpen:
+			return nil, httperror.NewForBadRequestWithSingleField("message", "invoice has not been issued yet")
+		default:
+			return nil, httperror.NewForBadRequestWithSingleField("message", "invoice was already paid")
+		}
+
+		// DEVELOPERS NOTE:
+		// We compare in cents to avoid floating point rounding errors.
+		if math.Round(req.Amount*100) > math.Round(m.AmountDue*100) {
+			return nil, httperror.NewForBadRequestWithSingleField("amount", fmt.Sprintf("cannot be more then the amount due of %.2f", m.AmountDue))
+		}
+
+		paidAt := req.PaidAt
+		if paidAt.IsZero() {
+			paidAt = time.Now()
+		}
+
+		m.Payments = append(m.Payments, &domain.InvoicePayment{
+			ID:                 primitive.NewObjectID(),
+			Amount:             req.Amount,
+			Method:             req.Method,
+			Reference:          req.Reference,
+			Notes:              req.Notes,
+			PaidAt:             paidAt,
+			RecordedAt:         time.Now(),
+			RecordedByUserID:   uid,
+			RecordedByUserName: uname,
+		})
+		m.AmountPaid += req.Amount
+		m.AmountDue = math.Round((m.AmountTotal-m.AmountPaid)*100) / 100
+		if m.AmountDue <= 0 {
+			m.AmountDue = 0
+			m.Status = domain.StatusPaid
+			m.PaidAt = paidAt
+		}
+		m.ModifiedAt = time.Now()
+
+		if err := impl.InvoiceStorer.UpdateByID(sessCtx, m); err != nil {
+			impl.Logger.Error("database update by id error", slog.Any("error", err))
+			return nil, err
+		}
+
+		impl.Logger.Debug("recorded invoice payment",
+			slog.Any("invoice_id", m.ID),
+			slog.Float64("amount", req.Amount),
+			slog.Float64("amount_due", m.AmountDue))
+
+		return m, nil
+	}
+
+	// Start a transaction
+	res, err := session.WithTransaction(ctx, transactionFunc)
+	if err != nil {
+		impl.Logger.Error("session failed error",
+			slog.Any("error", err))
+		return nil, err
+	}
+
+	return res.(*domain.Invoice), nil
+}
@@ -0,0 +1,144 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+const (
+	// StatusOpen indicates the invoice is the store's running statement which submissions are accruing onto.
+	StatusOpen = 1
+	// StatusSent indicates the billing period closed and the invoice was issued to the store.
+	StatusSent = 2
+	// StatusPaid indicates the store paid the invoice in full.
+	StatusPaid = 3
+	// StatusOverdue indicates the invoice was not paid in full by the due date.
+	StatusOverdue = 4
+	// PaymentMethodCheque indicates the store paid by cheque.
+	PaymentMethodCheque = 1
+	// PaymentMethodBankTransfer indicates the store paid by wire or e-transfer.
+	PaymentMethodBankTransfer = 2
+	// PaymentMethodCash indicates the store paid by cash.
+	PaymentMethodCash = 3
+	// PaymentMethodOther indicates the store paid by some other means, see the payment notes.
+	PaymentMethodOther = 4
+)
+
+// Invoice represents the monthly statement of all the comic submissions a
+// retail partner made which are billed to their store account.
+type Invoice struct {
+	ID                 primitive.ObjectID `bson:"_id" json:"id"`
+	InvoiceNumber      string             `bson:"invoice_number" json:"invoice_number"`
+	StoreID            primitive.ObjectID `bson:"store_id" json:"store_id"`
+	StoreName          string             `bson:"store_name" json:"store_name"`
+	Status             int8               `bson:"status" json:"status"`
+	PeriodStart        time.Time          `bson:"period_start" json:"period_start"`
+	PeriodEnd          time.Time          `bson:"period_end" json:"period_end"`
+	LineItems          []*InvoiceLineItem `bson:"line_items" json:"line_items"`
+	Currency           string             `bson:"currency" json:"currency"`
+	AmountTotal        float64            `bson:"amount_total" json:"amount_total"`
+	AmountPaid         float64            `bson:"amount_paid" json:"amount_paid"`
+	AmountDue          float64            `bson:"amount_due" json:"amount_due"`
+	Payments           []*InvoicePayment  `bson:"payments" json:"payments"`
+	PaymentTermsDays   int64              `bson:"payment_terms_days" json:"payment_terms_days"`
+	IssuedAt           time.Time          `bson:"issued_at,omitempty" json:"issued_at,omitempty"`
+	DueAt              time.Time          `bson:"due_at,omitempty" json:"due_at,omitempty"`
+	PaidAt             time.Time          `bson:"paid_at,omitempty" json:"paid_at,omitempty"`
+	LastReminderSentAt time.Time          `bson:"last_reminder_sent_at,omitempty" json:"last_reminder_sent_at,omitempty"`
+	ReminderCount      int64              `bson:"reminder_count" json:"reminder_count"`
+	// FileObjectKey is the location of the invoice PDF in our S3 bucket.
+	FileObjectKey       string    `bson:"file_object_key" json:"file_object_key"`
+	FileObjectURL       string    `bson:"file_object_url" json:"file_object_url"`
+	FileObjectURLExpiry time.Time `bson:"file_object_url_expiry" json:"file_object_url_expiry"`
+	CreatedAt           time.Time `bson:"created_at,omitempty" json:"created_at,omitempty"`
+	ModifiedAt          time.Time `bson:"modified_at,omitempty" json:"modified_at,omitempty"`
+}
+
+// InvoiceLineItem represents a single comic submission billed on the invoice.
+type InvoiceLineItem struct {
+	ComicSubmissionID primitive.ObjectID `bson:"comic_submission_id" json:"comic_submission_id"`
+	CPSRN             string             `bson:"cpsrn" json:"cpsrn"`
+	Item              string             `bson:"item" json:"item"`
+	ServiceType       int8               `bson:"service_type" json:"service_type"`
+	Amount            float64            `bson:"amount" json:"amount"`
+	CreatedAt         time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// InvoicePayment represents a payment the store made outside of our payment
+// processor which our staff recorded against the invoice.
+type InvoicePayment struct {
+	ID                 primitive.ObjectID `bson:"_id" json:"id"`
+	Amount             float64            `bson:"amount" json:"amount"`
+	Method             int8               `bson:"method" json:"method"`
+	Reference          string             `bson:"reference" json:"reference"`
+	Notes              string             `bson:"notes" json:"notes"`
+	PaidAt             time.Time          `bson:"paid_at" json:"paid_at"`
+	RecordedAt         time.Time          `bson:"recorded_at" json:"recorded_at"`
+	RecordedByUserID   primitive.ObjectID `bson:"recorded_by_user_id" json:"recorded_by_user_id"`
+	RecordedByUserName string             `bson:"recorded_by_user_name" json:"recorded_by_user_name"`
+}
+
+// InvoiceStorer Interface for invoice.
+type InvoiceStorer interface {
+	Create(ctx context.Context, m *Invoice) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*Invoice, error)
+	GetOrCreateOpen(ctx context.Context, m *Invoice) (*Invoice, error)
+	UpdateByID(ctx context.Context, m *Invoice) error
+	ListByFilter(ctx context.Context, m *InvoicePaginationListFilter) (*InvoicePaginationListResult, error)
+	ListAllOpenEndedBefore(ctx context.Context, t time.Time) ([]*Invoice, error)
+	ListAllUnpaidDueBefore(ctx context.Context, t time.Time) ([]*Invoice, error)
+}
+
+type InvoiceStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) InvoiceStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("invoices")
+
+	// The following few lines of code will create the index for our app for
+	// this colleciton.
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "store_id", Value: 1},
+				{Key: "status", Value: 1},
+				{Key: "period_start", Value: -1},
+			},
+		},
+		{
+			// A store only ever has one open invoice per billing period.
+			Keys: bson.D{
+				{Key: "store_id", Value: 1},
+				{Key: "period_start", Value: 1},
+			},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": StatusOpen}),
+		},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &InvoiceStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
@@ -0,0 +1,43 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetOrCreateOpen returns the store's open invoice for the billing period
+// starting at `m.PeriodStart`, inserting `m` if the store does not have one
+// yet.
+//
+// DEVELOPERS NOTE: Submissions are billed inside a transaction and MongoDB
+// aborts the whole transaction on a duplicate key error, therefore we upsert
+// against the open invoice so concurrent submissions, even from another
+// server, end up on the same invoice.
+func (impl InvoiceStorerImpl) GetOrCreateOpen(ctx context.Context, m *Invoice) (*Invoice, error) {
+	if m.ID == primitive.NilObjectID {
+		m.ID = primitive.NewObjectID()
+		impl.Logger.Warn("database insert invoice not included id value, created id now.", slog.Any("id", m.ID))
+	}
+
+	filter := bson.M{
+		"store_id":     m.StoreID,
+		"status":       StatusOpen,
+		"period_start": m.PeriodStart,
+	}
+	update := bson.M{"$setOnInsert": m}
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var result Invoice
+	err := impl.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+	if err != nil {
+		impl.Logger.Error("database get or create open invoice error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
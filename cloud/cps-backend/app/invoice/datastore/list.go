@@ -0,0 +1,117 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (impl InvoiceStorerImpl) ListByFilter(ctx context.Context, f *InvoicePaginationListFilter) (*InvoicePaginationListResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+	defer cancel()
+
+	filter, err := impl.newPaginationFilter(f)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add filter conditions to the filter
+	if !f.StoreID.IsZero() {
+		filter["store_id"] = f.StoreID
+	}
+	if f.Status != 0 {
+		filter["status"] = f.Status
+	}
+
+	impl.Logger.Debug("listing filter:",
+		slog.Any("filter", filter))
+
+	// Include additional filters for our cursor-based pagination pertaining to sorting and limit.
+	options, err := impl.newPaginationOptions(f)
+	if err != nil {
+		return nil, err
+	}
+
+	// DEVELOPERS NOTE:
+	// The line items and payments can be large so we exclude them from list
+	// results; they are available when fetching the invoice details.
+	options = options.SetProjection(map[string]int{"line_items": 0, "payments": 0})
+
+	// Execute the query
+	cursor, err := impl.Collection.Find(ctx, filter, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	// Retrieve the documents and check if there is a next page
+	results := []*Invoice{}
+	hasNextPage := false
+	for cursor.Next(ctx) {
+		document := &Invoice{}
+		if err := cursor.Decode(document); err != nil {
+			return nil, err
+		}
+		results = append(results, document)
+		// Stop fetching documents if we have reached the desired page size
+		if int64(len(results)) >= f.PageSize {
+			hasNextPage = true
+			break
+		}
+	}
+
+	// Get the next cursor and encode it
+	var nextCursor string
+	if hasNextPage {
+		nextCursor, err = impl.newPaginatorNextCursor(f, results)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &InvoicePaginationListResult{
+		Results:     results,
+		NextCursor:  nextCursor,
+		HasNextPage: hasNextPage,
+	}, nil
+}
+
+// ListAllOpenEndedBefore returns every open invoice whose billing period
+// ended before the time `t` and therefore is ready to be issued.
+func (impl InvoiceStorerImpl) ListAllOpenEndedBefore(ctx context.Context, t time.Time) ([]*Invoice, error) {
+	filter := bson.M{
+		"status":     StatusOpen,
+		"period_end": bson.M{"$lte": t},
+	}
+	return impl.listAll(ctx, filter)
+}
+
+// ListAllUnpaidDueBefore returns every issued invoice which was not paid in
+// full and whose due date is before the time `t`.
+func (impl InvoiceStorerImpl) ListAllUnpaidDueBefore(ctx context.Context, t time.Time) ([]*Invoice, error) {
+	filter := bson.M{
+		"status": bson.M{"$in": []int8{StatusSent, StatusOverdue}},
+		"due_at": bson.M{"$lt": t},
+	}
+	return impl.listAll(ctx, filter)
+}
+
+func (impl InvoiceStorerImpl) listAll(ctx context.Context, filter bson.M) ([]*Invoice, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := impl.Collection.Find(ctx, filter)
+	if err != nil {
+		impl.Logger.Error("database list all error", slog.Any("error", err))
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	results := []*Invoice{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,21 @@
+package httptransport
+
+import (
+	"log/slog"
+
+	invoice_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/controller"
+)
+
+// Handler Creates http request handler
+type Handler struct {
+	Logger     *slog.Logger
+	Controller invoice_c.InvoiceController
+}
+
+// NewHandler Constructor
+func NewHandler(loggerp *slog.Logger, c invoice_c.InvoiceController) *Handler {
+	return &Handler{
+		Logger:     loggerp,
+		Controller: c,
+	}
+}
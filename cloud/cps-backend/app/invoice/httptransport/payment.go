@@ -0,0 +1,55 @@
+package httptransport
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	invoice_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/controller"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+func UnmarshalCreatePaymentRequest(ctx context.Context, r *http.Request) (*invoice_c.InvoicePaymentCreateRequest, error) {
+	// Initialize our array which will store all the results from the remote server.
+	var requestData invoice_c.InvoicePaymentCreateRequest
+
+	defer r.Body.Close()
+
+	// Read the JSON string and convert it into our golang stuct else we need
+	// to send a `400 Bad Request` errror message back to the client,
+	err := json.NewDecoder(r.Body).Decode(&requestData) // [1]
+	if err != nil {
+		log.Println(err)
+		return nil, httperror.NewForSingleField(http.StatusBadRequest, "non_field_error", "payload structure is wrong")
+	}
+	return &requestData, nil
+}
+
+func (h *Handler) CreatePayment(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	data, err := UnmarshalCreatePaymentRequest(ctx, r)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	data.InvoiceID = objectID
+
+	m, err := h.Controller.CreatePayment(ctx, data)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	MarshalDetailResponse(m, w)
+}
@@ -0,0 +1,47 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Acquire takes the lease of the job for the holder and returns true, or
+// returns false if another holder has it. The lease is taken over once it
+// ran out so a crashed replica does not stop the job from running.
+func (impl JobLeaseStorerImpl) Acquire(ctx context.Context, name, holder string, lockFor time.Duration) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"name": name,
+		"$or": []bson.M{
+			{"locked_until": bson.M{"$lte": now}},
+			{"holder": holder},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"holder":       holder,
+			"locked_until": now.Add(lockFor),
+		},
+		"$setOnInsert": bson.M{
+			"_id": primitive.NewObjectID(),
+		},
+	}
+
+	// When another holder has the lease the filter does not match and the
+	// upsert fails on the unique name.
+	_, err := impl.Collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		impl.Logger.Error("database acquire error", slog.Any("error", err))
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,70 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+// JobLease makes sure only one replica of the server runs a scheduled job,
+// the replica holding the lease runs it and the others skip it.
+type JobLease struct {
+	ID primitive.ObjectID `bson:"_id" json:"id"`
+	// Name is the name of the scheduled job.
+	Name string `bson:"name" json:"name"`
+	// Holder identifies the replica which holds the lease.
+	Holder string `bson:"holder" json:"holder"`
+	// LockedUntil is when the lease runs out, mongodb deletes the record
+	// afterwards.
+	LockedUntil time.Time `bson:"locked_until" json:"locked_until"`
+}
+
+// JobLeaseStorer Interface for the leases of the scheduled jobs.
+type JobLeaseStorer interface {
+	Acquire(ctx context.Context, name, holder string, lockFor time.Duration) (bool, error)
+}
+
+type JobLeaseStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) JobLeaseStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("job_leases")
+
+	// The following few lines of code will create the index for our app for
+	// this colleciton.
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "name", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			// Have mongodb forget the leases which ran out for us.
+			Keys:    bson.D{{Key: "locked_until", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &JobLeaseStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
@@ -0,0 +1,99 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+const (
+	LoginAttemptKindAccount = "account"
+	LoginAttemptKindOTP     = "otp"
+	LoginAttemptKindIP      = "ip"
+)
+
+// LoginAttempt keeps track of the recent failed sign in attempts for a
+// single account or ip address.
+type LoginAttempt struct {
+	ID primitive.ObjectID `bson:"_id" json:"id"`
+	// Key uniquely identifies what is being tracked, for example
+	// `account:bart@example.com` or `ip:127.0.0.1`.
+	Key          string    `bson:"key" json:"key"`
+	Kind         string    `bson:"kind" json:"kind"`
+	FailedCount  int       `bson:"failed_count" json:"failed_count"`
+	LastFailedAt time.Time `bson:"last_failed_at" json:"last_failed_at"`
+	// NextAttemptAt is the earliest time another attempt is allowed, this
+	// grows with every failed attempt.
+	NextAttemptAt time.Time `bson:"next_attempt_at" json:"next_attempt_at"`
+	LockedUntil   time.Time `bson:"locked_until" json:"locked_until"`
+	// ExpiresAt is when mongodb will delete the record.
+	ExpiresAt time.Time `bson:"expires_at" json:"expires_at"`
+}
+
+// LoginAttemptStorer Interface for login attempts.
+type LoginAttemptStorer interface {
+	GetByKey(ctx context.Context, key string) (*LoginAttempt, error)
+	Upsert(ctx context.Context, m *LoginAttempt) error
+	DeleteByKey(ctx context.Context, key string) error
+}
+
+type LoginAttemptStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) LoginAttemptStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("login_attempts")
+
+	// The following few lines of code will create the index for our app for
+	// this colleciton.
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			// Have mongodb forget the old attempts for us.
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &LoginAttemptStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
+
+// AccountKey returns the key used to track the attempts against the email,
+// we track by email instead of user so unknown emails behave the same.
+func AccountKey(email string) string {
+	return LoginAttemptKindAccount + ":" + email
+}
+
+// OTPKey returns the key used to track the 2FA attempts of the user.
+func OTPKey(userID primitive.ObjectID) string {
+	return LoginAttemptKindOTP + ":" + userID.Hex()
+}
+
+// IPKey returns the key used to track the attempts from the ip address.
+func IPKey(ipAddress string) string {
+	return LoginAttemptKindIP + ":" + ipAddress
+}
@@ -0,0 +1,17 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (impl LoginAttemptStorerImpl) DeleteByKey(ctx context.Context, key string) error {
+	_, err := impl.Collection.DeleteOne(ctx, bson.M{"key": key})
+	if err != nil {
+		impl.Logger.Error("database delete by key error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
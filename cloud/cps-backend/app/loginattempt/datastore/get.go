@@ -0,0 +1,25 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (impl LoginAttemptStorerImpl) GetByKey(ctx context.Context, key string) (*LoginAttempt, error) {
+	filter := bson.M{"key": key}
+
+	var result LoginAttempt
+	err := impl.Collection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// This error means your query did not match any documents.
+			return nil, nil
+		}
+		impl.Logger.Error("database get by key error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,35 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (impl LoginAttemptStorerImpl) Upsert(ctx context.Context, m *LoginAttempt) error {
+	if m.ID == primitive.NilObjectID {
+		m.ID = primitive.NewObjectID()
+	}
+	filter := bson.M{"key": m.Key}
+	update := bson.M{
+		"$set": bson.M{
+			"kind":            m.Kind,
+			"failed_count":    m.FailedCount,
+			"last_failed_at":  m.LastFailedAt,
+			"next_attempt_at": m.NextAttemptAt,
+			"locked_until":    m.LockedUntil,
+			"expires_at":      m.ExpiresAt,
+		},
+		"$setOnInsert": bson.M{"_id": m.ID},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	if _, err := impl.Collection.UpdateOne(ctx, filter, update, opts); err != nil {
+		impl.Logger.Error("database upsert error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/datastore"
+	ses_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/session/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+// NotificationController Interface for the notification center of the
+// logged in user.
+type NotificationController interface {
+	ListByFilter(ctx context.Context, f *domain.NotificationPaginationListFilter) (*domain.NotificationPaginationListResult, error)
+	CountUnread(ctx context.Context) (int64, error)
+	MarkAsReadByID(ctx context.Context, id primitive.ObjectID) (*domain.Notification, error)
+	MarkAllAsRead(ctx context.Context) (int64, error)
+	Watch(ctx context.Context) (<-chan *domain.Notification, error)
+	IsSessionActive(ctx context.Context) (bool, error)
+}
+
+type NotificationControllerImpl struct {
+	Config             *config.Conf
+	Logger             *slog.Logger
+	NotificationStorer domain.NotificationStorer
+	SessionStorer      ses_s.SessionStorer
+}
+
+func NewController(
+	appCfg *config.Conf,
+	loggerp *slog.Logger,
+	n_storer domain.NotificationStorer,
+	ses_storer ses_s.SessionStorer,
+) NotificationController {
+	loggerp.Debug("notification controller initialization started...")
+	s := &NotificationControllerImpl{
+		Config:             appCfg,
+		Logger:             loggerp,
+		NotificationStorer: n_storer,
+		SessionStorer:      ses_storer,
+	}
+	s.Logger.Debug("notification controller initialized")
+	return s
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"context"
+
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+)
+
+func (c *NotificationControllerImpl) ListByFilter(ctx context.Context, f *domain.NotificationPaginationListFilter) (*domain.NotificationPaginationListResult, error) {
+	// Users can only see their own notifications.
+	f.UserID = ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+
+	c.Logger.Debug("listing using filter options:",
+		slog.Any("UserID", f.UserID),
+		slog.Any("Type", f.Type),
+		slog.Bool("UnreadOnly", f.UnreadOnly),
+		slog.Any("Cursor", f.Cursor),
+		slog.Int64("PageSize", f.PageSize),
+		slog.String("SortField", f.SortField),
+		slog.Int("SortOrder", int(f.SortOrder)))
+
+	m, err := c.NotificationStorer.ListByFilter(ctx, f)
+	if err != nil {
+		c.Logger.Error("database list by filter error", slog.Any("error", err))
+		return nil, err
+	}
+	return m, err
+}
+
+func (c *NotificationControllerImpl) CountUnread(ctx context.Context) (int64, error) {
+	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+
+	count, err := c.NotificationStorer.CountUnreadByUserID(ctx, userID)
+	if err != nil {
+		c.Logger.Error("database count unread error", slog.Any("error", err))
+		return 0, err
+	}
+	return count, nil
+}
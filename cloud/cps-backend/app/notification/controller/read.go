@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+func (c *NotificationControllerImpl) MarkAsReadByID(ctx context.Context, id primitive.ObjectID) (*domain.Notification, error) {
+	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+
+	m, err := c.NotificationStorer.GetByID(ctx, id)
+	if err != nil {
+		c.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	// Do not tell users whether the notifications of other users exist.
+	if m == nil || m.UserID != userID {
+		return nil, httperror.NewForSingleField(http.StatusNotFound, "id", "notification does not exist")
+	}
+	if m.IsRead {
+		return m, nil
+	}
+
+	if err := c.NotificationStorer.MarkAsReadByID(ctx, id); err != nil {
+		c.Logger.Error("database mark as read error", slog.Any("error", err))
+		return nil, err
+	}
+	m.IsRead = true
+	m.ReadAt = time.Now()
+	return m, nil
+}
+
+// MarkAllAsRead marks every unread notification of the logged in user as read
+// and returns how many notifications were changed.
+func (c *NotificationControllerImpl) MarkAllAsRead(ctx context.Context) (int64, error) {
+	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+
+	count, err := c.NotificationStorer.MarkAllAsReadByUserID(ctx, userID)
+	if err != nil {
+		c.Logger.Error("database mark all as read error", slog.Any("error", err))
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+)
+
+// Watch returns the notifications of the logged in user as they are created
+// until the context is cancelled.
+func (c *NotificationControllerImpl) Watch(ctx context.Context) (<-chan *domain.Notification, error) {
+	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+
+	ch, err := c.NotificationStorer.WatchByUserID(ctx, userID)
+	if err != nil {
+		c.Logger.Error("database watch error", slog.Any("error", err))
+		return nil, err
+	}
+	return ch, nil
+}
+
+// IsSessionActive returns whether the session of the logged in user still
+// exists, a long lived stream uses it to notice the user logged out or had
+// the session revoked after it was opened.
+func (c *NotificationControllerImpl) IsSessionActive(ctx context.Context) (bool, error) {
+	sessionID, _ := ctx.Value(constants.SessionID).(string)
+	if sessionID == "" {
+		return false, nil
+	}
+	ses, err := c.SessionStorer.GetBySessionID(ctx, sessionID)
+	if err != nil {
+		c.Logger.Error("database get by session id error", slog.Any("error", err))
+		return false, err
+	}
+	return ses != nil && time.Now().Before(ses.ExpiresAt), nil
+}
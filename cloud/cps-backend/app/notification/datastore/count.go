@@ -0,0 +1,20 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (impl NotificationStorerImpl) CountUnreadByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	filter := bson.M{"user_id": userID, "is_read": false}
+
+	count, err := impl.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		impl.Logger.Error("database count unread error", slog.Any("error", err))
+		return 0, err
+	}
+	return count, nil
+}
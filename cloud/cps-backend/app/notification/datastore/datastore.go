@@ -0,0 +1,104 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+const (
+	TypeSubmissionStatusChanged = 1
+	TypeSubmissionComment       = 2
+	TypeStoreApproved           = 3
+	TypeCreditsGranted          = 4
+)
+
+// Notification is a message shown to one user inside the app. Notifications
+// are saved in the same transaction as the business change so the user is
+// never notified about a change which was rolled back.
+type Notification struct {
+	ID     primitive.ObjectID `bson:"_id" json:"id"`
+	UserID primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Type   int8               `bson:"type" json:"type"`
+	Title  string             `bson:"title" json:"title"`
+	Body   string             `bson:"body" json:"body"`
+	// Link is the frontend path the user is taken to when they click on
+	// the notification, for example `/submissions/comics/<id>`.
+	Link      string             `bson:"link" json:"link"`
+	ObjectID  primitive.ObjectID `bson:"object_id" json:"object_id"`
+	IsRead    bool               `bson:"is_read" json:"is_read"`
+	ReadAt    time.Time          `bson:"read_at" json:"read_at,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// NotificationStorer Interface for notification.
+type NotificationStorer interface {
+	Create(ctx context.Context, m *Notification) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*Notification, error)
+	ListByFilter(ctx context.Context, f *NotificationPaginationListFilter) (*NotificationPaginationListResult, error)
+	CountUnreadByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error)
+	MarkAsReadByID(ctx context.Context, id primitive.ObjectID) error
+	MarkAllAsReadByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error)
+	WatchByUserID(ctx context.Context, userID primitive.ObjectID) (<-chan *Notification, error)
+}
+
+type NotificationStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) NotificationStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("notifications")
+
+	// The following few lines of code will create the index for our app for
+	// this colleciton.
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "is_read", Value: 1},
+			},
+		},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &NotificationStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
+
+// NewNotification returns an unread notification for the user.
+func NewNotification(userID primitive.ObjectID, notificationType int8, title, body, link string, objectID primitive.ObjectID) *Notification {
+	return &Notification{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		Type:      notificationType,
+		Title:     title,
+		Body:      body,
+		Link:      link,
+		ObjectID:  objectID,
+		CreatedAt: time.Now(),
+	}
+}
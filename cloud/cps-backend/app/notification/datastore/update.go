@@ -0,0 +1,35 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (impl NotificationStorerImpl) MarkAsReadByID(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{"_id": id, "is_read": false}
+	update := bson.M{"$set": bson.M{"is_read": true, "read_at": time.Now()}}
+
+	if _, err := impl.Collection.UpdateOne(ctx, filter, update); err != nil {
+		impl.Logger.Error("database mark as read error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+// MarkAllAsReadByUserID marks every unread notification of the user as read
+// and returns how many notifications were changed.
+func (impl NotificationStorerImpl) MarkAllAsReadByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	filter := bson.M{"user_id": userID, "is_read": false}
+	update := bson.M{"$set": bson.M{"is_read": true, "read_at": time.Now()}}
+
+	res, err := impl.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		impl.Logger.Error("database mark all as read error", slog.Any("error", err))
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
@@ -0,0 +1,54 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// WatchByUserID returns a channel which receives every notification created
+// for the user until the context is cancelled. It uses a MongoDB change
+// stream so notifications created by any instance of the server are
+// delivered, and only after their transaction committed.
+func (impl NotificationStorerImpl) WatchByUserID(ctx context.Context, userID primitive.ObjectID) (<-chan *Notification, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"operationType":        "insert",
+			"fullDocument.user_id": userID,
+		}}},
+	}
+
+	stream, err := impl.Collection.Watch(ctx, pipeline)
+	if err != nil {
+		impl.Logger.Error("database watch error", slog.Any("error", err))
+		return nil, err
+	}
+
+	ch := make(chan *Notification)
+	go func() {
+		defer close(ch)
+		defer stream.Close(context.Background())
+
+		for stream.Next(ctx) {
+			var event struct {
+				FullDocument *Notification `bson:"fullDocument"`
+			}
+			if err := stream.Decode(&event); err != nil {
+				impl.Logger.Error("database watch decode error", slog.Any("error", err))
+				continue
+			}
+			select {
+			case ch <- event.FullDocument:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := stream.Err(); err != nil && ctx.Err() == nil {
+			impl.Logger.Error("database watch error", slog.Any("error", err))
+		}
+	}()
+	return ch, nil
+}
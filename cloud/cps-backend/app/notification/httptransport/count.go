@@ -0,0 +1,33 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+// CountResponseIDO struct used to return how many notifications were
+// counted or changed.
+type CountResponseIDO struct {
+	Count int64 `json:"count"`
+}
+
+func (h *Handler) UnreadCount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	count, err := h.Controller.CountUnread(ctx)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalCountResponse(&CountResponseIDO{Count: count}, w)
+}
+
+func MarshalCountResponse(res *CountResponseIDO, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,21 @@
+package httptransport
+
+import (
+	"log/slog"
+
+	notification_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/controller"
+)
+
+// Handler Creates http request handler
+type Handler struct {
+	Logger     *slog.Logger
+	Controller notification_c.NotificationController
+}
+
+// NewHandler Constructor
+func NewHandler(loggerp *slog.Logger, c notification_c.NotificationController) *Handler {
+	return &Handler{
+		Logger:     loggerp,
+		Controller: c,
+	}
+}
@@ -0,0 +1,61 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	notification_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	f := &notification_d.NotificationPaginationListFilter{
+		Cursor:    "",
+		PageSize:  25,
+		SortField: "created_at",
+		SortOrder: -1, // 1=ascending | -1=descending
+	}
+
+	// Here is where you extract url parameters.
+	query := r.URL.Query()
+
+	cursor := query.Get("cursor")
+	if cursor != "" {
+		f.Cursor = cursor
+	}
+
+	pageSize := query.Get("page_size")
+	if pageSize != "" {
+		pageSize, _ := strconv.ParseInt(pageSize, 10, 64)
+		if pageSize == 0 || pageSize > 250 {
+			pageSize = 250
+		}
+		f.PageSize = pageSize
+	}
+
+	typeStr := query.Get("type")
+	if typeStr != "" {
+		notificationType, _ := strconv.ParseInt(typeStr, 10, 64)
+		f.Type = int8(notificationType)
+	}
+
+	f.UnreadOnly = query.Get("unread_only") == "true"
+
+	m, err := h.Controller.ListByFilter(ctx, f)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalListResponse(m, w)
+}
+
+func MarshalListResponse(res *notification_d.NotificationPaginationListResult, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,48 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	notification_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+func (h *Handler) MarkAsReadByID(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	m, err := h.Controller.MarkAsReadByID(ctx, objectID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalDetailResponse(m, w)
+}
+
+func (h *Handler) MarkAllAsRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	count, err := h.Controller.MarkAllAsRead(ctx)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalCountResponse(&CountResponseIDO{Count: count}, w)
+}
+
+func MarshalDetailResponse(res *notification_d.Notification, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
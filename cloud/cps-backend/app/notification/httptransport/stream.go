@@ -0,0 +1,75 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+// heartbeatInterval is how often a comment is written to the stream so
+// proxies do not close the idle connection, it is also how often we check
+// the session is still active.
+const heartbeatInterval = 25 * time.Second
+
+// Stream sends the notifications of the logged in user as Server-Sent Events
+// as soon as they are created, the connection stays open until the client
+// disconnects.
+func (h *Handler) Stream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	ch, err := h.Controller.Watch(ctx)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable buffering in nginx.
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			// Close the stream once the session is gone, otherwise a logged
+			// out or revoked user would keep receiving notifications.
+			active, err := h.Controller.IsSessionActive(ctx)
+			if err != nil || !active {
+				h.Logger.Warn("closing notification stream, session is no longer active", slog.Any("error", err))
+				return
+			}
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		case m, ok := <-ch:
+			if !ok {
+				// The change stream closed, the client will reconnect.
+				return
+			}
+			data, err := json.Marshal(m)
+			if err != nil {
+				h.Logger.Error("failed marshalling notification", slog.Any("error", err))
+				continue
+			}
+			fmt.Fprintf(w, "id: %s\nevent: notification\ndata: %s\n\n", m.ID.Hex(), data)
+			flusher.Flush()
+		}
+	}
+}
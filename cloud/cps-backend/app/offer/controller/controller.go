@@ -2,12 +2,14 @@ package controller
 
 import (
 	"context"
+	"log"
 
 	"log/slog"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	pm "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/paymentprocessor/stripe"
 	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
 	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
@@ -17,47 +19,50 @@ import (
 
 // Offerontroller Interface for store business logic controller.
 type Offerontroller interface {
-	// Create(ctx context.Context, m *domain.Offer) (*domain.Offer, error)
+	Create(ctx context.Context, m *domain.Offer) (*domain.Offer, error)
 	GetByID(ctx context.Context, id primitive.ObjectID) (*domain.Offer, error)
 	GetByServiceType(ctx context.Context, serviceType int8) (*domain.Offer, error)
 	UpdateByID(ctx context.Context, m *domain.Offer) (*domain.Offer, error)
 	ListByFilter(ctx context.Context, f *domain.OfferPaginationListFilter) (*domain.OfferPaginationListResult, error)
 	ListAsSelectOptionByFilter(ctx context.Context, f *domain.OfferPaginationListFilter) ([]*domain.OfferAsSelectOption, error)
-	// DeleteByID(ctx context.Context, id primitive.ObjectID) error
+	DeleteByID(ctx context.Context, id primitive.ObjectID) error
 }
 
 type OfferControllerImpl struct {
-	Config      *config.Conf
-	Logger      *slog.Logger
-	UUID        uuid.Provider
-	DbClient    *mongo.Client
-	StoreStorer store_s.StoreStorer
-	OfferStorer domain.OfferStorer
-	UserStorer  user_s.UserStorer
+	Config           *config.Conf
+	Logger           *slog.Logger
+	UUID             uuid.Provider
+	PaymentProcessor pm.PaymentProcessor
+	DbClient         *mongo.Client
+	StoreStorer      store_s.StoreStorer
+	OfferStorer      domain.OfferStorer
+	UserStorer       user_s.UserStorer
 }
 
 func NewController(
 	appCfg *config.Conf,
 	loggerp *slog.Logger,
 	uuidp uuid.Provider,
+	paymentProcessor pm.PaymentProcessor,
 	client *mongo.Client,
 	org_storer store_s.StoreStorer,
 	sub_storer domain.OfferStorer,
 	usr_storer user_s.UserStorer,
 ) Offerontroller {
 	s := &OfferControllerImpl{
-		Config:      appCfg,
-		Logger:      loggerp,
-		UUID:        uuidp,
-		DbClient:    client,
-		StoreStorer: org_storer,
-		OfferStorer: sub_storer,
-		UserStorer:  usr_storer,
+		Config:           appCfg,
+		Logger:           loggerp,
+		UUID:             uuidp,
+		PaymentProcessor: paymentProcessor,
+		DbClient:         client,
+		StoreStorer:      org_storer,
+		OfferStorer:      sub_storer,
+		UserStorer:       usr_storer,
 	}
 	s.Logger.Debug("offer controller initialization started...")
-	// if err := s.createDefaults(context.Background()); err != nil {
-	// 	log.Fatal(err)
-	// }
+	if err := s.seedFromFile(context.Background(), appCfg.AppServer.OfferSeedFilePath); err != nil {
+		log.Fatal(err)
+	}
 	s.Logger.Debug("offer controller initialized")
 	return s
 }
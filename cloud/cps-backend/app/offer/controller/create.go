@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+func (impl *OfferControllerImpl) Create(ctx context.Context, m *domain.Offer) (*domain.Offer, error) {
+	if err := permission.Require(ctx, permission.OfferManage); err != nil { // Security.
+		return nil, err
+	}
+
+	// Add defaults.
+	m.ID = primitive.NewObjectID()
+	m.CreatedAt = time.Now()
+	m.ModifiedAt = time.Now()
+	m.PriceCurrency = strings.ToUpper(m.PriceCurrency)
+	for _, p := range m.Prices {
+		p.Currency = strings.ToUpper(p.Currency)
+		p.StripePriceID = ""
+	}
+	m.StripeProductID = ""
+	m.StripePriceID = ""
+	m.IsSubscription = recurringIntervalFor(m.PayFrequency) != ""
+
+	if err := impl.checkServiceTypeAvailable(ctx, m); err != nil {
+		return nil, err
+	}
+
+	// Create the product and prices in the payment processor so the offer
+	// can be purchased. This is done before the transaction as the payment
+	// processor cannot be rolled back and the transaction may be retried.
+	if err := impl.pushToPaymentProcessor(m, nil); err != nil {
+		return nil, err
+	}
+
+	////
+	//// Start the transaction.
+	////
+
+	session, err := impl.DbClient.StartSession()
+	if err != nil {
+		impl.Logger.Error("start session error",
+			slog.Any("error", err))
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// Define a transaction function with a series of operations
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		// Check again as another offer may have been saved meanwhile.
+		if err := impl.checkServiceTypeAvailable(sessCtx, m); err != nil {
+			return nil, err
+		}
+
+		// Save to our database.
+		if err := impl.OfferStorer.Create(sessCtx, m); err != nil {
+			impl.Logger.Error("database create error", slog.Any("error", err))
+			return nil, err
+		}
+
+		return m, nil
+	}
+
+	// Start a transaction
+	res, err := session.WithTransaction(ctx, transactionFunc)
+	if err != nil {
+		impl.Logger.Error("session failed error",
+			slog.Any("error", err))
+		impl.discardFromPaymentProcessor(m)
+		return nil, err
+	}
+
+	return res.(*domain.Offer), nil
+}
@@ -11,6 +11,7 @@ import (
 
 	s_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (impl *OfferControllerImpl) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
@@ -28,6 +29,10 @@ func (impl *OfferControllerImpl) DeleteByID(ctx context.Context, id primitive.Ob
 
 	// Define a transaction function with a series of operations
 	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		if err := permission.Require(sessCtx, permission.OfferManage); err != nil { // Security.
+			return nil, err
+		}
+
 		d, err := impl.GetByID(sessCtx, id)
 		if err != nil {
 			impl.Logger.Error("database get by id error", slog.Any("error", err))
@@ -35,11 +40,19 @@ func (impl *OfferControllerImpl) DeleteByID(ctx context.Context, id primitive.Ob
 		}
 		if d == nil {
 			impl.Logger.Error("database returns nothing from get by id")
-			return nil, httperror.NewForBadRequestWithSingleField("id", "receipt type does not exist")
+			return nil, httperror.NewForBadRequestWithSingleField("id", "offer does not exist")
 		}
 		d.Status = s_d.StatusArchived
 		d.ModifiedAt = time.Now()
 
+		// Deactivate the product so it can no longer be purchased.
+		if d.StripeProductID != "" {
+			if _, err := impl.PaymentProcessor.UpdateProduct(d.StripeProductID, d.Name, d.Description, d.StripePriceID, false); err != nil {
+				impl.Logger.Error("payment processor update product error", slog.Any("error", err))
+				return nil, err
+			}
+		}
+
 		// Save to the database the modified store.
 		if err := impl.OfferStorer.UpdateByID(sessCtx, d); err != nil {
 			impl.Logger.Error("database update by id error", slog.Any("error", err))
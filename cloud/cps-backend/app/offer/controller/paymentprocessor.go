@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+// recurringIntervalFor returns the payment processor's billing interval for
+// the pay frequency or an empty string for one-time offers.
+func recurringIntervalFor(payFrequency int8) string {
+	switch payFrequency {
+	case domain.PayFrequencyDay:
+		return "day"
+	case domain.PayFrequencyWeek:
+		return "week"
+	case domain.PayFrequencyMonthly:
+		return "month"
+	case domain.PayFrequencyAnnual:
+		return "year"
+	default:
+		return ""
+	}
+}
+
+// pushToPaymentProcessor function creates or updates the product and prices
+// of the offer in the payment processor. Prices cannot be modified in the
+// payment processor so a new price is created for every changed amount, call
+// `archiveReplacedPrices` once the offer was saved. The `prev` offer is nil
+// for new offers.
+//
+// It must be called outside of a transaction, the payment processor cannot
+// roll back with us and a retried transaction would create the prices again.
+func (impl *OfferControllerImpl) pushToPaymentProcessor(m *domain.Offer, prev *domain.Offer) error {
+	if m.StripeProductID == "" {
+		metadata := map[string]string{"OfferID": m.ID.Hex()}
+		product, err := impl.PaymentProcessor.CreateProduct(m.Name, m.Description, metadata)
+		if err != nil {
+			impl.Logger.Error("payment processor create product error", slog.Any("error", err))
+			return err
+		}
+		m.StripeProductID = product.ID
+		m.PaymentProcessorName = impl.PaymentProcessor.GetName()
+	}
+
+	interval := recurringIntervalFor(m.PayFrequency)
+	frequencyChanged := prev == nil || prev.PayFrequency != m.PayFrequency
+
+	// The default price is handled the same as the other currencies.
+	defaultPrice := &domain.OfferPrice{Currency: m.PriceCurrency, Amount: m.Price}
+	prices := append([]*domain.OfferPrice{defaultPrice}, m.Prices...)
+	for _, p := range prices {
+		if prev != nil && !frequencyChanged {
+			if old := prev.PriceForCurrency(p.Currency); old.Currency == p.Currency && old.Amount == p.Amount && old.StripePriceID != "" {
+				p.StripePriceID = old.StripePriceID
+				continue
+			}
+		}
+		price, err := impl.PaymentProcessor.CreatePrice(m.StripeProductID, p.Currency, p.Amount, interval)
+		if err != nil {
+			impl.Logger.Error("payment processor create price error",
+				slog.String("currency", p.Currency),
+				slog.Any("error", err))
+			return err
+		}
+		p.StripePriceID = price.ID
+	}
+	m.StripePriceID = defaultPrice.StripePriceID
+	m.IsSubscription = interval != ""
+
+	if _, err := impl.PaymentProcessor.UpdateProduct(m.StripeProductID, m.Name, m.Description, m.StripePriceID, m.Status == domain.StatusActive); err != nil {
+		impl.Logger.Error("payment processor update product error", slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+// archiveReplacedPrices archives the prices of the `prev` offer which the
+// saved offer no longer uses so customers cannot be charged the old amount.
+// This is not fatal as the offer no longer references them.
+func (impl *OfferControllerImpl) archiveReplacedPrices(m *domain.Offer, prev *domain.Offer) {
+	inUse := map[string]bool{m.StripePriceID: true}
+	for _, p := range m.Prices {
+		inUse[p.StripePriceID] = true
+	}
+	old := append([]*domain.OfferPrice{{StripePriceID: prev.StripePriceID}}, prev.Prices...)
+	for _, p := range old {
+		if p.StripePriceID == "" || inUse[p.StripePriceID] {
+			continue
+		}
+		if err := impl.PaymentProcessor.ArchivePrice(p.StripePriceID); err != nil {
+			impl.Logger.Warn("payment processor archive price error",
+				slog.String("price_id", p.StripePriceID),
+				slog.Any("error", err))
+		}
+	}
+}
+
+// restorePaymentProcessor puts the product of an offer which could not be
+// saved back to the `prev` offer and archives the prices created for it.
+func (impl *OfferControllerImpl) restorePaymentProcessor(m *domain.Offer, prev *domain.Offer) {
+	impl.archiveReplacedPrices(prev, m)
+	if _, err := impl.PaymentProcessor.UpdateProduct(prev.StripeProductID, prev.Name, prev.Description, prev.StripePriceID, prev.Status == domain.StatusActive); err != nil {
+		// Do not return error, just keep it in the server logs.
+		impl.Logger.Error("payment processor restore product error",
+			slog.String("product_id", prev.StripeProductID),
+			slog.Any("error", err))
+	}
+}
+
+// discardFromPaymentProcessor deactivates the product created for an offer
+// which could not be saved so it cannot be purchased.
+func (impl *OfferControllerImpl) discardFromPaymentProcessor(m *domain.Offer) {
+	if m.StripeProductID == "" {
+		return
+	}
+	if _, err := impl.PaymentProcessor.UpdateProduct(m.StripeProductID, m.Name, m.Description, m.StripePriceID, false); err != nil {
+		// Do not return error, just keep it in the server logs.
+		impl.Logger.Error("payment processor deactivate product error",
+			slog.String("product_id", m.StripeProductID),
+			slog.Any("error", err))
+	}
+}
+
+// checkServiceTypeAvailable returns a validation error if the active offer
+// would compete with another active offer selling the same service type.
+func (impl *OfferControllerImpl) checkServiceTypeAvailable(sessCtx context.Context, m *domain.Offer) error {
+	if m.Status != domain.StatusActive || m.IsSubscription || m.ServiceType == 0 {
+		return nil
+	}
+	exists, err := impl.OfferStorer.CheckIfActiveExistsByServiceType(sessCtx, m.ServiceType, m.ID)
+	if err != nil {
+		impl.Logger.Error("database check if active exists by service type error", slog.Any("error", err))
+		return err
+	}
+	if exists {
+		return httperror.NewForBadRequestWithSingleField("service_type", "another active offer already exists for this service type")
+	}
+	return nil
+}
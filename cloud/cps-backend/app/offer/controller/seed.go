@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"log/slog"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
+)
+
+// seedFromFile function creates the offers listed in the JSON seed file which
+// do not exist yet in our database. Existing offers are left untouched so
+// changes made by staff are never overwritten. Seeding is skipped if no file
+// path was configured.
+func (impl *OfferControllerImpl) seedFromFile(ctx context.Context, filePath string) error {
+	if filePath == "" {
+		impl.Logger.Debug("offer seed file not configured, skipping seeding")
+		return nil
+	}
+	impl.Logger.Debug("offer seeding started...", slog.String("file_path", filePath))
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read offer seed file %s: %v", filePath, err)
+	}
+	var offers []*domain.Offer
+	if err := json.Unmarshal(data, &offers); err != nil {
+		return fmt.Errorf("failed to parse offer seed file %s: %v", filePath, err)
+	}
+
+	for _, o := range offers {
+		if o.ID.IsZero() {
+			return fmt.Errorf("offer `%s` in seed file %s is missing an id", o.Name, filePath)
+		}
+		existing, err := impl.OfferStorer.GetByID(ctx, o.ID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			continue
+		}
+		if err := impl.checkServiceTypeAvailable(ctx, o); err != nil {
+			impl.Logger.Warn("skipped seeding offer",
+				slog.String("name", o.Name),
+				slog.Any("error", err))
+			continue
+		}
+
+		impl.Logger.Debug("seeding offer...", slog.String("name", o.Name))
+		o.CreatedAt = time.Now()
+		o.ModifiedAt = time.Now()
+		if err := impl.OfferStorer.Create(ctx, o); err != nil {
+			return err
+		}
+	}
+
+	impl.Logger.Debug("offer seeding finished")
+	return nil
+}
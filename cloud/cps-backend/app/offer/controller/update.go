@@ -2,18 +2,71 @@ package controller
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"log/slog"
 
 	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
-	u_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
-	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func (impl *OfferControllerImpl) UpdateByID(ctx context.Context, ns *domain.Offer) (*domain.Offer, error) {
+	// Extract from our session the following data.
+	// uid := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	// uname := ctx.Value(constants.SessionUserName).(string)
+
+	if err := permission.Require(ctx, permission.OfferManage); err != nil { // Security.
+		return nil, err
+	}
+
+	// Fetch the original store.
+	os, err := impl.OfferStorer.GetByID(ctx, ns.ID)
+	if err != nil {
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if os == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "offer does not exist")
+	}
+
+	// Keep a copy so we know which prices changed.
+	prev := *os
+
+	os.ModifiedAt = time.Now()
+	os.Name = ns.Name
+	os.Description = ns.Description
+	os.Type = ns.Type
+	os.Status = ns.Status
+	os.BusinessFunction = ns.BusinessFunction
+	os.ServiceType = ns.ServiceType
+	os.SubscriptionCreditsPerCycle = ns.SubscriptionCreditsPerCycle
+	os.Price = ns.Price
+	os.PriceCurrency = strings.ToUpper(ns.PriceCurrency)
+	os.PayFrequency = ns.PayFrequency
+	os.IsSubscription = recurringIntervalFor(os.PayFrequency) != ""
+	os.Prices = make([]*domain.OfferPrice, 0, len(ns.Prices))
+	for _, p := range ns.Prices {
+		os.Prices = append(os.Prices, &domain.OfferPrice{
+			Currency: strings.ToUpper(p.Currency),
+			Amount:   p.Amount,
+		})
+	}
+
+	if err := impl.checkServiceTypeAvailable(ctx, os); err != nil {
+		return nil, err
+	}
+
+	// Push the changes to the payment processor so the product and prices
+	// customers are charged match our offer. This is done before the
+	// transaction as the payment processor cannot be rolled back and the
+	// transaction may be retried.
+	if err := impl.pushToPaymentProcessor(os, &prev); err != nil {
+		return nil, err
+	}
+
 	////
 	//// Start the transaction.
 	////
@@ -28,32 +81,24 @@ func (impl *OfferControllerImpl) UpdateByID(ctx context.Context, ns *domain.Offe
 
 	// Define a transaction function with a series of operations
 	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
-		// Extract from our session the following data.
-		urole := sessCtx.Value(constants.SessionUserRole).(int8)
-		// uid := sessCtx.Value(constants.SessionUserID).(primitive.ObjectID)
-		// uname := sessCtx.Value(constants.SessionUserName).(string)
-
-		switch urole { // Security.
-		case u_d.UserRoleRoot:
-			impl.Logger.Debug("access granted")
-		default:
-			return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
-		}
-
-		// Fetch the original store.
-		os, err := impl.OfferStorer.GetByID(sessCtx, ns.ID)
+		// Make sure nobody saved the offer since we read it, otherwise we
+		// would overwrite their prices with the ones we pushed.
+		cur, err := impl.OfferStorer.GetByID(sessCtx, ns.ID)
 		if err != nil {
 			impl.Logger.Error("database get by id error", slog.Any("error", err))
 			return nil, err
 		}
-		if os == nil {
-			return nil, httperror.NewForBadRequestWithSingleField("id", "receipt type does not exist")
+		if cur == nil {
+			return nil, httperror.NewForBadRequestWithSingleField("id", "offer does not exist")
+		}
+		if !cur.ModifiedAt.Equal(prev.ModifiedAt) {
+			return nil, httperror.NewForBadRequestWithSingleField("message", "offer was modified by someone else, please try again")
 		}
 
-		os.ModifiedAt = time.Now()
-		os.Status = ns.Status
-		os.BusinessFunction = ns.BusinessFunction
-		os.ServiceType = ns.ServiceType
+		// Check again as another offer may have been saved meanwhile.
+		if err := impl.checkServiceTypeAvailable(sessCtx, os); err != nil {
+			return nil, err
+		}
 
 		// Save to the database the modified store.
 		if err := impl.OfferStorer.UpdateByID(sessCtx, os); err != nil {
@@ -69,8 +114,12 @@ func (impl *OfferControllerImpl) UpdateByID(ctx context.Context, ns *domain.Offe
 	if err != nil {
 		impl.Logger.Error("session failed error",
 			slog.Any("error", err))
+		impl.restorePaymentProcessor(os, &prev)
 		return nil, err
 	}
 
+	// Only archive the old prices now the offer no longer references them.
+	impl.archiveReplacedPrices(os, &prev)
+
 	return res.(*domain.Offer), nil
 }
@@ -30,3 +30,20 @@ func (impl OfferStorerImpl) CheckIfExistsByID(ctx context.Context, id primitive.
 	}
 	return count >= 1, nil
 }
+
+// CheckIfActiveExistsByServiceType returns true if another active one-time
+// offer, other then the `excludeID` offer, is already selling the service type.
+func (impl OfferStorerImpl) CheckIfActiveExistsByServiceType(ctx context.Context, serviceType int8, excludeID primitive.ObjectID) (bool, error) {
+	filter := bson.M{
+		"service_type":    serviceType,
+		"status":          StatusActive,
+		"is_subscription": bson.M{"$ne": true},
+		"_id":             bson.M{"$ne": excludeID},
+	}
+	count, err := impl.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		impl.Logger.Error("database check if active exists by service type error", slog.Any("error", err))
+		return false, err
+	}
+	return count >= 1, nil
+}
@@ -3,6 +3,7 @@ package datastore
 import (
 	"context"
 	"log"
+	"strings"
 	"time"
 
 	"log/slog"
@@ -55,6 +56,13 @@ type Offer struct {
 	StripePriceID        string             `bson:"stripe_price_id" json:"stripe_price_id"`
 	StripeImageURL       string             `bson:"stripe_image_url" json:"stripe_image_url"`
 	IsSubscription       bool               `bson:"is_subscription" json:"is_subscription"`
+	// SubscriptionCreditsPerCycle is the number of free submission credits
+	// granted to the store every paid billing cycle of this subscription.
+	SubscriptionCreditsPerCycle int64 `bson:"subscription_credits_per_cycle" json:"subscription_credits_per_cycle"`
+
+	// Prices holds the price of this offer in currencies other then
+	// `PriceCurrency` so we can charge customers in their local currency.
+	Prices []*OfferPrice `bson:"prices" json:"prices"`
 
 	// Controls how the user is able to book in our system. Special thanks to http://www.heppnetz.de/ontologies/goodrelations/v1#BusinessFunction.
 	BusinessFunction int8 `bson:"business_function" json:"business_function"`
@@ -63,6 +71,47 @@ type Offer struct {
 	ServiceType int8 `bson:"service_type" json:"service_type"`
 }
 
+// OfferPrice represents the price of an offer in a particular currency.
+type OfferPrice struct {
+	Currency      string  `bson:"currency" json:"currency"`
+	Amount        float64 `bson:"amount" json:"amount"`
+	StripePriceID string  `bson:"stripe_price_id" json:"stripe_price_id"`
+}
+
+// PriceForCurrency returns the price of the offer in the currency, falling
+// back to the offer's default price if no price was set for the currency.
+func (o *Offer) PriceForCurrency(currency string) *OfferPrice {
+	for _, p := range o.Prices {
+		if strings.EqualFold(p.Currency, currency) {
+			return p
+		}
+	}
+	return &OfferPrice{
+		Currency:      o.PriceCurrency,
+		Amount:        o.Price,
+		StripePriceID: o.StripePriceID,
+	}
+}
+
+// SetPriceForCurrency sets the default price if the currency matches the
+// offer's default currency, else adds or replaces the price in `Prices`.
+func (o *Offer) SetPriceForCurrency(p *OfferPrice) {
+	p.Currency = strings.ToUpper(p.Currency)
+	if o.PriceCurrency == "" || strings.EqualFold(o.PriceCurrency, p.Currency) {
+		o.Price = p.Amount
+		o.PriceCurrency = p.Currency
+		o.StripePriceID = p.StripePriceID
+		return
+	}
+	for i, existing := range o.Prices {
+		if strings.EqualFold(existing.Currency, p.Currency) {
+			o.Prices[i] = p
+			return
+		}
+	}
+	o.Prices = append(o.Prices, p)
+}
+
 type OfferListFilter struct {
 	// Pagination related.
 	Cursor    primitive.ObjectID
@@ -97,6 +146,7 @@ type OfferStorer interface {
 	DeleteByID(ctx context.Context, id primitive.ObjectID) error
 	CheckIfExistsByNameInOrgBranch(ctx context.Context, name string, orgID primitive.ObjectID, branchID primitive.ObjectID) (bool, error)
 	CheckIfExistsByID(ctx context.Context, id primitive.ObjectID) (bool, error)
+	CheckIfActiveExistsByServiceType(ctx context.Context, serviceType int8, excludeID primitive.ObjectID) (bool, error)
 	// //TODO: Add more...
 }
 
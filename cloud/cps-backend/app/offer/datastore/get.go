@@ -59,7 +59,10 @@ func (impl OfferStorerImpl) GetByStripeProductID(ctx context.Context, stripeProd
 }
 
 func (impl OfferStorerImpl) GetByStripePriceID(ctx context.Context, stripePriceID string) (*Offer, error) {
-	filter := bson.M{"stripe_price_id": stripePriceID}
+	filter := bson.M{"$or": []bson.M{
+		{"stripe_price_id": stripePriceID},
+		{"prices.stripe_price_id": stripePriceID},
+	}}
 
 	var result Offer
 	err := impl.Collection.FindOne(ctx, filter).Decode(&result)
@@ -75,7 +78,10 @@ func (impl OfferStorerImpl) GetByStripePriceID(ctx context.Context, stripePriceI
 }
 
 func (impl OfferStorerImpl) GetByServiceType(ctx context.Context, serviceType int8) (*Offer, error) {
-	filter := bson.M{"service_type": serviceType}
+	// DEVELOPERS NOTE: Subscription offers share the service type of the
+	// credits they grant so we exclude them to return the one-time offer. Only
+	// one active one-time offer may exist per service type.
+	filter := bson.M{"service_type": serviceType, "status": StatusActive, "is_subscription": bson.M{"$ne": true}}
 
 	var result Offer
 	err := impl.Collection.FindOne(ctx, filter).Decode(&result)
@@ -0,0 +1,107 @@
+package httptransport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	sub_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+func UnmarshalCreateRequest(ctx context.Context, r *http.Request) (*sub_s.Offer, error) {
+	// Initialize our array which will store all the results from the remote server.
+	var requestData sub_s.Offer
+
+	defer r.Body.Close()
+
+	// Read the JSON string and convert it into our golang stuct else we need
+	// to send a `400 Bad Request` errror message back to the client,
+	err := json.NewDecoder(r.Body).Decode(&requestData) // [1]
+	if err != nil {
+		return nil, httperror.NewForSingleField(http.StatusBadRequest, "non_field_error", "payload structure is wrong")
+	}
+
+	// Perform our validation and return validation error on any issues detected.
+	if err := ValidateCreateRequest(&requestData); err != nil {
+		return nil, err
+	}
+	return &requestData, nil
+}
+
+func ValidateCreateRequest(dirtyData *sub_s.Offer) error {
+	e := make(map[string]string)
+
+	if dirtyData.Name == "" {
+		e["name"] = "missing value"
+	}
+	if dirtyData.PriceCurrency == "" {
+		e["price_currency"] = "missing value"
+	}
+	if dirtyData.Price < 0 {
+		e["price"] = "cannot be negative"
+	}
+	if dirtyData.PayFrequency <= 0 {
+		e["pay_frequency"] = "missing value"
+	}
+	if dirtyData.Type <= 0 {
+		e["type"] = "missing value"
+	}
+	if dirtyData.BusinessFunction == 0 {
+		e["business_function"] = "missing value"
+	}
+	if dirtyData.ServiceType <= 0 {
+		e["service_type"] = "missing value"
+	}
+	if dirtyData.Status <= 0 {
+		e["status"] = "missing value"
+	}
+	if dirtyData.SubscriptionCreditsPerCycle < 0 {
+		e["subscription_credits_per_cycle"] = "cannot be negative"
+	}
+	currencies := make(map[string]bool)
+	for _, p := range dirtyData.Prices {
+		currency := strings.ToUpper(p.Currency)
+		if currency == "" {
+			e["prices"] = "missing currency"
+		} else if currencies[currency] || strings.EqualFold(currency, dirtyData.PriceCurrency) {
+			e["prices"] = fmt.Sprintf("duplicate price for currency %v", currency)
+		}
+		if p.Amount < 0 {
+			e["prices"] = "price cannot be negative"
+		}
+		currencies[currency] = true
+	}
+
+	if len(e) != 0 {
+		return httperror.NewForBadRequest(&e)
+	}
+	return nil
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	data, err := UnmarshalCreateRequest(ctx, r)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	data, err = h.Controller.Create(ctx, data)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalCreateResponse(data, w)
+}
+
+func MarshalCreateResponse(res *sub_s.Offer, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
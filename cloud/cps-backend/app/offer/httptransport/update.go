@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
 	sub_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
 )
@@ -30,23 +32,9 @@ func UnmarshalUpdateRequest(ctx context.Context, r *http.Request) (*sub_s.Offer,
 	return &requestData, nil
 }
 
+// ValidateUpdateRequest validates the offer the same as on creation.
 func ValidateUpdateRequest(dirtyData *sub_s.Offer) error {
-	e := make(map[string]string)
-
-	if dirtyData.BusinessFunction == 0 {
-		e["business_function"] = "missing value"
-	}
-	if dirtyData.ServiceType <= 0 {
-		e["service_type"] = "missing value"
-	}
-	if dirtyData.Status <= 0 {
-		e["status"] = "missing value"
-	}
-
-	if len(e) != 0 {
-		return httperror.NewForBadRequest(&e)
-	}
-	return nil
+	return ValidateCreateRequest(dirtyData)
 }
 
 func (h *Handler) UpdateByID(w http.ResponseWriter, r *http.Request, id string) {
@@ -58,6 +46,12 @@ func (h *Handler) UpdateByID(w http.ResponseWriter, r *http.Request, id string)
 		return
 	}
 
+	data.ID, err = primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
 	org, err := h.Controller.UpdateByID(ctx, data)
 	if err != nil {
 		httperror.ResponseError(w, err)
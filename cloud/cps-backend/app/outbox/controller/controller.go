@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/emailer"
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/outbox/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+// OutboxController Interface for delivering the outbox messages after their
+// transaction committed.
+type OutboxController interface {
+	DispatchPending(ctx context.Context) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*domain.OutboxMessage, error)
+	ListByFilter(ctx context.Context, f *domain.OutboxMessagePaginationListFilter) (*domain.OutboxMessagePaginationListResult, error)
+	RetryByID(ctx context.Context, id primitive.ObjectID) (*domain.OutboxMessage, error)
+}
+
+type OutboxControllerImpl struct {
+	Config              *config.Conf
+	Logger              *slog.Logger
+	Emailer             emailer.Emailer
+	OutboxMessageStorer domain.OutboxMessageStorer
+}
+
+func NewController(
+	appCfg *config.Conf,
+	loggerp *slog.Logger,
+	emailer emailer.Emailer,
+	om_storer domain.OutboxMessageStorer,
+) OutboxController {
+	loggerp.Debug("outbox controller initialization started...")
+	s := &OutboxControllerImpl{
+		Config:              appCfg,
+		Logger:              loggerp,
+		Emailer:             emailer,
+		OutboxMessageStorer: om_storer,
+	}
+	s.Logger.Debug("outbox controller initialized")
+	return s
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/outbox/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/retry"
+)
+
+// retryPolicy gives up on a message after about three hours of attempts.
+var retryPolicy = retry.Policy{
+	MaxAttempts: 10,
+	BaseDelay:   30 * time.Second,
+	MaxDelay:    time.Hour,
+}
+
+const (
+	// lockDuration is how long the dispatcher has to deliver a message before
+	// another dispatcher assumes it crashed and picks the message up again.
+	lockDuration = 5 * time.Minute
+)
+
+// DispatchPending delivers every message which is due until there are none
+// left. Delivery is at least once, if the dispatcher crashes after sending
+// but before saving the result then the message is sent again.
+func (impl *OutboxControllerImpl) DispatchPending(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		m, err := impl.OutboxMessageStorer.ClaimNext(ctx, lockDuration)
+		if err != nil {
+			impl.Logger.Error("database claim next error", slog.Any("error", err))
+			return err
+		}
+		if m == nil {
+			return nil
+		}
+
+		impl.finish(m, impl.deliver(ctx, m))
+		if err := impl.OutboxMessageStorer.UpdateByID(ctx, m); err != nil {
+			impl.Logger.Error("database update by id error", slog.Any("error", err))
+			return err
+		}
+	}
+}
+
+func (impl *OutboxControllerImpl) deliver(ctx context.Context, m *domain.OutboxMessage) error {
+	switch m.Type {
+	case domain.TypeEmail:
+		if m.Email == nil {
+			return fmt.Errorf("outbox message has no email")
+		}
+		return impl.Emailer.SendMessage(ctx, m.Email.Message())
+	default:
+		return fmt.Errorf("unsupported outbox message type: %v", m.Type)
+	}
+}
+
+// finish records the result of the attempt on the message and schedules the
+// next attempt if it failed.
+func (impl *OutboxControllerImpl) finish(m *domain.OutboxMessage, err error) {
+	now := time.Now()
+	m.ModifiedAt = now
+	m.LockedUntil = time.Time{}
+	if err == nil {
+		m.Status = domain.StatusSent
+		m.LastError = ""
+		m.SentAt = now
+		// The rendered email can contain secrets, like the password reset
+		// link, so only the envelope is kept once it was delivered.
+		if m.Email != nil {
+			m.Email.Body = ""
+			m.Email.Text = ""
+			for _, a := range m.Email.Attachments {
+				a.Data = nil
+			}
+		}
+		impl.Logger.Debug("outbox message sent", slog.Any("outbox_message_id", m.ID))
+		return
+	}
+
+	m.LastError = err.Error()
+	if retryPolicy.GiveUp(m.Attempts) {
+		m.Status = domain.StatusFailed
+		impl.Logger.Error("outbox message failed, giving up",
+			slog.Any("outbox_message_id", m.ID),
+			slog.Int("attempts", m.Attempts),
+			slog.Any("error", err))
+		return
+	}
+
+	delay := retryPolicy.Delay(m.Attempts)
+	m.Status = domain.StatusPending
+	m.NextAttemptAt = now.Add(delay)
+	impl.Logger.Warn("outbox message failed, will retry",
+		slog.Any("outbox_message_id", m.ID),
+		slog.Int("attempts", m.Attempts),
+		slog.Duration("retry_in", delay),
+		slog.Any("error", err))
+}
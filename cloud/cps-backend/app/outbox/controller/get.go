@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"context"
+
+	"log/slog"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/outbox/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (c *OutboxControllerImpl) GetByID(ctx context.Context, id primitive.ObjectID) (*domain.OutboxMessage, error) {
+	if !permission.Can(ctx, permission.OutboxManage) {
+		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+
+	// Retrieve from our database the record for the specific id.
+	m, err := c.OutboxMessageStorer.GetByID(ctx, id)
+	if err != nil {
+		c.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if m == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "outbox message does not exist")
+	}
+	return m, err
+}
@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"log/slog"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/outbox/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RetryByID queues a message which we gave up on to be delivered again.
+func (c *OutboxControllerImpl) RetryByID(ctx context.Context, id primitive.ObjectID) (*domain.OutboxMessage, error) {
+	if !permission.Can(ctx, permission.OutboxManage) {
+		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+
+	m, err := c.OutboxMessageStorer.GetByID(ctx, id)
+	if err != nil {
+		c.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if m == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "outbox message does not exist")
+	}
+	if m.Status != domain.StatusFailed {
+		return nil, httperror.NewForBadRequestWithSingleField("status", "only failed outbox messages can be retried")
+	}
+
+	m.Status = domain.StatusPending
+	m.Attempts = 0
+	m.NextAttemptAt = time.Now()
+	m.ModifiedAt = time.Now()
+	if err := c.OutboxMessageStorer.UpdateByID(ctx, m); err != nil {
+		c.Logger.Error("database update by id error", slog.Any("error", err))
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,40 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Create queues the message, if the same message is already waiting to be
+// delivered then nothing is queued.
+//
+// DEVELOPERS NOTE: Messages are queued inside the transaction of the business
+// change and MongoDB aborts the whole transaction on a duplicate key error,
+// therefore we upsert against the pending message instead of relying on the
+// unique index to reject the duplicate.
+func (impl OutboxMessageStorerImpl) Create(ctx context.Context, m *OutboxMessage) error {
+	if m.ID == primitive.NilObjectID {
+		m.ID = primitive.NewObjectID()
+		impl.Logger.Warn("database insert outbox message not included id value, created id now.", slog.Any("id", m.ID))
+	}
+
+	filter := bson.M{
+		"dedup_key": m.DedupKey,
+		"status":    bson.M{"$lt": StatusSent},
+	}
+	update := bson.M{"$setOnInsert": m}
+	res, err := impl.Collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		impl.Logger.Error("database insert error", slog.Any("error", err))
+		return err
+	}
+	if res.UpsertedCount == 0 {
+		impl.Logger.Debug("outbox message already queued", slog.String("dedup_key", m.DedupKey))
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package datastore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/emailer"
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+const (
+	// TypeEmail is an email which was rendered when the business change was
+	// saved and is waiting to be sent.
+	TypeEmail = 1
+
+	StatusPending    = 1
+	StatusProcessing = 2
+	StatusSent       = 3
+	// StatusFailed means we gave up retrying, a staff member must retry it.
+	StatusFailed = 4
+)
+
+// OutboxMessage is a side effect, like sending an email, which is saved in
+// the same transaction as the business change and delivered after the
+// transaction commits. If the transaction rolls back the message is never
+// delivered.
+type OutboxMessage struct {
+	ID     primitive.ObjectID `bson:"_id" json:"id"`
+	Type   int8               `bson:"type" json:"type"`
+	Status int8               `bson:"status" json:"status"`
+	// DedupKey prevents the same message being queued twice while the first
+	// one is still waiting to be delivered.
+	DedupKey      string       `bson:"dedup_key" json:"dedup_key"`
+	Email         *OutboxEmail `bson:"email,omitempty" json:"email,omitempty"`
+	Attempts      int          `bson:"attempts" json:"attempts"`
+	LastError     string       `bson:"last_error" json:"last_error,omitempty"`
+	NextAttemptAt time.Time    `bson:"next_attempt_at" json:"next_attempt_at"`
+	LockedUntil   time.Time    `bson:"locked_until" json:"locked_until"`
+	CreatedAt     time.Time    `bson:"created_at" json:"created_at"`
+	ModifiedAt    time.Time    `bson:"modified_at" json:"modified_at"`
+	SentAt        time.Time    `bson:"sent_at" json:"sent_at"`
+}
+
+type OutboxEmail struct {
+	Sender    string `bson:"sender" json:"sender"`
+	Recipient string `bson:"recipient" json:"recipient"`
+	Subject   string `bson:"subject" json:"subject"`
+	// Body and Text are never returned by the API as they can contain links
+	// with secrets, for example the password reset link, and are cleared once
+	// the email was delivered.
+	Body string `bson:"body" json:"-"`
+	// Text is the optional plain text alternative of the HTML body.
+	Text        string              `bson:"text,omitempty" json:"-"`
+	Attachments []*OutboxAttachment `bson:"attachments,omitempty" json:"attachments,omitempty"`
+}
+
+type OutboxAttachment struct {
+	Filename    string `bson:"filename" json:"filename"`
+	ContentType string `bson:"content_type" json:"content_type"`
+	Data        []byte `bson:"data" json:"-"`
+}
+
+// Message returns the email the dispatcher hands to the emailer.
+func (e *OutboxEmail) Message() *emailer.Message {
+	m := &emailer.Message{
+		Sender:     e.Sender,
+		Recipients: []string{e.Recipient},
+		Subject:    e.Subject,
+		HTML:       e.Body,
+		Text:       e.Text,
+	}
+	for _, a := range e.Attachments {
+		m.Attachments = append(m.Attachments, &emailer.Attachment{
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Data:        a.Data,
+		})
+	}
+	return m
+}
+
+// OutboxMessageStorer Interface for the outbox.
+type OutboxMessageStorer interface {
+	Create(ctx context.Context, m *OutboxMessage) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*OutboxMessage, error)
+	ClaimNext(ctx context.Context, lockFor time.Duration) (*OutboxMessage, error)
+	UpdateByID(ctx context.Context, m *OutboxMessage) error
+	ListByFilter(ctx context.Context, f *OutboxMessagePaginationListFilter) (*OutboxMessagePaginationListResult, error)
+}
+
+type OutboxMessageStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) OutboxMessageStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("outbox_messages")
+
+	// The following few lines of code will create the index for our app for
+	// this colleciton.
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{
+			// Only messages which were not delivered yet are deduplicated so
+			// the same email can be sent again later on.
+			Keys: bson.D{{Key: "dedup_key", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": bson.M{"$lt": StatusSent}}),
+		},
+		{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "next_attempt_at", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{{Key: "created_at", Value: -1}},
+		},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &OutboxMessageStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
+
+// NewEmailMessage returns the email, which may have a plain text alternative
+// and attachments, ready to be delivered.
+func NewEmailMessage(e *OutboxEmail) *OutboxMessage {
+	h := sha256.New()
+	h.Write([]byte(e.Sender + "\n" + e.Recipient + "\n" + e.Subject + "\n" + e.Body + "\n" + e.Text))
+	for _, a := range e.Attachments {
+		h.Write([]byte("\n" + a.Filename + "\n"))
+		h.Write(a.Data)
+	}
+	now := time.Now()
+	return &OutboxMessage{
+		ID:            primitive.NewObjectID(),
+		Type:          TypeEmail,
+		Status:        StatusPending,
+		DedupKey:      "email:" + hex.EncodeToString(h.Sum(nil)),
+		Email:         e,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		ModifiedAt:    now,
+	}
+}
@@ -0,0 +1,75 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+func (impl OutboxMessageStorerImpl) ListByFilter(ctx context.Context, f *OutboxMessagePaginationListFilter) (*OutboxMessagePaginationListResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+	defer cancel()
+
+	filter, err := impl.newPaginationFilter(f)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add filter conditions to the filter
+	if f.Status != 0 {
+		filter["status"] = f.Status
+	}
+	if f.Type != 0 {
+		filter["type"] = f.Type
+	}
+	if f.Recipient != "" {
+		filter["email.recipient"] = f.Recipient
+	}
+
+	impl.Logger.Debug("listing filter:",
+		slog.Any("filter", filter))
+
+	// Include additional filters for our cursor-based pagination pertaining to sorting and limit.
+	options, err := impl.newPaginationOptions(f)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute the query
+	cursor, err := impl.Collection.Find(ctx, filter, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	// Retrieve the documents and check if there is a next page
+	results := []*OutboxMessage{}
+	hasNextPage := false
+	for cursor.Next(ctx) {
+		document := &OutboxMessage{}
+		if err := cursor.Decode(document); err != nil {
+			return nil, err
+		}
+		results = append(results, document)
+		// Stop fetching documents if we have reached the desired page size
+		if int64(len(results)) >= f.PageSize {
+			hasNextPage = true
+			break
+		}
+	}
+
+	// Get the next cursor and encode it
+	var nextCursor string
+	if hasNextPage {
+		nextCursor, err = impl.newPaginatorNextCursor(f, results)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &OutboxMessagePaginationListResult{
+		Results:     results,
+		NextCursor:  nextCursor,
+		HasNextPage: hasNextPage,
+	}, nil
+}
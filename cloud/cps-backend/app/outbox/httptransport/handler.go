@@ -0,0 +1,21 @@
+package httptransport
+
+import (
+	"log/slog"
+
+	outbox_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/outbox/controller"
+)
+
+// Handler Creates http request handler
+type Handler struct {
+	Logger     *slog.Logger
+	Controller outbox_c.OutboxController
+}
+
+// NewHandler Constructor
+func NewHandler(loggerp *slog.Logger, c outbox_c.OutboxController) *Handler {
+	return &Handler{
+		Logger:     loggerp,
+		Controller: c,
+	}
+}
@@ -0,0 +1,85 @@
+package datastore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+// PasswordResetToken is a single-use token emailed to the user who forgot
+// their password, only the hash of the token is saved.
+type PasswordResetToken struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	TokenHash string             `bson:"token_hash" json:"-"`
+	IPAddress string             `bson:"ip_address" json:"ip_address"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	// ExpiresAt is when the token stops working, mongodb deletes the record
+	// afterwards.
+	ExpiresAt time.Time `bson:"expires_at" json:"expires_at"`
+}
+
+// PasswordResetTokenStorer Interface for password reset tokens.
+type PasswordResetTokenStorer interface {
+	Create(ctx context.Context, m *PasswordResetToken) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*PasswordResetToken, error)
+	DeleteByID(ctx context.Context, id primitive.ObjectID) error
+	DeleteAllByUserID(ctx context.Context, userID primitive.ObjectID) error
+}
+
+type PasswordResetTokenStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) PasswordResetTokenStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("password_reset_tokens")
+
+	// The following few lines of code will create the index for our app for
+	// this colleciton.
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "token_hash", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}},
+		},
+		{
+			// Have mongodb delete the expired tokens for us.
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &PasswordResetTokenStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
+
+// HashToken returns the hash of the token we save and lookup by. The tokens
+// are long and random so a fast hash is enough.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,27 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (impl PasswordResetTokenStorerImpl) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
+	_, err := impl.Collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		impl.Logger.Error("database delete by id error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+func (impl PasswordResetTokenStorerImpl) DeleteAllByUserID(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := impl.Collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		impl.Logger.Error("database delete all by user id error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
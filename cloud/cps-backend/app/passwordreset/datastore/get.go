@@ -0,0 +1,25 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (impl PasswordResetTokenStorerImpl) GetByTokenHash(ctx context.Context, tokenHash string) (*PasswordResetToken, error) {
+	filter := bson.M{"token_hash": tokenHash}
+
+	var result PasswordResetToken
+	err := impl.Collection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// This error means your query did not match any documents.
+			return nil, nil
+		}
+		impl.Logger.Error("database get by token hash error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
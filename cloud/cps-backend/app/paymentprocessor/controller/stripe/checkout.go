@@ -80,16 +80,23 @@ func (impl *StripePaymentProcessorControllerImpl) CreateStripeCheckoutSessionURL
 			return "", errors.New("user has no customer id set by payment processor")
 		}
 
+		// STEP 4: Pick the price in the currency we charge this user in.
+		cur, err := impl.currencyForUser(sessCtx, u)
+		if err != nil {
+			return "", err
+		}
+
 		// Defensive code: Prevent executing if `product id` have not been created.
-		if o.StripePriceID == "" {
-			impl.Logger.Warn("this product is not ready")
-			return "", errors.New("this product is not ready")
+		price, err := impl.priceForCurrency(o, cur)
+		if err != nil {
+			return "", err
 		}
 
 		hasShippingAddress := u.ShippingCity != "" || u.ShippingCountry != "" || u.ShippingAddressLine1 != ""
 
 		impl.Logger.Debug("creating stripe checkout session",
-			slog.String("priceID", o.StripePriceID),
+			slog.String("priceID", price.StripePriceID),
+			slog.String("currency", price.Currency),
 			slog.Any("offerID", o.ID),
 			slog.Any("hasShippingAddress", hasShippingAddress))
 
@@ -102,6 +109,7 @@ func (impl *StripePaymentProcessorControllerImpl) CreateStripeCheckoutSessionURL
 		metadata["UserID"] = u.ID.Hex()
 		metadata["OfferID"] = o.ID.Hex()
 		metadata["Type"] = "Comic Book Submission"
+		metadata["Currency"] = price.Currency
 
 		// DEVELOPERS NOTE:
 		// THIS IS HOW WE SUBMIT OUR APPS CONFIGURAITON FOR THE PRODUCT AND
@@ -112,7 +120,7 @@ func (impl *StripePaymentProcessorControllerImpl) CreateStripeCheckoutSessionURL
 			"/submissions/comics/add/"+comicSubmissionID.Hex()+"/confirmation",  // Accepted URL
 			"/submissions/comics/add/"+comicSubmissionID.Hex()+"?canceled=true", // Cancelled URL
 			u.PaymentProcessorCustomerID,
-			o.StripePriceID,
+			price.StripePriceID,
 			metadata,
 			hasShippingAddress,
 		)
@@ -7,11 +7,12 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
-	mg "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/emailer/mailgun"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/emailer"
 	pm "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/paymentprocessor/stripe"
 	s3_storage "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/storage/s3"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
 	submission_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
+	credit_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/credit/datastore"
 	eventlog_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/eventlog/datastore"
 	offer_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
 	r_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/datastore"
@@ -19,6 +20,7 @@ import (
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	up_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/currency"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/kmutex"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/password"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
@@ -27,6 +29,9 @@ import (
 type StripePaymentProcessorController interface {
 	Webhook(ctx context.Context, header string, b []byte) error
 	CreateStripeCheckoutSessionURLForComicSubmissionID(ctx context.Context, comicSubmissionID primitive.ObjectID) (string, error)
+	CreateStripeSubscriptionCheckoutSessionURL(ctx context.Context, offerID primitive.ObjectID) (string, error)
+	CancelStoreSubscription(ctx context.Context, storeID primitive.ObjectID) (*org_s.Store, error)
+	ChangeStoreSubscription(ctx context.Context, storeID primitive.ObjectID, offerID primitive.ObjectID) (*org_s.Store, error)
 }
 
 type StripePaymentProcessorControllerImpl struct {
@@ -35,10 +40,11 @@ type StripePaymentProcessorControllerImpl struct {
 	UUID                  uuid.Provider
 	S3                    s3_storage.S3Storager
 	Password              password.Provider
-	Emailer               mg.Emailer
+	Emailer               emailer.Emailer
 	TemplatedEmailer      templatedemailer.TemplatedEmailer
 	PaymentProcessor      pm.PaymentProcessor
 	Kmutex                kmutex.Provider
+	Currency              currency.Provider
 	DbClient              *mongo.Client
 	StoreStorer           org_s.StoreStorer
 	UserStorer            user_s.UserStorer
@@ -47,6 +53,7 @@ type StripePaymentProcessorControllerImpl struct {
 	EventLogStorer        eventlog_s.EventLogStorer
 	ComicSubmissionStorer submission_s.ComicSubmissionStorer
 	UserPurchaseStorer    up_s.UserPurchaseStorer
+	CreditStorer          credit_s.CreditStorer
 }
 
 func NewController(
@@ -55,10 +62,11 @@ func NewController(
 	uuidp uuid.Provider,
 	s3 s3_storage.S3Storager,
 	passwordp password.Provider,
-	emailer mg.Emailer,
+	emailer emailer.Emailer,
 	te templatedemailer.TemplatedEmailer,
 	paymentProcessor pm.PaymentProcessor,
 	kmux kmutex.Provider,
+	currencyp currency.Provider,
 	client *mongo.Client,
 	org_storer org_s.StoreStorer,
 	sub_storer user_s.UserStorer,
@@ -67,6 +75,7 @@ func NewController(
 	evel eventlog_s.EventLogStorer,
 	sub_s submission_s.ComicSubmissionStorer,
 	up up_s.UserPurchaseStorer,
+	cr credit_s.CreditStorer,
 ) StripePaymentProcessorController {
 	loggerp.Debug("payment processor controller initialization started...")
 	s := &StripePaymentProcessorControllerImpl{
@@ -76,6 +85,7 @@ func NewController(
 		S3:                    s3,
 		Password:              passwordp,
 		Kmutex:                kmux,
+		Currency:              currencyp,
 		Emailer:               emailer,
 		TemplatedEmailer:      te,
 		PaymentProcessor:      paymentProcessor,
@@ -87,6 +97,7 @@ func NewController(
 		EventLogStorer:        evel,
 		ComicSubmissionStorer: sub_s,
 		UserPurchaseStorer:    up,
+		CreditStorer:          cr,
 	}
 	s.Logger.Debug("payment processor controller initialized")
 	return s
@@ -0,0 +1,95 @@
+package stripe
+
+import (
+	"log/slog"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	offer_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
+	org_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
+	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	up_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+// currencyFor returns the currency we charge in; the store's currency takes
+// priority over the currency of the user's country.
+func (impl *StripePaymentProcessorControllerImpl) currencyFor(s *org_s.Store, u *user_s.User) string {
+	if s != nil && s.Currency != "" {
+		// Stores saved before we validated the currency may have anything,
+		// so only use it if we can actually charge in it.
+		c := strings.ToUpper(strings.TrimSpace(s.Currency))
+		if impl.Currency.IsSupported(c) {
+			return c
+		}
+		impl.Logger.Warn("store currency is not supported, choosing currency by country",
+			slog.Any("store_id", s.ID),
+			slog.String("currency", s.Currency))
+	}
+	if u != nil {
+		return impl.Currency.CurrencyForCountry(u.Country)
+	}
+	return impl.Currency.BaseCurrency()
+}
+
+// currencyForUser returns the currency we charge the user in.
+func (impl *StripePaymentProcessorControllerImpl) currencyForUser(sessCtx mongo.SessionContext, u *user_s.User) (string, error) {
+	var s *org_s.Store
+	if !u.StoreID.IsZero() {
+		var err error
+		s, err = impl.StoreStorer.GetByID(sessCtx, u.StoreID)
+		if err != nil {
+			impl.Logger.Error("database get store by id error", slog.Any("err", err))
+			return "", err
+		}
+	}
+	return impl.currencyFor(s, u), nil
+}
+
+// priceForCurrency returns the offer's price in the currency, or a validation
+// error if the price has not been created in the payment processor yet.
+func (impl *StripePaymentProcessorControllerImpl) priceForCurrency(o *offer_s.Offer, currency string) (*offer_s.OfferPrice, error) {
+	p := o.PriceForCurrency(currency)
+	if p.StripePriceID == "" {
+		impl.Logger.Warn("this product is not ready",
+			slog.Any("offerID", o.ID),
+			slog.String("currency", currency))
+		return nil, httperror.NewForBadRequestWithSingleField("offer_id", "offer is not ready")
+	}
+	if p.Currency != currency {
+		impl.Logger.Warn("offer has no price for currency, falling back to default price",
+			slog.Any("offerID", o.ID),
+			slog.String("currency", currency),
+			slog.String("default_currency", p.Currency))
+	}
+	return p, nil
+}
+
+// amountInBaseCurrency converts the amount charged into our base currency,
+// returning zero and logging if we have no exchange rate so the purchase is
+// still recorded.
+func (impl *StripePaymentProcessorControllerImpl) amountInBaseCurrency(amount float64, currency string) (float64, float64) {
+	rate, err := impl.Currency.ExchangeRate(currency)
+	if err != nil {
+		impl.Logger.Error("no exchange rate for currency error",
+			slog.String("currency", currency),
+			slog.Any("err", err))
+		return 0, 0
+	}
+	baseAmount, _ := impl.Currency.ConvertToBase(amount, currency)
+	return baseAmount, rate
+}
+
+// setUserPurchaseAmounts records the amount charged in the currency the
+// customer paid in along with its value in our base currency.
+func (impl *StripePaymentProcessorControllerImpl) setUserPurchaseAmounts(up *up_s.UserPurchase, o *offer_s.Offer, amountTotal float64, currency string) {
+	currency = strings.ToUpper(currency)
+	price := o.PriceForCurrency(currency)
+	up.OfferPrice = price.Amount
+	up.OfferPriceCurrency = price.Currency
+	up.AmountTotal = amountTotal
+	up.Currency = currency
+	up.BaseCurrency = impl.Currency.BaseCurrency()
+	up.BaseAmountTotal, up.ExchangeRate = impl.amountInBaseCurrency(amountTotal, currency)
+}
@@ -0,0 +1,292 @@
+package stripe
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/stripe/stripe-go/v75"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	offer_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
+	org_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
+	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+// hasLiveSubscription returns true if the store has a subscription which the
+// payment processor is still billing.
+func hasLiveSubscription(s *org_s.Store) bool {
+	if s.PaymentProcessorSubscriptionID == "" {
+		return false
+	}
+	switch stripe.SubscriptionStatus(s.PaymentProcessorSubscriptionStatus) {
+	case stripe.SubscriptionStatusCanceled, stripe.SubscriptionStatusIncompleteExpired:
+		return false
+	default:
+		return true
+	}
+}
+
+// getSubscribableOffer returns the offer if it can be subscribed to, else a
+// validation error.
+func (impl *StripePaymentProcessorControllerImpl) getSubscribableOffer(sessCtx mongo.SessionContext, offerID primitive.ObjectID) (*offer_s.Offer, error) {
+	o, err := impl.OfferStorer.GetByID(sessCtx, offerID)
+	if err != nil {
+		impl.Logger.Error("database error", slog.Any("err", err))
+		return nil, err
+	}
+	if o == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("offer_id", "offer does not exist")
+	}
+	if !o.IsSubscription || o.Status != offer_s.StatusActive {
+		return nil, httperror.NewForBadRequestWithSingleField("offer_id", "offer is not an active subscription")
+	}
+	return o, nil
+}
+
+// getStoreForSubscriptionChange returns the store if the logged in user is
+// allowed to manage its subscription.
+func (impl *StripePaymentProcessorControllerImpl) getStoreForSubscriptionChange(sessCtx mongo.SessionContext, storeID primitive.ObjectID) (*org_s.Store, error) {
+	// Extract from our session the following data.
+	ustoreID := sessCtx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+
+	if err := permission.Require(sessCtx, permission.SubscriptionManage); err != nil { // Security.
+		return nil, err
+	}
+	if permission.Scope(sessCtx) != permission.ScopeSystem && ustoreID != storeID {
+		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+
+	s, err := impl.StoreStorer.GetByID(sessCtx, storeID)
+	if err != nil {
+		impl.Logger.Error("database error", slog.Any("err", err))
+		return nil, err
+	}
+	if s == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("store_id", "store does not exist")
+	}
+	if !hasLiveSubscription(s) {
+		return nil, httperror.NewForBadRequestWithSingleField("store_id", "store does not have a subscription")
+	}
+	return s, nil
+}
+
+// CreateStripeSubscriptionCheckoutSessionURL function returns the checkout
+// session URL for the logged in retailer to subscribe their store to the offer.
+func (impl *StripePaymentProcessorControllerImpl) CreateStripeSubscriptionCheckoutSessionURL(ctx context.Context, offerID primitive.ObjectID) (string, error) {
+	////
+	//// Start the transaction.
+	////
+
+	session, err := impl.DbClient.StartSession()
+	if err != nil {
+		impl.Logger.Error("start session error",
+			slog.Any("error", err))
+		return "", err
+	}
+	defer session.EndSession(ctx)
+
+	// Define a transaction function with a series of operations
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		// Extract from our session the following data.
+		// This user is the logged in retailer admin as they are the only ones
+		// whom can subscribe.
+		userID := sessCtx.Value(constants.SessionUserID).(primitive.ObjectID)
+
+		if permission.Scope(sessCtx) != permission.ScopeStore || !permission.Can(sessCtx, permission.SubscriptionManage) {
+			return "", httperror.NewForForbiddenWithSingleField("message", "only retailers can subscribe")
+		}
+
+		o, err := impl.getSubscribableOffer(sessCtx, offerID)
+		if err != nil {
+			return "", err
+		}
+
+		u, err := impl.UserStorer.GetByID(sessCtx, userID)
+		if err != nil {
+			impl.Logger.Error("database error", slog.Any("err", err))
+			return "", err
+		}
+		if u == nil {
+			return "", httperror.NewForBadRequestWithSingleField("message", "user does not exist")
+		}
+
+		s, err := impl.StoreStorer.GetByID(sessCtx, u.StoreID)
+		if err != nil {
+			impl.Logger.Error("database error", slog.Any("err", err))
+			return "", err
+		}
+		if s == nil {
+			return "", httperror.NewForBadRequestWithSingleField("message", "store does not exist")
+		}
+
+		// Defensive code: Prevent paying twice for the same store.
+		if hasLiveSubscription(s) {
+			return "", httperror.NewForBadRequestWithSingleField("message", "store already has a subscription, please change it instead")
+		}
+
+		// Defensive code: Prevent executing if no `customer id` exist from stripe.
+		if u.PaymentProcessorCustomerID == "" {
+			impl.Logger.Warn("not stripe payment processor customer id assigned to user.")
+			return "", httperror.NewForBadRequestWithSingleField("message", "user has no customer id set by payment processor")
+		}
+
+		price, err := impl.priceForCurrency(o, impl.currencyFor(s, u))
+		if err != nil {
+			return "", err
+		}
+
+		hasShippingAddress := u.ShippingCity != "" || u.ShippingCountry != "" || u.ShippingAddressLine1 != ""
+
+		// DEVELOPERS NOTE:
+		// THE METADATA IS ATTACHED TO THE SUBSCRIPTION SO OUR WEBHOOKS KNOW
+		// WHICH STORE TO GRANT THE CREDITS TO EVERY BILLING CYCLE.
+		metadata := make(map[string]string)
+		metadata["StoreID"] = s.ID.Hex()
+		metadata["UserID"] = u.ID.Hex()
+		metadata["OfferID"] = o.ID.Hex()
+		metadata["Type"] = "Store Subscription"
+		metadata["Currency"] = price.Currency
+
+		redirectURL, err := impl.PaymentProcessor.CreateSubscriptionCheckoutSessionURL(
+			impl.Emailer.GetFrontendDomainName(),
+			"/store/subscription/confirmation",  // Accepted URL
+			"/store/subscription?canceled=true", // Cancelled URL
+			u.PaymentProcessorCustomerID,
+			price.StripePriceID,
+			metadata,
+			hasShippingAddress,
+		)
+		if err != nil {
+			return "", err
+		}
+		impl.Logger.Debug("stripe subscription checkout session ready", slog.String("redirectURL", redirectURL))
+		return redirectURL, nil
+	}
+
+	// Start a transaction
+	res, err := session.WithTransaction(ctx, transactionFunc)
+	if err != nil {
+		impl.Logger.Error("session failed error",
+			slog.Any("error", err))
+		return "", err
+	}
+
+	return res.(string), nil
+}
+
+// CancelStoreSubscription function cancels the store's subscription at the
+// end of the current billing cycle.
+func (impl *StripePaymentProcessorControllerImpl) CancelStoreSubscription(ctx context.Context, storeID primitive.ObjectID) (*org_s.Store, error) {
+	session, err := impl.DbClient.StartSession()
+	if err != nil {
+		impl.Logger.Error("start session error",
+			slog.Any("error", err))
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		s, err := impl.getStoreForSubscriptionChange(sessCtx, storeID)
+		if err != nil {
+			return nil, err
+		}
+
+		sub, err := impl.PaymentProcessor.CancelSubscription(s.PaymentProcessorSubscriptionID)
+		if err != nil {
+			impl.Logger.Error("payment processor cancel subscription error", slog.Any("err", err))
+			return nil, err
+		}
+
+		s.PaymentProcessorSubscriptionStatus = string(sub.Status)
+		s.SubscriptionCancelAtPeriodEnd = sub.CancelAtPeriodEnd
+		s.ModifiedAt = time.Now()
+		if err := impl.StoreStorer.UpdateByID(sessCtx, s); err != nil {
+			impl.Logger.Error("database update error", slog.Any("err", err))
+			return nil, err
+		}
+		return s, nil
+	}
+
+	res, err := session.WithTransaction(ctx, transactionFunc)
+	if err != nil {
+		impl.Logger.Error("session failed error",
+			slog.Any("error", err))
+		return nil, err
+	}
+
+	return res.(*org_s.Store), nil
+}
+
+// ChangeStoreSubscription function upgrades or downgrades the store's
+// subscription to the offer; the price difference is prorated by the payment
+// processor.
+func (impl *StripePaymentProcessorControllerImpl) ChangeStoreSubscription(ctx context.Context, storeID primitive.ObjectID, offerID primitive.ObjectID) (*org_s.Store, error) {
+	session, err := impl.DbClient.StartSession()
+	if err != nil {
+		impl.Logger.Error("start session error",
+			slog.Any("error", err))
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		s, err := impl.getStoreForSubscriptionChange(sessCtx, storeID)
+		if err != nil {
+			return nil, err
+		}
+
+		o, err := impl.getSubscribableOffer(sessCtx, offerID)
+		if err != nil {
+			return nil, err
+		}
+		if o.ID == s.SubscriptionOfferID && !s.SubscriptionCancelAtPeriodEnd {
+			return nil, httperror.NewForBadRequestWithSingleField("offer_id", "store is already subscribed to this offer")
+		}
+
+		// Stay in the currency the subscription was purchased in.
+		var u *user_s.User
+		if !s.SubscriptionUserID.IsZero() {
+			u, err = impl.UserStorer.GetByID(sessCtx, s.SubscriptionUserID)
+			if err != nil {
+				impl.Logger.Error("database error", slog.Any("err", err))
+				return nil, err
+			}
+		}
+		price, err := impl.priceForCurrency(o, impl.currencyFor(s, u))
+		if err != nil {
+			return nil, err
+		}
+
+		sub, err := impl.PaymentProcessor.UpdateSubscriptionPrice(s.PaymentProcessorSubscriptionID, price.StripePriceID)
+		if err != nil {
+			impl.Logger.Error("payment processor update subscription error", slog.Any("err", err))
+			return nil, err
+		}
+
+		s.PaymentProcessorSubscriptionStatus = string(sub.Status)
+		s.SubscriptionCancelAtPeriodEnd = sub.CancelAtPeriodEnd
+		s.SubscriptionCurrentPeriodEnd = time.Unix(sub.CurrentPeriodEnd, 0)
+		s.SubscriptionOfferID = o.ID
+		s.SubscriptionOfferName = o.Name
+		s.ModifiedAt = time.Now()
+		if err := impl.StoreStorer.UpdateByID(sessCtx, s); err != nil {
+			impl.Logger.Error("database update error", slog.Any("err", err))
+			return nil, err
+		}
+		return s, nil
+	}
+
+	res, err := session.WithTransaction(ctx, transactionFunc)
+	if err != nil {
+		impl.Logger.Error("session failed error",
+			slog.Any("error", err))
+		return nil, err
+	}
+
+	return res.(*org_s.Store), nil
+}
@@ -1,6 +1,12 @@
 package stripe
 
-import "math"
+import (
+	"math"
+
+	"github.com/stripe/stripe-go/v75"
+
+	off_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
+)
 
 func round(num float64) int {
 	// https://stackoverflow.com/a/29786394
@@ -16,3 +22,23 @@ func toFixed(num float64, precision int) float64 {
 func fromStripeFormat(num int64) float64 {
 	return toFixed(float64(num)/100, 2)
 }
+
+// payFrequencyForPrice returns the pay frequency of the offer the price
+// belongs to, prices without a recurring interval are paid once.
+func payFrequencyForPrice(price *stripe.Price) int8 {
+	if price.Recurring == nil {
+		return off_d.PayFrequencyOneTime
+	}
+	switch price.Recurring.Interval {
+	case stripe.PriceRecurringIntervalDay:
+		return off_d.PayFrequencyDay
+	case stripe.PriceRecurringIntervalWeek:
+		return off_d.PayFrequencyWeek
+	case stripe.PriceRecurringIntervalMonth:
+		return off_d.PayFrequencyMonthly
+	case stripe.PriceRecurringIntervalYear:
+		return off_d.PayFrequencyAnnual
+	default:
+		return off_d.PayFrequencyOneTime
+	}
+}
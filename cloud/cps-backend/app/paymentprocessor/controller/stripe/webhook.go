@@ -82,6 +82,12 @@ func (impl *StripePaymentProcessorControllerImpl) Webhook(ctx context.Context, h
 			return nil, impl.webhookForPaymentIntentSucceeded(sessCtx, event, eventlog)
 		case "checkout.session.completed":
 			return nil, impl.webhookForCheckoutSessionCompleted(sessCtx, event, eventlog)
+		case "customer.subscription.created", "customer.subscription.updated":
+			return nil, impl.webhookForSubscriptionCreatedOrUpdated(sessCtx, event, eventlog)
+		case "customer.subscription.deleted":
+			return nil, impl.webhookForSubscriptionDeleted(sessCtx, event, eventlog)
+		case "invoice.paid":
+			return nil, impl.webhookForInvoicePaid(sessCtx, event, eventlog)
 		default:
 			impl.Logger.Warn("skip processing stripe event", slog.Any("eventType", event.Type))
 			return nil, nil
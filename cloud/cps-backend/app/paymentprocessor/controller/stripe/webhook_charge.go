@@ -31,6 +31,12 @@ func (c *StripePaymentProcessorControllerImpl) webhookForChargeSucceeded(sessCtx
 		return err
 	}
 
+	// DEVELOPERS NOTE: Subscription payments are handled by `invoice.paid`.
+	if chrg.Invoice != nil {
+		c.Logger.Debug("skip subscription charge", slog.String("webhook", string(event.Type)))
+		return c.markEventLogOK(sessCtx, el)
+	}
+
 	// DEVELOPERS NOTE: We do this to prevent duplicates.
 	c.Kmutex.Lockf("%v", chrg.PaymentIntent.ID)
 	defer func() {
@@ -149,8 +155,6 @@ func (c *StripePaymentProcessorControllerImpl) webhookForChargeSucceeded(sessCtx
 			OfferName:                   o.Name,
 			OfferDescription:            o.Description,
 			OfferType:                   o.Type,
-			OfferPrice:                  o.Price,
-			OfferPriceCurrency:          o.PriceCurrency,
 			OfferPayFrequency:           o.PayFrequency,
 			OfferBusinessFunction:       o.BusinessFunction,
 			OfferServiceType:            o.ServiceType,
@@ -163,8 +167,8 @@ func (c *StripePaymentProcessorControllerImpl) webhookForChargeSucceeded(sessCtx
 			PaymentProcessorReceiptURL:  chrg.ReceiptURL,
 			PaymentProcessorPurchaseID:  chrg.PaymentIntent.ID,
 			PaymentProcessorPurchasedAt: time.Now().In(location),
-			AmountTotal:                 fromStripeFormat(chrg.Amount),
 		}
+		c.setUserPurchaseAmounts(up, o, fromStripeFormat(chrg.Amount), string(chrg.Currency))
 		if err := c.UserPurchaseStorer.Create(sessCtx, up); err != nil {
 			c.Logger.Error("create user purchase error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
 			return err
@@ -187,8 +191,6 @@ func (c *StripePaymentProcessorControllerImpl) webhookForChargeSucceeded(sessCtx
 		up.OfferName = o.Name
 		up.OfferDescription = o.Description
 		up.OfferType = o.Type
-		up.OfferPrice = o.Price
-		up.OfferPriceCurrency = o.PriceCurrency
 		up.OfferPayFrequency = o.PayFrequency
 		up.OfferBusinessFunction = o.BusinessFunction
 		up.OfferServiceType = o.ServiceType
@@ -201,7 +203,7 @@ func (c *StripePaymentProcessorControllerImpl) webhookForChargeSucceeded(sessCtx
 		up.PaymentProcessorReceiptURL = chrg.ReceiptURL
 		up.PaymentProcessorPurchaseID = chrg.PaymentIntent.ID
 		up.PaymentProcessorPurchasedAt = time.Now().In(location)
-		up.AmountTotal = fromStripeFormat(chrg.Amount)
+		c.setUserPurchaseAmounts(up, o, fromStripeFormat(chrg.Amount), string(chrg.Currency))
 		if err := c.UserPurchaseStorer.UpdateByID(sessCtx, up); err != nil {
 			c.Logger.Error("update user purchase error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
 			return err
@@ -29,6 +29,13 @@ func (c *StripePaymentProcessorControllerImpl) webhookForCheckoutSessionComplete
 		return err
 	}
 
+	// DEVELOPERS NOTE: Subscription checkouts are handled by the
+	// `customer.subscription.*` and `invoice.paid` events.
+	if session.Mode == stripe.CheckoutSessionModeSubscription {
+		c.Logger.Debug("skip subscription checkout session", slog.String("webhook", string(event.Type)))
+		return c.markEventLogOK(sessCtx, el)
+	}
+
 	// DEVELOPERS NOTE: We do this to prevent duplicates.
 	c.Kmutex.Lockf("%v", session.PaymentIntent.ID)
 	defer func() {
@@ -102,6 +109,9 @@ func (c *StripePaymentProcessorControllerImpl) webhookForCheckoutSessionComplete
 		up.AmountSubtotal = fromStripeFormat(session.AmountSubtotal)
 		up.AmountTax = fromStripeFormat(session.TotalDetails.AmountTax)
 		up.AmountTotal = fromStripeFormat(session.AmountTotal)
+		up.Currency = strings.ToUpper(string(session.Currency))
+		up.BaseCurrency = c.Currency.BaseCurrency()
+		up.BaseAmountTotal, up.ExchangeRate = c.amountInBaseCurrency(up.AmountTotal, up.Currency)
 		if err := c.UserPurchaseStorer.UpdateByID(sessCtx, up); err != nil {
 			c.Logger.Error("updated user purchases error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
 			return err
@@ -0,0 +1,148 @@
+package stripe
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/stripe/stripe-go/v75"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	credit_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/credit/datastore"
+	el_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/eventlog/datastore"
+)
+
+// webhookForInvoicePaid function will handle Stripe's `invoice.paid` webhook
+// event in our system by granting the store its credits for the billing cycle.
+func (c *StripePaymentProcessorControllerImpl) webhookForInvoicePaid(sessCtx mongo.SessionContext, event stripe.Event, el *el_d.EventLog) error {
+	c.Logger.Debug("webhookForInvoicePaid: starting...", slog.String("webhook", string(event.Type)))
+
+	var inv stripe.Invoice
+
+	// Successfully cast to []byte
+	if err := json.Unmarshal(event.Data.Raw, &inv); err != nil {
+		c.Logger.Error("unmarshalling error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
+		return err
+	}
+
+	// DEVELOPERS NOTE: Only subscription billing cycles grant credits; the
+	// prorated invoices created by changing plans do not.
+	if inv.Subscription == nil || (inv.BillingReason != stripe.InvoiceBillingReasonSubscriptionCreate && inv.BillingReason != stripe.InvoiceBillingReasonSubscriptionCycle) {
+		c.Logger.Debug("skip invoice which is not a subscription billing cycle",
+			slog.String("invoice_id", inv.ID),
+			slog.Any("billing_reason", inv.BillingReason),
+			slog.String("webhook", string(event.Type)))
+		return c.markEventLogOK(sessCtx, el)
+	}
+
+	// DEVELOPERS NOTE: We do this to prevent duplicates.
+	c.Kmutex.Lockf("%v", inv.ID)
+	defer func() {
+		c.Kmutex.Unlockf("%v", inv.ID)
+	}()
+
+	exists, err := c.CreditStorer.CheckIfExistsByPaymentProcessorInvoiceID(sessCtx, inv.ID)
+	if err != nil {
+		c.Logger.Error("check credits by invoice id error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
+		return err
+	}
+	if exists {
+		c.Logger.Warn("credits already granted for invoice", slog.String("invoice_id", inv.ID), slog.String("webhook", string(event.Type)))
+		return c.markEventLogOK(sessCtx, el)
+	}
+
+	////
+	//// Get related records.
+	////
+
+	// DEVELOPERS NOTE: The invoice only includes the subscription id so we
+	// fetch it to make sure the store is linked before granting the credits.
+	sub, err := c.PaymentProcessor.GetSubscription(inv.Subscription.ID)
+	if err != nil {
+		c.Logger.Error("get subscription error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
+		return err
+	}
+	s, err := c.syncStoreSubscription(sessCtx, sub)
+	if err != nil {
+		c.Logger.Error("sync store subscription error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
+		return err
+	}
+	if s == nil {
+		c.Logger.Warn("skip invoice of subscription which was replaced",
+			slog.String("invoice_id", inv.ID),
+			slog.String("subscription_id", sub.ID),
+			slog.String("webhook", string(event.Type)))
+		return c.markEventLogOK(sessCtx, el)
+	}
+
+	o, err := c.OfferStorer.GetByID(sessCtx, s.SubscriptionOfferID)
+	if err != nil {
+		c.Logger.Error("get offer by id error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
+		return err
+	}
+	if o == nil {
+		c.Logger.Error("offer does not exist error", slog.String("webhook", string(event.Type)))
+		return errors.New("offer does not exist")
+	}
+
+	u, err := c.UserStorer.GetByID(sessCtx, s.SubscriptionUserID)
+	if err != nil {
+		c.Logger.Error("get user by id error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
+		return err
+	}
+	if u == nil {
+		c.Logger.Error("user does not exist error", slog.String("webhook", string(event.Type)))
+		return errors.New("user does not exist")
+	}
+
+	////
+	//// Grant the credits.
+	////
+
+	for no := int64(0); no < o.SubscriptionCreditsPerCycle; no++ {
+		m := &credit_s.Credit{
+			ID:                        primitive.NewObjectID(),
+			StoreID:                   s.ID,
+			StoreName:                 s.Name,
+			StoreTimezone:             s.Timezone,
+			CreatedAt:                 time.Now(),
+			ModifiedAt:                time.Now(),
+			Status:                    credit_s.StatusActive,
+			BusinessFunction:          credit_s.BusinessFunctionGrantFreeSubmission,
+			UserID:                    u.ID,
+			UserName:                  u.Name,
+			UserLexicalName:           u.LexicalName,
+			OfferID:                   o.ID,
+			OfferName:                 o.Name,
+			OfferServiceType:          o.ServiceType,
+			PaymentProcessorInvoiceID: inv.ID,
+		}
+		if err := c.CreditStorer.Create(sessCtx, m); err != nil {
+			c.Logger.Error("create credit error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
+			return err
+		}
+	}
+	c.Logger.Debug("granted subscription credits",
+		slog.Any("store_id", s.ID),
+		slog.Int64("credits", o.SubscriptionCreditsPerCycle),
+		slog.String("webhook", string(event.Type)))
+
+	if err := c.markEventLogOK(sessCtx, el); err != nil {
+		return err
+	}
+
+	c.Logger.Debug("webhookForInvoicePaid: finished", slog.String("webhook", string(event.Type)))
+	return nil
+}
+
+// markEventLogOK function marks the logevent as processed.
+func (c *StripePaymentProcessorControllerImpl) markEventLogOK(sessCtx mongo.SessionContext, el *el_d.EventLog) error {
+	el.Status = el_d.StatusOK
+	if err := c.EventLogStorer.UpdateByID(sessCtx, el); err != nil {
+		c.Logger.Error("update event log error", slog.Any("err", err))
+		return err
+	}
+	return nil
+}
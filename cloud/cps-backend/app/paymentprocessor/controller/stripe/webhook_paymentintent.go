@@ -30,6 +30,13 @@ func (c *StripePaymentProcessorControllerImpl) webhookForPaymentIntentSucceeded(
 		c.Logger.Error("unmarshalling error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
 		return err
 	}
+
+	// DEVELOPERS NOTE: Subscription payments are handled by `invoice.paid`.
+	if pi.Invoice != nil {
+		c.Logger.Debug("skip subscription payment intent", slog.String("webhook", string(event.Type)))
+		return c.markEventLogOK(sessCtx, el)
+	}
+
 	// DEVELOPERS NOTE: We do this to prevent duplicates.
 	c.Kmutex.Lockf("%v", pi.ID)
 	defer func() {
@@ -149,8 +156,6 @@ func (c *StripePaymentProcessorControllerImpl) webhookForPaymentIntentSucceeded(
 			OfferName:                  o.Name,
 			OfferDescription:           o.Description,
 			OfferType:                  o.Type,
-			OfferPrice:                 o.Price,
-			OfferPriceCurrency:         o.PriceCurrency,
 			OfferPayFrequency:          o.PayFrequency,
 			OfferBusinessFunction:      o.BusinessFunction,
 			OfferServiceType:           o.ServiceType,
@@ -165,8 +170,8 @@ func (c *StripePaymentProcessorControllerImpl) webhookForPaymentIntentSucceeded(
 			PaymentProcessorPurchaseStatus: string(pi.Status),
 			PaymentProcessorPurchasedAt:    time.Now().In(location),
 			// PaymentProcessorAmount:      fromStripeFormat(chrg.Amount),
-			AmountTotal: fromStripeFormat(pi.Amount),
 		}
+		c.setUserPurchaseAmounts(up, o, fromStripeFormat(pi.Amount), string(pi.Currency))
 		if err := c.UserPurchaseStorer.Create(sessCtx, up); err != nil {
 			c.Logger.Error("create user purchase error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
 			return err
@@ -189,8 +194,6 @@ func (c *StripePaymentProcessorControllerImpl) webhookForPaymentIntentSucceeded(
 		up.OfferName = o.Name
 		up.OfferDescription = o.Description
 		up.OfferType = o.Type
-		up.OfferPrice = o.Price
-		up.OfferPriceCurrency = o.PriceCurrency
 		up.OfferPayFrequency = o.PayFrequency
 		up.OfferBusinessFunction = o.BusinessFunction
 		up.OfferServiceType = o.ServiceType
@@ -205,7 +208,7 @@ func (c *StripePaymentProcessorControllerImpl) webhookForPaymentIntentSucceeded(
 		up.PaymentProcessorPurchaseStatus = string(pi.Status)
 		up.PaymentProcessorPurchasedAt = time.Now()
 		// up.PaymentProcessorAmount = fromStripeFormat(chrg.Amount)
-		up.AmountTotal = fromStripeFormat(pi.Amount)
+		c.setUserPurchaseAmounts(up, o, fromStripeFormat(pi.Amount), string(pi.Currency))
 		if err := c.UserPurchaseStorer.UpdateByID(sessCtx, up); err != nil {
 			c.Logger.Error("update user purchase error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
 			return err
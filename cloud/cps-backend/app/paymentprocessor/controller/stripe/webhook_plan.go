@@ -33,6 +33,19 @@ func (c *StripePaymentProcessorControllerImpl) webhookForPlanCreatedOrUpdated(se
 	}
 	el.Status = el_d.StatusOK
 
+	// DEVELOPERS NOTE: Archived plans are prices which were replaced so
+	// ignore them else we would replace the new price with the old one.
+	if !plan.Active {
+		c.Logger.Debug("webhookForPlanCreatedOrUpdated: plan is archived, skipping",
+			slog.String("plan_id", plan.ID),
+			slog.String("webhook", string(event.Type)))
+		if err := c.EventLogStorer.UpdateByID(sessCtx, el); err != nil {
+			c.Logger.Error("update event log error", slog.Any("err", err))
+			return err
+		}
+		return nil
+	}
+
 	off, err := c.OfferStorer.GetByStripeProductID(sessCtx, plan.Product.ID)
 	if err != nil {
 		c.Logger.Error("get offer error",
@@ -51,8 +64,11 @@ func (c *StripePaymentProcessorControllerImpl) webhookForPlanCreatedOrUpdated(se
 	//// Update the offer.
 	////
 
-	off.Price = fromStripeFormat(plan.Amount)
-	off.PriceCurrency = strings.ToUpper(string(plan.Currency))
+	off.SetPriceForCurrency(&off_d.OfferPrice{
+		Currency:      strings.ToUpper(string(plan.Currency)),
+		Amount:        fromStripeFormat(plan.Amount),
+		StripePriceID: plan.ID,
+	})
 	switch plan.Interval {
 	case stripe.PlanIntervalDay:
 		off.PayFrequency = off_d.PayFrequencyDay
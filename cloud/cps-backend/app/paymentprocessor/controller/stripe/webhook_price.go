@@ -30,6 +30,19 @@ func (c *StripePaymentProcessorControllerImpl) webhookForPriceCreatedOrUpdated(s
 	}
 	el.Status = el_d.StatusOK
 
+	// DEVELOPERS NOTE: Prices are archived when the offer's price changes so
+	// ignore them else we would replace the new price with the old one.
+	if !price.Active {
+		c.Logger.Debug("webhookForPriceCreatedOrUpdated: price is archived, skipping",
+			slog.String("price_id", price.ID),
+			slog.String("webhook", string(event.Type)))
+		if err := c.EventLogStorer.UpdateByID(sessCtx, el); err != nil {
+			c.Logger.Error("update event log error", slog.Any("err", err))
+			return err
+		}
+		return nil
+	}
+
 	off, err := c.OfferStorer.GetByStripeProductID(sessCtx, price.Product.ID)
 	if err != nil {
 		c.Logger.Error("get offer error", slog.Any("price.Product", price.Product), slog.String("webhook", string(event.Type)))
@@ -44,10 +57,13 @@ func (c *StripePaymentProcessorControllerImpl) webhookForPriceCreatedOrUpdated(s
 	//// Update the offer.
 	////
 
-	off.StripePriceID = price.ID
-	off.Price = fromStripeFormat(price.UnitAmount)
-	off.PriceCurrency = strings.ToUpper(string(price.Currency))
-	off.PayFrequency = off_d.PayFrequencyOneTime
+	off.SetPriceForCurrency(&off_d.OfferPrice{
+		Currency:      strings.ToUpper(string(price.Currency)),
+		Amount:        fromStripeFormat(price.UnitAmount),
+		StripePriceID: price.ID,
+	})
+	off.PayFrequency = payFrequencyForPrice(&price)
+	off.IsSubscription = price.Recurring != nil
 
 	if err := c.OfferStorer.UpdateByID(sessCtx, off); err != nil {
 		c.Logger.Error("create offer error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
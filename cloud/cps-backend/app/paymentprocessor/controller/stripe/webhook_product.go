@@ -40,6 +40,19 @@ func (c *StripePaymentProcessorControllerImpl) webhookForProductCreated(sessCtx
 	}
 	el.Status = el_d.StatusOK
 
+	// DEVELOPERS NOTE: Products created through our admin API already have
+	// an offer, which is referenced in the metadata, so do not duplicate it.
+	if product.Metadata["OfferID"] != "" {
+		c.Logger.Debug("webhookForProductCreated: product belongs to existing offer, skipping",
+			slog.String("offer_id", product.Metadata["OfferID"]),
+			slog.String("webhook", string(event.Type)))
+		if err := c.EventLogStorer.UpdateByID(sessCtx, el); err != nil {
+			c.Logger.Error("update event log error", slog.Any("err", err))
+			return err
+		}
+		return nil
+	}
+
 	////
 	//// Create the offer.
 	////
@@ -121,10 +134,15 @@ func (c *StripePaymentProcessorControllerImpl) webhookForProductUpdated(sessCtx
 			return err
 		}
 		if price != nil {
-			off.StripePriceID = price.ID
-			off.Price = fromStripeFormat(price.UnitAmount)
-			off.PriceCurrency = strings.ToUpper(string(price.Currency))
-			off.PayFrequency = off_d.PayFrequencyOneTime
+			off.SetPriceForCurrency(&off_d.OfferPrice{
+				Currency:      strings.ToUpper(string(price.Currency)),
+				Amount:        fromStripeFormat(price.UnitAmount),
+				StripePriceID: price.ID,
+			})
+			// Every save of the offer updates the product so keep the
+			// frequency of its subscription offers.
+			off.PayFrequency = payFrequencyForPrice(price)
+			off.IsSubscription = price.Recurring != nil
 			c.Logger.Debug("updated pricing of product", slog.String("webhook", string(event.Type)))
 		}
 	}
@@ -0,0 +1,193 @@
+package stripe
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/stripe/stripe-go/v75"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	el_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/eventlog/datastore"
+	offer_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
+	org_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
+)
+
+// webhookForSubscriptionCreatedOrUpdated function will handle Stripe's `customer.subscription.created` and `customer.subscription.updated` webhook events in our system.
+func (c *StripePaymentProcessorControllerImpl) webhookForSubscriptionCreatedOrUpdated(sessCtx mongo.SessionContext, event stripe.Event, el *el_d.EventLog) error {
+	c.Logger.Debug("webhookForSubscriptionCreatedOrUpdated: starting...", slog.String("webhook", string(event.Type)))
+
+	var sub stripe.Subscription
+
+	// Successfully cast to []byte
+	if err := json.Unmarshal(event.Data.Raw, &sub); err != nil {
+		c.Logger.Error("unmarshalling error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
+		return err
+	}
+
+	// DEVELOPERS NOTE: We do this to prevent duplicates.
+	c.Kmutex.Lockf("%v", sub.ID)
+	defer func() {
+		c.Kmutex.Unlockf("%v", sub.ID)
+	}()
+
+	if _, err := c.syncStoreSubscription(sessCtx, &sub); err != nil {
+		c.Logger.Error("sync store subscription error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
+		return err
+	}
+
+	////
+	//// Mark the logevent as processed.
+	////
+
+	el.Status = el_d.StatusOK
+	if err := c.EventLogStorer.UpdateByID(sessCtx, el); err != nil {
+		c.Logger.Error("update event log error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
+		return err
+	}
+
+	c.Logger.Debug("webhookForSubscriptionCreatedOrUpdated: finished", slog.String("webhook", string(event.Type)))
+	return nil
+}
+
+// webhookForSubscriptionDeleted function will handle Stripe's `customer.subscription.deleted` webhook event in our system.
+func (c *StripePaymentProcessorControllerImpl) webhookForSubscriptionDeleted(sessCtx mongo.SessionContext, event stripe.Event, el *el_d.EventLog) error {
+	c.Logger.Debug("webhookForSubscriptionDeleted: starting...", slog.String("webhook", string(event.Type)))
+
+	var sub stripe.Subscription
+
+	// Successfully cast to []byte
+	if err := json.Unmarshal(event.Data.Raw, &sub); err != nil {
+		c.Logger.Error("unmarshalling error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
+		return err
+	}
+
+	// DEVELOPERS NOTE: We do this to prevent duplicates.
+	c.Kmutex.Lockf("%v", sub.ID)
+	defer func() {
+		c.Kmutex.Unlockf("%v", sub.ID)
+	}()
+
+	s, err := c.StoreStorer.GetByPaymentProcessorSubscriptionID(sessCtx, sub.ID)
+	if err != nil {
+		c.Logger.Error("get store by subscription id error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
+		return err
+	}
+	if s != nil {
+		// DEVELOPERS NOTE: We keep the subscription id and offer on record
+		// so staff can see what the store was last subscribed to.
+		s.PaymentProcessorSubscriptionStatus = string(stripe.SubscriptionStatusCanceled)
+		s.SubscriptionCancelAtPeriodEnd = false
+		s.ModifiedAt = time.Now()
+		if err := c.StoreStorer.UpdateByID(sessCtx, s); err != nil {
+			c.Logger.Error("update store error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
+			return err
+		}
+	} else {
+		c.Logger.Warn("no store for deleted subscription",
+			slog.String("subscription_id", sub.ID),
+			slog.String("webhook", string(event.Type)))
+	}
+
+	////
+	//// Mark the logevent as processed.
+	////
+
+	el.Status = el_d.StatusOK
+	if err := c.EventLogStorer.UpdateByID(sessCtx, el); err != nil {
+		c.Logger.Error("update event log error", slog.Any("err", err), slog.String("webhook", string(event.Type)))
+		return err
+	}
+
+	c.Logger.Debug("webhookForSubscriptionDeleted: finished", slog.String("webhook", string(event.Type)))
+	return nil
+}
+
+// syncStoreSubscription function copies the payment processor's subscription
+// details onto the store which owns the subscription. Returns nil if the
+// subscription does not belong to the store anymore.
+func (c *StripePaymentProcessorControllerImpl) syncStoreSubscription(sessCtx mongo.SessionContext, sub *stripe.Subscription) (*org_s.Store, error) {
+	////
+	//// Get the store.
+	////
+
+	s, err := c.StoreStorer.GetByPaymentProcessorSubscriptionID(sessCtx, sub.ID)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		// DEVELOPERS NOTE: The first event for a subscription arrives before
+		// we linked it so we rely on the metadata attached at checkout.
+		storeID, err := primitive.ObjectIDFromHex(sub.Metadata["StoreID"])
+		if err != nil {
+			c.Logger.Error("converting object id from hex error",
+				slog.Any("Error", err),
+				slog.Any("Metadata Key", "StoreID"),
+				slog.Any("Metadata Value", sub.Metadata["StoreID"]))
+			return nil, err
+		}
+		s, err = c.StoreStorer.GetByID(sessCtx, storeID)
+		if err != nil {
+			return nil, err
+		}
+		if s == nil {
+			return nil, fmt.Errorf("store does not exist for subscription %v", sub.ID)
+		}
+
+		// DEVELOPERS NOTE: A late event for an old or replaced subscription
+		// must not overwrite the subscription the store is paying for now.
+		if hasLiveSubscription(s) {
+			c.Logger.Warn("ignoring subscription as store has another live subscription",
+				slog.Any("store_id", s.ID),
+				slog.String("subscription_id", sub.ID),
+				slog.String("store_subscription_id", s.PaymentProcessorSubscriptionID))
+			return nil, nil
+		}
+	}
+
+	////
+	//// Get the offer based on the price being paid.
+	////
+
+	var o *offer_s.Offer
+	if sub.Items != nil && len(sub.Items.Data) > 0 && sub.Items.Data[0].Price != nil {
+		o, err = c.OfferStorer.GetByStripePriceID(sessCtx, sub.Items.Data[0].Price.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if o == nil {
+		if offerID, err := primitive.ObjectIDFromHex(sub.Metadata["OfferID"]); err == nil {
+			o, err = c.OfferStorer.GetByID(sessCtx, offerID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if o == nil {
+		return nil, fmt.Errorf("offer does not exist for subscription %v", sub.ID)
+	}
+
+	////
+	//// Update the store.
+	////
+
+	if s.SubscriptionUserID.IsZero() || s.PaymentProcessorSubscriptionID != sub.ID {
+		if userID, err := primitive.ObjectIDFromHex(sub.Metadata["UserID"]); err == nil {
+			s.SubscriptionUserID = userID
+		}
+	}
+	s.PaymentProcessorSubscriptionID = sub.ID
+	s.PaymentProcessorSubscriptionStatus = string(sub.Status)
+	s.SubscriptionOfferID = o.ID
+	s.SubscriptionOfferName = o.Name
+	s.SubscriptionCurrentPeriodEnd = time.Unix(sub.CurrentPeriodEnd, 0)
+	s.SubscriptionCancelAtPeriodEnd = sub.CancelAtPeriodEnd
+	s.ModifiedAt = time.Now()
+	if err := c.StoreStorer.UpdateByID(sessCtx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,109 @@
+package stripe
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	org_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+type StoreSubscriptionRequestIDO struct {
+	StoreID primitive.ObjectID `json:"store_id"`
+	OfferID primitive.ObjectID `json:"offer_id"`
+}
+
+func UnmarshalStoreSubscriptionRequest(r *http.Request, requireOffer bool) (*StoreSubscriptionRequestIDO, error) {
+	var requestData StoreSubscriptionRequestIDO
+
+	defer r.Body.Close()
+
+	// Read the JSON string and convert it into our golang stuct else we need
+	// to send a `400 Bad Request` errror message back to the client,
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		return nil, httperror.NewForSingleField(http.StatusBadRequest, "non_field_error", "payload structure is wrong")
+	}
+
+	e := make(map[string]string)
+	if requestData.StoreID.IsZero() {
+		e["store_id"] = "missing value"
+	}
+	if requireOffer && requestData.OfferID.IsZero() {
+		e["offer_id"] = "missing value"
+	}
+	if len(e) != 0 {
+		return nil, httperror.NewForBadRequest(&e)
+	}
+	return &requestData, nil
+}
+
+func (h *Handler) CreateStripeSubscriptionCheckoutSessionURL(w http.ResponseWriter, r *http.Request, offerIDString string) {
+	ctx := r.Context()
+
+	offerID, err := primitive.ObjectIDFromHex(offerIDString)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	checkoutSessionURL, err := h.Controller.CreateStripeSubscriptionCheckoutSessionURL(ctx, offerID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	// Create temporary structure
+	res := &CreateStripeCheckoutSessionURLForComicSubmissionIDResponseIDO{
+		CheckoutSessionURL: checkoutSessionURL,
+	}
+
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) CancelStoreSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	data, err := UnmarshalStoreSubscriptionRequest(r, false)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	s, err := h.Controller.CancelStoreSubscription(ctx, data.StoreID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalStoreResponse(s, w)
+}
+
+func (h *Handler) ChangeStoreSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	data, err := UnmarshalStoreSubscriptionRequest(r, true)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	s, err := h.Controller.ChangeStoreSubscription(ctx, data.StoreID, data.OfferID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalStoreResponse(s, w)
+}
+
+func MarshalStoreResponse(res *org_s.Store, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -11,6 +11,7 @@ import (
 	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/datastore"
 	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/currency"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
 )
 
@@ -28,6 +29,7 @@ type ReceiptControllerImpl struct {
 	Config        *config.Conf
 	Logger        *slog.Logger
 	UUID          uuid.Provider
+	Currency      currency.Provider
 	DbClient      *mongo.Client
 	StoreStorer   store_s.StoreStorer
 	ReceiptStorer domain.ReceiptStorer
@@ -37,6 +39,7 @@ func NewController(
 	appCfg *config.Conf,
 	loggerp *slog.Logger,
 	uuidp uuid.Provider,
+	currencyp currency.Provider,
 	client *mongo.Client,
 	org_storer store_s.StoreStorer,
 	sub_storer domain.ReceiptStorer,
@@ -45,6 +48,7 @@ func NewController(
 		Config:        appCfg,
 		Logger:        loggerp,
 		UUID:          uuidp,
+		Currency:      currencyp,
 		DbClient:      client,
 		StoreStorer:   org_storer,
 		ReceiptStorer: sub_storer,
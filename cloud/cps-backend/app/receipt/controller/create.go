@@ -3,29 +3,26 @@ package controller
 import (
 	"context"
 	"log/slog"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	s_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/datastore"
-	u_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (c *ReceiptControllerImpl) Create(ctx context.Context, m *s_d.Receipt) (*s_d.Receipt, error) {
 	// Extract from our session the following data.
-	urole := ctx.Value(constants.SessionUserRole).(int8)
 	// uid := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
 	// uname := ctx.Value(constants.SessionUserName).(string)
 	oid := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
 	oname := ctx.Value(constants.SessionUserStoreName).(string)
 
-	switch urole { // Security.
-	case u_d.UserRoleRoot:
-		c.Logger.Debug("access granted")
-	default:
-		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	if err := permission.Require(ctx, permission.ReceiptManage); err != nil { // Security.
+		return nil, err
 	}
 
 	// Add defaults.
@@ -40,6 +37,24 @@ func (c *ReceiptControllerImpl) Create(ctx context.Context, m *s_d.Receipt) (*s_
 	// m.ModifiedByUserName = uname
 	m.Status = s_d.StatusActive
 
+	// The amount in our base currency is always computed by us, never trust
+	// the base amount or exchange rate sent by the client.
+	m.Currency = strings.ToUpper(strings.TrimSpace(m.Currency))
+	if m.Currency == "" {
+		m.Currency = c.Currency.BaseCurrency()
+	}
+	rate, err := c.Currency.ExchangeRate(m.Currency)
+	if err != nil {
+		return nil, httperror.NewForBadRequestWithSingleField("currency", "currency is not supported")
+	}
+	baseAmountTotal, err := c.Currency.ConvertToBase(m.AmountTotal, m.Currency)
+	if err != nil {
+		return nil, err
+	}
+	m.BaseCurrency = c.Currency.BaseCurrency()
+	m.BaseAmountTotal = baseAmountTotal
+	m.ExchangeRate = rate
+
 	// Save to our database.
 	if err := c.ReceiptStorer.Create(ctx, m); err != nil {
 		c.Logger.Error("database create error", slog.Any("error", err))
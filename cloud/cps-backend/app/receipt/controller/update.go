@@ -9,24 +9,20 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/datastore"
-	u_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (c *ReceiptControllerImpl) UpdateByID(ctx context.Context, ns *domain.Receipt) (*domain.Receipt, error) {
 	// Extract from our session the following data.
-	urole := ctx.Value(constants.SessionUserRole).(int8)
 	// uid := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
 	// uname := ctx.Value(constants.SessionUserName).(string)
 	oid := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
 	oname := ctx.Value(constants.SessionUserStoreName).(string)
 
-	switch urole { // Security.
-	case u_d.UserRoleRoot:
-		c.Logger.Debug("access granted")
-	default:
-		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	if err := permission.Require(ctx, permission.ReceiptManage); err != nil { // Security.
+		return nil, err
 	}
 
 	// Fetch the original store.
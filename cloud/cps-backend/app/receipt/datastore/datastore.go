@@ -41,6 +41,14 @@ type Receipt struct {
 
 	PaymentProcessorPurchaseID  string    `bson:"payment_processor_purchase_id" json:"payment_processor_purchase_id"`
 	PaymentProcessorPurchasedAt time.Time `bson:"payment_processor_purchased_at" json:"payment_processor_purchased_at"`
+
+	// AmountTotal is the amount charged in the `Currency` the customer paid in.
+	AmountTotal float64 `bson:"amount_total" json:"amount_total"`
+	Currency    string  `bson:"currency" json:"currency"`
+	// BaseAmountTotal is the `AmountTotal` converted into our `BaseCurrency` using the `ExchangeRate` at the time of purchase.
+	BaseAmountTotal float64 `bson:"base_amount_total" json:"base_amount_total"`
+	BaseCurrency    string  `bson:"base_currency" json:"base_currency"`
+	ExchangeRate    float64 `bson:"exchange_rate" json:"exchange_rate"`
 }
 
 type StripeReceipt struct {
@@ -84,6 +92,8 @@ type ReceiptStorer interface {
 	GetByPaymentProcessorReceiptID(ctx context.Context, paymentProcessorReceiptID string) (*Receipt, error)
 	GetByPaymentProcessorPurchaseID(ctx context.Context, paymentProcessorPurchaseID string) (*Receipt, error)
 	UpdateByID(ctx context.Context, m *Receipt) error
+	UpdateStoreByStoreID(ctx context.Context, storeID primitive.ObjectID, storeName string) (int64, error)
+	UpdateUserNameByUserID(ctx context.Context, userID primitive.ObjectID, userName, userLexicalName string) (int64, error)
 	ListByFilter(ctx context.Context, m *ReceiptPaginationListFilter) (*ReceiptPaginationListResult, error)
 	ListAsSelectOptionByFilter(ctx context.Context, f *ReceiptPaginationListFilter) ([]*ReceiptAsSelectOption, error)
 	DeleteByID(ctx context.Context, id primitive.ObjectID) error
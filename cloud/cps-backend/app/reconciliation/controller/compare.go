@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/stripe/stripe-go/v75"
+
+	submission_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
+	r_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/datastore"
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/reconciliation/datastore"
+	up_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/datastore"
+)
+
+// reconciliationRecord groups everything we know about a single purchase so
+// it can be compared against what the payment processor charged.
+type reconciliationRecord struct {
+	PaymentProcessorPurchaseID string
+	Submission                 *submission_s.ComicSubmission
+	Receipt                    *r_s.Receipt
+	UserPurchase               *up_s.UserPurchase
+	Charge                     *stripe.PaymentIntent // Nil if the payment processor has no successful charge.
+}
+
+// collectProcessorCharges returns every successful submission charge the
+// payment processor made within the time window.
+func (impl *ReconciliationControllerImpl) collectProcessorCharges(periodStart, periodEnd time.Time) ([]*stripe.PaymentIntent, error) {
+	pis, err := impl.PaymentProcessor.ListPaymentIntentsByCreatedRange(periodStart, periodEnd)
+	if err != nil {
+		impl.Logger.Error("payment processor list payment intents error", slog.Any("error", err))
+		return nil, err
+	}
+
+	charges := []*stripe.PaymentIntent{}
+	for _, pi := range pis {
+		// DEVELOPERS NOTE: Only successful payment intents have actually
+		// moved money so we ignore every other state.
+		if pi.Status != stripe.PaymentIntentStatusSucceeded {
+			continue
+		}
+		// Subscription payments are backed by an invoice and grant credits
+		// instead of paying for a submission, so they never have one.
+		if pi.Invoice != nil {
+			continue
+		}
+		charges = append(charges, pi)
+	}
+	return charges, nil
+}
+
+// buildDiscrepancies compares each record against the payment processor's
+// charge and returns every problem found.
+func buildDiscrepancies(records []*reconciliationRecord) []*domain.ReconciliationDiscrepancy {
+	discrepancies := []*domain.ReconciliationDiscrepancy{}
+	for _, rec := range records {
+		d := &domain.ReconciliationDiscrepancy{
+			PaymentProcessorPurchaseID: rec.PaymentProcessorPurchaseID,
+		}
+		if rec.Charge != nil {
+			d.ProcessorAmount = fromStripeFormat(rec.Charge.AmountReceived)
+			d.Currency = string(rec.Charge.Currency)
+		}
+		if rec.Submission != nil {
+			d.ComicSubmissionID = rec.Submission.ID
+			d.CPSRN = rec.Submission.CPSRN
+			d.StoreID = rec.Submission.StoreID
+			d.StoreName = rec.Submission.StoreName
+			d.RecordedAmount = rec.Submission.AmountTotal
+		}
+		if rec.Receipt != nil && rec.Receipt.Currency != "" {
+			d.RecordedCurrency = rec.Receipt.Currency
+		} else if rec.UserPurchase != nil {
+			d.RecordedCurrency = rec.UserPurchase.Currency
+		}
+
+		// CASE 1: Payment processor charged for something we do not know about.
+		if rec.Submission == nil {
+			if rec.Charge != nil {
+				o := *d
+				o.Type = domain.DiscrepancyTypeOrphanCharge
+				o.Message = "payment processor charge has no comic submission"
+				discrepancies = append(discrepancies, &o)
+			}
+			continue
+		}
+
+		// CASE 2: We recorded a purchase the payment processor never charged.
+		if rec.Charge == nil {
+			o := *d
+			o.Type = domain.DiscrepancyTypeMissingCharge
+			o.Message = "comic submission is purchased but payment processor has no successful charge"
+			discrepancies = append(discrepancies, &o)
+		} else if d.RecordedCurrency != "" && !strings.EqualFold(d.Currency, d.RecordedCurrency) {
+			// CASE 3: Both sides exist but disagree on the currency, the
+			// amounts cannot be compared.
+			o := *d
+			o.Type = domain.DiscrepancyTypeAmountMismatch
+			o.Message = fmt.Sprintf("payment processor charged %.2f %v but we recorded %.2f %v",
+				d.ProcessorAmount, strings.ToUpper(d.Currency), d.RecordedAmount, strings.ToUpper(d.RecordedCurrency))
+			discrepancies = append(discrepancies, &o)
+		} else if math.Abs(d.ProcessorAmount-d.RecordedAmount) >= 0.01 {
+			// CASE 3: Both sides exist but disagree on the amount.
+			o := *d
+			o.Type = domain.DiscrepancyTypeAmountMismatch
+			o.Message = fmt.Sprintf("payment processor charged %.2f but we recorded %.2f", d.ProcessorAmount, d.RecordedAmount)
+			discrepancies = append(discrepancies, &o)
+		}
+
+		// CASE 4: Our own bookkeeping records are incomplete.
+		if rec.Receipt == nil {
+			o := *d
+			o.Type = domain.DiscrepancyTypeMissingReceipt
+			o.Message = "comic submission is purchased but has no receipt"
+			discrepancies = append(discrepancies, &o)
+		}
+		if rec.UserPurchase == nil {
+			o := *d
+			o.Type = domain.DiscrepancyTypeMissingUserPurchase
+			o.Message = "comic submission is purchased but has no user purchase"
+			discrepancies = append(discrepancies, &o)
+		}
+	}
+	return discrepancies
+}
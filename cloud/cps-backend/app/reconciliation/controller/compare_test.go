@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stripe/stripe-go/v75"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	pm "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/paymentprocessor/stripe"
+	submission_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
+	r_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/datastore"
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/reconciliation/datastore"
+	up_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/datastore"
+)
+
+func TestCollectProcessorCharges(t *testing.T) {
+	periodStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 0, 1)
+
+	pp := new(pm.MockPaymentProcessor)
+	pp.On("ListPaymentIntentsByCreatedRange", periodStart, periodEnd).Return([]*stripe.PaymentIntent{
+		{ID: "pi_1", Status: stripe.PaymentIntentStatusSucceeded, AmountReceived: 1000},
+		{ID: "pi_2", Status: stripe.PaymentIntentStatusCanceled},
+		{ID: "pi_3", Status: stripe.PaymentIntentStatusSucceeded, AmountReceived: 2500},
+	}, nil)
+
+	impl := &ReconciliationControllerImpl{
+		Logger:           slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		PaymentProcessor: pp,
+	}
+	charges, err := impl.collectProcessorCharges(periodStart, periodEnd)
+	if err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if len(charges) != 2 {
+		t.Fatalf("expected 2 charges but got %v", len(charges))
+	}
+	if charges[0].ID != "pi_1" || charges[1].ID != "pi_3" {
+		t.Errorf("unexpected charges %v and %v", charges[0].ID, charges[1].ID)
+	}
+	pp.AssertExpectations(t)
+}
+
+func TestBuildDiscrepancies(t *testing.T) {
+	cs := func(purchaseID string, amount float64) *submission_s.ComicSubmission {
+		return &submission_s.ComicSubmission{ID: primitive.NewObjectID(), PaymentProcessorPurchaseID: purchaseID, AmountTotal: amount}
+	}
+	charge := func(id string, amount int64) *stripe.PaymentIntent {
+		return &stripe.PaymentIntent{ID: id, Status: stripe.PaymentIntentStatusSucceeded, AmountReceived: amount, Currency: stripe.CurrencyCAD}
+	}
+
+	tests := []struct {
+		name     string
+		record   *reconciliationRecord
+		expected []int8
+	}{
+		{
+			name:     "matching",
+			record:   &reconciliationRecord{PaymentProcessorPurchaseID: "pi_1", Submission: cs("pi_1", 10), Receipt: &r_s.Receipt{}, UserPurchase: &up_s.UserPurchase{}, Charge: charge("pi_1", 1000)},
+			expected: []int8{},
+		},
+		{
+			name:     "orphan charge",
+			record:   &reconciliationRecord{PaymentProcessorPurchaseID: "pi_2", Charge: charge("pi_2", 1000)},
+			expected: []int8{domain.DiscrepancyTypeOrphanCharge},
+		},
+		{
+			name:     "amount mismatch",
+			record:   &reconciliationRecord{PaymentProcessorPurchaseID: "pi_3", Submission: cs("pi_3", 10), Receipt: &r_s.Receipt{}, UserPurchase: &up_s.UserPurchase{}, Charge: charge("pi_3", 1500)},
+			expected: []int8{domain.DiscrepancyTypeAmountMismatch},
+		},
+		{
+			name:     "currency mismatch",
+			record:   &reconciliationRecord{PaymentProcessorPurchaseID: "pi_6", Submission: cs("pi_6", 10), Receipt: &r_s.Receipt{Currency: "USD"}, UserPurchase: &up_s.UserPurchase{Currency: "USD"}, Charge: charge("pi_6", 1000)},
+			expected: []int8{domain.DiscrepancyTypeAmountMismatch},
+		},
+		{
+			name:     "same currency in another case",
+			record:   &reconciliationRecord{PaymentProcessorPurchaseID: "pi_7", Submission: cs("pi_7", 10), Receipt: &r_s.Receipt{Currency: "CAD"}, UserPurchase: &up_s.UserPurchase{Currency: "CAD"}, Charge: charge("pi_7", 1000)},
+			expected: []int8{},
+		},
+		{
+			name:     "missing receipt and user purchase",
+			record:   &reconciliationRecord{PaymentProcessorPurchaseID: "pi_4", Submission: cs("pi_4", 10), Charge: charge("pi_4", 1000)},
+			expected: []int8{domain.DiscrepancyTypeMissingReceipt, domain.DiscrepancyTypeMissingUserPurchase},
+		},
+		{
+			name:     "missing charge",
+			record:   &reconciliationRecord{PaymentProcessorPurchaseID: "pi_5", Submission: cs("pi_5", 10), Receipt: &r_s.Receipt{}, UserPurchase: &up_s.UserPurchase{}},
+			expected: []int8{domain.DiscrepancyTypeMissingCharge},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := buildDiscrepancies([]*reconciliationRecord{tt.record})
+			if len(actual) != len(tt.expected) {
+				t.Fatalf("expected %v discrepancies but got %v", len(tt.expected), len(actual))
+			}
+			for i, d := range actual {
+				if d.Type != tt.expected[i] {
+					t.Errorf("expected type %v but got %v", tt.expected[i], d.Type)
+				}
+				if d.PaymentProcessorPurchaseID != tt.record.PaymentProcessorPurchaseID {
+					t.Errorf("expected purchase id %v but got %v", tt.record.PaymentProcessorPurchaseID, d.PaymentProcessorPurchaseID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	pm "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/paymentprocessor/stripe"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
+	submission_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
+	r_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/datastore"
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/reconciliation/datastore"
+	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	up_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/kmutex"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
+)
+
+// ReconciliationController Interface for payment reconciliation business logic controller.
+type ReconciliationController interface {
+	Create(ctx context.Context, req *ReconciliationCreateRequest) (*domain.ReconciliationReport, error)
+	RunNightly(ctx context.Context) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*domain.ReconciliationReport, error)
+	ListByFilter(ctx context.Context, f *domain.ReconciliationReportPaginationListFilter) (*domain.ReconciliationReportPaginationListResult, error)
+}
+
+type ReconciliationControllerImpl struct {
+	Config                     *config.Conf
+	Logger                     *slog.Logger
+	UUID                       uuid.Provider
+	Kmutex                     kmutex.Provider
+	TemplatedEmailer           templatedemailer.TemplatedEmailer
+	PaymentProcessor           pm.PaymentProcessor
+	DbClient                   *mongo.Client
+	UserStorer                 user_s.UserStorer
+	ReceiptStorer              r_s.ReceiptStorer
+	UserPurchaseStorer         up_s.UserPurchaseStorer
+	ComicSubmissionStorer      submission_s.ComicSubmissionStorer
+	ReconciliationReportStorer domain.ReconciliationReportStorer
+}
+
+func NewController(
+	appCfg *config.Conf,
+	loggerp *slog.Logger,
+	uuidp uuid.Provider,
+	kmux kmutex.Provider,
+	te templatedemailer.TemplatedEmailer,
+	paymentProcessor pm.PaymentProcessor,
+	client *mongo.Client,
+	usr_storer user_s.UserStorer,
+	r_storer r_s.ReceiptStorer,
+	up_storer up_s.UserPurchaseStorer,
+	sub_storer submission_s.ComicSubmissionStorer,
+	rr_storer domain.ReconciliationReportStorer,
+) ReconciliationController {
+	loggerp.Debug("reconciliation controller initialization started...")
+	s := &ReconciliationControllerImpl{
+		Config:                     appCfg,
+		Logger:                     loggerp,
+		UUID:                       uuidp,
+		Kmutex:                     kmux,
+		TemplatedEmailer:           te,
+		PaymentProcessor:           paymentProcessor,
+		DbClient:                   client,
+		UserStorer:                 usr_storer,
+		ReceiptStorer:              r_storer,
+		UserPurchaseStorer:         up_storer,
+		ComicSubmissionStorer:      sub_storer,
+		ReconciliationReportStorer: rr_storer,
+	}
+	s.Logger.Debug("reconciliation controller initialized")
+	return s
+}
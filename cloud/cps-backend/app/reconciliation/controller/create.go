@@ -0,0 +1,246 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/stripe/stripe-go/v75"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	submission_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/reconciliation/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+// maxReconciliationPeriod is the longest window staff may reconcile at once
+// so we do not overwhelm the payment processor's API.
+const maxReconciliationPeriod = 31 * 24 * time.Hour
+
+type ReconciliationCreateRequest struct {
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+}
+
+// Create function runs the reconciliation immediately for the requested
+// period and returns the generated report.
+func (impl *ReconciliationControllerImpl) Create(ctx context.Context, req *ReconciliationCreateRequest) (*domain.ReconciliationReport, error) {
+	// Extract from our session the following data.
+	uid := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	uname := ctx.Value(constants.SessionUserName).(string)
+
+	if err := permission.Require(ctx, permission.ReconciliationManage); err != nil { // Security.
+		return nil, err
+	}
+
+	// Default to the same window used by the nightly job.
+	if req.PeriodStart.IsZero() && req.PeriodEnd.IsZero() {
+		req.PeriodStart, req.PeriodEnd = nightlyPeriod(time.Now())
+	}
+	if req.PeriodEnd.IsZero() {
+		req.PeriodEnd = time.Now().UTC()
+	}
+
+	e := make(map[string]string)
+	if req.PeriodStart.IsZero() {
+		e["period_start"] = "missing value"
+	}
+	if !req.PeriodStart.IsZero() && !req.PeriodStart.Before(req.PeriodEnd) {
+		e["period_end"] = "must be after period start"
+	}
+	if req.PeriodEnd.Sub(req.PeriodStart) > maxReconciliationPeriod {
+		e["period_end"] = "period cannot be longer then 31 days"
+	}
+	if len(e) != 0 {
+		return nil, httperror.NewForBadRequest(&e)
+	}
+
+	return impl.run(ctx, req.PeriodStart, req.PeriodEnd, uid, uname)
+}
+
+// RunNightly function reconciles the previous day and is intended to be
+// executed by the scheduler.
+func (impl *ReconciliationControllerImpl) RunNightly(ctx context.Context) error {
+	periodStart, periodEnd := nightlyPeriod(time.Now())
+	_, err := impl.run(ctx, periodStart, periodEnd, primitive.NilObjectID, "System")
+	return err
+}
+
+// nightlyPeriod returns the start and end of the previous day in UTC.
+func nightlyPeriod(now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	periodEnd := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return periodEnd.AddDate(0, 0, -1), periodEnd
+}
+
+func (impl *ReconciliationControllerImpl) run(ctx context.Context, periodStart, periodEnd time.Time, uid primitive.ObjectID, uname string) (*domain.ReconciliationReport, error) {
+	// DEVELOPERS NOTE: Only one reconciliation may run at a time.
+	impl.Kmutex.Lock("reconciliation")
+	defer impl.Kmutex.Unlock("reconciliation")
+
+	impl.Logger.Debug("reconciliation starting...",
+		slog.Time("period_start", periodStart),
+		slog.Time("period_end", periodEnd))
+
+	report := &domain.ReconciliationReport{
+		ID:                   primitive.NewObjectID(),
+		Status:               domain.StatusRunning,
+		PeriodStart:          periodStart,
+		PeriodEnd:            periodEnd,
+		PaymentProcessorName: impl.PaymentProcessor.GetName(),
+		Discrepancies:        []*domain.ReconciliationDiscrepancy{},
+		CreatedAt:            time.Now(),
+		CreatedByUserID:      uid,
+		CreatedByUserName:    uname,
+		ModifiedAt:           time.Now(),
+	}
+	if err := impl.ReconciliationReportStorer.Create(ctx, report); err != nil {
+		impl.Logger.Error("database create error", slog.Any("error", err))
+		return nil, err
+	}
+
+	charges, err := impl.collectProcessorCharges(periodStart, periodEnd)
+	if err != nil {
+		impl.failReport(ctx, report, err)
+		return nil, err
+	}
+
+	records, submissionCount, err := impl.gatherRecords(ctx, periodStart, periodEnd, charges)
+	if err != nil {
+		impl.failReport(ctx, report, err)
+		return nil, err
+	}
+
+	report.ChargeCount = int64(len(charges))
+	report.SubmissionCount = submissionCount
+	for _, pi := range charges {
+		report.ProcessorAmountTotal += fromStripeFormat(pi.AmountReceived)
+	}
+	for _, rec := range records {
+		if rec.Submission != nil {
+			report.RecordedAmountTotal += rec.Submission.AmountTotal
+		}
+	}
+	report.ProcessorAmountTotal = toFixed(report.ProcessorAmountTotal, 2)
+	report.RecordedAmountTotal = toFixed(report.RecordedAmountTotal, 2)
+	report.Discrepancies = buildDiscrepancies(records)
+	report.DiscrepancyCount = int64(len(report.Discrepancies))
+	report.Status = domain.StatusCompleted
+	report.CompletedAt = time.Now()
+	report.ModifiedAt = time.Now()
+	if err := impl.ReconciliationReportStorer.UpdateByID(ctx, report); err != nil {
+		impl.Logger.Error("database update error", slog.Any("error", err))
+		return nil, err
+	}
+
+	impl.Logger.Debug("reconciliation finished",
+		slog.Any("report_id", report.ID),
+		slog.Int64("charge_count", report.ChargeCount),
+		slog.Int64("submission_count", report.SubmissionCount),
+		slog.Int64("discrepancy_count", report.DiscrepancyCount))
+
+	// Staff should be notified but a failed email must not fail the report.
+	if err := impl.sendReportEmails(ctx, report); err != nil {
+		impl.Logger.Error("failed sending reconciliation report emails", slog.Any("error", err))
+	}
+
+	return report, nil
+}
+
+// gatherRecords pairs every purchased submission in the period with its
+// receipt, user purchase and charge; afterwards it appends every charge
+// which could not be paired.
+func (impl *ReconciliationControllerImpl) gatherRecords(ctx context.Context, periodStart, periodEnd time.Time, charges []*stripe.PaymentIntent) ([]*reconciliationRecord, int64, error) {
+	chargeByID := make(map[string]*stripe.PaymentIntent, len(charges))
+	for _, pi := range charges {
+		chargeByID[pi.ID] = pi
+	}
+
+	submissions, err := impl.ComicSubmissionStorer.ListAllPurchasedBetween(ctx, periodStart, periodEnd)
+	if err != nil {
+		impl.Logger.Error("database list all purchased between error", slog.Any("error", err))
+		return nil, 0, err
+	}
+
+	records := []*reconciliationRecord{}
+	seen := make(map[string]bool)
+	for _, cs := range submissions {
+		rec, err := impl.newRecord(ctx, cs)
+		if err != nil {
+			return nil, 0, err
+		}
+		if pi, ok := chargeByID[cs.PaymentProcessorPurchaseID]; ok {
+			rec.Charge = pi
+		} else {
+			// DEVELOPERS NOTE: The charge may have been created just outside
+			// our window so we confirm directly with the payment processor.
+			pi, err := impl.PaymentProcessor.GetPaymentIntent(cs.PaymentProcessorPurchaseID)
+			if err != nil {
+				impl.Logger.Warn("payment processor get payment intent error",
+					slog.String("payment_intent_id", cs.PaymentProcessorPurchaseID),
+					slog.Any("error", err))
+			} else if pi != nil && pi.Status == stripe.PaymentIntentStatusSucceeded {
+				rec.Charge = pi
+			}
+		}
+		seen[cs.PaymentProcessorPurchaseID] = true
+		records = append(records, rec)
+	}
+
+	for _, pi := range charges {
+		if seen[pi.ID] {
+			continue
+		}
+		// DEVELOPERS NOTE: The submission may have been recorded just outside
+		// our window so we look it up before calling it an orphan.
+		cs, err := impl.ComicSubmissionStorer.GetByPaymentProcessorPurchaseID(ctx, pi.ID)
+		if err != nil {
+			impl.Logger.Error("database get by payment processor purchase id error", slog.Any("error", err))
+			return nil, 0, err
+		}
+		rec := &reconciliationRecord{PaymentProcessorPurchaseID: pi.ID}
+		if cs != nil {
+			rec, err = impl.newRecord(ctx, cs)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+		rec.Charge = pi
+		records = append(records, rec)
+	}
+
+	return records, int64(len(submissions)), nil
+}
+
+// newRecord looks up our own bookkeeping records for the submission.
+func (impl *ReconciliationControllerImpl) newRecord(ctx context.Context, cs *submission_s.ComicSubmission) (*reconciliationRecord, error) {
+	r, err := impl.ReceiptStorer.GetByPaymentProcessorPurchaseID(ctx, cs.PaymentProcessorPurchaseID)
+	if err != nil {
+		impl.Logger.Error("database get receipt error", slog.Any("error", err))
+		return nil, err
+	}
+	up, err := impl.UserPurchaseStorer.GetByPaymentProcessorPurchaseID(ctx, cs.PaymentProcessorPurchaseID)
+	if err != nil {
+		impl.Logger.Error("database get user purchase error", slog.Any("error", err))
+		return nil, err
+	}
+	return &reconciliationRecord{
+		PaymentProcessorPurchaseID: cs.PaymentProcessorPurchaseID,
+		Submission:                 cs,
+		Receipt:                    r,
+		UserPurchase:               up,
+	}, nil
+}
+
+// failReport marks the report as errored so staff can see the job did not
+// complete.
+func (impl *ReconciliationControllerImpl) failReport(ctx context.Context, report *domain.ReconciliationReport, reason error) {
+	report.Status = domain.StatusError
+	report.Error = reason.Error()
+	report.ModifiedAt = time.Now()
+	if err := impl.ReconciliationReportStorer.UpdateByID(ctx, report); err != nil {
+		impl.Logger.Error("database update error", slog.Any("error", err))
+	}
+}
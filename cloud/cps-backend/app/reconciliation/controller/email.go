@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/reconciliation/datastore"
+)
+
+func (impl *ReconciliationControllerImpl) sendReportEmails(ctx context.Context, m *domain.ReconciliationReport) error {
+	impl.Logger.Debug("sending to root staff",
+		slog.Any("report-id", m.ID))
+
+	response, err := impl.UserStorer.ListAllRootStaff(ctx)
+	if err != nil {
+		impl.Logger.Error("database list all staff error",
+			slog.Any("report-id", m.ID),
+			slog.Any("error", err))
+		return err
+	}
+
+	recipients := []templatedemailer.Recipient{}
+	for _, u := range response.Results {
+		recipients = append(recipients, templatedemailer.Recipient{Email: u.Email, Locale: u.Locale})
+	}
+
+	counts := make(map[int8]int64)
+	for _, d := range m.Discrepancies {
+		counts[d.Type]++
+	}
+
+	period := fmt.Sprintf("%v to %v", m.PeriodStart.Format("2006-01-02 15:04 MST"), m.PeriodEnd.Format("2006-01-02 15:04 MST"))
+
+	if err := impl.TemplatedEmailer.SendReconciliationReportEmailToStaff(
+		ctx,
+		recipients,
+		m.ID.Hex(),
+		period,
+		m.ChargeCount,
+		m.SubmissionCount,
+		counts[domain.DiscrepancyTypeMissingReceipt],
+		counts[domain.DiscrepancyTypeMissingUserPurchase],
+		counts[domain.DiscrepancyTypeAmountMismatch],
+		counts[domain.DiscrepancyTypeOrphanCharge],
+		counts[domain.DiscrepancyTypeMissingCharge],
+		m.DiscrepancyCount,
+	); err != nil {
+		impl.Logger.Error("send reconciliation report email to staff error",
+			slog.Any("report-id", m.ID),
+			slog.Any("error", err))
+		return err
+	}
+	return nil
+}
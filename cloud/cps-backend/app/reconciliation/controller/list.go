@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"context"
+
+	"log/slog"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/reconciliation/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+func (c *ReconciliationControllerImpl) ListByFilter(ctx context.Context, f *domain.ReconciliationReportPaginationListFilter) (*domain.ReconciliationReportPaginationListResult, error) {
+	if !permission.Can(ctx, permission.ReconciliationManage) {
+		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+
+	c.Logger.Debug("listing using filter options:",
+		slog.Any("Status", f.Status),
+		slog.Any("Cursor", f.Cursor),
+		slog.Int64("PageSize", f.PageSize),
+		slog.String("SortField", f.SortField),
+		slog.Int("SortOrder", int(f.SortOrder)))
+
+	m, err := c.ReconciliationReportStorer.ListByFilter(ctx, f)
+	if err != nil {
+		c.Logger.Error("database list by filter error", slog.Any("error", err))
+		return nil, err
+	}
+	return m, err
+}
@@ -0,0 +1,119 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+const (
+	StatusRunning   = 1
+	StatusCompleted = 2
+	StatusError     = 3
+	// DiscrepancyTypeMissingReceipt indicates a purchased submission has no `Receipt` on record.
+	DiscrepancyTypeMissingReceipt = 1
+	// DiscrepancyTypeMissingUserPurchase indicates a purchased submission has no `UserPurchase` on record.
+	DiscrepancyTypeMissingUserPurchase = 2
+	// DiscrepancyTypeAmountMismatch indicates the amount we recorded differs from the amount the payment processor charged.
+	DiscrepancyTypeAmountMismatch = 3
+	// DiscrepancyTypeOrphanCharge indicates the payment processor charged for something which has no submission in our system.
+	DiscrepancyTypeOrphanCharge = 4
+	// DiscrepancyTypeMissingCharge indicates we recorded a purchase which the payment processor has no successful charge for.
+	DiscrepancyTypeMissingCharge = 5
+)
+
+// DiscrepancyTypeMap provides a human readable label for every discrepancy type.
+var DiscrepancyTypeMap = map[int8]string{
+	DiscrepancyTypeMissingReceipt:      "Missing receipt",
+	DiscrepancyTypeMissingUserPurchase: "Missing user purchase",
+	DiscrepancyTypeAmountMismatch:      "Amount mismatch",
+	DiscrepancyTypeOrphanCharge:        "Orphan charge",
+	DiscrepancyTypeMissingCharge:       "Missing charge",
+}
+
+// ReconciliationReport represents the results of comparing our purchase
+// records with the payment processor's charges over a period of time.
+type ReconciliationReport struct {
+	ID                   primitive.ObjectID           `bson:"_id" json:"id"`
+	Status               int8                         `bson:"status" json:"status"`
+	PeriodStart          time.Time                    `bson:"period_start" json:"period_start"`
+	PeriodEnd            time.Time                    `bson:"period_end" json:"period_end"`
+	PaymentProcessorName string                       `bson:"payment_processor_name" json:"payment_processor_name"`
+	ChargeCount          int64                        `bson:"charge_count" json:"charge_count"`
+	SubmissionCount      int64                        `bson:"submission_count" json:"submission_count"`
+	ProcessorAmountTotal float64                      `bson:"processor_amount_total" json:"processor_amount_total"`
+	RecordedAmountTotal  float64                      `bson:"recorded_amount_total" json:"recorded_amount_total"`
+	DiscrepancyCount     int64                        `bson:"discrepancy_count" json:"discrepancy_count"`
+	Discrepancies        []*ReconciliationDiscrepancy `bson:"discrepancies" json:"discrepancies"`
+	Error                string                       `bson:"error" json:"error,omitempty"`
+	CreatedAt            time.Time                    `bson:"created_at,omitempty" json:"created_at,omitempty"`
+	CreatedByUserID      primitive.ObjectID           `bson:"created_by_user_id,omitempty" json:"created_by_user_id,omitempty"`
+	CreatedByUserName    string                       `bson:"created_by_user_name" json:"created_by_user_name"`
+	ModifiedAt           time.Time                    `bson:"modified_at,omitempty" json:"modified_at,omitempty"`
+	CompletedAt          time.Time                    `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+}
+
+// ReconciliationDiscrepancy represents a single problem detected while
+// reconciling our records against the payment processor.
+type ReconciliationDiscrepancy struct {
+	Type                       int8               `bson:"type" json:"type"`
+	ComicSubmissionID          primitive.ObjectID `bson:"comic_submission_id,omitempty" json:"comic_submission_id,omitempty"`
+	CPSRN                      string             `bson:"cpsrn" json:"cpsrn"`
+	StoreID                    primitive.ObjectID `bson:"store_id,omitempty" json:"store_id,omitempty"`
+	StoreName                  string             `bson:"store_name" json:"store_name"`
+	PaymentProcessorPurchaseID string             `bson:"payment_processor_purchase_id" json:"payment_processor_purchase_id"`
+	ProcessorAmount            float64            `bson:"processor_amount" json:"processor_amount"`
+	RecordedAmount             float64            `bson:"recorded_amount" json:"recorded_amount"`
+	Currency                   string             `bson:"currency" json:"currency"`
+	RecordedCurrency           string             `bson:"recorded_currency" json:"recorded_currency"`
+	Message                    string             `bson:"message" json:"message"`
+}
+
+// ReconciliationReportStorer Interface for store.
+type ReconciliationReportStorer interface {
+	Create(ctx context.Context, m *ReconciliationReport) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*ReconciliationReport, error)
+	GetLatest(ctx context.Context) (*ReconciliationReport, error)
+	UpdateByID(ctx context.Context, m *ReconciliationReport) error
+	ListByFilter(ctx context.Context, m *ReconciliationReportPaginationListFilter) (*ReconciliationReportPaginationListResult, error)
+	DeleteByID(ctx context.Context, id primitive.ObjectID) error
+}
+
+type ReconciliationReportStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) ReconciliationReportStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("reconciliation_reports")
+
+	// The following few lines of code will create the index for our app for
+	// this colleciton.
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "period_start", Value: -1},
+		},
+	}
+	_, err := uc.Indexes().CreateOne(context.TODO(), indexModel)
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &ReconciliationReportStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
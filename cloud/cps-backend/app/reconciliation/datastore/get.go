@@ -0,0 +1,43 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (impl ReconciliationReportStorerImpl) GetByID(ctx context.Context, id primitive.ObjectID) (*ReconciliationReport, error) {
+	filter := bson.M{"_id": id}
+
+	var result ReconciliationReport
+	err := impl.Collection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// This error means your query did not match any documents.
+			return nil, nil
+		}
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (impl ReconciliationReportStorerImpl) GetLatest(ctx context.Context) (*ReconciliationReport, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "period_start", Value: -1}})
+
+	var result ReconciliationReport
+	err := impl.Collection.FindOne(ctx, bson.M{}, opts).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// This error means your query did not match any documents.
+			return nil, nil
+		}
+		impl.Logger.Error("database get latest error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
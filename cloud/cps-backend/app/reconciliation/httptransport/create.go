@@ -0,0 +1,51 @@
+package httptransport
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	reconciliation_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/reconciliation/controller"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+func UnmarshalCreateRequest(ctx context.Context, r *http.Request) (*reconciliation_c.ReconciliationCreateRequest, error) {
+	// Initialize our array which will store all the results from the remote server.
+	var requestData reconciliation_c.ReconciliationCreateRequest
+
+	defer r.Body.Close()
+
+	// DEVELOPERS NOTE: An empty body is allowed and will reconcile the
+	// previous day.
+	if r.ContentLength == 0 {
+		return &requestData, nil
+	}
+
+	// Read the JSON string and convert it into our golang stuct else we need
+	// to send a `400 Bad Request` errror message back to the client,
+	err := json.NewDecoder(r.Body).Decode(&requestData) // [1]
+	if err != nil {
+		log.Println(err)
+		return nil, httperror.NewForSingleField(http.StatusBadRequest, "non_field_error", "payload structure is wrong")
+	}
+	return &requestData, nil
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	data, err := UnmarshalCreateRequest(ctx, r)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	m, err := h.Controller.Create(ctx, data)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	MarshalDetailResponse(m, w)
+}
@@ -0,0 +1,21 @@
+package httptransport
+
+import (
+	"log/slog"
+
+	reconciliation_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/reconciliation/controller"
+)
+
+// Handler Creates http request handler
+type Handler struct {
+	Logger     *slog.Logger
+	Controller reconciliation_c.ReconciliationController
+}
+
+// NewHandler Constructor
+func NewHandler(loggerp *slog.Logger, c reconciliation_c.ReconciliationController) *Handler {
+	return &Handler{
+		Logger:     loggerp,
+		Controller: c,
+	}
+}
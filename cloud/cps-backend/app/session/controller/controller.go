@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/session/datastore"
+	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
+)
+
+// SessionController Interface for the logged in sessions business logic controller.
+type SessionController interface {
+	ListByUserID(ctx context.Context, userID primitive.ObjectID) ([]*domain.Session, error)
+	DeleteByID(ctx context.Context, id primitive.ObjectID) error
+	DeleteAllOthers(ctx context.Context) (int64, error)
+	DeleteAllByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error)
+}
+
+type SessionControllerImpl struct {
+	Config        *config.Conf
+	Logger        *slog.Logger
+	UUID          uuid.Provider
+	DbClient      *mongo.Client
+	UserStorer    user_s.UserStorer
+	SessionStorer domain.SessionStorer
+}
+
+func NewController(
+	appCfg *config.Conf,
+	loggerp *slog.Logger,
+	uuidp uuid.Provider,
+	client *mongo.Client,
+	usr_storer user_s.UserStorer,
+	ses_storer domain.SessionStorer,
+) SessionController {
+	loggerp.Debug("session controller initialization started...")
+	s := &SessionControllerImpl{
+		Config:        appCfg,
+		Logger:        loggerp,
+		UUID:          uuidp,
+		DbClient:      client,
+		UserStorer:    usr_storer,
+		SessionStorer: ses_storer,
+	}
+	s.Logger.Debug("session controller initialized")
+	return s
+}
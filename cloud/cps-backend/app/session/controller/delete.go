@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+// DeleteByID function revokes the session which logs out the device using
+// it. Users may revoke their own sessions and staff may revoke any session.
+func (impl *SessionControllerImpl) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
+	// Extract from our session the following data.
+	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+
+	s, err := impl.SessionStorer.GetByID(ctx, id)
+	if err != nil {
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return err
+	}
+	if s == nil {
+		return httperror.NewForBadRequestWithSingleField("id", "session does not exist")
+	}
+	if s.UserID != userID && !permission.Can(ctx, permission.SessionManage) {
+		impl.Logger.Warn("user does not have permission to revoke session of other user")
+		return httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+
+	if err := impl.SessionStorer.DeleteByID(ctx, id); err != nil {
+		impl.Logger.Error("database delete by id error", slog.Any("error", err))
+		return err
+	}
+	impl.Logger.Debug("revoked session",
+		slog.Any("session_id", id),
+		slog.Any("user_id", s.UserID))
+	return nil
+}
+
+// DeleteAllOthers function revokes all the logged in user's sessions except
+// the session making this request.
+func (impl *SessionControllerImpl) DeleteAllOthers(ctx context.Context) (int64, error) {
+	// Extract from our session the following data.
+	sessionID := ctx.Value(constants.SessionID).(string)
+	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+
+	count, err := impl.SessionStorer.DeleteAllByUserID(ctx, userID, sessionID)
+	if err != nil {
+		impl.Logger.Error("database delete all by user id error", slog.Any("error", err))
+		return 0, err
+	}
+	impl.Logger.Debug("revoked other sessions",
+		slog.Any("user_id", userID),
+		slog.Int64("count", count))
+	return count, nil
+}
+
+// DeleteAllByUserID function revokes every session of the user, for example
+// when the account was compromised. Only staff are allowed to do this.
+func (impl *SessionControllerImpl) DeleteAllByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	if !permission.Can(ctx, permission.SessionManage) {
+		impl.Logger.Warn("user does not have permission to revoke sessions of other users")
+		return 0, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+
+	u, err := impl.UserStorer.GetByID(ctx, userID)
+	if err != nil {
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return 0, err
+	}
+	if u == nil {
+		return 0, httperror.NewForBadRequestWithSingleField("user_id", "user does not exist")
+	}
+
+	count, err := impl.SessionStorer.DeleteAllByUserID(ctx, userID, "")
+	if err != nil {
+		impl.Logger.Error("database delete all by user id error", slog.Any("error", err))
+		return 0, err
+	}
+	impl.Logger.Debug("revoked all sessions of user",
+		slog.Any("user_id", userID),
+		slog.Int64("count", count))
+	return count, nil
+}
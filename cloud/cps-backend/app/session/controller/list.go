@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/session/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+// ListByUserID function returns the sessions of the user, if no user is
+// specified then the logged in user's sessions are returned. Only staff may
+// list the sessions of other users.
+func (impl *SessionControllerImpl) ListByUserID(ctx context.Context, userID primitive.ObjectID) ([]*domain.Session, error) {
+	// Extract from our session the following data.
+	sessionID := ctx.Value(constants.SessionID).(string)
+	currentUserID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+
+	if userID.IsZero() {
+		userID = currentUserID
+	}
+	if userID != currentUserID && !permission.Can(ctx, permission.SessionManage) {
+		impl.Logger.Warn("user does not have permission to list sessions of other users")
+		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+
+	res, err := impl.SessionStorer.ListByUserID(ctx, userID)
+	if err != nil {
+		impl.Logger.Error("database list by user id error", slog.Any("error", err))
+		return nil, err
+	}
+	for _, s := range res {
+		s.IsCurrent = s.SessionID == sessionID
+	}
+	return res, nil
+}
@@ -0,0 +1,94 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+// Session represents a device or browser the user is logged in with. The
+// session is only valid while this record exists, deleting it logs the user
+// out of that device.
+type Session struct {
+	ID primitive.ObjectID `bson:"_id" json:"id"`
+	// SessionID is the secret uuid embedded in the user's access and refresh tokens.
+	SessionID string `bson:"session_id" json:"-"`
+	// RefreshTokenID is the id of the only refresh token which may be used
+	// next, it changes every time the tokens are refreshed.
+	RefreshTokenID string `bson:"refresh_token_id" json:"-"`
+	// RefreshedAt is when the refresh token was last rotated.
+	RefreshedAt time.Time          `bson:"refreshed_at" json:"refreshed_at"`
+	UserID      primitive.ObjectID `bson:"user_id" json:"user_id"`
+	UserName    string             `bson:"user_name" json:"user_name"`
+	IPAddress   string             `bson:"ip_address" json:"ip_address"`
+	UserAgent   string             `bson:"user_agent" json:"user_agent"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	LastSeenAt  time.Time          `bson:"last_seen_at" json:"last_seen_at"`
+	// ExpiresAt is when the refresh token expires, mongodb deletes the record afterwards.
+	ExpiresAt time.Time `bson:"expires_at" json:"expires_at"`
+
+	// IsCurrent is set when listing to indicate the session making the request.
+	IsCurrent bool `bson:"-" json:"is_current"`
+}
+
+// SessionStorer Interface for session.
+type SessionStorer interface {
+	Create(ctx context.Context, m *Session) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*Session, error)
+	GetBySessionID(ctx context.Context, sessionID string) (*Session, error)
+	UpdateByID(ctx context.Context, m *Session) error
+	ListByUserID(ctx context.Context, userID primitive.ObjectID) ([]*Session, error)
+	DeleteByID(ctx context.Context, id primitive.ObjectID) error
+	DeleteBySessionID(ctx context.Context, sessionID string) error
+	// DeleteAllByUserID deletes every session of the user except the
+	// `exceptSessionID` session, which may be empty, and returns the number deleted.
+	DeleteAllByUserID(ctx context.Context, userID primitive.ObjectID, exceptSessionID string) (int64, error)
+}
+
+type SessionStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) SessionStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("sessions")
+
+	// The following few lines of code will create the index for our app for
+	// this colleciton.
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "session_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "last_seen_at", Value: -1}},
+		},
+		{
+			// Have mongodb delete the expired sessions for us.
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &SessionStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
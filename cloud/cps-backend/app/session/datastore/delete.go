@@ -0,0 +1,40 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (impl SessionStorerImpl) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
+	_, err := impl.Collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		impl.Logger.Error("database delete by id error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+func (impl SessionStorerImpl) DeleteBySessionID(ctx context.Context, sessionID string) error {
+	_, err := impl.Collection.DeleteOne(ctx, bson.M{"session_id": sessionID})
+	if err != nil {
+		impl.Logger.Error("database delete by session id error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+func (impl SessionStorerImpl) DeleteAllByUserID(ctx context.Context, userID primitive.ObjectID, exceptSessionID string) (int64, error) {
+	filter := bson.M{"user_id": userID}
+	if exceptSessionID != "" {
+		filter["session_id"] = bson.M{"$ne": exceptSessionID}
+	}
+	res, err := impl.Collection.DeleteMany(ctx, filter)
+	if err != nil {
+		impl.Logger.Error("database delete all by user id error", slog.Any("error", err))
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
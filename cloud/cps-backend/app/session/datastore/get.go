@@ -0,0 +1,42 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (impl SessionStorerImpl) GetByID(ctx context.Context, id primitive.ObjectID) (*Session, error) {
+	filter := bson.M{"_id": id}
+
+	var result Session
+	err := impl.Collection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// This error means your query did not match any documents.
+			return nil, nil
+		}
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (impl SessionStorerImpl) GetBySessionID(ctx context.Context, sessionID string) (*Session, error) {
+	filter := bson.M{"session_id": sessionID}
+
+	var result Session
+	err := impl.Collection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// This error means your query did not match any documents.
+			return nil, nil
+		}
+		impl.Logger.Error("database get by session id error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,21 @@
+package httptransport
+
+import (
+	"log/slog"
+
+	session_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/session/controller"
+)
+
+// Handler Creates http request handler
+type Handler struct {
+	Logger     *slog.Logger
+	Controller session_c.SessionController
+}
+
+// NewHandler Constructor
+func NewHandler(loggerp *slog.Logger, c session_c.SessionController) *Handler {
+	return &Handler{
+		Logger:     loggerp,
+		Controller: c,
+	}
+}
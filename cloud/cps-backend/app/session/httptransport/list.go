@@ -0,0 +1,44 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	session_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/session/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Here is where you extract url parameters.
+	query := r.URL.Query()
+
+	var userID primitive.ObjectID
+	userIDStr := query.Get("user_id")
+	if userIDStr != "" {
+		var err error
+		userID, err = primitive.ObjectIDFromHex(userIDStr)
+		if err != nil {
+			httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("user_id", "invalid value"))
+			return
+		}
+	}
+
+	m, err := h.Controller.ListByUserID(ctx, userID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalListResponse(m, w)
+}
+
+func MarshalListResponse(res []*session_d.Session, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
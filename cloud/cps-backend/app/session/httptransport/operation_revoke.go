@@ -0,0 +1,74 @@
+package httptransport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+type RevokeResponse struct {
+	Count int64 `json:"count"`
+}
+
+type RevokeUserRequest struct {
+	UserID primitive.ObjectID `json:"user_id"`
+}
+
+func UnmarshalOperationRevokeUserRequest(ctx context.Context, r *http.Request) (*RevokeUserRequest, error) {
+	// Initialize our array which will store all the results from the remote server.
+	var requestData RevokeUserRequest
+
+	defer r.Body.Close()
+
+	// Read the JSON string and convert it into our golang stuct else we need
+	// to send a `400 Bad Request` errror message back to the client,
+	err := json.NewDecoder(r.Body).Decode(&requestData) // [1]
+	if err != nil {
+		return nil, httperror.NewForSingleField(http.StatusBadRequest, "non_field_error", "payload structure is wrong")
+	}
+	if requestData.UserID.IsZero() {
+		return nil, httperror.NewForBadRequestWithSingleField("user_id", "missing value")
+	}
+	return &requestData, nil
+}
+
+// OperationRevokeOthers logs out all the user's other devices.
+func (h *Handler) OperationRevokeOthers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	count, err := h.Controller.DeleteAllOthers(ctx)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	MarshalRevokeResponse(&RevokeResponse{Count: count}, w)
+}
+
+// OperationRevokeUser logs out all the devices of the user.
+func (h *Handler) OperationRevokeUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := UnmarshalOperationRevokeUserRequest(ctx, r)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	count, err := h.Controller.DeleteAllByUserID(ctx, req.UserID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	MarshalRevokeResponse(&RevokeResponse{Count: count}, w)
+}
+
+func MarshalRevokeResponse(res *RevokeResponse, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -7,17 +7,15 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
-	mg "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/emailer/mailgun"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/emailer"
 	s3_storage "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/storage/s3"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
-	attachment_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/attachment/datastore"
-	submission_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
-	credit_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/credit/datastore"
-	receipt_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/datastore"
+	notification_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/datastore"
 	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
+	sj_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/datastore"
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
-	userpurchase_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/currency"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
 )
 
@@ -34,54 +32,48 @@ type StoreController interface {
 }
 
 type StoreControllerImpl struct {
-	Config                *config.Conf
-	Logger                *slog.Logger
-	UUID                  uuid.Provider
-	S3                    s3_storage.S3Storager
-	Emailer               mg.Emailer
-	TemplatedEmailer      templatedemailer.TemplatedEmailer
-	DbClient              *mongo.Client
-	UserStorer            user_s.UserStorer
-	StoreStorer           store_s.StoreStorer
-	CreditStorer          credit_s.CreditStorer
-	AttachmentStorer      attachment_s.AttachmentStorer
-	ReceiptStorer         receipt_s.ReceiptStorer
-	UserPurchaseStorer    userpurchase_s.UserPurchaseStorer
-	ComicSubmissionStorer submission_s.ComicSubmissionStorer
+	Config             *config.Conf
+	Logger             *slog.Logger
+	UUID               uuid.Provider
+	Currency           currency.Provider
+	S3                 s3_storage.S3Storager
+	Emailer            emailer.Emailer
+	TemplatedEmailer   templatedemailer.TemplatedEmailer
+	DbClient           *mongo.Client
+	UserStorer         user_s.UserStorer
+	StoreStorer        store_s.StoreStorer
+	SyncJobStorer      sj_s.SyncJobStorer
+	NotificationStorer notification_s.NotificationStorer
 }
 
 func NewController(
 	appCfg *config.Conf,
 	loggerp *slog.Logger,
 	uuidp uuid.Provider,
+	currencyp currency.Provider,
 	s3 s3_storage.S3Storager,
-	emailer mg.Emailer,
+	emailer emailer.Emailer,
 	te templatedemailer.TemplatedEmailer,
 	client *mongo.Client,
 	org_storer store_s.StoreStorer,
 	usr_storer user_s.UserStorer,
-	st_storer submission_s.ComicSubmissionStorer,
-	credit_storer credit_s.CreditStorer,
-	attch_storer attachment_s.AttachmentStorer,
-	receipt_storer receipt_s.ReceiptStorer,
-	up_storer userpurchase_s.UserPurchaseStorer,
+	sj_storer sj_s.SyncJobStorer,
+	n_storer notification_s.NotificationStorer,
 ) StoreController {
 	loggerp.Debug("store controller initialization started...")
 	s := &StoreControllerImpl{
-		Config:                appCfg,
-		Logger:                loggerp,
-		UUID:                  uuidp,
-		S3:                    s3,
-		Emailer:               emailer,
-		DbClient:              client,
-		TemplatedEmailer:      te,
-		UserStorer:            usr_storer,
-		ComicSubmissionStorer: st_storer,
-		StoreStorer:           org_storer,
-		CreditStorer:          credit_storer,
-		AttachmentStorer:      attch_storer,
-		ReceiptStorer:         receipt_storer,
-		UserPurchaseStorer:    up_storer,
+		Config:             appCfg,
+		Logger:             loggerp,
+		UUID:               uuidp,
+		Currency:           currencyp,
+		S3:                 s3,
+		Emailer:            emailer,
+		DbClient:           client,
+		TemplatedEmailer:   te,
+		UserStorer:         usr_storer,
+		StoreStorer:        org_storer,
+		SyncJobStorer:      sj_storer,
+		NotificationStorer: n_storer,
 	}
 	s.Logger.Debug("store controller initialized")
 	return s
@@ -7,10 +7,11 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
 	s_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
-	user_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (c *StoreControllerImpl) Create(ctx context.Context, m *s_d.Store) (*s_d.Store, error) {
@@ -20,7 +21,7 @@ func (c *StoreControllerImpl) Create(ctx context.Context, m *s_d.Store) (*s_d.St
 	userRole := ctx.Value(constants.SessionUserRole).(int8)
 
 	// Apply protection based on ownership and role.
-	if userRole != user_d.UserRoleRoot {
+	if !permission.Can(ctx, permission.StoreCreate) {
 		c.Logger.Error("authenticated user is not staff role error",
 			slog.Any("role", userRole),
 			slog.Any("userID", userID))
@@ -43,25 +44,22 @@ func (c *StoreControllerImpl) Create(ctx context.Context, m *s_d.Store) (*s_d.St
 		return nil, err
 	}
 
-	// Send notifications in the background.
+	// Queue the notifications, they are delivered by the outbox dispatcher.
 	if m.Status == s_d.StoreActiveStatus {
 		c.Logger.Debug("store became active, sending email to retailer staff")
-		go func(m *s_d.Store) {
-			res, err := c.UserStorer.ListAllRetailerStaffForStoreID(context.Background(), m.ID)
-			if err != nil {
-				c.Logger.Error("list store error", slog.Any("error", err))
-				return
-			}
-			var retailerEmails []string
-			for _, u := range res.Results {
-				retailerEmails = append(retailerEmails, u.Email)
-			}
-			if err := c.TemplatedEmailer.SendRetailerStoreActiveEmailToRetailers(retailerEmails, m.Name); err != nil {
-				c.Logger.Error("failed sending templated error", slog.Any("error", err))
-				return
-			}
-
-		}(m)
+		res, err := c.UserStorer.ListAllRetailerStaffForStoreID(ctx, m.ID)
+		if err != nil {
+			c.Logger.Error("list store error", slog.Any("error", err))
+			return nil, err
+		}
+		var recipients []templatedemailer.Recipient
+		for _, u := range res.Results {
+			recipients = append(recipients, templatedemailer.Recipient{Email: u.Email, Locale: u.Locale})
+		}
+		if err := c.TemplatedEmailer.SendRetailerStoreActiveEmailToRetailers(ctx, recipients, m.Name); err != nil {
+			c.Logger.Error("failed sending templated error", slog.Any("error", err))
+			return nil, err
+		}
 	}
 
 	return m, nil
@@ -7,9 +7,9 @@ import (
 	"log/slog"
 
 	org_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
-	user_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (impl *StoreControllerImpl) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
@@ -18,7 +18,7 @@ func (impl *StoreControllerImpl) DeleteByID(ctx context.Context, id primitive.Ob
 	userRole := ctx.Value(constants.SessionUserRole).(int8)
 
 	// Apply protection based on ownership and role.
-	if userRole != user_d.UserRoleRoot {
+	if !permission.Can(ctx, permission.StoreDelete) {
 		impl.Logger.Error("authenticated user is not staff role error",
 			slog.Any("role", userRole),
 			slog.Any("userID", userID))
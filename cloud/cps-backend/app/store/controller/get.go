@@ -4,9 +4,9 @@ import (
 	"context"
 
 	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
-	user_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"log/slog"
 )
@@ -17,7 +17,7 @@ func (c *StoreControllerImpl) GetByID(ctx context.Context, id primitive.ObjectID
 	userRole := ctx.Value(constants.SessionUserRole).(int8)
 
 	// If user is not administrator nor belongs to the store then error.
-	if userRole != user_d.UserRoleRoot && id != userStoreID {
+	if !permission.Can(ctx, permission.StoreViewAll) && id != userStoreID {
 		c.Logger.Error("authenticated user is not staff role nor belongs to the store error",
 			slog.Any("userRole", userRole),
 			slog.Any("userStoreID", userStoreID))
@@ -2,17 +2,23 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
+	notification_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/datastore"
 	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
 	s_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
-	user_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	sj_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (impl *StoreControllerImpl) UpdateByID(ctx context.Context, ns *domain.Store) (*domain.Store, error) {
@@ -49,7 +55,7 @@ func (impl *StoreControllerImpl) UpdateByID(ctx context.Context, ns *domain.Stor
 		userName := ctx.Value(constants.SessionUserName).(string)
 
 		// If user is not administrator nor belongs to the store then error.
-		if userRole != user_d.UserRoleRoot && os.ID != userStoreID {
+		if !permission.Can(ctx, permission.StoreApprove) && os.ID != userStoreID {
 			impl.Logger.Error("authenticated user is not staff role nor belongs to the store error",
 				slog.Any("userRole", userRole),
 				slog.Any("userStoreID", userStoreID))
@@ -65,9 +71,9 @@ func (impl *StoreControllerImpl) UpdateByID(ctx context.Context, ns *domain.Stor
 		os.ModifiedByUserID = userID
 		os.ModifiedByUserName = userName
 		os.Type = ns.Type
-		os.Status = ns.Status
 		os.Name = ns.Name
 		os.WebsiteURL = ns.WebsiteURL
+		os.Locale = ns.Locale
 		os.EstimatedSubmissionsPerMonth = ns.EstimatedSubmissionsPerMonth
 		os.HasOtherGradingService = ns.HasOtherGradingService
 		os.OtherGradingServiceName = ns.OtherGradingServiceName
@@ -79,30 +85,61 @@ func (impl *StoreControllerImpl) UpdateByID(ctx context.Context, ns *domain.Stor
 		os.Level = ns.Level
 		os.SpecialCollection = ns.SpecialCollection
 
+		// Only staff are allowed to approve a store and change how it is billed.
+		if permission.Can(ctx, permission.StoreApprove) {
+			os.Status = ns.Status
+			os.PaymentMode = ns.PaymentMode
+			os.PaymentTermsDays = ns.PaymentTermsDays
+			// An empty currency means we choose it by the country of the user.
+			currency := strings.ToUpper(strings.TrimSpace(ns.Currency))
+			if currency != "" && !impl.Currency.IsSupported(currency) {
+				return nil, httperror.NewForBadRequestWithSingleField("currency", "currency is not supported")
+			}
+			os.Currency = currency
+		}
+
 		// Save to the database the modified store.
-		if err := impl.StoreStorer.UpdateByID(ctx, os); err != nil {
+		if err := impl.StoreStorer.UpdateByID(sessCtx, os); err != nil {
 			impl.Logger.Error("database update by id error", slog.Any("error", err))
 			return nil, err
 		}
 
-		// Send notifications in the background.
-		if previousStatus != ns.Status && ns.Status == s_d.StoreActiveStatus {
-			impl.Logger.Debug("store became active, sending email to retailer staff")
-			go func(m *s_d.Store) {
-				res, err := impl.UserStorer.ListAllRetailerStaffForStoreID(context.Background(), m.ID)
-				if err != nil {
-					impl.Logger.Error("list store error", slog.Any("error", err))
-					return
-				}
-				var retailerEmails []string
-				for _, u := range res.Results {
-					retailerEmails = append(retailerEmails, u.Email)
-				}
-				if err := impl.TemplatedEmailer.SendRetailerStoreActiveEmailToRetailers(retailerEmails, m.Name); err != nil {
-					impl.Logger.Error("failed sending templated error", slog.Any("error", err))
-					return
+		// Queue copying the store details onto the related records, this is
+		// saved in the same transaction so the change cannot be lost.
+		if err := impl.SyncJobStorer.Create(sessCtx, sj_s.NewSyncJob(sj_s.TypeStore, os.ID)); err != nil {
+			impl.Logger.Error("sync job create error", slog.Any("error", err))
+			return nil, err
+		}
+
+		// Queue the notifications within the transaction so they are only
+		// delivered if the update commits.
+		if previousStatus != os.Status && os.Status == s_d.StoreActiveStatus {
+			impl.Logger.Debug("store became active, notifying retailer staff")
+			res, err := impl.UserStorer.ListAllRetailerStaffForStoreID(sessCtx, os.ID)
+			if err != nil {
+				impl.Logger.Error("list store error", slog.Any("error", err))
+				return nil, err
+			}
+			var recipients []templatedemailer.Recipient
+			for _, u := range res.Results {
+				recipients = append(recipients, templatedemailer.Recipient{Email: u.Email, Locale: u.Locale})
+
+				n := notification_s.NewNotification(
+					u.ID,
+					notification_s.TypeStoreApproved,
+					i18n.T(u.Locale, "Your store is active"),
+					fmt.Sprintf(i18n.T(u.Locale, "The store %v was approved, you can now create submissions."), os.Name),
+					"/dashboard",
+					os.ID)
+				if err := impl.NotificationStorer.Create(sessCtx, n); err != nil {
+					impl.Logger.Error("database create notification error", slog.Any("error", err))
+					return nil, err
 				}
-			}(os)
+			}
+			if err := impl.TemplatedEmailer.SendRetailerStoreActiveEmailToRetailers(sessCtx, recipients, os.Name); err != nil {
+				impl.Logger.Error("failed sending templated error", slog.Any("error", err))
+				return nil, err
+			}
 		}
 
 		////
@@ -122,26 +159,5 @@ func (impl *StoreControllerImpl) UpdateByID(ctx context.Context, ns *domain.Stor
 
 	org := res.(*domain.Store)
 
-	// Run the following update tasks in the background regardless of this
-	// function completing.
-	go func(o *domain.Store) {
-		impl.updateRelatedUsersInBackground(o)
-	}(org)
-	go func(o *domain.Store) {
-		impl.updateRelatedComicSubmissionsInBackground(o)
-	}(org)
-	go func(o *domain.Store) {
-		impl.updateRelatedAttachmentsInBackground(o)
-	}(org)
-	go func(o *domain.Store) {
-		impl.updateRelatedCreditsInBackground(o)
-	}(org)
-	go func(o *domain.Store) {
-		impl.updateRelatedReceiptsInBackground(o)
-	}(org)
-	go func(o *domain.Store) {
-		impl.updateRelateUserPurchasesInBackground(o)
-	}(org)
-
 	return org, nil
 }
@@ -32,6 +32,12 @@ const (
 	RequestWelcomePackageYes           = 1
 	RequestWelcomePackageNo            = 2
 	SpecialCollection040001            = 1
+	// PaymentModeCard indicates the store pays for every submission by card through the payment processor.
+	PaymentModeCard = 1
+	// PaymentModeStoreAccount indicates the store's submissions are billed to the store account and invoiced monthly.
+	PaymentModeStoreAccount = 2
+	// DefaultPaymentTermsDays is the number of days the store has to pay an invoice when no terms were set.
+	DefaultPaymentTermsDays = 30
 )
 
 type Store struct {
@@ -61,6 +67,30 @@ type Store struct {
 	// generating a CSPRN.
 	SpecialCollection int8   `bson:"special_collection" json:"special_collection"`
 	Timezone          string `bson:"timezone" json:"timezone"` // Created by system.
+	// Locale is the language of the documents we generate for the store and
+	// the default of its new users, see `i18n.SupportedLocales`.
+	Locale string `bson:"locale" json:"locale,omitempty"`
+
+	// PaymentProcessorSubscriptionID is the unique id set by the payment processor for the store's subscription plan.
+	PaymentProcessorSubscriptionID string `bson:"payment_processor_subscription_id" json:"payment_processor_subscription_id"`
+	// PaymentProcessorSubscriptionStatus stores the status set by the payment processor, for example `active`, `past_due` or `canceled`.
+	PaymentProcessorSubscriptionStatus string `bson:"payment_processor_subscription_status" json:"payment_processor_subscription_status"`
+	// SubscriptionOfferID is the offer the store is currently subscribed to.
+	SubscriptionOfferID   primitive.ObjectID `bson:"subscription_offer_id,omitempty" json:"subscription_offer_id,omitempty"`
+	SubscriptionOfferName string             `bson:"subscription_offer_name" json:"subscription_offer_name"`
+	// SubscriptionUserID is the retailer whom purchased the subscription and is granted the credits every paid billing cycle.
+	SubscriptionUserID primitive.ObjectID `bson:"subscription_user_id,omitempty" json:"subscription_user_id,omitempty"`
+	// SubscriptionCurrentPeriodEnd is when the current paid billing cycle ends.
+	SubscriptionCurrentPeriodEnd time.Time `bson:"subscription_current_period_end" json:"subscription_current_period_end"`
+	// SubscriptionCancelAtPeriodEnd indicates the subscription was canceled and will end with the current billing cycle.
+	SubscriptionCancelAtPeriodEnd bool `bson:"subscription_cancel_at_period_end" json:"subscription_cancel_at_period_end"`
+
+	// PaymentMode controls whether the store pays per submission by card or is billed to their store account.
+	PaymentMode int8 `bson:"payment_mode" json:"payment_mode"`
+	// PaymentTermsDays is the number of days after an invoice is issued before payment is due, for example `30` for net 30.
+	PaymentTermsDays int64 `bson:"payment_terms_days" json:"payment_terms_days"`
+	// Currency is the currency the store is charged in, for example `USD`. If empty then the currency is chosen by the country of the user.
+	Currency string `bson:"currency" json:"currency"`
 }
 
 type StoreComment struct {
@@ -107,7 +137,9 @@ type StoreAsSelectOption struct {
 type StoreStorer interface {
 	Create(ctx context.Context, m *Store) error
 	GetByID(ctx context.Context, id primitive.ObjectID) (*Store, error)
+	GetByPaymentProcessorSubscriptionID(ctx context.Context, paymentProcessorSubscriptionID string) (*Store, error)
 	UpdateByID(ctx context.Context, m *Store) error
+	UpdateUserNameByUserID(ctx context.Context, userID primitive.ObjectID, userName string) (int64, error)
 	ListByFilter(ctx context.Context, m *StorePaginationListFilter) (*StorePaginationListResult, error)
 	ListAsSelectOptionByFilter(ctx context.Context, f *StorePaginationListFilter) ([]*StoreAsSelectOption, error)
 	DeleteByID(ctx context.Context, id primitive.ObjectID) error
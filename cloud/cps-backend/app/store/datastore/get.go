@@ -24,3 +24,19 @@ func (impl StoreStorerImpl) GetByID(ctx context.Context, id primitive.ObjectID)
 	}
 	return &result, nil
 }
+
+func (impl StoreStorerImpl) GetByPaymentProcessorSubscriptionID(ctx context.Context, paymentProcessorSubscriptionID string) (*Store, error) {
+	filter := bson.M{"payment_processor_subscription_id": paymentProcessorSubscriptionID}
+
+	var result Store
+	err := impl.Collection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// This error means your query did not match any documents.
+			return nil, nil
+		}
+		impl.Logger.Error("database get by payment processor subscription id error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
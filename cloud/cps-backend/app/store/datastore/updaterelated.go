@@ -0,0 +1,30 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UpdateUserNameByUserID updates the name saved on the records the user
+// created or last modified.
+func (impl StoreStorerImpl) UpdateUserNameByUserID(ctx context.Context, userID primitive.ObjectID, userName string) (int64, error) {
+	var count int64
+	for _, field := range []string{"created_by", "modified_by"} {
+		filter := bson.M{field + "_user_id": userID}
+		update := bson.M{"$set": bson.M{
+			field + "_user_name": userName,
+			"modified_at":        time.Now(),
+		}}
+		result, err := impl.Collection.UpdateMany(ctx, filter, update)
+		if err != nil {
+			impl.Logger.Error("database update many error", slog.Any("error", err))
+			return count, err
+		}
+		count += result.ModifiedCount
+	}
+	return count, nil
+}
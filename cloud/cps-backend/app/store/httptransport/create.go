@@ -10,6 +10,7 @@ import (
 
 	sub_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
 )
 
 func UnmarshalCreateRequest(ctx context.Context, r *http.Request) (*sub_s.Store, error) {
@@ -83,6 +84,10 @@ func ValidateCreateRequest(dirtyData *sub_s.Store) error {
 		}
 	}
 
+	if dirtyData.Locale != "" && !i18n.IsSupported(dirtyData.Locale) {
+		e["locale"] = "unsupported value"
+	}
+
 	if len(e) != 0 {
 		return httperror.NewForBadRequest(&e)
 	}
@@ -9,6 +9,7 @@ import (
 
 	sub_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -82,6 +83,16 @@ func ValidateUpdateRequest(dirtyData *sub_s.Store) error {
 			e["timezone"] = "unsupported value"
 		}
 	}
+	if dirtyData.PaymentMode == sub_s.PaymentModeStoreAccount && dirtyData.PaymentTermsDays <= 0 {
+		e["payment_terms_days"] = "missing value"
+	}
+	if dirtyData.PaymentTermsDays < 0 {
+		e["payment_terms_days"] = "cannot be negative"
+	}
+
+	if dirtyData.Locale != "" && !i18n.IsSupported(dirtyData.Locale) {
+		e["locale"] = "unsupported value"
+	}
 
 	if len(e) != 0 {
 		return httperror.NewForBadRequest(&e)
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	attachment_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/attachment/datastore"
+	submission_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
+	credit_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/credit/datastore"
+	receipt_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/datastore"
+	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/datastore"
+	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	userpurchase_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+// SyncJobController Interface for copying store and user changes onto the
+// records which keep a copy of their names.
+type SyncJobController interface {
+	ProcessPending(ctx context.Context) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*domain.SyncJob, error)
+	ListByFilter(ctx context.Context, f *domain.SyncJobPaginationListFilter) (*domain.SyncJobPaginationListResult, error)
+	RetryByID(ctx context.Context, id primitive.ObjectID) (*domain.SyncJob, error)
+}
+
+type SyncJobControllerImpl struct {
+	Config                *config.Conf
+	Logger                *slog.Logger
+	SyncJobStorer         domain.SyncJobStorer
+	UserStorer            user_s.UserStorer
+	StoreStorer           store_s.StoreStorer
+	ComicSubmissionStorer submission_s.ComicSubmissionStorer
+	AttachmentStorer      attachment_s.AttachmentStorer
+	CreditStorer          credit_s.CreditStorer
+	ReceiptStorer         receipt_s.ReceiptStorer
+	UserPurchaseStorer    userpurchase_s.UserPurchaseStorer
+}
+
+func NewController(
+	appCfg *config.Conf,
+	loggerp *slog.Logger,
+	sj_storer domain.SyncJobStorer,
+	usr_storer user_s.UserStorer,
+	org_storer store_s.StoreStorer,
+	sub_storer submission_s.ComicSubmissionStorer,
+	att_storer attachment_s.AttachmentStorer,
+	cred_storer credit_s.CreditStorer,
+	rec_storer receipt_s.ReceiptStorer,
+	up_storer userpurchase_s.UserPurchaseStorer,
+) SyncJobController {
+	loggerp.Debug("sync job controller initialization started...")
+	s := &SyncJobControllerImpl{
+		Config:                appCfg,
+		Logger:                loggerp,
+		SyncJobStorer:         sj_storer,
+		UserStorer:            usr_storer,
+		StoreStorer:           org_storer,
+		ComicSubmissionStorer: sub_storer,
+		AttachmentStorer:      att_storer,
+		CreditStorer:          cred_storer,
+		ReceiptStorer:         rec_storer,
+		UserPurchaseStorer:    up_storer,
+	}
+	s.Logger.Debug("sync job controller initialized")
+	return s
+}
@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"context"
+
+	"log/slog"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (c *SyncJobControllerImpl) GetByID(ctx context.Context, id primitive.ObjectID) (*domain.SyncJob, error) {
+	if !permission.Can(ctx, permission.SyncJobManage) {
+		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+
+	// Retrieve from our database the record for the specific id.
+	m, err := c.SyncJobStorer.GetByID(ctx, id)
+	if err != nil {
+		c.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if m == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "sync job does not exist")
+	}
+	return m, err
+}
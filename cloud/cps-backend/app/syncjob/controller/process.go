@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/retry"
+)
+
+// retryPolicy gives up on a job after about three hours of attempts.
+var retryPolicy = retry.Policy{
+	MaxAttempts: 10,
+	BaseDelay:   30 * time.Second,
+	MaxDelay:    time.Hour,
+}
+
+const (
+	// lockDuration is how long a worker has to finish a job before another
+	// worker assumes it crashed and picks the job up again.
+	lockDuration = 10 * time.Minute
+)
+
+// ProcessPending runs every job which is due until there are none left.
+func (impl *SyncJobControllerImpl) ProcessPending(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		job, err := impl.SyncJobStorer.ClaimNext(ctx, lockDuration)
+		if err != nil {
+			impl.Logger.Error("database claim next error", slog.Any("error", err))
+			return err
+		}
+		if job == nil {
+			return nil
+		}
+
+		count, err := impl.process(ctx, job)
+		impl.finish(job, count, err)
+		if err := impl.SyncJobStorer.UpdateByID(ctx, job); err != nil {
+			impl.Logger.Error("database update by id error", slog.Any("error", err))
+			return err
+		}
+	}
+}
+
+// finish records the result of the attempt on the job and schedules the next
+// attempt if it failed.
+func (impl *SyncJobControllerImpl) finish(job *domain.SyncJob, count int64, err error) {
+	now := time.Now()
+	job.ModifiedAt = now
+	job.LockedUntil = time.Time{}
+	if err == nil {
+		job.Status = domain.StatusCompleted
+		job.LastError = ""
+		job.ModifiedCount = count
+		job.CompletedAt = now
+		impl.Logger.Debug("sync job completed",
+			slog.Any("sync_job_id", job.ID),
+			slog.Int64("modified_count", count))
+		return
+	}
+
+	job.LastError = err.Error()
+	if retryPolicy.GiveUp(job.Attempts) {
+		job.Status = domain.StatusFailed
+		impl.Logger.Error("sync job failed, giving up",
+			slog.Any("sync_job_id", job.ID),
+			slog.Int("attempts", job.Attempts),
+			slog.Any("error", err))
+		return
+	}
+
+	delay := retryPolicy.Delay(job.Attempts)
+	job.Status = domain.StatusPending
+	job.NextAttemptAt = now.Add(delay)
+	impl.Logger.Warn("sync job failed, will retry",
+		slog.Any("sync_job_id", job.ID),
+		slog.Int("attempts", job.Attempts),
+		slog.Duration("retry_in", delay),
+		slog.Any("error", err))
+}
+
+func (impl *SyncJobControllerImpl) process(ctx context.Context, job *domain.SyncJob) (int64, error) {
+	switch job.Type {
+	case domain.TypeStore:
+		return impl.syncStore(ctx, job)
+	case domain.TypeUser:
+		return impl.syncUser(ctx, job)
+	default:
+		return 0, fmt.Errorf("unsupported sync job type: %v", job.Type)
+	}
+}
+
+// syncStore copies the latest store details, we always read the store again
+// so jobs can run in any order and running one twice is harmless.
+func (impl *SyncJobControllerImpl) syncStore(ctx context.Context, job *domain.SyncJob) (int64, error) {
+	s, err := impl.StoreStorer.GetByID(ctx, job.EntityID)
+	if err != nil {
+		return 0, err
+	}
+	if s == nil {
+		impl.Logger.Warn("sync job store does not exist", slog.Any("store_id", job.EntityID))
+		return 0, nil
+	}
+
+	updates := []func() (int64, error){
+		func() (int64, error) {
+			return impl.UserStorer.UpdateStoreByStoreID(ctx, s.ID, s.Name, s.Level, s.Timezone)
+		},
+		func() (int64, error) {
+			return impl.ComicSubmissionStorer.UpdateStoreByStoreID(ctx, s.ID, s.Name, s.Timezone)
+		},
+		func() (int64, error) {
+			return impl.AttachmentStorer.UpdateStoreByStoreID(ctx, s.ID, s.Name, s.Timezone)
+		},
+		func() (int64, error) {
+			return impl.CreditStorer.UpdateStoreByStoreID(ctx, s.ID, s.Name, s.Timezone)
+		},
+		func() (int64, error) {
+			return impl.ReceiptStorer.UpdateStoreByStoreID(ctx, s.ID, s.Name)
+		},
+		func() (int64, error) {
+			return impl.UserPurchaseStorer.UpdateStoreByStoreID(ctx, s.ID, s.Name, s.Timezone)
+		},
+	}
+	return runAll(updates)
+}
+
+// syncUser copies the latest user names, see `syncStore`.
+func (impl *SyncJobControllerImpl) syncUser(ctx context.Context, job *domain.SyncJob) (int64, error) {
+	u, err := impl.UserStorer.GetByID(ctx, job.EntityID)
+	if err != nil {
+		return 0, err
+	}
+	if u == nil {
+		impl.Logger.Warn("sync job user does not exist", slog.Any("user_id", job.EntityID))
+		return 0, nil
+	}
+
+	updates := []func() (int64, error){
+		func() (int64, error) {
+			return impl.ComicSubmissionStorer.UpdateInspectorNameByInspectorID(ctx, u.ID, u.FirstName, u.LastName)
+		},
+		func() (int64, error) {
+			return impl.ComicSubmissionStorer.UpdateCustomerNameByCustomerID(ctx, u.ID, u.FirstName, u.LastName)
+		},
+		func() (int64, error) {
+			return impl.AttachmentStorer.UpdateUserNameByUserID(ctx, u.ID, u.Name)
+		},
+		func() (int64, error) {
+			return impl.CreditStorer.UpdateUserNameByUserID(ctx, u.ID, u.Name, u.LexicalName)
+		},
+		func() (int64, error) {
+			return impl.ReceiptStorer.UpdateUserNameByUserID(ctx, u.ID, u.Name, u.LexicalName)
+		},
+		func() (int64, error) {
+			return impl.StoreStorer.UpdateUserNameByUserID(ctx, u.ID, u.Name)
+		},
+		func() (int64, error) {
+			return impl.UserPurchaseStorer.UpdateUserNameByUserID(ctx, u.ID, u.Name, u.LexicalName)
+		},
+	}
+	return runAll(updates)
+}
+
+// runAll stops at the first error, the updates are idempotent so the retry
+// simply runs all of them again.
+func runAll(updates []func() (int64, error)) (int64, error) {
+	var total int64
+	for _, update := range updates {
+		count, err := update()
+		if err != nil {
+			return total, err
+		}
+		total += count
+	}
+	return total, nil
+}
@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"log/slog"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RetryByID queues a job which we gave up on to be tried again.
+func (c *SyncJobControllerImpl) RetryByID(ctx context.Context, id primitive.ObjectID) (*domain.SyncJob, error) {
+	if !permission.Can(ctx, permission.SyncJobManage) {
+		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+
+	m, err := c.SyncJobStorer.GetByID(ctx, id)
+	if err != nil {
+		c.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if m == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "sync job does not exist")
+	}
+	if m.Status != domain.StatusFailed {
+		return nil, httperror.NewForBadRequestWithSingleField("status", "only failed sync jobs can be retried")
+	}
+
+	m.Status = domain.StatusPending
+	m.Attempts = 0
+	m.NextAttemptAt = time.Now()
+	m.ModifiedAt = time.Now()
+	if err := c.SyncJobStorer.UpdateByID(ctx, m); err != nil {
+		c.Logger.Error("database update by id error", slog.Any("error", err))
+		return nil, err
+	}
+	return m, nil
+}
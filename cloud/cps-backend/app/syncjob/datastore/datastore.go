@@ -0,0 +1,112 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+const (
+	// TypeStore copies the store name, level and timezone onto the records
+	// which belong to the store.
+	TypeStore = 1
+	// TypeUser copies the user names onto the records which belong to or
+	// were worked on by the user.
+	TypeUser = 2
+
+	StatusPending    = 1
+	StatusProcessing = 2
+	StatusCompleted  = 3
+	// StatusFailed means we gave up retrying, a staff member must retry it.
+	StatusFailed = 4
+)
+
+// SyncJob represents a change to a store or user which still needs to be
+// copied onto the other records which save a copy of their names.
+type SyncJob struct {
+	ID       primitive.ObjectID `bson:"_id" json:"id"`
+	Type     int8               `bson:"type" json:"type"`
+	EntityID primitive.ObjectID `bson:"entity_id" json:"entity_id"`
+	Status   int8               `bson:"status" json:"status"`
+	// Attempts is how many times a worker picked up this job.
+	Attempts      int       `bson:"attempts" json:"attempts"`
+	LastError     string    `bson:"last_error" json:"last_error,omitempty"`
+	ModifiedCount int64     `bson:"modified_count" json:"modified_count"`
+	NextAttemptAt time.Time `bson:"next_attempt_at" json:"next_attempt_at"`
+	// LockedUntil lets another worker pick up the job if the worker which
+	// was processing it crashed.
+	LockedUntil time.Time `bson:"locked_until" json:"locked_until"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+	ModifiedAt  time.Time `bson:"modified_at" json:"modified_at"`
+	CompletedAt time.Time `bson:"completed_at" json:"completed_at"`
+}
+
+// SyncJobStorer Interface for sync jobs.
+type SyncJobStorer interface {
+	Create(ctx context.Context, m *SyncJob) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*SyncJob, error)
+	ClaimNext(ctx context.Context, lockFor time.Duration) (*SyncJob, error)
+	UpdateByID(ctx context.Context, m *SyncJob) error
+	ListByFilter(ctx context.Context, f *SyncJobPaginationListFilter) (*SyncJobPaginationListResult, error)
+}
+
+type SyncJobStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) SyncJobStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("sync_jobs")
+
+	// The following few lines of code will create the index for our app for
+	// this colleciton.
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "next_attempt_at", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "entity_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+		},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &SyncJobStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
+
+// NewSyncJob returns a job which is ready to be picked up by a worker.
+func NewSyncJob(jobType int8, entityID primitive.ObjectID) *SyncJob {
+	now := time.Now()
+	return &SyncJob{
+		ID:            primitive.NewObjectID(),
+		Type:          jobType,
+		EntityID:      entityID,
+		Status:        StatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		ModifiedAt:    now,
+	}
+}
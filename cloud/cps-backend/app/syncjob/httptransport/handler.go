@@ -0,0 +1,21 @@
+package httptransport
+
+import (
+	"log/slog"
+
+	syncjob_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/controller"
+)
+
+// Handler Creates http request handler
+type Handler struct {
+	Logger     *slog.Logger
+	Controller syncjob_c.SyncJobController
+}
+
+// NewHandler Constructor
+func NewHandler(loggerp *slog.Logger, c syncjob_c.SyncJobController) *Handler {
+	return &Handler{
+		Logger:     loggerp,
+		Controller: c,
+	}
+}
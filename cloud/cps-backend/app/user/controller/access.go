@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+// checkCanManageUser returns a `403 Forbidden` error if the logged in user is
+// not allowed to manage a user with the role in the store. Store managers may
+// only manage the retailer staff of their own store.
+func (impl *UserControllerImpl) checkCanManageUser(ctx context.Context, storeID primitive.ObjectID, role int8) error {
+	if permission.Can(ctx, permission.UserManage) {
+		return nil
+	}
+	if permission.Can(ctx, permission.UserManageStore) {
+		tid, _ := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+		if r := permission.GetRole(role); r != nil && r.Scope == permission.ScopeStore && storeID == tid {
+			return nil
+		}
+	}
+	return httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+}
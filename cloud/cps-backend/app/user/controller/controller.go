@@ -8,16 +8,15 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
-	attachment_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/attachment/datastore"
-	submission_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/datastore"
-	credit_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/credit/datastore"
-	receipt_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/datastore"
+	la_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/loginattempt/datastore"
+	ses_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/session/datastore"
 	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
+	sj_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/datastore"
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
-	userpurchase_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/password"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 // UserController Interface for user business logic controller.
@@ -34,23 +33,24 @@ type UserController interface {
 	Star(ctx context.Context, id primitive.ObjectID) (*user_s.User, error)
 	ChangePassword(ctx context.Context, req *UserOperationChangePasswordRequest) error
 	ChangeTwoFactorAuthentication(ctx context.Context, req *UserOperationChangeTwoFactorAuthenticationRequest) error
+	Unlock(ctx context.Context, req *UserOperationUnlockRequest) error
+	ChangeRole(ctx context.Context, req *UserOperationChangeRoleRequest) (*user_s.User, error)
+	ListRoles(ctx context.Context) ([]*permission.Role, error)
 	//TODO: Add more...
 }
 
 type UserControllerImpl struct {
-	Config                *config.Conf
-	Logger                *slog.Logger
-	UUID                  uuid.Provider
-	Password              password.Provider
-	DbClient              *mongo.Client
-	UserStorer            user_s.UserStorer
-	ComicSubmissionStorer submission_s.ComicSubmissionStorer
-	StoreStorer           store_s.StoreStorer
-	CreditStorer          credit_s.CreditStorer
-	AttachmentStorer      attachment_s.AttachmentStorer
-	ReceiptStorer         receipt_s.ReceiptStorer
-	UserPurchaseStorer    userpurchase_s.UserPurchaseStorer
-	TemplatedEmailer      templatedemailer.TemplatedEmailer
+	Config             *config.Conf
+	Logger             *slog.Logger
+	UUID               uuid.Provider
+	Password           password.Provider
+	DbClient           *mongo.Client
+	UserStorer         user_s.UserStorer
+	StoreStorer        store_s.StoreStorer
+	SessionStorer      ses_s.SessionStorer
+	LoginAttemptStorer la_s.LoginAttemptStorer
+	SyncJobStorer      sj_s.SyncJobStorer
+	TemplatedEmailer   templatedemailer.TemplatedEmailer
 }
 
 func NewController(
@@ -61,27 +61,23 @@ func NewController(
 	client *mongo.Client,
 	org_storer store_s.StoreStorer,
 	usr_storer user_s.UserStorer,
-	st_storer submission_s.ComicSubmissionStorer,
-	credit_storer credit_s.CreditStorer,
-	attch_storer attachment_s.AttachmentStorer,
-	receipt_storer receipt_s.ReceiptStorer,
-	up_storer userpurchase_s.UserPurchaseStorer,
+	ses_storer ses_s.SessionStorer,
+	la_storer la_s.LoginAttemptStorer,
+	sj_storer sj_s.SyncJobStorer,
 	temailer templatedemailer.TemplatedEmailer,
 ) UserController {
 	s := &UserControllerImpl{
-		Config:                appCfg,
-		Logger:                loggerp,
-		UUID:                  uuidp,
-		Password:              passwordp,
-		DbClient:              client,
-		UserStorer:            usr_storer,
-		ComicSubmissionStorer: st_storer,
-		StoreStorer:           org_storer,
-		CreditStorer:          credit_storer,
-		AttachmentStorer:      attch_storer,
-		ReceiptStorer:         receipt_storer,
-		UserPurchaseStorer:    up_storer,
-		TemplatedEmailer:      temailer,
+		Config:             appCfg,
+		Logger:             loggerp,
+		UUID:               uuidp,
+		Password:           passwordp,
+		DbClient:           client,
+		UserStorer:         usr_storer,
+		StoreStorer:        org_storer,
+		SessionStorer:      ses_storer,
+		LoginAttemptStorer: la_storer,
+		SyncJobStorer:      sj_storer,
+		TemplatedEmailer:   temailer,
 	}
 	loggerp.Debug("user controller initialization started...")
 	loggerp.Debug("user controller initialized")
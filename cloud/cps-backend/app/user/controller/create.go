@@ -13,6 +13,7 @@ import (
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 type UserCreateRequestIDO struct {
@@ -20,6 +21,7 @@ type UserCreateRequestIDO struct {
 	FirstName                                       string             `json:"first_name"`
 	LastName                                        string             `json:"last_name"`
 	Email                                           string             `json:"email"`
+	Locale                                          string             `json:"locale,omitempty"`
 	Phone                                           string             `json:"phone,omitempty"`
 	Country                                         string             `json:"country,omitempty"`
 	Region                                          string             `json:"region,omitempty"`
@@ -61,6 +63,7 @@ func (impl *UserControllerImpl) userFromCreateRequest(requestData *UserCreateReq
 		FirstName:                             requestData.FirstName,
 		LastName:                              requestData.LastName,
 		Email:                                 requestData.Email,
+		Locale:                                requestData.Locale,
 		Phone:                                 requestData.Phone,
 		Country:                               requestData.Country,
 		Region:                                requestData.Region,
@@ -114,13 +117,20 @@ func (impl *UserControllerImpl) Create(ctx context.Context, requestData *UserCre
 		}
 
 		// Extract from our session the following data.
-		userRole := sessCtx.Value(constants.SessionUserRole).(int8)
 		userID := sessCtx.Value(constants.SessionUserID).(primitive.ObjectID)
 		userName, _ := sessCtx.Value(constants.SessionUserName).(string)
 
+		// Store managers may only add staff to their own store.
+		if !permission.Can(sessCtx, permission.UserManage) {
+			m.StoreID, _ = sessCtx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+		}
+
 		// Apply filtering based on ownership and role.
-		if userRole != user_s.UserRoleRoot {
-			return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+		if permission.GetRole(m.Role) == nil {
+			return nil, httperror.NewForBadRequestWithSingleField("role", "does not exist")
+		}
+		if err := impl.checkCanManageUser(sessCtx, m.StoreID, m.Role); err != nil {
+			return nil, err
 		}
 
 		// Lookup the user in our database, else return a `400 Bad Request` error.
@@ -151,6 +161,9 @@ func (impl *UserControllerImpl) Create(ctx context.Context, requestData *UserCre
 		m.Email = strings.ToLower(m.Email)
 		m.StoreID = o.ID
 		m.StoreName = o.Name
+		if m.Locale == "" {
+			m.Locale = o.Locale
+		}
 		m.ID = primitive.NewObjectID()
 		m.CreatedAt = time.Now()
 		m.CreatedByUserID = userID
@@ -181,7 +194,7 @@ func (impl *UserControllerImpl) Create(ctx context.Context, requestData *UserCre
 		}
 
 		// Send email to user of the new password.
-		if err := impl.TemplatedEmailer.SendNewUserTemporaryPasswordEmail(m.Email, m.FirstName, temporaryPassword); err != nil {
+		if err := impl.TemplatedEmailer.SendNewUserTemporaryPasswordEmail(sessCtx, m.Locale, m.Email, m.FirstName, temporaryPassword); err != nil {
 			impl.Logger.Error("failed sending verification email with error", slog.Any("err", err))
 			return nil, err
 		}
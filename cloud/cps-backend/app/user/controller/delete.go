@@ -8,8 +8,8 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
-	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (impl *UserControllerImpl) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
@@ -27,14 +27,6 @@ func (impl *UserControllerImpl) DeleteByID(ctx context.Context, id primitive.Obj
 
 	// Define a transaction function with a series of operations
 	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
-		// Extract from our session the following data.
-		userRole := sessCtx.Value(constants.SessionUserRole).(int8)
-
-		// Apply filtering based on ownership and role.
-		if userRole != user_s.UserRoleRoot {
-			return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
-		}
-
 		// STEP 1: Lookup the record or error.
 		user, err := impl.UserStorer.GetByID(sessCtx, id)
 		if err != nil {
@@ -46,6 +38,24 @@ func (impl *UserControllerImpl) DeleteByID(ctx context.Context, id primitive.Obj
 			return nil, err
 		}
 
+		// Apply filtering based on ownership and role.
+		if err := impl.checkCanManageUser(sessCtx, user.StoreID, user.Role); err != nil {
+			return nil, err
+		}
+
+		// Security: Prevent store staff from removing the owner of their store.
+		if !permission.Can(sessCtx, permission.UserManage) && !user.StoreID.IsZero() {
+			s, err := impl.StoreStorer.GetByID(sessCtx, user.StoreID)
+			if err != nil {
+				impl.Logger.Error("database get by id error", slog.Any("error", err))
+				return nil, err
+			}
+			if s != nil && s.CreatedByUserID == user.ID {
+				impl.Logger.Warn("store owner cannot be deleted error")
+				return nil, httperror.NewForForbiddenWithSingleField("message", "the owner of the store cannot be removed")
+			}
+		}
+
 		// Security: Prevent deletion of root user(s).
 		if user.Role == user_s.UserRoleRoot {
 			impl.Logger.Warn("root user(s) cannot be deleted error")
@@ -57,6 +67,10 @@ func (impl *UserControllerImpl) DeleteByID(ctx context.Context, id primitive.Obj
 			impl.Logger.Error("database delete by id error", slog.Any("error", err))
 			return nil, err
 		}
+		if _, err := impl.SessionStorer.DeleteAllByUserID(sessCtx, id, ""); err != nil {
+			impl.Logger.Error("database delete sessions error", slog.Any("error", err))
+			return nil, err
+		}
 		return nil, nil
 	}
 
@@ -10,11 +10,11 @@ import (
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (c *UserControllerImpl) GetByID(ctx context.Context, id primitive.ObjectID) (*user_s.User, error) {
 	// Extract from our session the following data.
-	userRole, _ := ctx.Value(constants.SessionUserRole).(int8)
 	userStoreID, _ := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
 
 	// Retrieve from our database the record for the specific id.
@@ -25,7 +25,7 @@ func (c *UserControllerImpl) GetByID(ctx context.Context, id primitive.ObjectID)
 	}
 
 	// Apply filtering based on ownership and role.
-	if userRole != user_s.UserRoleRoot {
+	if m != nil && !permission.Can(ctx, permission.UserManage) {
 		if userStoreID != m.StoreID {
 			c.Logger.Error("permission error",
 				slog.Any("userStoreID", userStoreID),
@@ -4,18 +4,18 @@ import (
 	"context"
 	"log/slog"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (c *UserControllerImpl) ListByFilter(ctx context.Context, f *user_s.UserPaginationListFilter) (*user_s.UserPaginationListResult, error) {
-	// Extract from our session the following data.
-	userRole := ctx.Value(constants.SessionUserRole).(int8)
-
 	// Apply filtering based on ownership and role.
-	if userRole != user_s.UserRoleRoot {
-		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	if err := c.applyStoreStaffFilter(ctx, f); err != nil {
+		return nil, err
 	}
 
 	c.Logger.Debug("listing using filter options:",
@@ -38,12 +38,9 @@ func (c *UserControllerImpl) ListByFilter(ctx context.Context, f *user_s.UserPag
 }
 
 func (c *UserControllerImpl) ListAsSelectOptionByFilter(ctx context.Context, f *user_s.UserPaginationListFilter) ([]*user_s.UserAsSelectOption, error) {
-	// Extract from our session the following data.
-	userRole := ctx.Value(constants.SessionUserRole).(int8)
-
 	// Apply filtering based on ownership and role.
-	if userRole != user_s.UserRoleRoot {
-		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	if err := c.applyStoreStaffFilter(ctx, f); err != nil {
+		return nil, err
 	}
 
 	c.Logger.Debug("listing using filter options:",
@@ -58,3 +55,21 @@ func (c *UserControllerImpl) ListAsSelectOptionByFilter(ctx context.Context, f *
 	}
 	return m, err
 }
+
+// applyStoreStaffFilter limits the filter to the retailer staff of the logged
+// in user's store unless they may manage every user.
+func (c *UserControllerImpl) applyStoreStaffFilter(ctx context.Context, f *user_s.UserPaginationListFilter) error {
+	if permission.Can(ctx, permission.UserManage) {
+		return nil
+	}
+	if err := permission.Require(ctx, permission.UserManageStore); err != nil {
+		return err
+	}
+	f.StoreID, _ = ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+	if f.Role == 0 {
+		f.Roles = permission.RolesForScope(permission.ScopeStore)
+	} else if r := permission.GetRole(f.Role); r == nil || r.Scope != permission.ScopeStore {
+		return httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+	return nil
+}
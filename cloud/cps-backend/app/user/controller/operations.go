@@ -99,14 +99,6 @@ func (impl *UserControllerImpl) Star(ctx context.Context, id primitive.ObjectID)
 		userID, _ := sessCtx.Value(constants.SessionUserID).(primitive.ObjectID)
 		userName, _ := sessCtx.Value(constants.SessionUserName).(string)
 
-		// Extract from our session the following data.
-		userRole := sessCtx.Value(constants.SessionUserRole).(int8)
-
-		// Apply filtering based on ownership and role.
-		if userRole != user_s.UserRoleRoot {
-			return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
-		}
-
 		// Lookup the user in our database, else return a `400 Bad Request` error.
 		ou, err := impl.UserStorer.GetByID(sessCtx, id)
 		if err != nil {
@@ -118,6 +110,11 @@ func (impl *UserControllerImpl) Star(ctx context.Context, id primitive.ObjectID)
 			return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
 		}
 
+		// Apply filtering based on ownership and role.
+		if err := impl.checkCanManageUser(sessCtx, ou.StoreID, ou.Role); err != nil {
+			return nil, err
+		}
+
 		ou.IsStarred = !ou.IsStarred
 		ou.ModifiedByUserID = userID
 		ou.ModifiedAt = time.Now()
@@ -160,14 +157,6 @@ func (impl *UserControllerImpl) ArchiveByID(ctx context.Context, id primitive.Ob
 		userID, _ := sessCtx.Value(constants.SessionUserID).(primitive.ObjectID)
 		userName, _ := sessCtx.Value(constants.SessionUserName).(string)
 
-		// Extract from our session the following data.
-		userRole := sessCtx.Value(constants.SessionUserRole).(int8)
-
-		// Apply filtering based on ownership and role.
-		if userRole != user_s.UserRoleRoot {
-			return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
-		}
-
 		// Lookup the user in our database, else return a `400 Bad Request` error.
 		ou, err := impl.UserStorer.GetByID(sessCtx, id)
 		if err != nil {
@@ -179,6 +168,11 @@ func (impl *UserControllerImpl) ArchiveByID(ctx context.Context, id primitive.Ob
 			return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
 		}
 
+		// Apply filtering based on ownership and role.
+		if err := impl.checkCanManageUser(sessCtx, ou.StoreID, ou.Role); err != nil {
+			return nil, err
+		}
+
 		if ou.Status == user_s.UserStatusActive {
 			ou.Status = user_s.UserStatusArchived
 			impl.Logger.Debug("user was archived", slog.String("user_id", ou.ID.Hex()))
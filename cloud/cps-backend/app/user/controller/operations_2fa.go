@@ -8,9 +8,9 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
-	u_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 type UserOperationChangeTwoFactorAuthenticationRequest struct {
@@ -36,18 +36,13 @@ func (impl *UserControllerImpl) ChangeTwoFactorAuthentication(ctx context.Contex
 	// Get variables from our user authenticated session.
 	//
 
-	tid, _ := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
-	role, _ := ctx.Value(constants.SessionUserRole).(int8)
 	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
 	// userName, _ := ctx.Value(constants.SessionUserName).(string)
 	// ipAddress, _ := ctx.Value(constants.SessionIPAddress).(string)
 
-	switch role {
-	case u_s.UserRoleRoot:
-		break
-	default:
+	if err := permission.RequireAny(ctx, permission.UserManage, permission.UserManageStore); err != nil {
 		impl.Logger.Error("you do not have permission to change password")
-		return httperror.NewForForbiddenWithSingleField("message", "you do not have permission to change password")
+		return err
 	}
 
 	//
@@ -88,8 +83,8 @@ func (impl *UserControllerImpl) ChangeTwoFactorAuthentication(ctx context.Contex
 		}
 
 		// Defensive Code: Tenancy protection
-		if u.StoreID != tid && role != u_s.UserRoleRoot {
-			return nil, httperror.NewForForbiddenWithSingleField("security", "you do not belong to this organization")
+		if err := impl.checkCanManageUser(sessCtx, u.StoreID, u.Role); err != nil {
+			return nil, err
 		}
 
 		if req.OTPEnabled {
@@ -115,6 +110,14 @@ func (impl *UserControllerImpl) ChangeTwoFactorAuthentication(ctx context.Contex
 			u.OTPValidated = false
 			u.OTPSecret = ""
 			u.OTPAuthURL = ""
+
+			// Recovery codes are still needed if security keys are set up.
+			if !u.WebAuthnEnabled {
+				u.OTPRecoveryCodes = nil
+				u.OTPRecoveryCodesRemaining = 0
+				u.OTPBackupCodeHash = ""
+				u.OTPBackupCodeHashAlgorithm = ""
+			}
 		}
 
 		// Update user record.
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+type UserOperationChangeRoleRequest struct {
+	UserID primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Role   int8               `bson:"role" json:"role"`
+}
+
+func (impl *UserControllerImpl) validateOperationChangeRoleRequest(ctx context.Context, dirtyData *UserOperationChangeRoleRequest) error {
+	e := make(map[string]string)
+
+	if dirtyData.UserID.IsZero() {
+		e["user_id"] = "missing value"
+	}
+	if dirtyData.Role == 0 {
+		e["role"] = "missing value"
+	} else if permission.GetRole(dirtyData.Role) == nil {
+		e["role"] = "does not exist"
+	}
+
+	if len(e) != 0 {
+		return httperror.NewForBadRequest(&e)
+	}
+	return nil
+}
+
+// ChangeRole assigns the role to the user and logs them out of all their
+// devices so their next session picks up the new permissions.
+func (impl *UserControllerImpl) ChangeRole(ctx context.Context, req *UserOperationChangeRoleRequest) (*user_s.User, error) {
+	if err := permission.RequireAny(ctx, permission.UserAssignRole, permission.UserManageStore); err != nil {
+		impl.Logger.Error("you do not have permission to change roles")
+		return nil, err
+	}
+	if err := impl.validateOperationChangeRoleRequest(ctx, req); err != nil {
+		impl.Logger.Error("validation error", slog.Any("error", err))
+		return nil, err
+	}
+
+	session, err := impl.DbClient.StartSession()
+	if err != nil {
+		impl.Logger.Error("start session error",
+			slog.Any("error", err))
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// Define a transaction function with a series of operations
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		userID, _ := sessCtx.Value(constants.SessionUserID).(primitive.ObjectID)
+		userName, _ := sessCtx.Value(constants.SessionUserName).(string)
+
+		u, err := impl.UserStorer.GetByID(sessCtx, req.UserID)
+		if err != nil {
+			impl.Logger.Error("database get by id error", slog.Any("error", err))
+			return nil, err
+		}
+		if u == nil {
+			return nil, httperror.NewForBadRequestWithSingleField("user_id", "user does not exist")
+		}
+		if u.ID == userID {
+			return nil, httperror.NewForBadRequestWithSingleField("user_id", "you cannot change your own role")
+		}
+
+		// Store managers may only move staff between the store roles, both
+		// the current and the new role must be allowed for them.
+		if !permission.Can(sessCtx, permission.UserAssignRole) {
+			if err := impl.checkCanManageUser(sessCtx, u.StoreID, u.Role); err != nil {
+				return nil, err
+			}
+			if err := impl.checkCanManageUser(sessCtx, u.StoreID, req.Role); err != nil {
+				return nil, err
+			}
+		}
+
+		if u.Role == req.Role {
+			return u, nil
+		}
+		u.Role = req.Role
+		u.ModifiedAt = time.Now()
+		u.ModifiedByUserID = userID
+		u.ModifiedByName = userName
+		if err := impl.UserStorer.UpdateByID(sessCtx, u); err != nil {
+			impl.Logger.Error("user update by id error", slog.Any("error", err))
+			return nil, err
+		}
+
+		// The role is cached with the session so log the user out.
+		if _, err := impl.SessionStorer.DeleteAllByUserID(sessCtx, u.ID, ""); err != nil {
+			impl.Logger.Error("delete sessions error", slog.Any("err", err))
+			return nil, err
+		}
+		impl.Logger.Debug("user role changed",
+			slog.String("user_id", u.ID.Hex()),
+			slog.Any("role", u.Role))
+		return u, nil
+	}
+
+	// Start a transaction
+	res, err := session.WithTransaction(ctx, transactionFunc)
+	if err != nil {
+		impl.Logger.Error("session failed error",
+			slog.Any("error", err))
+		return nil, err
+	}
+	return res.(*user_s.User), nil
+}
+
+// ListRoles returns every role along with the permissions it grants.
+func (impl *UserControllerImpl) ListRoles(ctx context.Context) ([]*permission.Role, error) {
+	if err := permission.RequireAny(ctx, permission.UserManage, permission.UserManageStore); err != nil {
+		return nil, err
+	}
+	return permission.Roles(), nil
+}
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	la_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/loginattempt/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+type UserOperationUnlockRequest struct {
+	UserID primitive.ObjectID `bson:"user_id" json:"user_id"`
+}
+
+// Unlock clears the failed sign in attempts of the user so they can sign in
+// right away instead of waiting for the lock to expire.
+func (impl *UserControllerImpl) Unlock(ctx context.Context, req *UserOperationUnlockRequest) error {
+	if err := permission.RequireAny(ctx, permission.UserManage, permission.UserManageStore); err != nil {
+		impl.Logger.Error("you do not have permission to unlock users")
+		return err
+	}
+	if req.UserID.IsZero() {
+		return httperror.NewForBadRequestWithSingleField("user_id", "missing value")
+	}
+
+	u, err := impl.UserStorer.GetByID(ctx, req.UserID)
+	if err != nil {
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return err
+	}
+	if u == nil {
+		return httperror.NewForBadRequestWithSingleField("user_id", "user does not exist")
+	}
+	if err := impl.checkCanManageUser(ctx, u.StoreID, u.Role); err != nil {
+		return err
+	}
+
+	for _, key := range []string{la_s.AccountKey(u.Email), la_s.OTPKey(u.ID)} {
+		if err := impl.LoginAttemptStorer.DeleteByKey(ctx, key); err != nil {
+			impl.Logger.Error("database delete login attempts error", slog.Any("error", err))
+			return err
+		}
+	}
+	impl.Logger.Debug("user unlocked", slog.String("user_id", u.ID.Hex()))
+	return nil
+}
@@ -8,9 +8,9 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
-	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 type UserOperationChangePasswordRequest struct {
@@ -53,18 +53,13 @@ func (impl *UserControllerImpl) ChangePassword(ctx context.Context, req *UserOpe
 	// Get variables from our user authenticated session.
 	//
 
-	tid, _ := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
-	role, _ := ctx.Value(constants.SessionUserRole).(int8)
 	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
 	// userName, _ := ctx.Value(constants.SessionUserName).(string)
 	// ipAddress, _ := ctx.Value(constants.SessionIPAddress).(string)
 
-	switch role {
-	case user_s.UserRoleRoot:
-		break
-	default:
+	if err := permission.RequireAny(ctx, permission.UserManage, permission.UserManageStore); err != nil {
 		impl.Logger.Error("you do not have permission to change password")
-		return httperror.NewForForbiddenWithSingleField("message", "you do not have permission to change password")
+		return err
 	}
 
 	//
@@ -105,8 +100,8 @@ func (impl *UserControllerImpl) ChangePassword(ctx context.Context, req *UserOpe
 		}
 
 		// Defensive Code: Tenancy protection
-		if u.StoreID != tid && role != user_s.UserRoleRoot {
-			return nil, httperror.NewForForbiddenWithSingleField("security", "you do not belong to this organization")
+		if err := impl.checkCanManageUser(sessCtx, u.StoreID, u.Role); err != nil {
+			return nil, err
 		}
 
 		passwordHash, err := impl.Password.GenerateHashFromPassword(req.Password)
@@ -127,6 +122,12 @@ func (impl *UserControllerImpl) ChangePassword(ctx context.Context, req *UserOpe
 			return nil, err
 		}
 
+		// Log the user out of all their devices so the new password is required.
+		if _, err := impl.SessionStorer.DeleteAllByUserID(sessCtx, u.ID, ""); err != nil {
+			impl.Logger.Error("delete sessions error", slog.Any("err", err))
+			return nil, err
+		}
+
 		return u, nil
 	}
 
@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	sj_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/datastore"
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 type UserUpdateRequestIDO struct {
@@ -19,6 +22,7 @@ type UserUpdateRequestIDO struct {
 	FirstName                                       string             `json:"first_name"`
 	LastName                                        string             `json:"last_name"`
 	Email                                           string             `json:"email"`
+	Locale                                          string             `json:"locale,omitempty"`
 	Password                                        string             `json:"password"`
 	PasswordRepeated                                string             `json:"password_repeated"`
 	Phone                                           string             `json:"phone,omitempty"`
@@ -69,6 +73,7 @@ func (impl *UserControllerImpl) userFromUpdateRequest(requestData *UserUpdateReq
 		FirstName:                             requestData.FirstName,
 		LastName:                              requestData.LastName,
 		Email:                                 requestData.Email,
+		Locale:                                requestData.Locale,
 		PasswordHash:                          passwordHash,
 		PasswordHashAlgorithm:                 impl.Password.AlgorithmName(),
 		Phone:                                 requestData.Phone,
@@ -127,14 +132,6 @@ func (impl *UserControllerImpl) UpdateByID(ctx context.Context, requestData *Use
 		userID, _ := sessCtx.Value(constants.SessionUserID).(primitive.ObjectID)
 		userName, _ := sessCtx.Value(constants.SessionUserName).(string)
 
-		// Extract from our session the following data.
-		userRole := sessCtx.Value(constants.SessionUserRole).(int8)
-
-		// Apply filtering based on ownership and role.
-		if userRole != user_s.UserRoleRoot {
-			return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
-		}
-
 		// Lookup the user in our database, else return a `400 Bad Request` error.
 		ou, err := impl.UserStorer.GetByID(sessCtx, nu.ID)
 		if err != nil {
@@ -146,6 +143,16 @@ func (impl *UserControllerImpl) UpdateByID(ctx context.Context, requestData *Use
 			return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
 		}
 
+		// Apply filtering based on ownership and role.
+		if err := impl.checkCanManageUser(sessCtx, ou.StoreID, ou.Role); err != nil {
+			return nil, err
+		}
+
+		// Store managers cannot move staff to another store.
+		if !permission.Can(sessCtx, permission.UserManage) {
+			nu.StoreID = ou.StoreID
+		}
+
 		// Lookup the store in our database, else return a `400 Bad Request` error.
 		o, err := impl.StoreStorer.GetByID(sessCtx, nu.StoreID)
 		if err != nil {
@@ -157,14 +164,20 @@ func (impl *UserControllerImpl) UpdateByID(ctx context.Context, requestData *Use
 			return nil, httperror.NewForBadRequestWithSingleField("store_id", "store does not exist")
 		}
 
+		// The email can only be changed by the user confirming the new
+		// address, otherwise the password reset channel could be taken over.
+		if nu.Email != "" && !strings.EqualFold(nu.Email, ou.Email) {
+			return nil, httperror.NewForBadRequestWithSingleField("email", "can only be changed by the user from their profile")
+		}
+
 		ou.StoreID = o.ID
 		ou.StoreName = o.Name
 		ou.FirstName = nu.FirstName
 		ou.LastName = nu.LastName
 		ou.Name = fmt.Sprintf("%s %s", nu.FirstName, nu.LastName)
 		ou.LexicalName = fmt.Sprintf("%s, %s", nu.LastName, nu.FirstName)
-		ou.Email = nu.Email
 		ou.Phone = nu.Phone
+		ou.Locale = nu.Locale
 		ou.Country = nu.Country
 		ou.Region = nu.Region
 		ou.City = nu.City
@@ -197,6 +210,13 @@ func (impl *UserControllerImpl) UpdateByID(ctx context.Context, requestData *Use
 			return nil, err
 		}
 
+		// Queue copying the user names onto their related records, this is
+		// saved in the same transaction so the change cannot be lost.
+		if err := impl.SyncJobStorer.Create(sessCtx, sj_s.NewSyncJob(sj_s.TypeUser, ou.ID)); err != nil {
+			impl.Logger.Error("sync job create error", slog.Any("error", err))
+			return nil, err
+		}
+
 		////
 		//// End transaction with success.
 		////
@@ -214,41 +234,6 @@ func (impl *UserControllerImpl) UpdateByID(ctx context.Context, requestData *Use
 
 	user := res.(*user_s.User)
 
-	////
-	//// Update related (in background)
-	////
-
-	go func(usr *user_s.User) {
-		if err := impl.updateRelatedComicsInBackground(usr); err != nil {
-			impl.Logger.Error("update related comics failed", slog.Any("error", err))
-		}
-	}(user)
-	go func(usr *user_s.User) {
-		if err := impl.updateRelatedAttachmentsInBackground(usr); err != nil {
-			impl.Logger.Error("update related attachments failed", slog.Any("error", err))
-		}
-	}(user)
-	go func(usr *user_s.User) {
-		if err := impl.updateRelatedCreditsInBackground(usr); err != nil {
-			impl.Logger.Error("update related credits failed", slog.Any("error", err))
-		}
-	}(user)
-	go func(usr *user_s.User) {
-		if err := impl.updateRelatedReceiptsInBackground(usr); err != nil {
-			impl.Logger.Error("update related receipts failed", slog.Any("error", err))
-		}
-	}(user)
-	go func(usr *user_s.User) {
-		if err := impl.updateRelatedStoreInBackground(usr); err != nil {
-			impl.Logger.Error("update related stores failed", slog.Any("error", err))
-		}
-	}(user)
-	go func(usr *user_s.User) {
-		if err := impl.updateRelateUserPurchasesInBackground(usr); err != nil {
-			impl.Logger.Error("update related user purchase failed", slog.Any("error", err))
-		}
-	}(user)
-
 	// End.
 
 	return user, nil
@@ -19,8 +19,23 @@ const (
 	UserRoleRoot       = 1
 	UserRoleRetailer   = 2
 	UserRoleCustomer   = 3
+	// UserRoleGrader is staff who grade the submissions of every store.
+	UserRoleGrader = 4
+	// UserRoleShippingClerk is staff who receive and ship the submissions.
+	UserRoleShippingClerk = 5
+	// UserRoleStoreManager is a retailer who also manages the staff of their store.
+	UserRoleStoreManager = 6
 )
 
+// The notification emails the user can unsubscribe from.
+const (
+	// NotificationSubmissionStatusChanged is the email telling the customer
+	// and retailer their submission was received, graded or shipped.
+	NotificationSubmissionStatusChanged = "submission_status_changed"
+)
+
+var Notifications = []string{NotificationSubmissionStatusChanged}
+
 type User struct {
 	ID                        primitive.ObjectID `bson:"_id" json:"id"`
 	StoreID                   primitive.ObjectID `bson:"store_id" json:"store_id,omitempty"`
@@ -32,6 +47,7 @@ type User struct {
 	Name                      string             `bson:"name" json:"name"`
 	LexicalName               string             `bson:"lexical_name" json:"lexical_name"`
 	Email                     string             `bson:"email" json:"email"`
+	Locale                    string             `bson:"locale" json:"locale,omitempty"` // The language we email the user in.
 	PasswordHashAlgorithm     string             `bson:"password_hash_algorithm" json:"password_hash_algorithm,omitempty"`
 	PasswordHash              string             `bson:"password_hash" json:"password_hash,omitempty"`
 	Role                      int8               `bson:"role" json:"role"`
@@ -96,11 +112,44 @@ type User struct {
 	OTPAuthURL string `bson:"otp_auth_url" json:"-"`
 
 	// OTPBackupCodeHash is the one-time use backup code which resets the 2FA settings and allow the user to setup 2FA from scratch for the user.
+	//
+	// Deprecated: replaced by `OTPRecoveryCodes`, only kept so users who
+	// enrolled before can still use their code once.
 	OTPBackupCodeHash string `bson:"otp_backup_code_hash" json:"-"`
 
 	// OTPBackupCodeHashAlgorithm tracks the hashing algorithm used.
 	OTPBackupCodeHashAlgorithm string `bson:"otp_backup_code_hash_algorithm" json:"-"`
 
+	// OTPRecoveryCodes are the single-use codes the user can enter instead of
+	// their 2FA token, they are generated when 2FA is enabled.
+	OTPRecoveryCodes []*OTPRecoveryCode `bson:"otp_recovery_codes" json:"-"`
+
+	// OTPRecoveryCodesRemaining is the number of `OTPRecoveryCodes` which were not used yet.
+	OTPRecoveryCodesRemaining int `bson:"otp_recovery_codes_remaining" json:"otp_recovery_codes_remaining"`
+
+	// WebAuthnEnabled is true while the user has at least one hardware
+	// security key or passkey registered, it forces 2FA during login like
+	// `OTPEnabled` does and `OTPValidated` gets set once a key was used.
+	WebAuthnEnabled bool `bson:"webauthn_enabled" json:"webauthn_enabled"`
+
+	// OIDCSubject is the identifier of the user at our single sign-on
+	// identity provider, it is set the first time the user signs in with it.
+	OIDCSubject string `bson:"oidc_subject" json:"-"`
+
+	// PendingEmail is the new email address the user asked to change to, it
+	// only replaces `Email` once the user clicks the link we sent to it.
+	PendingEmail string `bson:"pending_email" json:"pending_email,omitempty"`
+
+	// EmailChangeCode is the code of the link we sent to `PendingEmail`.
+	EmailChangeCode string `bson:"email_change_code,omitempty" json:"-"`
+
+	// EmailChangeExpiry is when the `EmailChangeCode` stops working.
+	EmailChangeExpiry time.Time `bson:"email_change_expiry,omitempty" json:"-"`
+
+	// UnsubscribedNotifications are the notification emails the user opted
+	// out of, see `Notifications`.
+	UnsubscribedNotifications []string `bson:"unsubscribed_notifications" json:"unsubscribed_notifications,omitempty"`
+
 	HowLongCollectingComicBooksForGrading           int8 `bson:"how_long_collecting_comic_books_for_grading" json:"how_long_collecting_comic_books_for_grading"`
 	HasPreviouslySubmittedComicBookForGrading       int8 `bson:"has_previously_submitted_comic_book_for_grading" json:"has_previously_submitted_comic_book_for_grading"`
 	HasOwnedGradedComicBooks                        int8 `bson:"has_owned_graded_comic_books" json:"has_owned_graded_comic_books"`
@@ -110,6 +159,23 @@ type User struct {
 	HasRegularlyAttendedComicConsOrCollectibleShows int8 `bson:"has_regularly_attended_comic_cons_or_collectible_shows" json:"has_regularly_attended_comic_cons_or_collectible_shows"`
 }
 
+// IsSubscribedTo returns false if the user unsubscribed from the notification.
+func (u *User) IsSubscribedTo(notification string) bool {
+	for _, n := range u.UnsubscribedNotifications {
+		if n == notification {
+			return false
+		}
+	}
+	return true
+}
+
+// OTPRecoveryCode is one hashed 2FA recovery code.
+type OTPRecoveryCode struct {
+	Hash          string    `bson:"hash" json:"-"`
+	HashAlgorithm string    `bson:"hash_algorithm" json:"-"`
+	UsedAt        time.Time `bson:"used_at" json:"-"`
+}
+
 type UserComment struct {
 	ID               primitive.ObjectID `bson:"_id" json:"id"`
 	StoreID          primitive.ObjectID `bson:"store_id" json:"store_id"`
@@ -161,9 +227,11 @@ type UserStorer interface {
 	GetByID(ctx context.Context, id primitive.ObjectID) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	GetByVerificationCode(ctx context.Context, verificationCode string) (*User, error)
+	GetByEmailChangeCode(ctx context.Context, code string) (*User, error)
 	GetByPaymentProcessorCustomerID(ctx context.Context, paymentProcessorCustomerID string) (*User, error)
 	CheckIfExistsByEmail(ctx context.Context, email string) (bool, error)
 	UpdateByID(ctx context.Context, m *User) error
+	UpdateStoreByStoreID(ctx context.Context, storeID primitive.ObjectID, storeName string, storeLevel int8, storeTimezone string) (int64, error)
 	ListByFilter(ctx context.Context, f *UserPaginationListFilter) (*UserPaginationListResult, error)
 	ListAsSelectOptionByFilter(ctx context.Context, f *UserPaginationListFilter) ([]*UserAsSelectOption, error)
 	ListAllRootStaff(ctx context.Context) (*UserPaginationListResult, error)
@@ -57,6 +57,22 @@ func (impl UserStorerImpl) GetByVerificationCode(ctx context.Context, verificati
 	return &result, nil
 }
 
+func (impl UserStorerImpl) GetByEmailChangeCode(ctx context.Context, code string) (*User, error) {
+	filter := bson.D{{"email_change_code", code}}
+
+	var result User
+	err := impl.Collection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// This error means your query did not match any documents.
+			return nil, nil
+		}
+		impl.Logger.Error("database get by email change code error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
+
 func (impl UserStorerImpl) GetByPaymentProcessorCustomerID(ctx context.Context, paymentProcessorCustomerID string) (*User, error) {
 	filter := bson.D{{"payment_processor_customer_id", paymentProcessorCustomerID}}
 
@@ -24,6 +24,8 @@ func (impl UserStorerImpl) ListByFilter(ctx context.Context, f *UserPaginationLi
 	}
 	if f.Role > 0 {
 		filter["role"] = f.Role
+	} else if len(f.Roles) > 0 {
+		filter["role"] = bson.M{"$in": f.Roles}
 	}
 	if f.FirstName != "" {
 		filter["first_name"] = f.FirstName
@@ -118,7 +120,7 @@ func (impl UserStorerImpl) ListAllRetailerStaffForStoreID(ctx context.Context, s
 		PageSize:  1_000_000,
 		SortField: "created_at",
 		SortOrder: -1,
-		Role:      UserRoleRetailer,
+		Roles:     []int8{UserRoleRetailer, UserRoleStoreManager},
 		StoreID:   storeID,
 		Status:    UserStatusActive,
 	}
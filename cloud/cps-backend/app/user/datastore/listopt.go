@@ -30,6 +30,8 @@ func (impl UserStorerImpl) ListAsSelectOptionByFilter(ctx context.Context, f *Us
 	}
 	if f.Role > 0 {
 		query["role"] = f.Role
+	} else if len(f.Roles) > 0 {
+		query["role"] = bson.M{"$in": f.Roles}
 	}
 	if f.FirstName != "" {
 		query["first_name"] = f.FirstName
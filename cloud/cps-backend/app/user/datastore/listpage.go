@@ -27,6 +27,7 @@ type UserPaginationListFilter struct {
 	// Filter related.
 	StoreID      primitive.ObjectID
 	Role         int8
+	Roles        []int8 // Only used when `Role` is not set.
 	Status       int8
 	UUIDs        []string
 	SearchText   string
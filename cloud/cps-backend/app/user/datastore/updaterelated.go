@@ -0,0 +1,28 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UpdateStoreByStoreID updates the store details saved on every user of the
+// store.
+func (impl UserStorerImpl) UpdateStoreByStoreID(ctx context.Context, storeID primitive.ObjectID, storeName string, storeLevel int8, storeTimezone string) (int64, error) {
+	filter := bson.M{"store_id": storeID}
+	update := bson.M{"$set": bson.M{
+		"store_name":     storeName,
+		"store_level":    storeLevel,
+		"store_timezone": storeTimezone,
+		"modified_at":    time.Now(),
+	}}
+	result, err := impl.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		impl.Logger.Error("database update many error", slog.Any("error", err))
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
@@ -9,6 +9,7 @@ import (
 	usr_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/controller"
 	usr_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
 )
 
 func UnmarshalCreateRequest(ctx context.Context, r *http.Request) (*usr_c.UserCreateRequestIDO, error) {
@@ -130,6 +131,10 @@ func ValidateCreateRequest(dirtyData *usr_c.UserCreateRequestIDO) error {
 			e["has_regularly_attended_comic_cons_or_collectible_shows"] = "missing value"
 		}
 	}
+	if dirtyData.Locale != "" && !i18n.IsSupported(dirtyData.Locale) {
+		e["locale"] = "unsupported value"
+	}
+
 	if len(e) != 0 {
 		return httperror.NewForBadRequest(&e)
 	}
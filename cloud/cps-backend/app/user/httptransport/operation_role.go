@@ -0,0 +1,74 @@
+package httptransport
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	c_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/controller"
+	usr_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+func UnmarshalOperationChangeRoleRequest(ctx context.Context, r *http.Request) (*c_c.UserOperationChangeRoleRequest, error) {
+	// Initialize our array which will store all the results from the remote server.
+	var requestData c_c.UserOperationChangeRoleRequest
+
+	defer r.Body.Close()
+
+	// Read the JSON string and convert it into our golang stuct else we need
+	// to send a `400 Bad Request` errror message back to the client,
+	err := json.NewDecoder(r.Body).Decode(&requestData) // [1]
+	if err != nil {
+		log.Println("UnmarshalOperationChangeRoleRequest | NewDecoder/Decode | err:", err)
+		return nil, httperror.NewForSingleField(http.StatusBadRequest, "non_field_error", "payload structure is wrong")
+	}
+
+	return &requestData, nil
+}
+
+func (h *Handler) OperationChangeRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reqData, err := UnmarshalOperationChangeRoleRequest(ctx, r)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	data, err := h.Controller.ChangeRole(ctx, reqData)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalOperationChangeRoleResponse(data, w)
+}
+
+func MarshalOperationChangeRoleResponse(res *usr_s.User, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) ListRoles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	data, err := h.Controller.ListRoles(ctx)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalListRolesResponse(data, w)
+}
+
+func MarshalListRolesResponse(res []*permission.Role, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,44 @@
+package httptransport
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	c_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/controller"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+func UnmarshalOperationUnlockRequest(ctx context.Context, r *http.Request) (*c_c.UserOperationUnlockRequest, error) {
+	// Initialize our array which will store all the results from the remote server.
+	var requestData c_c.UserOperationUnlockRequest
+
+	defer r.Body.Close()
+
+	// Read the JSON string and convert it into our golang stuct else we need
+	// to send a `400 Bad Request` errror message back to the client,
+	err := json.NewDecoder(r.Body).Decode(&requestData) // [1]
+	if err != nil {
+		log.Println("UnmarshalOperationUnlockRequest | NewDecoder/Decode | err:", err)
+		return nil, httperror.NewForSingleField(http.StatusBadRequest, "non_field_error", "payload structure is wrong")
+	}
+
+	return &requestData, nil
+}
+
+func (h *Handler) OperationUnlock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reqData, err := UnmarshalOperationUnlockRequest(ctx, r)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := h.Controller.Unlock(ctx, reqData); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -11,6 +11,7 @@ import (
 	usr_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/controller"
 	usr_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/i18n"
 )
 
 func UnmarshalUpdateRequest(ctx context.Context, r *http.Request) (*usr_c.UserUpdateRequestIDO, error) {
@@ -129,6 +130,10 @@ func ValidateUpdateRequest(dirtyData *usr_c.UserUpdateRequestIDO) error {
 			e["has_regularly_attended_comic_cons_or_collectible_shows"] = "missing value"
 		}
 	}
+	if dirtyData.Locale != "" && !i18n.IsSupported(dirtyData.Locale) {
+		e["locale"] = "unsupported value"
+	}
+
 	if len(e) != 0 {
 		return httperror.NewForBadRequest(&e)
 	}
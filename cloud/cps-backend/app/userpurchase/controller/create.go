@@ -7,26 +7,21 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
-	u_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	s_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
-	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (c *UserPurchaseControllerImpl) Create(ctx context.Context, m *s_d.UserPurchase) (*s_d.UserPurchase, error) {
 	// Extract from our session the following data.
-	urole, _ := ctx.Value(constants.SessionUserRole).(int8)
 	// uid := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
 	// uname := ctx.Value(constants.SessionUserName).(string)
 	oid, _ := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
 	oname, _ := ctx.Value(constants.SessionUserStoreName).(string)
 	otz, _ := ctx.Value(constants.SessionUserStoreTimezone).(string)
 
-	switch urole { // Security.
-	case u_d.UserRoleRoot:
-		c.Logger.Debug("access granted")
-	default:
-		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	if err := permission.Require(ctx, permission.PurchaseManage); err != nil { // Security.
+		return nil, err
 	}
 
 	// Add defaults.
@@ -6,19 +6,18 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
-	u_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
 )
 
 func (c *UserPurchaseControllerImpl) ListByFilter(ctx context.Context, f *domain.UserPurchasePaginationListFilter) (*domain.UserPurchasePaginationListResult, error) {
 	// // Extract from our session the following data.
 	// userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
 	userOID := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
-	userRole := ctx.Value(constants.SessionUserRole).(int8)
 	//
 	// Apply filtering based on ownership and role.
-	if userRole != u_s.UserRoleRoot {
+	if !permission.Can(ctx, permission.PurchaseViewAll) {
 		f.StoreID = userOID
 	}
 
@@ -60,6 +60,12 @@ type UserPurchase struct {
 	AmountTax float64 `bson:"amount_tax" json:"amount_tax"`
 	// AmountTotal of total of all items after discounts and taxes are applied.
 	AmountTotal float64 `bson:"amount_total" json:"amount_total"`
+	// Currency is the currency the customer was charged in, all the amounts above are in this currency.
+	Currency string `bson:"currency" json:"currency"`
+	// BaseAmountTotal is the `AmountTotal` converted into our `BaseCurrency` using the `ExchangeRate` at the time of purchase.
+	BaseAmountTotal float64 `bson:"base_amount_total" json:"base_amount_total"`
+	BaseCurrency    string  `bson:"base_currency" json:"base_currency"`
+	ExchangeRate    float64 `bson:"exchange_rate" json:"exchange_rate"`
 }
 
 type UserPurchaseListFilter struct {
@@ -90,6 +96,8 @@ type UserPurchaseStorer interface {
 	GetByPaymentProcessorPurchaseID(ctx context.Context, paymentProcessorPurchaseID string) (*UserPurchase, error)
 	GetByComicSubmissionID(ctx context.Context, comicSubmissionID primitive.ObjectID) (*UserPurchase, error)
 	UpdateByID(ctx context.Context, m *UserPurchase) error
+	UpdateStoreByStoreID(ctx context.Context, storeID primitive.ObjectID, storeName, storeTimezone string) (int64, error)
+	UpdateUserNameByUserID(ctx context.Context, userID primitive.ObjectID, userName, userLexicalName string) (int64, error)
 	ListByFilter(ctx context.Context, m *UserPurchasePaginationListFilter) (*UserPurchasePaginationListResult, error)
 	ListAsSelectOptionByFilter(ctx context.Context, f *UserPurchasePaginationListFilter) ([]*UserPurchaseAsSelectOption, error)
 	DeleteByID(ctx context.Context, id primitive.ObjectID) error
@@ -0,0 +1,82 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+// WebAuthnCredential is a hardware security key or passkey the user
+// registered for signing in.
+type WebAuthnCredential struct {
+	ID     primitive.ObjectID `bson:"_id" json:"id"`
+	UserID primitive.ObjectID `bson:"user_id" json:"user_id"`
+	// Name is picked by the user so they can tell their keys apart.
+	Name string `bson:"name" json:"name"`
+	// CredentialID is the id the authenticator gave the credential.
+	CredentialID []byte `bson:"credential_id" json:"-"`
+	// PublicKey is the COSE encoded public key.
+	PublicKey []byte `bson:"public_key" json:"-"`
+	Algorithm int64  `bson:"algorithm" json:"algorithm"`
+	SignCount uint32 `bson:"sign_count" json:"-"`
+	AAGUID    []byte `bson:"aaguid" json:"-"`
+	// UserVerified is true if the authenticator verified the user with a
+	// pin or biometric on registration, only these can be used to sign in
+	// without a password.
+	UserVerified bool      `bson:"user_verified" json:"user_verified"`
+	CreatedAt    time.Time `bson:"created_at" json:"created_at"`
+	LastUsedAt   time.Time `bson:"last_used_at" json:"last_used_at"`
+}
+
+// WebAuthnCredentialStorer Interface for webauthn credentials.
+type WebAuthnCredentialStorer interface {
+	Create(ctx context.Context, m *WebAuthnCredential) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*WebAuthnCredential, error)
+	GetByCredentialID(ctx context.Context, credentialID []byte) (*WebAuthnCredential, error)
+	UpdateByID(ctx context.Context, m *WebAuthnCredential) error
+	ListByUserID(ctx context.Context, userID primitive.ObjectID) ([]*WebAuthnCredential, error)
+	DeleteByID(ctx context.Context, id primitive.ObjectID) error
+}
+
+type WebAuthnCredentialStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) WebAuthnCredentialStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("webauthn_credentials")
+
+	// The following few lines of code will create the index for our app for
+	// this colleciton.
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "credential_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &WebAuthnCredentialStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
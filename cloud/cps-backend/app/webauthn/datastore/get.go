@@ -0,0 +1,42 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (impl WebAuthnCredentialStorerImpl) GetByID(ctx context.Context, id primitive.ObjectID) (*WebAuthnCredential, error) {
+	filter := bson.M{"_id": id}
+
+	var result WebAuthnCredential
+	err := impl.Collection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// This error means your query did not match any documents.
+			return nil, nil
+		}
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (impl WebAuthnCredentialStorerImpl) GetByCredentialID(ctx context.Context, credentialID []byte) (*WebAuthnCredential, error) {
+	filter := bson.M{"credential_id": credentialID}
+
+	var result WebAuthnCredential
+	err := impl.Collection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// This error means your query did not match any documents.
+			return nil, nil
+		}
+		impl.Logger.Error("database get by credential id error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,31 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ListByUserID returns all the credentials of the user with the newest
+// first. Users only have a handful of keys so we do not paginate.
+func (impl WebAuthnCredentialStorerImpl) ListByUserID(ctx context.Context, userID primitive.ObjectID) ([]*WebAuthnCredential, error) {
+	filter := bson.M{"user_id": userID}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := impl.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		impl.Logger.Error("database list by user id error", slog.Any("error", err))
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results = []*WebAuthnCredential{}
+	if err := cursor.All(ctx, &results); err != nil {
+		impl.Logger.Error("database list by user id decode error", slog.Any("error", err))
+		return nil, err
+	}
+	return results, nil
+}
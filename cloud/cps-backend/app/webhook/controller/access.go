@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+// getWebhookForManage returns the webhook if the logged in user may manage
+// it. Store staff only see the webhooks of their own store, the webhooks of
+// other stores are reported as not existing.
+func (impl *WebhookControllerImpl) getWebhookForManage(ctx context.Context, id primitive.ObjectID) (*domain.Webhook, error) {
+	if err := permission.Require(ctx, permission.WebhookManage); err != nil {
+		return nil, err
+	}
+
+	m, err := impl.WebhookStorer.GetByID(ctx, id)
+	if err != nil {
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if m == nil {
+		return nil, httperror.NewForSingleField(http.StatusNotFound, "id", "webhook does not exist")
+	}
+	if permission.Scope(ctx) != permission.ScopeSystem {
+		storeID, _ := ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+		if m.StoreID != storeID {
+			return nil, httperror.NewForSingleField(http.StatusNotFound, "id", "webhook does not exist")
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/datastore"
+	whd_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhookdelivery/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+// WebhookController Interface for the webhooks of the retail partners and
+// for sending them their events.
+type WebhookController interface {
+	Create(ctx context.Context, req *WebhookCreateRequestIDO) (*WebhookCreateResponseIDO, error)
+	GetByID(ctx context.Context, id primitive.ObjectID) (*domain.Webhook, error)
+	UpdateByID(ctx context.Context, req *WebhookUpdateRequestIDO) (*domain.Webhook, error)
+	ListByFilter(ctx context.Context, f *domain.WebhookListFilter) ([]*domain.Webhook, error)
+	DeleteByID(ctx context.Context, id primitive.ObjectID) error
+	PingByID(ctx context.Context, id primitive.ObjectID) (*whd_s.WebhookDelivery, error)
+	ListDeliveriesByFilter(ctx context.Context, f *whd_s.WebhookDeliveryPaginationListFilter) (*whd_s.WebhookDeliveryPaginationListResult, error)
+	RedeliverByID(ctx context.Context, deliveryID primitive.ObjectID) (*whd_s.WebhookDelivery, error)
+	DispatchPending(ctx context.Context) error
+}
+
+type WebhookControllerImpl struct {
+	Config                *config.Conf
+	Logger                *slog.Logger
+	HTTPClient            *http.Client
+	StoreStorer           store_s.StoreStorer
+	WebhookStorer         domain.WebhookStorer
+	WebhookDeliveryStorer whd_s.WebhookDeliveryStorer
+}
+
+func NewController(
+	appCfg *config.Conf,
+	loggerp *slog.Logger,
+	org_storer store_s.StoreStorer,
+	wh_storer domain.WebhookStorer,
+	whd_storer whd_s.WebhookDeliveryStorer,
+) WebhookController {
+	loggerp.Debug("webhook controller initialization started...")
+	s := &WebhookControllerImpl{
+		Config:                appCfg,
+		Logger:                loggerp,
+		HTTPClient:            newHTTPClient(appCfg.AppServer.IsDeveloperMode),
+		StoreStorer:           org_storer,
+		WebhookStorer:         wh_storer,
+		WebhookDeliveryStorer: whd_storer,
+	}
+	s.Logger.Debug("webhook controller initialized")
+	return s
+}
@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+// secretPrefix makes our webhook secrets easy to recognize, for example by
+// secret scanners.
+const secretPrefix = "whsec_"
+
+type WebhookCreateRequestIDO struct {
+	// StoreID is only used by our staff, store staff always create the
+	// webhooks of their own store.
+	StoreID     primitive.ObjectID `json:"store_id"`
+	URL         string             `json:"url"`
+	Description string             `json:"description"`
+	Events      []string           `json:"events"`
+}
+
+// WebhookCreateResponseIDO includes the secret, it is only ever returned
+// once so the partner must copy it right away.
+type WebhookCreateResponseIDO struct {
+	Webhook *domain.Webhook `json:"webhook"`
+	Secret  string          `json:"secret"`
+}
+
+// validateWebhook returns the validation errors of the fields which are
+// shared by the create and update requests.
+func validateWebhook(e map[string]string, rawURL, description string, events []string, isDeveloperMode bool) {
+	if rawURL == "" {
+		e["url"] = "missing value"
+	} else if u, err := url.Parse(rawURL); err != nil || u.Host == "" {
+		e["url"] = "invalid value"
+	} else if u.Scheme != "https" && !(isDeveloperMode && u.Scheme == "http") {
+		e["url"] = "must use https"
+	} else if len(rawURL) > 2048 {
+		e["url"] = "too long"
+	}
+	if len(description) > 255 {
+		e["description"] = "too long"
+	}
+	if len(events) == 0 {
+		e["events"] = "missing value"
+	}
+	for _, event := range events {
+		if !slices.Contains(domain.Events, event) {
+			e["events"] = "unsupported event " + event
+			break
+		}
+	}
+}
+
+// normalizeEvents returns the events sorted and without duplicates.
+func normalizeEvents(events []string) []string {
+	res := slices.Clone(events)
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
+
+func (impl *WebhookControllerImpl) Create(ctx context.Context, req *WebhookCreateRequestIDO) (*WebhookCreateResponseIDO, error) {
+	if err := permission.Require(ctx, permission.WebhookManage); err != nil {
+		return nil, err
+	}
+
+	req.URL = strings.TrimSpace(req.URL)
+	req.Description = strings.TrimSpace(req.Description)
+
+	e := make(map[string]string)
+	validateWebhook(e, req.URL, req.Description, req.Events, impl.Config.AppServer.IsDeveloperMode)
+	if permission.Scope(ctx) == permission.ScopeSystem && req.StoreID.IsZero() {
+		e["store_id"] = "missing value"
+	}
+	if len(e) != 0 {
+		return nil, httperror.NewForBadRequest(&e)
+	}
+
+	storeID := req.StoreID
+	if permission.Scope(ctx) != permission.ScopeSystem {
+		storeID, _ = ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+	}
+	store, err := impl.StoreStorer.GetByID(ctx, storeID)
+	if err != nil {
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if store == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("store_id", "store does not exist")
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		impl.Logger.Error("secret generation error", slog.Any("error", err))
+		return nil, err
+	}
+
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	m := &domain.Webhook{
+		ID:              primitive.NewObjectID(),
+		StoreID:         store.ID,
+		StoreName:       store.Name,
+		URL:             req.URL,
+		Description:     req.Description,
+		Events:          normalizeEvents(req.Events),
+		Status:          domain.StatusActive,
+		Secret:          secret,
+		CreatedByUserID: userID,
+		CreatedAt:       time.Now(),
+		ModifiedAt:      time.Now(),
+	}
+	if err := impl.WebhookStorer.Create(ctx, m); err != nil {
+		return nil, err
+	}
+	impl.Logger.Info("webhook created",
+		slog.String("webhook_id", m.ID.Hex()),
+		slog.String("store_id", m.StoreID.Hex()))
+
+	return &WebhookCreateResponseIDO{
+		Webhook: m,
+		Secret:  secret,
+	}, nil
+}
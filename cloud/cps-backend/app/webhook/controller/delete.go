@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeleteByID deletes the webhook along with its delivery log, the deliveries
+// which were not sent yet are dropped.
+func (impl *WebhookControllerImpl) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
+	m, err := impl.getWebhookForManage(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := impl.WebhookStorer.DeleteByID(ctx, m.ID); err != nil {
+		return err
+	}
+	if _, err := impl.WebhookDeliveryStorer.DeleteAllByWebhookID(ctx, m.ID); err != nil {
+		return err
+	}
+	impl.Logger.Info("webhook deleted", slog.String("webhook_id", m.ID.Hex()))
+	return nil
+}
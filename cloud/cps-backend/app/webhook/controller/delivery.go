@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	whd_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhookdelivery/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+func (impl *WebhookControllerImpl) ListDeliveriesByFilter(ctx context.Context, f *whd_s.WebhookDeliveryPaginationListFilter) (*whd_s.WebhookDeliveryPaginationListResult, error) {
+	if err := permission.Require(ctx, permission.WebhookManage); err != nil {
+		return nil, err
+	}
+
+	// Apply filtering based on ownership, our staff may look at the
+	// deliveries of every store.
+	if permission.Scope(ctx) != permission.ScopeSystem {
+		f.StoreID, _ = ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+	}
+
+	impl.Logger.Debug("listing using filter options:",
+		slog.Any("WebhookID", f.WebhookID),
+		slog.Any("StoreID", f.StoreID),
+		slog.Any("Status", f.Status),
+		slog.String("Event", f.Event),
+		slog.Any("Cursor", f.Cursor),
+		slog.Int64("PageSize", f.PageSize),
+		slog.String("SortField", f.SortField),
+		slog.Int("SortOrder", int(f.SortOrder)))
+
+	m, err := impl.WebhookDeliveryStorer.ListByFilter(ctx, f)
+	if err != nil {
+		impl.Logger.Error("database list by filter error", slog.Any("error", err))
+		return nil, err
+	}
+	return m, err
+}
+
+// RedeliverByID queues the event of the delivery to be sent again. The
+// original delivery is kept in the log and the new one shares its event id.
+func (impl *WebhookControllerImpl) RedeliverByID(ctx context.Context, deliveryID primitive.ObjectID) (*whd_s.WebhookDelivery, error) {
+	if err := permission.Require(ctx, permission.WebhookManage); err != nil {
+		return nil, err
+	}
+
+	od, err := impl.WebhookDeliveryStorer.GetByID(ctx, deliveryID)
+	if err != nil {
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if od == nil {
+		return nil, httperror.NewForSingleField(http.StatusNotFound, "id", "webhook delivery does not exist")
+	}
+	wh, err := impl.getWebhookForManage(ctx, od.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	d := whd_s.NewDelivery(wh.ID, wh.StoreID, od.EventID, od.Event, wh.URL, od.Payload)
+	d.RedeliveryOfID = od.ID
+	if err := impl.WebhookDeliveryStorer.Create(ctx, d); err != nil {
+		impl.Logger.Error("database create error", slog.Any("error", err))
+		return nil, err
+	}
+	return d, nil
+}
@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	whd_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhookdelivery/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/metrics"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/retry"
+)
+
+// retryPolicy spreads the attempts of an event over about 44 hours so a
+// partner has close to two days to fix their endpoint.
+var retryPolicy = retry.Policy{
+	MaxAttempts: 16,
+	BaseDelay:   time.Minute,
+	MaxDelay:    6 * time.Hour,
+}
+
+const (
+	// lockDuration is how long the dispatcher has to send an event before
+	// another dispatcher assumes it crashed and picks the delivery up again.
+	lockDuration = 5 * time.Minute
+
+	// maxResponseBodySize is how much of the response we keep in the
+	// delivery log to help partners debug their endpoint.
+	maxResponseBodySize = 1024
+)
+
+// DispatchPending sends every delivery which is due until there are none
+// left. Delivery is at least once, partners should use the event id to
+// ignore the events they already processed.
+func (impl *WebhookControllerImpl) DispatchPending(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		d, err := impl.WebhookDeliveryStorer.ClaimNext(ctx, lockDuration)
+		if err != nil {
+			impl.Logger.Error("database claim next error", slog.Any("error", err))
+			return err
+		}
+		if d == nil {
+			return nil
+		}
+
+		impl.finish(d, impl.deliver(ctx, d), true)
+		if err := impl.WebhookDeliveryStorer.UpdateByID(ctx, d); err != nil {
+			impl.Logger.Error("database update by id error", slog.Any("error", err))
+			return err
+		}
+	}
+}
+
+// deliver sends the payload to the webhook and records the response on the
+// delivery. Only a `2xx` response counts as delivered.
+func (impl *WebhookControllerImpl) deliver(ctx context.Context, d *whd_s.WebhookDelivery) error {
+	wh, err := impl.WebhookStorer.GetByID(ctx, d.WebhookID)
+	if err != nil {
+		return err
+	}
+	if wh == nil {
+		return fmt.Errorf("webhook was deleted")
+	}
+
+	// Send to the current url so retries pick up a fixed endpoint.
+	d.URL = wh.URL
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewBufferString(d.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "CPS-Webhooks/1.0")
+	req.Header.Set("X-CPS-Event", d.Event)
+	req.Header.Set("X-CPS-Delivery", d.ID.Hex())
+	req.Header.Set("X-CPS-Signature", fmt.Sprintf("t=%d,v1=%s", timestamp, sign(wh.Secret, timestamp, d.Payload)))
+
+	res, err := impl.HTTPClient.Do(req)
+	if err != nil {
+		d.ResponseStatusCode = 0
+		d.ResponseBody = ""
+		return err
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxResponseBodySize))
+	d.ResponseStatusCode = res.StatusCode
+	d.ResponseBody = string(body)
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// sign returns the signature partners verify the payload with, it is the
+// HMAC-SHA256 of the timestamp and the payload joined by a dot. Including the
+// timestamp lets partners reject replayed requests.
+func sign(secret string, timestamp int64, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.%s", timestamp, payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// finish records the result of the attempt on the delivery and schedules the
+// next attempt if it failed and `retry` is set.
+func (impl *WebhookControllerImpl) finish(d *whd_s.WebhookDelivery, err error, retry bool) {
+	now := time.Now()
+	d.ModifiedAt = now
+	d.LockedUntil = time.Time{}
+	if err == nil {
+		d.Status = whd_s.StatusDelivered
+		d.LastError = ""
+		d.DeliveredAt = now
+		metrics.IncWebhookDelivery(d.Event, metrics.WebhookDelivered)
+		impl.Logger.Debug("webhook delivered", slog.Any("webhook_delivery_id", d.ID))
+		return
+	}
+
+	d.LastError = err.Error()
+	if !retry || retryPolicy.GiveUp(d.Attempts) {
+		d.Status = whd_s.StatusFailed
+		metrics.IncWebhookDelivery(d.Event, metrics.WebhookFailed)
+		impl.Logger.Warn("webhook delivery failed, giving up",
+			slog.Any("webhook_delivery_id", d.ID),
+			slog.Int("attempts", d.Attempts),
+			slog.Any("error", err))
+		return
+	}
+
+	delay := retryPolicy.Delay(d.Attempts)
+	d.Status = whd_s.StatusPending
+	d.NextAttemptAt = now.Add(delay)
+	metrics.IncWebhookDelivery(d.Event, metrics.WebhookRetrying)
+	impl.Logger.Debug("webhook delivery failed, will retry",
+		slog.Any("webhook_delivery_id", d.ID),
+		slog.Int("attempts", d.Attempts),
+		slog.Duration("retry_in", delay),
+		slog.Any("error", err))
+}
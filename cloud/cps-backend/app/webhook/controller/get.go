@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/datastore"
+)
+
+func (impl *WebhookControllerImpl) GetByID(ctx context.Context, id primitive.ObjectID) (*domain.Webhook, error) {
+	return impl.getWebhookForManage(ctx, id)
+}
@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/permission"
+)
+
+func (impl *WebhookControllerImpl) ListByFilter(ctx context.Context, f *domain.WebhookListFilter) ([]*domain.Webhook, error) {
+	if err := permission.Require(ctx, permission.WebhookManage); err != nil {
+		return nil, err
+	}
+
+	// Apply filtering based on ownership, our staff may look at the webhooks
+	// of every store.
+	if permission.Scope(ctx) != permission.ScopeSystem {
+		f.StoreID, _ = ctx.Value(constants.SessionUserStoreID).(primitive.ObjectID)
+	}
+
+	res, err := impl.WebhookStorer.ListByFilter(ctx, f)
+	if err != nil {
+		impl.Logger.Error("database list by filter error", slog.Any("error", err))
+		return nil, err
+	}
+	return res, nil
+}
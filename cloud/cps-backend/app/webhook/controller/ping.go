@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/webhookpublisher"
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/datastore"
+	whd_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhookdelivery/datastore"
+)
+
+// PingData is the data of the `ping` event.
+type PingData struct {
+	WebhookID primitive.ObjectID `json:"webhook_id"`
+	StoreID   primitive.ObjectID `json:"store_id"`
+}
+
+// PingByID sends a `ping` event to the webhook right away so the partner can
+// test their endpoint. The result is returned and saved in the delivery log,
+// failed pings are not retried.
+func (impl *WebhookControllerImpl) PingByID(ctx context.Context, id primitive.ObjectID) (*whd_s.WebhookDelivery, error) {
+	wh, err := impl.getWebhookForManage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &webhookpublisher.Payload{
+		ID:        primitive.NewObjectID(),
+		Event:     domain.EventPing,
+		CreatedAt: time.Now().UTC(),
+		Data:      &PingData{WebhookID: wh.ID, StoreID: wh.StoreID},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		impl.Logger.Error("failed marshalling webhook payload", slog.Any("error", err))
+		return nil, err
+	}
+
+	d := whd_s.NewDelivery(wh.ID, wh.StoreID, payload.ID, domain.EventPing, wh.URL, string(body))
+	d.Status = whd_s.StatusProcessing
+	d.Attempts = 1
+	impl.finish(d, impl.deliver(ctx, d), false)
+	if err := impl.WebhookDeliveryStorer.Create(ctx, d); err != nil {
+		impl.Logger.Error("database create error", slog.Any("error", err))
+		return nil, err
+	}
+	return d, nil
+}
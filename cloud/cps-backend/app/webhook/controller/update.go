@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+type WebhookUpdateRequestIDO struct {
+	ID          primitive.ObjectID `json:"id"`
+	URL         string             `json:"url"`
+	Description string             `json:"description"`
+	Events      []string           `json:"events"`
+	Status      int8               `json:"status"`
+}
+
+func (impl *WebhookControllerImpl) UpdateByID(ctx context.Context, req *WebhookUpdateRequestIDO) (*domain.Webhook, error) {
+	m, err := impl.getWebhookForManage(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	req.URL = strings.TrimSpace(req.URL)
+	req.Description = strings.TrimSpace(req.Description)
+
+	e := make(map[string]string)
+	validateWebhook(e, req.URL, req.Description, req.Events, impl.Config.AppServer.IsDeveloperMode)
+	if req.Status != domain.StatusActive && req.Status != domain.StatusDisabled {
+		e["status"] = "unsupported value"
+	}
+	if len(e) != 0 {
+		return nil, httperror.NewForBadRequest(&e)
+	}
+
+	m.URL = req.URL
+	m.Description = req.Description
+	m.Events = normalizeEvents(req.Events)
+	m.Status = req.Status
+	m.ModifiedAt = time.Now()
+	if err := impl.WebhookStorer.UpdateByID(ctx, m); err != nil {
+		impl.Logger.Error("database update by id error", slog.Any("error", err))
+		return nil, err
+	}
+	return m, nil
+}
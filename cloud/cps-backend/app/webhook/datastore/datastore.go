@@ -0,0 +1,110 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+const (
+	StatusActive = 1
+	// StatusDisabled webhooks are kept with their delivery log but are not
+	// sent any events.
+	StatusDisabled = 2
+)
+
+const (
+	EventSubmissionCreated       = "submission.created"
+	EventSubmissionStatusChanged = "submission.status_changed"
+	// EventSubmissionCompleted is sent once the submission was graded, on
+	// top of the `submission.status_changed` event.
+	EventSubmissionCompleted = "submission.completed"
+	EventCreditGranted       = "credit.granted"
+	// EventPing is only sent when the partner tests their endpoint, it
+	// cannot be subscribed to.
+	EventPing = "ping"
+)
+
+// Events are the events partners can subscribe their webhooks to.
+var Events = []string{
+	EventSubmissionCreated,
+	EventSubmissionStatusChanged,
+	EventSubmissionCompleted,
+	EventCreditGranted,
+}
+
+// Webhook is an endpoint of a retail partner which we send the events of
+// their store to. Every payload is signed with the secret so the partner can
+// verify it came from us.
+type Webhook struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	StoreID     primitive.ObjectID `bson:"store_id" json:"store_id"`
+	StoreName   string             `bson:"store_name" json:"store_name"`
+	URL         string             `bson:"url" json:"url"`
+	Description string             `bson:"description" json:"description"`
+	Events      []string           `bson:"events" json:"events"`
+	Status      int8               `bson:"status" json:"status"`
+	// Secret is only returned once when the webhook is created, unlike the
+	// api keys we cannot hash it as we need it to sign the payloads.
+	Secret          string             `bson:"secret" json:"-"`
+	CreatedByUserID primitive.ObjectID `bson:"created_by_user_id" json:"created_by_user_id"`
+	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
+	ModifiedAt      time.Time          `bson:"modified_at" json:"modified_at"`
+}
+
+// WebhookListFilter filters the webhooks by the store, zero values are
+// ignored.
+type WebhookListFilter struct {
+	StoreID primitive.ObjectID
+}
+
+// WebhookStorer Interface for webhook.
+type WebhookStorer interface {
+	Create(ctx context.Context, m *Webhook) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*Webhook, error)
+	UpdateByID(ctx context.Context, m *Webhook) error
+	ListByFilter(ctx context.Context, f *WebhookListFilter) ([]*Webhook, error)
+	ListActiveByStoreIDAndEvent(ctx context.Context, storeID primitive.ObjectID, event string) ([]*Webhook, error)
+	DeleteByID(ctx context.Context, id primitive.ObjectID) error
+}
+
+type WebhookStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) WebhookStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("webhooks")
+
+	// The following few lines of code will create the index for our app for
+	// this colleciton.
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "store_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "store_id", Value: 1}, {Key: "status", Value: 1}, {Key: "events", Value: 1}},
+		},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &WebhookStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
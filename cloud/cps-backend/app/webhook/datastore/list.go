@@ -0,0 +1,49 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ListByFilter returns the webhooks with the newest first. Stores only have
+// a handful of webhooks so we do not paginate.
+func (impl WebhookStorerImpl) ListByFilter(ctx context.Context, f *WebhookListFilter) ([]*Webhook, error) {
+	filter := bson.M{}
+	if !f.StoreID.IsZero() {
+		filter["store_id"] = f.StoreID
+	}
+	return impl.list(ctx, filter)
+}
+
+// ListActiveByStoreIDAndEvent returns the webhooks of the store which should
+// be sent the event.
+func (impl WebhookStorerImpl) ListActiveByStoreIDAndEvent(ctx context.Context, storeID primitive.ObjectID, event string) ([]*Webhook, error) {
+	filter := bson.M{
+		"store_id": storeID,
+		"status":   StatusActive,
+		"events":   event,
+	}
+	return impl.list(ctx, filter)
+}
+
+func (impl WebhookStorerImpl) list(ctx context.Context, filter bson.M) ([]*Webhook, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := impl.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		impl.Logger.Error("database list by filter error", slog.Any("error", err))
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results = []*Webhook{}
+	if err := cursor.All(ctx, &results); err != nil {
+		impl.Logger.Error("database list by filter decode error", slog.Any("error", err))
+		return nil, err
+	}
+	return results, nil
+}
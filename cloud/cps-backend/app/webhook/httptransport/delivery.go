@@ -0,0 +1,127 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	whd_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhookdelivery/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+func (h *Handler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	f := &whd_s.WebhookDeliveryPaginationListFilter{
+		Cursor:    "",
+		PageSize:  25,
+		SortField: "created_at",
+		SortOrder: -1, // 1=ascending | -1=descending
+	}
+
+	// Here is where you extract url parameters.
+	query := r.URL.Query()
+
+	cursor := query.Get("cursor")
+	if cursor != "" {
+		f.Cursor = cursor
+	}
+
+	pageSize := query.Get("page_size")
+	if pageSize != "" {
+		pageSize, _ := strconv.ParseInt(pageSize, 10, 64)
+		if pageSize == 0 || pageSize > 250 {
+			pageSize = 250
+		}
+		f.PageSize = pageSize
+	}
+
+	webhookIDStr := query.Get("webhook_id")
+	if webhookIDStr != "" {
+		webhookID, err := primitive.ObjectIDFromHex(webhookIDStr)
+		if err != nil {
+			httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("webhook_id", "invalid value"))
+			return
+		}
+		f.WebhookID = webhookID
+	}
+
+	storeIDStr := query.Get("store_id")
+	if storeIDStr != "" {
+		storeID, err := primitive.ObjectIDFromHex(storeIDStr)
+		if err != nil {
+			httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("store_id", "invalid value"))
+			return
+		}
+		f.StoreID = storeID
+	}
+
+	statusStr := query.Get("status")
+	if statusStr != "" {
+		status, _ := strconv.ParseInt(statusStr, 10, 64)
+		f.Status = int8(status)
+	}
+
+	f.Event = query.Get("event")
+
+	m, err := h.Controller.ListDeliveriesByFilter(ctx, f)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalDeliveryListResponse(m, w)
+}
+
+func MarshalDeliveryListResponse(res *whd_s.WebhookDeliveryPaginationListResult, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) RedeliverByID(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	m, err := h.Controller.RedeliverByID(ctx, objectID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	MarshalDeliveryDetailResponse(m, w)
+}
+
+func (h *Handler) PingByID(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	m, err := h.Controller.PingByID(ctx, objectID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalDeliveryDetailResponse(m, w)
+}
+
+func MarshalDeliveryDetailResponse(res *whd_s.WebhookDelivery, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,21 @@
+package httptransport
+
+import (
+	"log/slog"
+
+	webhook_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/controller"
+)
+
+// Handler Creates http request handler
+type Handler struct {
+	Logger     *slog.Logger
+	Controller webhook_c.WebhookController
+}
+
+// NewHandler Constructor
+func NewHandler(loggerp *slog.Logger, c webhook_c.WebhookController) *Handler {
+	return &Handler{
+		Logger:     loggerp,
+		Controller: c,
+	}
+}
@@ -0,0 +1,45 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	webhook_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	f := &webhook_s.WebhookListFilter{}
+
+	// Here is where you extract url parameters.
+	query := r.URL.Query()
+
+	storeIDStr := query.Get("store_id")
+	if storeIDStr != "" {
+		storeID, err := primitive.ObjectIDFromHex(storeIDStr)
+		if err != nil {
+			httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("store_id", "invalid value"))
+			return
+		}
+		f.StoreID = storeID
+	}
+
+	m, err := h.Controller.ListByFilter(ctx, f)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalListResponse(m, w)
+}
+
+func MarshalListResponse(res []*webhook_s.Webhook, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,46 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ClaimNext atomically marks the oldest delivery which is due as processing
+// and returns it, or returns nil if there is nothing to send. Deliveries left
+// processing by a dispatcher which crashed are picked up again once their
+// lock expires.
+func (impl WebhookDeliveryStorerImpl) ClaimNext(ctx context.Context, lockFor time.Duration) (*WebhookDelivery, error) {
+	now := time.Now()
+	filter := bson.M{"$or": []bson.M{
+		{"status": StatusPending, "next_attempt_at": bson.M{"$lte": now}},
+		{"status": StatusProcessing, "locked_until": bson.M{"$lte": now}},
+	}}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       StatusProcessing,
+			"locked_until": now.Add(lockFor),
+			"modified_at":  now,
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var result WebhookDelivery
+	err := impl.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// This error means there are no deliveries waiting.
+			return nil, nil
+		}
+		impl.Logger.Error("database claim next error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
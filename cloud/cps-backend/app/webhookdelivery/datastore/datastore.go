@@ -0,0 +1,126 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	c "github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+const (
+	StatusPending    = 1
+	StatusProcessing = 2
+	StatusDelivered  = 3
+	// StatusFailed means we gave up retrying, the partner can redeliver it.
+	StatusFailed = 4
+)
+
+// WebhookDelivery is one event sent to one webhook. Deliveries are saved in
+// the same transaction as the business change and sent after the
+// transaction commits, they are kept afterwards as the delivery log of the
+// webhook.
+type WebhookDelivery struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	WebhookID primitive.ObjectID `bson:"webhook_id" json:"webhook_id"`
+	StoreID   primitive.ObjectID `bson:"store_id" json:"store_id"`
+	// EventID is the same for every redelivery of the event so partners can
+	// ignore the events they already processed.
+	EventID primitive.ObjectID `bson:"event_id" json:"event_id"`
+	Event   string             `bson:"event" json:"event"`
+	URL     string             `bson:"url" json:"url"`
+	// Payload is the exact body we send, it is saved as a string so the
+	// signature is the same on every attempt.
+	Payload string `bson:"payload" json:"payload"`
+	Status  int8   `bson:"status" json:"status"`
+	// RedeliveryOfID is the delivery this one was copied from, if any.
+	RedeliveryOfID     primitive.ObjectID `bson:"redelivery_of_id,omitempty" json:"redelivery_of_id,omitempty"`
+	Attempts           int                `bson:"attempts" json:"attempts"`
+	LastError          string             `bson:"last_error" json:"last_error,omitempty"`
+	ResponseStatusCode int                `bson:"response_status_code" json:"response_status_code,omitempty"`
+	ResponseBody       string             `bson:"response_body" json:"response_body,omitempty"`
+	NextAttemptAt      time.Time          `bson:"next_attempt_at" json:"next_attempt_at"`
+	LockedUntil        time.Time          `bson:"locked_until" json:"locked_until"`
+	CreatedAt          time.Time          `bson:"created_at" json:"created_at"`
+	ModifiedAt         time.Time          `bson:"modified_at" json:"modified_at"`
+	DeliveredAt        time.Time          `bson:"delivered_at" json:"delivered_at"`
+}
+
+// WebhookDeliveryStorer Interface for the webhook deliveries.
+type WebhookDeliveryStorer interface {
+	Create(ctx context.Context, m *WebhookDelivery) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*WebhookDelivery, error)
+	ClaimNext(ctx context.Context, lockFor time.Duration) (*WebhookDelivery, error)
+	UpdateByID(ctx context.Context, m *WebhookDelivery) error
+	ListByFilter(ctx context.Context, f *WebhookDeliveryPaginationListFilter) (*WebhookDeliveryPaginationListResult, error)
+	DeleteAllByWebhookID(ctx context.Context, webhookID primitive.ObjectID) (int64, error)
+}
+
+type WebhookDeliveryStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) WebhookDeliveryStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("webhook_deliveries")
+
+	// The following few lines of code will create the index for our app for
+	// this colleciton.
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "next_attempt_at", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "webhook_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "store_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+		},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &WebhookDeliveryStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
+
+// NewDelivery returns a delivery of the payload which is ready to be sent.
+func NewDelivery(webhookID, storeID, eventID primitive.ObjectID, event, url, payload string) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:            primitive.NewObjectID(),
+		WebhookID:     webhookID,
+		StoreID:       storeID,
+		EventID:       eventID,
+		Event:         event,
+		URL:           url,
+		Payload:       payload,
+		Status:        StatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		ModifiedAt:    now,
+	}
+}
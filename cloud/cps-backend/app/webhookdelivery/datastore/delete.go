@@ -0,0 +1,20 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeleteAllByWebhookID deletes the delivery log of the webhook and returns
+// the number deleted.
+func (impl WebhookDeliveryStorerImpl) DeleteAllByWebhookID(ctx context.Context, webhookID primitive.ObjectID) (int64, error) {
+	res, err := impl.Collection.DeleteMany(ctx, bson.M{"webhook_id": webhookID})
+	if err != nil {
+		impl.Logger.Error("database delete all by webhook id error", slog.Any("error", err))
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
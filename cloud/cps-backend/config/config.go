@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 )
 
 type Conf struct {
@@ -11,8 +12,10 @@ type Conf struct {
 	DB               dbConfig
 	AWS              awsConfig
 	PDFBuilder       pdfBuilderConfig
-	Emailer          mailgunConfig
+	Emailer          emailerConfig
 	PaymentProcessor paymentProcessorConfig
+	Currency         currencyConfig
+	OIDC             oidcConfig
 }
 
 type serverConf struct {
@@ -27,6 +30,25 @@ type serverConf struct {
 	AppDomainName           string
 	IsDeveloperMode         bool
 	Enable2FAOnRegistration bool
+	// OfferSeedFilePath is the optional JSON file of offers to create on startup.
+	OfferSeedFilePath string
+	// AccessTokenExpiry is how long an access token is valid for, keep this
+	// short as the refresh token is used to get a new one.
+	AccessTokenExpiry time.Duration
+	// RefreshTokenExpiry is how long a session lasts without being refreshed.
+	RefreshTokenExpiry time.Duration
+	// WebAuthnRequiredForRoot forces root users to sign in with a hardware
+	// security key or passkey.
+	WebAuthnRequiredForRoot bool
+	// PasswordResetTokenExpiry is how long a forgot password link works for.
+	PasswordResetTokenExpiry time.Duration
+	// MetricsToken is the bearer token Prometheus must send to scrape the
+	// `/metrics` endpoint, without it the endpoint is only open in developer
+	// mode.
+	MetricsToken string
+	// TrustedProxies is a comma separated list of the ip addresses or CIDR
+	// ranges of our reverse proxies, only they may set `X-Forwarded-For`.
+	TrustedProxies string
 }
 
 type dbConfig struct {
@@ -52,12 +74,32 @@ type pdfBuilderConfig struct {
 	DataDirectoryPath string
 }
 
-type mailgunConfig struct {
-	APIKey           string
-	Domain           string
-	APIBase          string
+type emailerConfig struct {
+	// Transport delivers our emails, one of `mailgun`, `smtp`, `file` or
+	// `memory`. The last two never send anything and are for development.
+	Transport        string
 	SenderEmail      string
 	MaintenanceEmail string
+	Mailgun          mailgunConfig
+	SMTP             smtpConfig
+	// FileDirectoryPath is where the `file` transport writes the `.eml` files.
+	FileDirectoryPath string
+}
+
+type mailgunConfig struct {
+	APIKey  string
+	Domain  string
+	APIBase string
+}
+
+type smtpConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// StartTLS upgrades the connection before authenticating, turn it off
+	// only for local mail catchers which do not support it.
+	StartTLS bool
 }
 
 type paymentProcessorConfig struct {
@@ -66,6 +108,30 @@ type paymentProcessorConfig struct {
 	WebhookSecretKey string
 }
 
+type currencyConfig struct {
+	// Base is the currency our books are kept in, for example `CAD`.
+	Base string
+	// ExchangeRates is a comma separated list of how many units of the base
+	// currency one unit of the foreign currency is worth, for example
+	// `USD=1.36,MXN=0.079`.
+	ExchangeRates string
+}
+
+type oidcConfig struct {
+	// IssuerURL of the OpenID Connect identity provider, for example
+	// `https://accounts.google.com`. Single sign-on is off if left empty.
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the page of our frontend the identity provider sends
+	// the user back to, it posts the code to our backend.
+	RedirectURL string
+	// AllowedDomains is a comma separated list of the email domains which
+	// may sign in, for example `example.com,example.org`. Nobody can sign
+	// in with single sign-on while it is empty.
+	AllowedDomains string
+}
+
 func New() *Conf {
 	var c Conf
 	c.AppServer.IsDeveloperMode = getEnvBool("CPS_BACKEND_APP_IS_DEVELOPER_MODE", false, true) // If in doubt assume developer mode!
@@ -79,6 +145,13 @@ func New() *Conf {
 	c.AppServer.APIDomainName = getEnv("CPS_BACKEND_API_DOMAIN_NAME", true)
 	c.AppServer.AppDomainName = getEnv("CPS_BACKEND_APP_DOMAIN_NAME", true)
 	c.AppServer.Enable2FAOnRegistration = getEnvBool("CPS_BACKEND_APP_ENABLE_2FA_ON_REGISTRATION", false, false)
+	c.AppServer.OfferSeedFilePath = getEnv("CPS_BACKEND_APP_OFFER_SEED_FILE_PATH", false)
+	c.AppServer.AccessTokenExpiry = getEnvDuration("CPS_BACKEND_APP_ACCESS_TOKEN_EXPIRY", false, 15*time.Minute)
+	c.AppServer.RefreshTokenExpiry = getEnvDuration("CPS_BACKEND_APP_REFRESH_TOKEN_EXPIRY", false, 14*24*time.Hour)
+	c.AppServer.WebAuthnRequiredForRoot = getEnvBool("CPS_BACKEND_APP_WEBAUTHN_REQUIRED_FOR_ROOT", false, false)
+	c.AppServer.PasswordResetTokenExpiry = getEnvDuration("CPS_BACKEND_APP_PASSWORD_RESET_TOKEN_EXPIRY", false, 30*time.Minute)
+	c.AppServer.MetricsToken = getEnv("CPS_BACKEND_APP_METRICS_TOKEN", false)
+	c.AppServer.TrustedProxies = getEnv("CPS_BACKEND_APP_TRUSTED_PROXIES", false)
 
 	c.DB.URI = getEnv("CPS_BACKEND_DB_URI", true)
 	c.DB.Name = getEnv("CPS_BACKEND_DB_NAME", true)
@@ -97,16 +170,40 @@ func New() *Conf {
 	c.PDFBuilder.CCUGTemplatePath = getEnv("CPS_BACKEND_PDF_BUILDER_CCUG_TEMPLATE_FILE_PATH", true)
 	c.PDFBuilder.DataDirectoryPath = getEnv("CPS_BACKEND_PDF_BUILDER_DATA_DIRECTORY_PATH", true)
 
-	c.Emailer.APIKey = getEnv("CPS_BACKEND_MAILGUN_API_KEY", true)
-	c.Emailer.Domain = getEnv("CPS_BACKEND_MAILGUN_DOMAIN", true)
-	c.Emailer.APIBase = getEnv("CPS_BACKEND_MAILGUN_API_BASE", true)
+	c.Emailer.Transport = getEnv("CPS_BACKEND_EMAILER_TRANSPORT", false)
+	if c.Emailer.Transport == "" {
+		c.Emailer.Transport = "mailgun"
+	}
 	c.Emailer.SenderEmail = getEnv("CPS_BACKEND_MAILGUN_SENDER_EMAIL", true)
 	c.Emailer.MaintenanceEmail = getEnv("CPS_BACKEND_MAILGUN_MAINTENANCE_EMAIL", true)
+	isMailgun := c.Emailer.Transport == "mailgun"
+	c.Emailer.Mailgun.APIKey = getEnv("CPS_BACKEND_MAILGUN_API_KEY", isMailgun)
+	c.Emailer.Mailgun.Domain = getEnv("CPS_BACKEND_MAILGUN_DOMAIN", isMailgun)
+	c.Emailer.Mailgun.APIBase = getEnv("CPS_BACKEND_MAILGUN_API_BASE", isMailgun)
+	isSMTP := c.Emailer.Transport == "smtp"
+	c.Emailer.SMTP.Host = getEnv("CPS_BACKEND_SMTP_HOST", isSMTP)
+	c.Emailer.SMTP.Port = getEnvInt("CPS_BACKEND_SMTP_PORT", false, 587)
+	c.Emailer.SMTP.Username = getEnv("CPS_BACKEND_SMTP_USERNAME", false)
+	c.Emailer.SMTP.Password = getEnv("CPS_BACKEND_SMTP_PASSWORD", false)
+	c.Emailer.SMTP.StartTLS = getEnvBool("CPS_BACKEND_SMTP_STARTTLS", false, true)
+	c.Emailer.FileDirectoryPath = getEnv("CPS_BACKEND_EMAILER_FILE_DIRECTORY_PATH", c.Emailer.Transport == "file")
 
 	c.PaymentProcessor.SecretKey = getEnv("CPS_BACKEND_PAYMENT_PROCESSOR_SECRET_KEY", true)
 	c.PaymentProcessor.PublicKey = getEnv("CPS_BACKEND_PAYMENT_PROCESSOR_PUBLIC_KEY", true)
 	c.PaymentProcessor.WebhookSecretKey = getEnv("CPS_BACKEND_PAYMENT_PROCESSOR_WEBHOOK_SECRET_KEY", true)
 
+	c.Currency.Base = getEnv("CPS_BACKEND_CURRENCY_BASE", false)
+	if c.Currency.Base == "" {
+		c.Currency.Base = "CAD"
+	}
+	c.Currency.ExchangeRates = getEnv("CPS_BACKEND_CURRENCY_EXCHANGE_RATES", false)
+
+	c.OIDC.IssuerURL = getEnv("CPS_BACKEND_OIDC_ISSUER_URL", false)
+	c.OIDC.ClientID = getEnv("CPS_BACKEND_OIDC_CLIENT_ID", false)
+	c.OIDC.ClientSecret = getEnv("CPS_BACKEND_OIDC_CLIENT_SECRET", false)
+	c.OIDC.RedirectURL = getEnv("CPS_BACKEND_OIDC_REDIRECT_URL", false)
+	c.OIDC.AllowedDomains = getEnv("CPS_BACKEND_OIDC_ALLOWED_DOMAINS", false)
+
 	return &c
 }
 
@@ -129,3 +226,27 @@ func getEnvBool(key string, required bool, defaultValue bool) bool {
 	}
 	return value
 }
+
+func getEnvInt(key string, required bool, defaultValue int) int {
+	valueStr := getEnv(key, required)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		log.Fatalf("Invalid integer value for environment variable %s", key)
+	}
+	return value
+}
+
+func getEnvDuration(key string, required bool, defaultValue time.Duration) time.Duration {
+	valueStr := getEnv(key, required)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := time.ParseDuration(valueStr)
+	if err != nil || value <= 0 {
+		log.Fatalf("Invalid duration value for environment variable %s", key)
+	}
+	return value
+}
@@ -21,4 +21,8 @@ const (
 	SessionUserStoreName
 	SessionUserStoreLevel
 	SessionUserStoreTimezone
+	SessionUserAgent
+	SessionAPIKey
+	SessionAPIKeyID
+	SessionAPIKeyPermissions
 )
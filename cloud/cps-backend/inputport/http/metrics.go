@@ -0,0 +1,125 @@
+package http
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/metrics"
+)
+
+// statusRecorder remembers the status code written by the handlers so we can
+// label the request metrics with it.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+
+	// route is set by `HandleRequests`, requests the middleware rejected
+	// before reaching it do not have one.
+	route string
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *statusRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	return rec.ResponseWriter.Write(b)
+}
+
+// Flush is needed by the server-sent event streams.
+func (rec *statusRecorder) Flush() {
+	if f, ok := rec.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (rec *statusRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// instrument records the count and latency of every API request by route.
+func (port *httpInputPort) instrument(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		done := metrics.TrackHTTPRequestInFlight()
+		defer done()
+
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+		fn(rec, r)
+
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+		if rec.route == "" {
+			rec.route = "rejected"
+		}
+		metrics.ObserveHTTPRequest(r.Method, rec.route, rec.status, time.Since(start))
+	}
+}
+
+// setRoute labels the request metrics with the route which handled it.
+func setRoute(w http.ResponseWriter, route string) {
+	if rec, ok := w.(*statusRecorder); ok {
+		rec.route = route
+	}
+}
+
+// routePattern returns the path with the parameters replaced by `{id}`, for
+// example "/api/v1/comic-submission/65a.../perma-delete" gives
+// "/api/v1/comic-submission/{id}/perma-delete". The routes in `HandleRequests`
+// only use lowercase words as fixed segments so anything else is a parameter.
+func routePattern(path string) string {
+	p := strings.Split(path, "/")
+	for i := 4; i < len(p); i++ { // Skip the "", "api", "v1" and resource segments.
+		if !isFixedSegment(p[i]) {
+			p[i] = "{id}"
+		}
+	}
+	return strings.Join(p, "/")
+}
+
+func isFixedSegment(s string) bool {
+	if s == "" || len(s) > 32 {
+		return false
+	}
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// metricsHandler exposes the metrics to Prometheus, protected by a bearer
+// token. The metrics give away our traffic and internals so without a token
+// the endpoint is disabled unless we are in developer mode.
+func (port *httpInputPort) metricsHandler() http.HandlerFunc {
+	h := promhttp.Handler()
+	token := port.Config.AppServer.MetricsToken
+	if token == "" && !port.Config.AppServer.IsDeveloperMode {
+		port.Logger.Warn("metrics endpoint disabled, set CPS_BACKEND_APP_METRICS_TOKEN to enable it")
+		return func(w http.ResponseWriter, r *http.Request) {
+			http.NotFound(w, r)
+		}
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token != "" {
+			got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+		h.ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"log"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// parseTrustedProxies parses the comma separated list of ip addresses or
+// CIDR ranges of the reverse proxies in front of our server.
+func parseTrustedProxies(value string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, s := range strings.Split(value, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if !strings.Contains(s, "/") {
+			if ip := net.ParseIP(s); ip != nil && ip.To4() != nil {
+				s += "/32"
+			} else {
+				s += "/128"
+			}
+		}
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			log.Fatalf("trusted proxy is corrupted for the value `%v`: %v", s, err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
+
+func (mid *middleware) isTrustedProxy(ip net.IP) bool {
+	for _, n := range mid.TrustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the ip address of the client. The forwarded headers are
+// set by whoever sends the request so they are only believed when the
+// request came from one of our reverse proxies, else anyone could pick a new
+// ip address for every request and dodge the per ip limits.
+func (mid *middleware) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	remote := net.ParseIP(host)
+	if remote == nil || !mid.isTrustedProxy(remote) {
+		return host
+	}
+
+	// Every proxy appends the address it received the request from, so walk
+	// from the right and stop at the first address which is not our proxy.
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		hops := strings.Split(xff, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			ip := net.ParseIP(strings.TrimSpace(hops[i]))
+			if ip == nil {
+				break
+			}
+			if !mid.isTrustedProxy(ip) {
+				return ip.String()
+			}
+		}
+	}
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-Ip"))); ip != nil {
+		return ip.String()
+	}
+	return host
+}
@@ -11,7 +11,9 @@ import (
 
 	"go.uber.org/ratelimit"
 
+	apikey_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/apikey/controller"
 	gateway_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/controller"
+	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/blacklist"
@@ -32,6 +34,8 @@ type middleware struct {
 	UUID              uuid.Provider
 	Blacklist         blacklist.Provider
 	GatewayController gateway_c.GatewayController
+	APIKeyController  apikey_c.APIKeyController
+	TrustedProxies    []*net.IPNet
 }
 
 func NewMiddleware(
@@ -42,14 +46,18 @@ func NewMiddleware(
 	jwtp jwt.Provider,
 	blp blacklist.Provider,
 	gatewayController gateway_c.GatewayController,
+	apiKeyController apikey_c.APIKeyController,
 ) Middleware {
 	return &middleware{
+		Config:            configp,
 		Logger:            loggerp,
 		UUID:              uuidp,
 		Time:              timep,
 		JWT:               jwtp,
 		Blacklist:         blp,
 		GatewayController: gatewayController,
+		APIKeyController:  apiKeyController,
+		TrustedProxies:    parseTrustedProxies(configp.AppServer.TrustedProxies),
 	}
 }
 
@@ -258,6 +266,29 @@ func (mid *middleware) JWTProcessorMiddleware(fn http.HandlerFunc) http.HandlerF
 		// Extract our auth header array.
 		reqToken := r.Header.Get("Authorization")
 
+		// The browser `EventSource` cannot set headers so the notification
+		// stream accepts a single use ticket as an url parameter instead, we
+		// do not put the access token in the url where it would get logged.
+		if reqToken == "" && r.URL.Path == "/api/v1/notifications/stream" {
+			if ticket := r.URL.Query().Get("ticket"); ticket != "" {
+				sessionID, err := mid.GatewayController.RedeemStreamTicket(ctx, ticket)
+				if err != nil {
+					mid.Logger.Error("redeem stream ticket error", slog.Any("err", err), slog.Any("middleware", "JWTProcessorMiddleware"))
+					http.Error(w, "attempting to access a protected endpoint", http.StatusUnauthorized)
+					return
+				}
+				if sessionID == "" {
+					mid.Logger.Warn("invalid stream ticket", slog.Any("middleware", "JWTProcessorMiddleware"))
+					http.Error(w, "attempting to access a protected endpoint", http.StatusUnauthorized)
+					return
+				}
+				ctx = context.WithValue(ctx, constants.SessionIsAuthorized, true)
+				ctx = context.WithValue(ctx, constants.SessionID, sessionID)
+				fn(w, r.WithContext(ctx))
+				return
+			}
+		}
+
 		// For debugging purposes.
 		// log.Println("JWTProcessorMiddleware | reqToken:", reqToken)
 
@@ -266,6 +297,16 @@ func (mid *middleware) JWTProcessorMiddleware(fn http.HandlerFunc) http.HandlerF
 		// step!
 		if reqToken != "" && strings.Contains(reqToken, "undefined") == false {
 
+			// API keys are the alternative to the JWT for integrations which
+			// cannot login interactively, the key gets verified in the
+			// `PostJWTProcessorMiddleware`.
+			if apiKey, ok := strings.CutPrefix(reqToken, "APIKey "); ok {
+				ctx = context.WithValue(ctx, constants.SessionIsAuthorized, true)
+				ctx = context.WithValue(ctx, constants.SessionAPIKey, apiKey)
+				fn(w, r.WithContext(ctx))
+				return
+			}
+
 			// Special thanks to "poise" via https://stackoverflow.com/a/44700761
 			splitToken := strings.Split(reqToken, "JWT ")
 			if len(splitToken) < 2 {
@@ -350,6 +391,11 @@ func (mid *middleware) PostJWTProcessorMiddleware(fn http.HandlerFunc) http.Hand
 		// Get our authorization information.
 		isAuthorized, ok := ctx.Value(constants.SessionIsAuthorized).(bool)
 		if ok && isAuthorized {
+			if apiKey, ok := ctx.Value(constants.SessionAPIKey).(string); ok {
+				mid.apiKeyProcessor(fn, apiKey, w, r)
+				return
+			}
+
 			sessionID := ctx.Value(constants.SessionID).(string)
 
 			// Lookup our user profile in the session or return 500 error.
@@ -368,6 +414,17 @@ func (mid *middleware) PostJWTProcessorMiddleware(fn http.HandlerFunc) http.Hand
 				return
 			}
 
+			// Root users must finish signing in with a hardware security key
+			// before they can use anything other than the login endpoints.
+			if mid.requiresWebAuthn(user) {
+				urlSplit := ctx.Value("url_split").([]string)
+				if len(urlSplit) < 3 || !webAuthnEnrollmentPaths[urlSplit[2]] {
+					mid.Logger.Warn("security key required", slog.Any("url", urlSplit), slog.Any("middleware", "PostJWTProcessorMiddleware"))
+					http.Error(w, "hardware security key required", http.StatusForbidden)
+					return
+				}
+			}
+
 			// // If system administrator disabled the user account then we need
 			// // to generate a 403 error letting the user know their account has
 			// // been disabled and you cannot access the protected API endpoint.
@@ -390,35 +447,109 @@ func (mid *middleware) PostJWTProcessorMiddleware(fn http.HandlerFunc) http.Hand
 
 			// Save individual pieces of the user profile.
 			ctx = context.WithValue(ctx, constants.SessionID, sessionID)
-			ctx = context.WithValue(ctx, constants.SessionUserID, user.ID)
-			ctx = context.WithValue(ctx, constants.SessionUserRole, user.Role)
-			ctx = context.WithValue(ctx, constants.SessionUserName, user.Name)
-			ctx = context.WithValue(ctx, constants.SessionUserFirstName, user.FirstName)
-			ctx = context.WithValue(ctx, constants.SessionUserLastName, user.LastName)
-			ctx = context.WithValue(ctx, constants.SessionUserStoreID, user.StoreID)
-			ctx = context.WithValue(ctx, constants.SessionUserStoreName, user.StoreName)
-			ctx = context.WithValue(ctx, constants.SessionUserStoreLevel, user.StoreLevel)
-			ctx = context.WithValue(ctx, constants.SessionUserStoreTimezone, user.StoreTimezone)
+			ctx = withUser(ctx, user)
 		}
 
 		fn(w, r.WithContext(ctx))
 	}
 }
 
+// webAuthnEnrollmentPaths are the endpoints a root user may use before they
+// signed in with a hardware security key, enough to register one.
+var webAuthnEnrollmentPaths = map[string]bool{
+	"webauthn": true,
+	"profile":  true,
+	"logout":   true,
+	"otp":      true,
+}
+
+// requiresWebAuthn returns true if the security key policy applies to the
+// user and the current session was not confirmed with a security key.
+func (mid *middleware) requiresWebAuthn(user *user_s.User) bool {
+	if !mid.Config.AppServer.WebAuthnRequiredForRoot || user.Role != user_s.UserRoleRoot {
+		return false
+	}
+	return !(user.WebAuthnEnabled && user.OTPValidated)
+}
+
+// apiKeyDeniedPaths are the endpoints which manage the account itself, they
+// are never available to api keys so a leaked key cannot take it over.
+var apiKeyDeniedPaths = map[string]bool{
+	"logout":      true,
+	"profile":     true,
+	"otp":         true,
+	"sessions":    true,
+	"session":     true,
+	"api-keys":    true,
+	"api-key":     true,
+	"users":       true,
+	"user":        true,
+	"invitations": true,
+	"invitation":  true,
+	"webauthn":    true,
+	"webhooks":    true,
+	"webhook":     true,
+}
+
+// apiKeyProcessor is the `PostJWTProcessorMiddleware` for requests made with
+// an api key instead of a JWT.
+func (mid *middleware) apiKeyProcessor(fn http.HandlerFunc, apiKey string, w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user, key, err := mid.APIKeyController.Authenticate(ctx, apiKey)
+	if err != nil {
+		mid.Logger.Warn("Authenticate error", slog.Any("err", err), slog.Any("middleware", "PostJWTProcessorMiddleware"))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if user == nil {
+		mid.Logger.Warn("invalid api key", slog.Any("middleware", "PostJWTProcessorMiddleware"))
+		http.Error(w, "attempting to access a protected endpoint", http.StatusUnauthorized)
+		return
+	}
+
+	urlSplit := ctx.Value("url_split").([]string)
+	if len(urlSplit) >= 3 && apiKeyDeniedPaths[urlSplit[2]] {
+		mid.Logger.Warn("api key used on denied endpoint", slog.Any("url", urlSplit), slog.Any("middleware", "PostJWTProcessorMiddleware"))
+		http.Error(w, "api keys cannot access this endpoint", http.StatusForbidden)
+		return
+	}
+	if key.ReadOnly && r.Method != http.MethodGet {
+		mid.Logger.Warn("read only api key used for write", slog.Any("url", urlSplit), slog.Any("middleware", "PostJWTProcessorMiddleware"))
+		http.Error(w, "api key is read only", http.StatusForbidden)
+		return
+	}
+
+	ctx = context.WithValue(ctx, constants.SessionUser, user)
+	ctx = context.WithValue(ctx, constants.SessionAPIKeyID, key.ID)
+	ctx = context.WithValue(ctx, constants.SessionAPIKeyPermissions, key.Permissions)
+	ctx = withUser(ctx, user)
+
+	fn(w, r.WithContext(ctx))
+}
+
+// withUser saves the individual pieces of the user profile to the context.
+func withUser(ctx context.Context, user *user_s.User) context.Context {
+	ctx = context.WithValue(ctx, constants.SessionUserID, user.ID)
+	ctx = context.WithValue(ctx, constants.SessionUserRole, user.Role)
+	ctx = context.WithValue(ctx, constants.SessionUserName, user.Name)
+	ctx = context.WithValue(ctx, constants.SessionUserFirstName, user.FirstName)
+	ctx = context.WithValue(ctx, constants.SessionUserLastName, user.LastName)
+	ctx = context.WithValue(ctx, constants.SessionUserStoreID, user.StoreID)
+	ctx = context.WithValue(ctx, constants.SessionUserStoreName, user.StoreName)
+	ctx = context.WithValue(ctx, constants.SessionUserStoreLevel, user.StoreLevel)
+	ctx = context.WithValue(ctx, constants.SessionUserStoreTimezone, user.StoreTimezone)
+	return ctx
+}
+
 func (mid *middleware) IPAddressMiddleware(fn http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// Extract the IPAddress. Code taken from: https://stackoverflow.com/a/55738279
-		IPAddress := r.Header.Get("X-Real-Ip")
-		if IPAddress == "" {
-			IPAddress = r.Header.Get("X-Forwarded-For")
-		}
-		if IPAddress == "" {
-			IPAddress = r.RemoteAddr
-		}
+		IPAddress := mid.clientIP(r)
 
 		// Save our IP address to the context.
 		ctx := r.Context()
 		ctx = context.WithValue(ctx, constants.SessionIPAddress, IPAddress)
+		ctx = context.WithValue(ctx, constants.SessionUserAgent, r.UserAgent())
 		fn(w, r.WithContext(ctx)) // Flow to the next middleware.
 	}
 }
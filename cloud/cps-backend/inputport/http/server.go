@@ -8,17 +8,28 @@ import (
 
 	"github.com/rs/cors"
 
+	apikey "github.com/LuchaComics/monorepo/cloud/cps-backend/app/apikey/httptransport"
 	attachment "github.com/LuchaComics/monorepo/cloud/cps-backend/app/attachment/httptransport"
 	comicsub "github.com/LuchaComics/monorepo/cloud/cps-backend/app/comicsub/httptransport"
 	credit "github.com/LuchaComics/monorepo/cloud/cps-backend/app/credit/httptransport"
 	customer "github.com/LuchaComics/monorepo/cloud/cps-backend/app/customer/httptransport"
+	emailtemplate "github.com/LuchaComics/monorepo/cloud/cps-backend/app/emailtemplate/httptransport"
 	gateway "github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/httptransport"
+	health "github.com/LuchaComics/monorepo/cloud/cps-backend/app/health/httptransport"
+	invitation "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/httptransport"
+	invoice "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/httptransport"
+	notification "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/httptransport"
 	offer "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/httptransport"
+	outbox "github.com/LuchaComics/monorepo/cloud/cps-backend/app/outbox/httptransport"
 	strpp "github.com/LuchaComics/monorepo/cloud/cps-backend/app/paymentprocessor/httptransport/stripe"
 	receipt "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/httptransport"
+	reconciliation "github.com/LuchaComics/monorepo/cloud/cps-backend/app/reconciliation/httptransport"
+	session "github.com/LuchaComics/monorepo/cloud/cps-backend/app/session/httptransport"
 	store "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/httptransport"
+	syncjob "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/httptransport"
 	user "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/httptransport"
 	userpurchase "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/httptransport"
+	webhook "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/httptransport"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/inputport/http/middleware"
 )
@@ -44,6 +55,17 @@ type httpInputPort struct {
 	UserPurchase           *userpurchase.Handler
 	StripePaymentProcessor *strpp.Handler
 	Credit                 *credit.Handler
+	Reconciliation         *reconciliation.Handler
+	Invoice                *invoice.Handler
+	Session                *session.Handler
+	Invitation             *invitation.Handler
+	APIKey                 *apikey.Handler
+	SyncJob                *syncjob.Handler
+	Outbox                 *outbox.Handler
+	EmailTemplate          *emailtemplate.Handler
+	Notification           *notification.Handler
+	Webhook                *webhook.Handler
+	Health                 *health.Handler
 }
 
 func NewInputPort(
@@ -61,6 +83,17 @@ func NewInputPort(
 	usrp *userpurchase.Handler,
 	strpp *strpp.Handler,
 	cr *credit.Handler,
+	rec *reconciliation.Handler,
+	invc *invoice.Handler,
+	ses *session.Handler,
+	invt *invitation.Handler,
+	apik *apikey.Handler,
+	sj *syncjob.Handler,
+	ob *outbox.Handler,
+	et *emailtemplate.Handler,
+	nt *notification.Handler,
+	wh *webhook.Handler,
+	hc *health.Handler,
 ) InputPortServer {
 	// Initialize the ServeMux.
 	mux := http.NewServeMux()
@@ -93,11 +126,23 @@ func NewInputPort(
 		UserPurchase:           usrp,
 		StripePaymentProcessor: strpp,
 		Credit:                 cr,
+		Reconciliation:         rec,
+		Invoice:                invc,
+		Session:                ses,
+		Invitation:             invt,
+		APIKey:                 apik,
+		SyncJob:                sj,
+		Outbox:                 ob,
+		EmailTemplate:          et,
+		Notification:           nt,
+		Webhook:                wh,
+		Health:                 hc,
 		Server:                 srv,
 	}
 
 	// Attach the HTTP server controller to the ServerMux.
-	mux.HandleFunc("/", mid.Attach(p.HandleRequests))
+	mux.HandleFunc("/", p.instrument(mid.Attach(p.HandleRequests)))
+	mux.HandleFunc("/metrics", p.metricsHandler())
 
 	return p
 }
@@ -124,10 +169,16 @@ func (port *httpInputPort) HandleRequests(w http.ResponseWriter, r *http.Request
 	p := ctx.Value("url_split").([]string)
 	n := len(p)
 
+	setRoute(w, routePattern(r.URL.Path))
+
 	switch {
 	// --- GATEWAY & PROFILE & DASHBOARD --- //
 	case n == 3 && p[1] == "v1" && p[2] == "health-check" && r.Method == http.MethodGet:
-		port.Gateway.HealthCheck(w, r)
+		port.Health.Liveness(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "health-check" && p[3] == "live" && r.Method == http.MethodGet:
+		port.Health.Liveness(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "health-check" && p[3] == "ready" && r.Method == http.MethodGet:
+		port.Health.Readiness(w, r)
 	case n == 3 && p[1] == "v1" && p[2] == "version" && r.Method == http.MethodGet:
 		port.Gateway.Version(w, r)
 	case n == 3 && p[1] == "v1" && p[2] == "greeting" && r.Method == http.MethodPost:
@@ -150,6 +201,14 @@ func (port *httpInputPort) HandleRequests(w http.ResponseWriter, r *http.Request
 		port.Gateway.ProfileUpdate(w, r)
 	case n == 4 && p[1] == "v1" && p[2] == "profile" && p[3] == "change-password" && r.Method == http.MethodPut:
 		port.Gateway.ProfileChangePassword(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "profile" && p[3] == "change-email" && r.Method == http.MethodPost:
+		port.Gateway.ProfileChangeEmail(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "profile" && p[3] == "change-email" && r.Method == http.MethodDelete:
+		port.Gateway.ProfileCancelEmailChange(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "public" && p[3] == "confirm-email-change" && r.Method == http.MethodPost:
+		port.Gateway.ConfirmEmailChange(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "public" && p[3] == "unsubscribe" && r.Method == http.MethodPost:
+		port.Gateway.Unsubscribe(w, r)
 	case n == 3 && p[1] == "v1" && p[2] == "forgot-password" && r.Method == http.MethodPost:
 		port.Gateway.ForgotPassword(w, r)
 	case n == 3 && p[1] == "v1" && p[2] == "password-reset" && r.Method == http.MethodPost:
@@ -166,6 +225,28 @@ func (port *httpInputPort) HandleRequests(w http.ResponseWriter, r *http.Request
 		port.Gateway.DisableOTP(w, r)
 	case n == 4 && p[1] == "v1" && p[2] == "otp" && p[3] == "recovery" && r.Method == http.MethodPost:
 		port.Gateway.RecoveryOTP(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "otp" && p[3] == "recovery-codes" && r.Method == http.MethodPost:
+		port.Gateway.RegenerateOTPRecoveryCodes(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "webauthn" && p[3] == "register" && p[4] == "begin" && r.Method == http.MethodPost:
+		port.Gateway.WebAuthnRegisterBegin(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "webauthn" && p[3] == "register" && p[4] == "finish" && r.Method == http.MethodPost:
+		port.Gateway.WebAuthnRegisterFinish(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "webauthn" && p[3] == "credentials" && r.Method == http.MethodGet:
+		port.Gateway.WebAuthnListCredentials(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "webauthn" && p[3] == "credential" && r.Method == http.MethodDelete:
+		port.Gateway.WebAuthnDeleteCredential(w, r, p[4])
+	case n == 5 && p[1] == "v1" && p[2] == "webauthn" && p[3] == "validate" && p[4] == "begin" && r.Method == http.MethodPost:
+		port.Gateway.WebAuthnValidateBegin(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "webauthn" && p[3] == "validate" && p[4] == "finish" && r.Method == http.MethodPost:
+		port.Gateway.WebAuthnValidateFinish(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "login" && p[3] == "webauthn" && p[4] == "begin" && r.Method == http.MethodPost:
+		port.Gateway.WebAuthnLoginBegin(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "login" && p[3] == "webauthn" && p[4] == "finish" && r.Method == http.MethodPost:
+		port.Gateway.WebAuthnLoginFinish(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "login" && p[3] == "oidc" && p[4] == "begin" && r.Method == http.MethodPost:
+		port.Gateway.OIDCLoginBegin(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "login" && p[3] == "oidc" && p[4] == "finish" && r.Method == http.MethodPost:
+		port.Gateway.OIDCLoginFinish(w, r)
 
 	// --- REGISTRY --- // (TODO)
 	case n == 4 && p[1] == "v1" && p[2] == "cpsrn" && r.Method == http.MethodGet:
@@ -250,6 +331,12 @@ func (port *httpInputPort) HandleRequests(w http.ResponseWriter, r *http.Request
 		port.User.OperationChangePassword(w, r)
 	case n == 5 && p[1] == "v1" && p[2] == "users" && p[3] == "operations" && p[4] == "change-2fa" && r.Method == http.MethodPost:
 		port.User.OperationChangeTwoFactorAuthentication(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "users" && p[3] == "operations" && p[4] == "unlock" && r.Method == http.MethodPost:
+		port.User.OperationUnlock(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "users" && p[3] == "operations" && p[4] == "change-role" && r.Method == http.MethodPost:
+		port.User.OperationChangeRole(w, r)
+	case n == 3 && p[1] == "v1" && p[2] == "roles" && r.Method == http.MethodGet:
+		port.User.ListRoles(w, r)
 	case n == 4 && p[1] == "v1" && p[2] == "users" && p[3] == "select-options" && r.Method == http.MethodGet:
 		port.User.ListAsSelectOptions(w, r)
 
@@ -270,8 +357,12 @@ func (port *httpInputPort) HandleRequests(w http.ResponseWriter, r *http.Request
 		port.StripePaymentProcessor.CreateStripeCheckoutSessionURLForComicSubmissionID(w, r, p[4])
 	// case n == 4 && p[1] == "v1" && p[2] == "stripe" && p[3] == "complete-checkout-session" && r.Method == http.MethodGet:
 	// 	port.PaymentProcessor.CompleteStripeCheckoutSession(w, r)
-	// case n == 4 && p[1] == "v1" && p[2] == "stripe" && p[3] == "cancel-subscription" && r.Method == http.MethodPost:
-	// 	port.PaymentProcessor.CancelStripeSubscription(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "stripe" && p[3] == "create-subscription-checkout-session" && r.Method == http.MethodPost:
+		port.StripePaymentProcessor.CreateStripeSubscriptionCheckoutSessionURL(w, r, p[4])
+	case n == 4 && p[1] == "v1" && p[2] == "stripe" && p[3] == "cancel-subscription" && r.Method == http.MethodPost:
+		port.StripePaymentProcessor.CancelStoreSubscription(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "stripe" && p[3] == "change-subscription" && r.Method == http.MethodPost:
+		port.StripePaymentProcessor.ChangeStoreSubscription(w, r)
 	// // case n == 4 && p[1] == "v1" && p[2] == "public" && p[3] == "stripe-webhook":
 	// // 	port.PaymentProcessor.StripeWebhook(w, r)
 	// case n == 4 && p[1] == "v1" && p[2] == "stripe" && p[3] == "receipts" && r.Method == http.MethodGet:
@@ -282,14 +373,14 @@ func (port *httpInputPort) HandleRequests(w http.ResponseWriter, r *http.Request
 	// --- OFFERS --- //
 	case n == 3 && p[1] == "v1" && p[2] == "offers" && r.Method == http.MethodGet:
 		port.Offer.List(w, r)
-	// case n == 3 && p[1] == "v1" && p[2] == "offers" && r.Method == http.MethodPost:
-	// 	port.Offer.Create(w, r)
+	case n == 3 && p[1] == "v1" && p[2] == "offers" && r.Method == http.MethodPost:
+		port.Offer.Create(w, r)
 	case n == 4 && p[1] == "v1" && p[2] == "offer" && r.Method == http.MethodGet:
 		port.Offer.GetByID(w, r, p[3])
 	case n == 4 && p[1] == "v1" && p[2] == "offer" && r.Method == http.MethodPut:
 		port.Offer.UpdateByID(w, r, p[3])
-	// case n == 4 && p[1] == "v1" && p[2] == "offer" && r.Method == http.MethodDelete:
-	// 	port.Offer.DeleteByID(w, r, p[3])
+	case n == 4 && p[1] == "v1" && p[2] == "offer" && r.Method == http.MethodDelete:
+		port.Offer.DeleteByID(w, r, p[3])
 	// case n == 5 && p[1] == "v1" && p[2] == "offer" && p[3] == "operation" && p[4] == "create-comment" && r.Method == http.MethodPost:
 	// 	port.Offer.OperationCreateComment(w, r)
 	case n == 4 && p[1] == "v1" && p[2] == "offers" && p[3] == "select-options" && r.Method == http.MethodGet:
@@ -307,12 +398,117 @@ func (port *httpInputPort) HandleRequests(w http.ResponseWriter, r *http.Request
 	case n == 4 && p[1] == "v1" && p[2] == "credit" && r.Method == http.MethodPut:
 		port.Credit.UpdateByID(w, r, p[3])
 
+	// --- RECONCILIATION REPORTS --- //
+	case n == 3 && p[1] == "v1" && p[2] == "reconciliation-reports" && r.Method == http.MethodGet:
+		port.Reconciliation.List(w, r)
+	case n == 3 && p[1] == "v1" && p[2] == "reconciliation-reports" && r.Method == http.MethodPost:
+		port.Reconciliation.Create(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "reconciliation-report" && r.Method == http.MethodGet:
+		port.Reconciliation.GetByID(w, r, p[3])
+
+	// --- INVOICES --- //
+	case n == 3 && p[1] == "v1" && p[2] == "invoices" && r.Method == http.MethodGet:
+		port.Invoice.List(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "invoice" && r.Method == http.MethodGet:
+		port.Invoice.GetByID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "invoice" && p[4] == "issue" && r.Method == http.MethodPost:
+		port.Invoice.IssueByID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "invoice" && p[4] == "payments" && r.Method == http.MethodPost:
+		port.Invoice.CreatePayment(w, r, p[3])
+
+	// --- SESSIONS --- //
+	case n == 3 && p[1] == "v1" && p[2] == "sessions" && r.Method == http.MethodGet:
+		port.Session.List(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "session" && r.Method == http.MethodDelete:
+		port.Session.DeleteByID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "sessions" && p[3] == "operation" && p[4] == "revoke-others" && r.Method == http.MethodPost:
+		port.Session.OperationRevokeOthers(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "sessions" && p[3] == "operation" && p[4] == "revoke-user" && r.Method == http.MethodPost:
+		port.Session.OperationRevokeUser(w, r)
+
+	// --- INVITATIONS --- //
+	case n == 3 && p[1] == "v1" && p[2] == "invitations" && r.Method == http.MethodGet:
+		port.Invitation.List(w, r)
+	case n == 3 && p[1] == "v1" && p[2] == "invitations" && r.Method == http.MethodPost:
+		port.Invitation.Create(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "invitation" && r.Method == http.MethodDelete:
+		port.Invitation.DeleteByID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "invitations" && p[3] == "operation" && p[4] == "resend" && r.Method == http.MethodPost:
+		port.Invitation.OperationResend(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "public" && p[3] == "invitation" && r.Method == http.MethodGet:
+		port.Invitation.GetByToken(w, r, p[4])
+	case n == 5 && p[1] == "v1" && p[2] == "public" && p[3] == "invitations" && p[4] == "accept" && r.Method == http.MethodPost:
+		port.Invitation.Accept(w, r)
+
+	// --- SYNC JOBS --- //
+	case n == 3 && p[1] == "v1" && p[2] == "sync-jobs" && r.Method == http.MethodGet:
+		port.SyncJob.List(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "sync-job" && r.Method == http.MethodGet:
+		port.SyncJob.GetByID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "sync-job" && p[4] == "retry" && r.Method == http.MethodPost:
+		port.SyncJob.RetryByID(w, r, p[3])
+
+	// --- OUTBOX --- //
+	case n == 3 && p[1] == "v1" && p[2] == "outbox-messages" && r.Method == http.MethodGet:
+		port.Outbox.List(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "outbox-message" && r.Method == http.MethodGet:
+		port.Outbox.GetByID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "outbox-message" && p[4] == "retry" && r.Method == http.MethodPost:
+		port.Outbox.RetryByID(w, r, p[3])
+
+	// --- EMAIL TEMPLATES --- //
+	case n == 3 && p[1] == "v1" && p[2] == "email-templates" && r.Method == http.MethodGet:
+		port.EmailTemplate.List(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "email-template" && p[4] == "preview" && r.Method == http.MethodGet:
+		port.EmailTemplate.Preview(w, r, p[3])
+
+	// --- NOTIFICATIONS --- //
+	case n == 3 && p[1] == "v1" && p[2] == "notifications" && r.Method == http.MethodGet:
+		port.Notification.List(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "notifications" && p[3] == "unread-count" && r.Method == http.MethodGet:
+		port.Notification.UnreadCount(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "notifications" && p[3] == "stream" && r.Method == http.MethodGet:
+		port.Notification.Stream(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "notifications" && p[3] == "stream-ticket" && r.Method == http.MethodPost:
+		port.Gateway.StreamTicket(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "notifications" && p[3] == "mark-all-read" && r.Method == http.MethodPost:
+		port.Notification.MarkAllAsRead(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "notification" && p[4] == "mark-read" && r.Method == http.MethodPost:
+		port.Notification.MarkAsReadByID(w, r, p[3])
+
+	// --- WEBHOOKS --- //
+	case n == 3 && p[1] == "v1" && p[2] == "webhooks" && r.Method == http.MethodGet:
+		port.Webhook.List(w, r)
+	case n == 3 && p[1] == "v1" && p[2] == "webhooks" && r.Method == http.MethodPost:
+		port.Webhook.Create(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "webhook" && r.Method == http.MethodGet:
+		port.Webhook.GetByID(w, r, p[3])
+	case n == 4 && p[1] == "v1" && p[2] == "webhook" && r.Method == http.MethodPut:
+		port.Webhook.UpdateByID(w, r, p[3])
+	case n == 4 && p[1] == "v1" && p[2] == "webhook" && r.Method == http.MethodDelete:
+		port.Webhook.DeleteByID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "webhook" && p[4] == "ping" && r.Method == http.MethodPost:
+		port.Webhook.PingByID(w, r, p[3])
+	case n == 3 && p[1] == "v1" && p[2] == "webhook-deliveries" && r.Method == http.MethodGet:
+		port.Webhook.ListDeliveries(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "webhook-delivery" && p[4] == "redeliver" && r.Method == http.MethodPost:
+		port.Webhook.RedeliverByID(w, r, p[3])
+
+	// --- API KEYS --- //
+	case n == 3 && p[1] == "v1" && p[2] == "api-keys" && r.Method == http.MethodGet:
+		port.APIKey.List(w, r)
+	case n == 3 && p[1] == "v1" && p[2] == "api-keys" && r.Method == http.MethodPost:
+		port.APIKey.Create(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "api-key" && r.Method == http.MethodDelete:
+		port.APIKey.DeleteByID(w, r, p[3])
+
 	// --- USER PURCHASES --- //
 	case n == 3 && p[1] == "v1" && p[2] == "user-purchases" && r.Method == http.MethodGet:
 		port.UserPurchase.List(w, r)
 
 	// --- CATCH ALL: D.N.E. ---
 	default:
+		setRoute(w, "unmatched")
 		port.Logger.Debug("404 request",
 			slog.Int("n", n),
 			slog.String("m", r.Method),
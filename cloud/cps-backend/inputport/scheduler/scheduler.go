@@ -0,0 +1,207 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	invoice_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/controller"
+	joblease_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/joblease/datastore"
+	outbox_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/outbox/controller"
+	reconciliation_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/reconciliation/controller"
+	syncjob_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/controller"
+	webhook_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/controller"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+type InputPortServer interface {
+	Run()
+	Shutdown()
+}
+
+// job represents a background task which the scheduler executes whenever
+// the `next` function says it is due.
+type job struct {
+	name string
+	next func(now time.Time) time.Time
+	run  func(ctx context.Context) error
+	// frequent jobs only log their start and finish at the debug level.
+	frequent bool
+	// lease is how long the replica which runs the job keeps the other
+	// replicas from running it, it must outlast the difference between
+	// their clocks. Jobs without one run in every replica so they must be
+	// safe to run concurrently.
+	lease time.Duration
+}
+
+// dailyJobLease keeps the other replicas from running a daily job which
+// woke up at the same hour.
+const dailyJobLease = 6 * time.Hour
+
+type schedulerInputPort struct {
+	Config         *config.Conf
+	Logger         *slog.Logger
+	Reconciliation reconciliation_c.ReconciliationController
+	Invoice        invoice_c.InvoiceController
+	SyncJob        syncjob_c.SyncJobController
+	Outbox         outbox_c.OutboxController
+	Webhook        webhook_c.WebhookController
+	JobLease       joblease_s.JobLeaseStorer
+	holder         string
+	jobs           []*job
+	done           chan struct{}
+	wg             sync.WaitGroup
+}
+
+func NewInputPort(
+	configp *config.Conf,
+	loggerp *slog.Logger,
+	rc reconciliation_c.ReconciliationController,
+	ic invoice_c.InvoiceController,
+	sjc syncjob_c.SyncJobController,
+	obc outbox_c.OutboxController,
+	whc webhook_c.WebhookController,
+	jl joblease_s.JobLeaseStorer,
+) InputPortServer {
+	// Identifies this replica as the holder of the job leases.
+	hostname, _ := os.Hostname()
+
+	p := &schedulerInputPort{
+		Config:         configp,
+		Logger:         loggerp,
+		Reconciliation: rc,
+		Invoice:        ic,
+		SyncJob:        sjc,
+		Outbox:         obc,
+		Webhook:        whc,
+		JobLease:       jl,
+		holder:         fmt.Sprintf("%v-%d", hostname, os.Getpid()),
+		done:           make(chan struct{}),
+	}
+
+	// Register all our background jobs.
+	p.jobs = []*job{
+		{
+			name:  "payment-reconciliation",
+			next:  dailyAt(2), // 2 AM UTC, after all of the previous day's payments settled.
+			run:   rc.RunNightly,
+			lease: dailyJobLease,
+		},
+		{
+			name:  "invoice-issue",
+			next:  dailyAt(3), // Issues the store invoices whose monthly billing period ended.
+			run:   ic.IssueEndedPeriods,
+			lease: dailyJobLease,
+		},
+		{
+			name:  "invoice-overdue-reminders",
+			next:  dailyAt(14), // 2 PM UTC so reminders arrive during North American business hours.
+			run:   ic.SendOverdueReminders,
+			lease: dailyJobLease,
+		},
+		{
+			name:     "sync-jobs",
+			next:     every(15 * time.Second), // Copies store and user changes onto their related records.
+			run:      sjc.ProcessPending,
+			frequent: true,
+		},
+		{
+			name:     "outbox-dispatch",
+			next:     every(5 * time.Second), // Delivers the queued emails once their transaction committed.
+			run:      obc.DispatchPending,
+			frequent: true,
+		},
+		{
+			name:     "webhook-dispatch",
+			next:     every(5 * time.Second), // Sends the queued webhook events once their transaction committed.
+			run:      whc.DispatchPending,
+			frequent: true,
+		},
+	}
+
+	return p
+}
+
+func (port *schedulerInputPort) Run() {
+	port.Logger.Info("scheduler running")
+	for _, j := range port.jobs {
+		port.wg.Add(1)
+		go port.loop(j)
+	}
+}
+
+func (port *schedulerInputPort) Shutdown() {
+	close(port.done)
+	port.wg.Wait()
+	port.Logger.Info("scheduler shutdown")
+}
+
+func (port *schedulerInputPort) loop(j *job) {
+	defer port.wg.Done()
+	for {
+		wait := time.Until(j.next(time.Now()))
+		port.Logger.Debug("scheduler waiting for job",
+			slog.String("job", j.name),
+			slog.Duration("wait", wait))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-port.done:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		level := slog.LevelInfo
+		if j.frequent {
+			level = slog.LevelDebug
+		}
+
+		if j.lease > 0 {
+			acquired, err := port.JobLease.Acquire(context.Background(), j.name, port.holder, j.lease)
+			if err != nil {
+				port.Logger.Error("scheduler job lease failed",
+					slog.String("job", j.name),
+					slog.Any("error", err))
+				continue
+			}
+			if !acquired {
+				port.Logger.Log(context.Background(), level, "scheduler job skipped, another replica is running it", slog.String("job", j.name))
+				continue
+			}
+		}
+
+		port.Logger.Log(context.Background(), level, "scheduler job starting", slog.String("job", j.name))
+		if err := j.run(context.Background()); err != nil {
+			port.Logger.Error("scheduler job failed",
+				slog.String("job", j.name),
+				slog.Any("error", err))
+			continue
+		}
+		port.Logger.Log(context.Background(), level, "scheduler job finished", slog.String("job", j.name))
+	}
+}
+
+// dailyAt returns a function which calculates the next time the clock reaches
+// the hour in UTC.
+func dailyAt(hour int) func(now time.Time) time.Time {
+	return func(now time.Time) time.Time {
+		now = now.UTC()
+		next := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, time.UTC)
+		if !next.After(now) {
+			next = next.AddDate(0, 0, 1)
+		}
+		return next
+	}
+}
+
+// every returns a function which calculates the next time after waiting the
+// interval since the job last finished.
+func every(interval time.Duration) func(now time.Time) time.Time {
+	return func(now time.Time) time.Time {
+		return now.Add(interval)
+	}
+}
@@ -10,21 +10,25 @@ import (
 	_ "go.uber.org/automaxprocs" // Automatically set GOMAXPROCS to match Linux container CPU quota.
 
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/inputport/http"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/inputport/scheduler"
 )
 
 type Application struct {
 	Logger     *slog.Logger
 	HttpServer http.InputPortServer
+	Scheduler  scheduler.InputPortServer
 }
 
 // NewApplication is application construction function which is automatically called by `Google Wire` dependency injection library.
 func NewApplication(
 	loggerp *slog.Logger,
 	httpServer http.InputPortServer,
+	sched scheduler.InputPortServer,
 ) Application {
 	return Application{
 		Logger:     loggerp,
 		HttpServer: httpServer,
+		Scheduler:  sched,
 	}
 }
 
@@ -35,6 +39,9 @@ func (a Application) Execute() {
 	// Run in background the HTTP server.
 	go a.HttpServer.Run()
 
+	// Run in background our scheduled jobs.
+	go a.Scheduler.Run()
+
 	a.Logger.Info("Application started")
 
 	// Run the main loop blocking code while other input ports run in background.
@@ -45,6 +52,7 @@ func (a Application) Execute() {
 
 func (a Application) Shutdown() {
 	a.HttpServer.Shutdown()
+	a.Scheduler.Shutdown()
 	a.Logger.Info("Application shutdown")
 }
 
@@ -0,0 +1,120 @@
+package currency
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+// Provider provides interface for abstracting currency selection and
+// conversion into our base currency.
+type Provider interface {
+	// BaseCurrency returns the currency our books are kept in.
+	BaseCurrency() string
+	// CurrencyForCountry returns the currency we charge customers located in
+	// the country, or the base currency if we do not support the country.
+	CurrencyForCountry(country string) string
+	// ConvertToBase converts the amount in the currency into our base currency.
+	ConvertToBase(amount float64, currency string) (float64, error)
+	// ExchangeRate returns how many units of the base currency one unit of the currency is worth.
+	ExchangeRate(currency string) (float64, error)
+	// IsSupported returns true if we can charge in the currency, meaning we
+	// know how to convert it back into our base currency.
+	IsSupported(currency string) bool
+}
+
+type currencyProvider struct {
+	base  string
+	rates map[string]float64
+}
+
+// countryCurrencies maps the countries we serve to the currency we charge
+// in. Keys are the ISO 3166 country code and the common names our frontend
+// submits.
+var countryCurrencies = map[string]string{
+	"CA":                       "CAD",
+	"CAN":                      "CAD",
+	"CANADA":                   "CAD",
+	"US":                       "USD",
+	"USA":                      "USD",
+	"UNITED STATES":            "USD",
+	"UNITED STATES OF AMERICA": "USD",
+	"MX":                       "MXN",
+	"MEX":                      "MXN",
+	"MEXICO":                   "MXN",
+	"MÉXICO":                   "MXN",
+}
+
+// NewProvider Constructor that returns the currency provider.
+func NewProvider(cfg *config.Conf) Provider {
+	p, err := newProvider(cfg.Currency.Base, cfg.Currency.ExchangeRates)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return p
+}
+
+func newProvider(base string, exchangeRates string) (*currencyProvider, error) {
+	p := &currencyProvider{
+		base:  strings.ToUpper(base),
+		rates: map[string]float64{strings.ToUpper(base): 1},
+	}
+	for _, pair := range strings.Split(exchangeRates, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		arr := strings.Split(pair, "=")
+		if len(arr) != 2 {
+			return nil, fmt.Errorf("exchange rate is corrupted for the value `%v`", pair)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(arr[1]), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("exchange rate is not a positive number for the value `%v`", pair)
+		}
+		p.rates[strings.ToUpper(strings.TrimSpace(arr[0]))] = rate
+	}
+	return p, nil
+}
+
+func (p *currencyProvider) BaseCurrency() string {
+	return p.base
+}
+
+func (p *currencyProvider) CurrencyForCountry(country string) string {
+	c, ok := countryCurrencies[strings.ToUpper(strings.TrimSpace(country))]
+	if !ok {
+		return p.base
+	}
+	// Only choose the currency if we know how to convert it back into our
+	// base currency, else we cannot keep our books.
+	if _, ok := p.rates[c]; !ok {
+		return p.base
+	}
+	return c
+}
+
+func (p *currencyProvider) ExchangeRate(currency string) (float64, error) {
+	rate, ok := p.rates[strings.ToUpper(currency)]
+	if !ok {
+		return 0, fmt.Errorf("no exchange rate for currency `%v`", currency)
+	}
+	return rate, nil
+}
+
+func (p *currencyProvider) IsSupported(currency string) bool {
+	_, ok := p.rates[strings.ToUpper(strings.TrimSpace(currency))]
+	return ok
+}
+
+func (p *currencyProvider) ConvertToBase(amount float64, currency string) (float64, error) {
+	rate, err := p.ExchangeRate(currency)
+	if err != nil {
+		return 0, err
+	}
+	return math.Round(amount*rate*100) / 100, nil
+}
@@ -0,0 +1,85 @@
+package currency
+
+import (
+	"testing"
+)
+
+func TestCurrencyForCountry(t *testing.T) {
+	p, err := newProvider("CAD", "USD=1.36, MXN=0.079")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := map[string]string{
+		"CA":            "CAD",
+		"Canada":        "CAD",
+		"us":            "USD",
+		"United States": "USD",
+		"Mexico":        "MXN",
+		"MX":            "MXN",
+		"France":        "CAD",
+		"":              "CAD",
+	}
+	for country, expected := range data {
+		if actual := p.CurrencyForCountry(country); actual != expected {
+			t.Errorf("Incorrect currency for `%v`, got: %v, want: %v.", country, actual, expected)
+		}
+	}
+}
+
+func TestCurrencyForCountryWithoutExchangeRate(t *testing.T) {
+	p, err := newProvider("CAD", "USD=1.36")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := p.CurrencyForCountry("Mexico"); actual != "CAD" {
+		t.Errorf("Incorrect currency, got: %v, want: %v.", actual, "CAD")
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	p, err := newProvider("CAD", "USD=1.36")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := map[string]bool{
+		"CAD":  true,
+		"usd":  true,
+		" USD": true,
+		"MXN":  false,
+		"":     false,
+	}
+	for currency, expected := range data {
+		if actual := p.IsSupported(currency); actual != expected {
+			t.Errorf("Incorrect support for `%v`, got: %v, want: %v.", currency, actual, expected)
+		}
+	}
+}
+
+func TestConvertToBase(t *testing.T) {
+	p, err := newProvider("CAD", "USD=1.36,MXN=0.079")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actual, _ := p.ConvertToBase(100, "CAD"); actual != 100 {
+		t.Errorf("Incorrect amount, got: %v, want: %v.", actual, 100)
+	}
+	if actual, _ := p.ConvertToBase(100, "usd"); actual != 136 {
+		t.Errorf("Incorrect amount, got: %v, want: %v.", actual, 136)
+	}
+	if actual, _ := p.ConvertToBase(1500, "MXN"); actual != 118.5 {
+		t.Errorf("Incorrect amount, got: %v, want: %v.", actual, 118.5)
+	}
+	if _, err := p.ConvertToBase(100, "EUR"); err == nil {
+		t.Errorf("Expected error for unsupported currency.")
+	}
+}
+
+func TestNewProviderCorruptedRates(t *testing.T) {
+	for _, rates := range []string{"USD", "USD=abc", "USD=-1"} {
+		if _, err := newProvider("CAD", rates); err == nil {
+			t.Errorf("Expected error for exchange rates `%v`.", rates)
+		}
+	}
+}
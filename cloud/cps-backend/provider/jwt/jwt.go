@@ -11,7 +11,11 @@ import (
 type Provider interface {
 	GenerateJWTToken(uuid string, ad time.Duration) (string, time.Time, error)
 	GenerateJWTTokenPair(uuid string, ad time.Duration, rd time.Duration) (string, time.Time, string, time.Time, error)
+	GenerateJWTTokenPairWithRefreshTokenID(uuid string, refreshTokenID string, ad time.Duration, rd time.Duration) (string, time.Time, string, time.Time, error)
 	ProcessJWTToken(reqToken string) (string, error)
+	ProcessJWTRefreshToken(reqToken string) (string, string, error)
+	GenerateUnsubscribeToken(userID string, notification string) (string, error)
+	ProcessUnsubscribeToken(reqToken string) (string, string, error)
 }
 
 type jwtProvider struct {
@@ -35,6 +39,23 @@ func (p jwtProvider) GenerateJWTTokenPair(uuid string, ad time.Duration, rd time
 	return jwt_utils.GenerateJWTTokenPair(p.hmacSecret, uuid, ad, rd)
 }
 
+// GenerateJWTTokenPairWithRefreshTokenID Generate the `access token` and `refresh token` where the refresh token can only be used once.
+func (p jwtProvider) GenerateJWTTokenPairWithRefreshTokenID(uuid string, refreshTokenID string, ad time.Duration, rd time.Duration) (string, time.Time, string, time.Time, error) {
+	return jwt_utils.GenerateJWTTokenPairWithRefreshTokenID(p.hmacSecret, uuid, refreshTokenID, ad, rd)
+}
+
 func (p jwtProvider) ProcessJWTToken(reqToken string) (string, error) {
 	return jwt_utils.ProcessJWTToken(p.hmacSecret, reqToken)
 }
+
+func (p jwtProvider) ProcessJWTRefreshToken(reqToken string) (string, string, error) {
+	return jwt_utils.ProcessJWTRefreshToken(p.hmacSecret, reqToken)
+}
+
+func (p jwtProvider) GenerateUnsubscribeToken(userID string, notification string) (string, error) {
+	return jwt_utils.GenerateUnsubscribeToken(p.hmacSecret, userID, notification)
+}
+
+func (p jwtProvider) ProcessUnsubscribeToken(reqToken string) (string, string, error) {
+	return jwt_utils.ProcessUnsubscribeToken(p.hmacSecret, reqToken)
+}
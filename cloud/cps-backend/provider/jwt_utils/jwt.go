@@ -1,17 +1,29 @@
 package jwt_utils
 
 import (
+	"errors"
 	"time"
 
 	jwt "github.com/dgrijalva/jwt-go"
 )
 
+const (
+	// AccessTokenType is the `token_type` claim value of access tokens.
+	AccessTokenType = "access"
+	// RefreshTokenType is the `token_type` claim value of refresh tokens.
+	RefreshTokenType = "refresh"
+	// UnsubscribeTokenType is the `token_type` claim value of the tokens in
+	// the unsubscribe links of our notification emails.
+	UnsubscribeTokenType = "unsubscribe"
+)
+
 // GenerateJWTToken Generate the `access token` for the secret key.
 func GenerateJWTToken(hmacSecret []byte, uuid string, ad time.Duration) (string, time.Time, error) {
 	token := jwt.New(jwt.SigningMethodHS256)
 	expiresIn := time.Now().Add(ad)
 	claims := token.Claims.(jwt.MapClaims)
 	claims["session_uuid"] = uuid
+	claims["token_type"] = AccessTokenType
 	claims["exp"] = expiresIn.Unix()
 
 	tokenString, err := token.SignedString(hmacSecret)
@@ -24,16 +36,17 @@ func GenerateJWTToken(hmacSecret []byte, uuid string, ad time.Duration) (string,
 
 // GenerateJWTTokenPair Generate the `access token` and `refresh token` for the secret key.
 func GenerateJWTTokenPair(hmacSecret []byte, uuid string, ad time.Duration, rd time.Duration) (string, time.Time, string, time.Time, error) {
+	return GenerateJWTTokenPairWithRefreshTokenID(hmacSecret, uuid, "", ad, rd)
+}
+
+// GenerateJWTTokenPairWithRefreshTokenID Generate the `access token` and
+// `refresh token` for the secret key where the refresh token carries the
+// `refreshTokenID` so it can only be used once.
+func GenerateJWTTokenPairWithRefreshTokenID(hmacSecret []byte, uuid string, refreshTokenID string, ad time.Duration, rd time.Duration) (string, time.Time, string, time.Time, error) {
 	//
 	// Generate token.
 	//
-	token := jwt.New(jwt.SigningMethodHS256)
-	expiresIn := time.Now().Add(ad)
-	claims := token.Claims.(jwt.MapClaims)
-	claims["session_uuid"] = uuid
-	claims["exp"] = expiresIn.Unix()
-
-	tokenString, err := token.SignedString(hmacSecret)
+	tokenString, expiresIn, err := GenerateJWTToken(hmacSecret, uuid, ad)
 	if err != nil {
 		return "", time.Now(), "", time.Now(), err
 	}
@@ -45,6 +58,8 @@ func GenerateJWTTokenPair(hmacSecret []byte, uuid string, ad time.Duration, rd t
 	refreshExpiresIn := time.Now().Add(rd)
 	rtClaims := refreshToken.Claims.(jwt.MapClaims)
 	rtClaims["session_uuid"] = uuid
+	rtClaims["token_type"] = RefreshTokenType
+	rtClaims["jti"] = refreshTokenID
 	rtClaims["exp"] = refreshExpiresIn.Unix()
 
 	refreshTokenString, err := refreshToken.SignedString(hmacSecret)
@@ -55,18 +70,87 @@ func GenerateJWTTokenPair(hmacSecret []byte, uuid string, ad time.Duration, rd t
 	return tokenString, expiresIn, refreshTokenString, refreshExpiresIn, nil
 }
 
-// ProcessJWTToken validates either the `access token` or `refresh token` and returns either the `uuid` if success or error on failure.
+// ProcessJWTToken validates the `access token` and returns either the `uuid` if success or error on failure.
 func ProcessJWTToken(hmacSecret []byte, reqToken string) (string, error) {
+	claims, err := parse(hmacSecret, reqToken)
+	if err != nil {
+		return "", err
+	}
+
+	// Do not allow the long lived refresh or unsubscribe tokens to be used as
+	// an access token.
+	if tokenType, _ := claims["token_type"].(string); tokenType == RefreshTokenType || tokenType == UnsubscribeTokenType {
+		return "", errors.New("token cannot be used for access")
+	}
+	uuid, _ := claims["session_uuid"].(string)
+	if uuid == "" {
+		return "", errors.New("missing session uuid")
+	}
+	return uuid, nil
+}
+
+// ProcessJWTRefreshToken validates the `refresh token` and returns the `uuid`
+// and the refresh token id if success or error on failure.
+func ProcessJWTRefreshToken(hmacSecret []byte, reqToken string) (string, string, error) {
+	claims, err := parse(hmacSecret, reqToken)
+	if err != nil {
+		return "", "", err
+	}
+	if tokenType, _ := claims["token_type"].(string); tokenType != RefreshTokenType {
+		return "", "", errors.New("not a refresh token")
+	}
+	uuid, _ := claims["session_uuid"].(string)
+	if uuid == "" {
+		return "", "", errors.New("missing session uuid")
+	}
+	refreshTokenID, _ := claims["jti"].(string)
+	return uuid, refreshTokenID, nil
+}
+
+// GenerateUnsubscribeToken generates the token which lets the user opt out of
+// the notification without logging in. It does not expire as the link stays
+// in the inbox of the user.
+func GenerateUnsubscribeToken(hmacSecret []byte, userID string, notification string) (string, error) {
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["sub"] = userID
+	claims["notification"] = notification
+	claims["token_type"] = UnsubscribeTokenType
+	return token.SignedString(hmacSecret)
+}
+
+// ProcessUnsubscribeToken validates the `unsubscribe token` and returns the
+// user id and the notification if success or error on failure.
+func ProcessUnsubscribeToken(hmacSecret []byte, reqToken string) (string, string, error) {
+	claims, err := parse(hmacSecret, reqToken)
+	if err != nil {
+		return "", "", err
+	}
+	if tokenType, _ := claims["token_type"].(string); tokenType != UnsubscribeTokenType {
+		return "", "", errors.New("not an unsubscribe token")
+	}
+	userID, _ := claims["sub"].(string)
+	notification, _ := claims["notification"].(string)
+	if userID == "" || notification == "" {
+		return "", "", errors.New("missing user id or notification")
+	}
+	return userID, notification, nil
+}
+
+func parse(hmacSecret []byte, reqToken string) (jwt.MapClaims, error) {
 	token, err := jwt.Parse(reqToken, func(t *jwt.Token) (interface{}, error) {
+		// Only accept the signing method we issue tokens with.
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
 		return hmacSecret, nil
 	})
-	if err == nil && token.Valid {
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			uuid := claims["session_uuid"].(string)
-			// m["exp"] := string(claims["exp"].(float64))
-			return uuid, nil
-		}
-		return "", err
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
 	}
-	return "", err
+	return claims, nil
 }
@@ -53,3 +53,59 @@ func TestProcessJWTToken(t *testing.T) {
 		t.Errorf("jwt claim is wrong, got %v but was expecting %v", actualUUID, sampleUUID)
 	}
 }
+
+func TestProcessJWTRefreshToken(t *testing.T) {
+	sampleHMACSecret := []byte("123secret")
+	sampleUUID := "xxx-xxx-xxx-xxx"
+	sampleRefreshTokenID := "yyy-yyy-yyy-yyy"
+	sampleAccessDuration := 100 * time.Second
+	sampleRefreshDuration := sampleAccessDuration + 72*time.Hour
+
+	actualAccessToken, _, actualRefreshToken, _, err := GenerateJWTTokenPairWithRefreshTokenID(sampleHMACSecret, sampleUUID, sampleRefreshTokenID, sampleAccessDuration, sampleRefreshDuration)
+	if err != nil {
+		t.Errorf("received an error %v", err)
+	}
+
+	actualUUID, actualRefreshTokenID, err := ProcessJWTRefreshToken(sampleHMACSecret, actualRefreshToken)
+	if err != nil {
+		t.Errorf("received an error %v", err)
+	}
+	if sampleUUID != actualUUID {
+		t.Errorf("jwt claim is wrong, got %v but was expecting %v", actualUUID, sampleUUID)
+	}
+	if sampleRefreshTokenID != actualRefreshTokenID {
+		t.Errorf("jwt claim is wrong, got %v but was expecting %v", actualRefreshTokenID, sampleRefreshTokenID)
+	}
+
+	// The tokens must not be interchangeable.
+	if _, _, err := ProcessJWTRefreshToken(sampleHMACSecret, actualAccessToken); err == nil {
+		t.Error("access token was accepted as a refresh token")
+	}
+	if _, err := ProcessJWTToken(sampleHMACSecret, actualRefreshToken); err == nil {
+		t.Error("refresh token was accepted as an access token")
+	}
+}
+
+func TestUnsubscribeToken(t *testing.T) {
+	sampleHMACSecret := []byte("123secret")
+
+	token, err := GenerateUnsubscribeToken(sampleHMACSecret, "user-1", "submission_status_changed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	userID, notification, err := ProcessUnsubscribeToken(sampleHMACSecret, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if userID != "user-1" || notification != "submission_status_changed" {
+		t.Errorf("Incorrect claims, got: %v and %v.", userID, notification)
+	}
+
+	// The unsubscribe link must never log anyone in.
+	if _, err := ProcessJWTToken(sampleHMACSecret, token); err == nil {
+		t.Error("unsubscribe token was accepted as an access token")
+	}
+	if _, _, err := ProcessUnsubscribeToken([]byte("other"), token); err == nil {
+		t.Error("unsubscribe token with a different secret was accepted")
+	}
+}
@@ -0,0 +1,365 @@
+// Package oidc signs users in with an external OpenID Connect identity
+// provider using the authorization code flow with PKCE, see
+// https://openid.net/specs/openid-connect-core-1_0.html and RFC 7636.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+var (
+	// ErrNotConfigured is returned when no identity provider was configured.
+	ErrNotConfigured = errors.New("single sign-on is not configured")
+
+	// ErrInvalidToken is returned, wrapped, when the identity provider's
+	// response cannot be trusted.
+	ErrInvalidToken = errors.New("invalid id token")
+)
+
+// Claims are the verified claims of the user's id token.
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	GivenName     string
+	FamilyName    string
+	Nonce         string
+}
+
+// Provider talks to the configured identity provider.
+type Provider interface {
+	// IsEnabled returns true if an identity provider was configured.
+	IsEnabled() bool
+	// IsAllowedEmail returns true if the email belongs to one of the allowed
+	// domains, no email is allowed if the list is empty.
+	IsAllowedEmail(email string) bool
+	// NewPKCE returns a random code verifier and its S256 code challenge.
+	NewPKCE() (verifier string, challenge string, err error)
+	// AuthCodeURL returns the URL of the identity provider's login page.
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems the authorization code and verifies the returned id
+	// token. The caller must compare the nonce.
+	Exchange(ctx context.Context, code, codeVerifier string) (*Claims, error)
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type oidcProvider struct {
+	issuerURL      string
+	clientID       string
+	clientSecret   string
+	redirectURL    string
+	allowedDomains []string
+	client         *http.Client
+
+	mu        sync.Mutex
+	discovery *discoveryDocument
+	keys      map[string]*rsa.PublicKey
+	keysAt    time.Time
+}
+
+// NewProvider returns the provider for the identity provider in our
+// configuration, it only talks to the identity provider once used.
+func NewProvider(cfg *config.Conf) Provider {
+	var domains []string
+	for _, d := range strings.Split(cfg.OIDC.AllowedDomains, ",") {
+		if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+			domains = append(domains, d)
+		}
+	}
+	return &oidcProvider{
+		issuerURL:      strings.TrimSuffix(cfg.OIDC.IssuerURL, "/"),
+		clientID:       cfg.OIDC.ClientID,
+		clientSecret:   cfg.OIDC.ClientSecret,
+		redirectURL:    cfg.OIDC.RedirectURL,
+		allowedDomains: domains,
+		client:         &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *oidcProvider) IsEnabled() bool {
+	return p.issuerURL != "" && p.clientID != ""
+}
+
+func (p *oidcProvider) IsAllowedEmail(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+	for _, d := range p.allowedDomains {
+		if domain == d {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *oidcProvider) NewPKCE() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	verifier := base64.RawURLEncoding.EncodeToString(b)
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+func (p *oidcProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	doc, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(doc.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.clientID)
+	q.Set("redirect_uri", p.redirectURL)
+	q.Set("scope", "openid email profile")
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+func (p *oidcProvider) Exchange(ctx context.Context, code, codeVerifier string) (*Claims, error) {
+	doc, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.redirectURL)
+	form.Set("code_verifier", codeVerifier)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, doc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.clientID), url.QueryEscape(p.clientSecret))
+
+	var tokenRes struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.doJSON(req, &tokenRes)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK || tokenRes.IDToken == "" {
+		return nil, fmt.Errorf("%w: token endpoint returned %d %s %s", ErrInvalidToken, status, tokenRes.Error, tokenRes.ErrorDescription)
+	}
+	return p.verifyIDToken(ctx, doc, tokenRes.IDToken)
+}
+
+// verifyIDToken checks the signature, issuer, audience and expiry of the id
+// token and returns its claims.
+func (p *oidcProvider) verifyIDToken(ctx context.Context, doc *discoveryDocument, rawIDToken string) (*Claims, error) {
+	parser := &jwt.Parser{ValidMethods: []string{jwt.SigningMethodRS256.Alg()}}
+	token, err := parser.Parse(rawIDToken, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.getKey(ctx, doc, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	mc, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	if iss, _ := mc["iss"].(string); iss != doc.Issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, iss)
+	}
+	if !hasAudience(mc["aud"], p.clientID) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+	if _, ok := mc["exp"]; !ok {
+		return nil, fmt.Errorf("%w: missing expiry", ErrInvalidToken)
+	}
+
+	c := &Claims{}
+	c.Subject, _ = mc["sub"].(string)
+	c.Email, _ = mc["email"].(string)
+	c.Name, _ = mc["name"].(string)
+	c.GivenName, _ = mc["given_name"].(string)
+	c.FamilyName, _ = mc["family_name"].(string)
+	c.Nonce, _ = mc["nonce"].(string)
+	switch v := mc["email_verified"].(type) {
+	case bool:
+		c.EmailVerified = v
+	case string: // Some providers send it as a string.
+		c.EmailVerified = v == "true"
+	}
+	if c.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+	return c, nil
+}
+
+func hasAudience(aud interface{}, clientID string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == clientID
+	case []interface{}:
+		for _, a := range v {
+			if s, _ := a.(string); s == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *oidcProvider) getDiscovery(ctx context.Context) (*discoveryDocument, error) {
+	if !p.IsEnabled() {
+		return nil, ErrNotConfigured
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.issuerURL+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	var doc discoveryDocument
+	status, err := p.doJSON(req, &doc)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("discovery returned %d", status)
+	}
+	if strings.TrimSuffix(doc.Issuer, "/") != p.issuerURL {
+		return nil, fmt.Errorf("discovery issuer %q does not match %q", doc.Issuer, p.issuerURL)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, errors.New("discovery document is incomplete")
+	}
+	p.discovery = &doc
+	return p.discovery, nil
+}
+
+// getKey returns the signing key with the id, the keys are fetched again
+// when we do not know the id as the identity provider rotates its keys.
+func (p *oidcProvider) getKey(ctx context.Context, doc *discoveryDocument, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	// Do not let unknown key ids make us hammer the identity provider.
+	if time.Since(p.keysAt) < time.Minute && p.keys != nil {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, doc.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	status, err := p.doJSON(req, &set)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("jwks returned %d", status)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		key, err := parseRSAKey(k)
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	p.keys = keys
+	p.keysAt = time.Now()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key id %q", kid)
+}
+
+func parseRSAKey(k jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+	exp := new(big.Int).SetBytes(e)
+	if !exp.IsInt64() || exp.Int64() < 3 || exp.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid exponent")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+}
+
+func (p *oidcProvider) doJSON(req *http.Request, v interface{}) (int, error) {
+	res, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return 0, err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return res.StatusCode, fmt.Errorf("decoding %s: %w", req.URL.Path, err)
+	}
+	return res.StatusCode, nil
+}
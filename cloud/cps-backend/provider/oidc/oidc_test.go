@@ -0,0 +1,172 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+// fakeIdentityProvider is a stand-in OpenID Connect identity provider which
+// issues an id token for a single authorization code.
+type fakeIdentityProvider struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	code      string
+	challenge string
+	claims    jwt.MapClaims
+}
+
+func newFakeIdentityProvider(t *testing.T) *fakeIdentityProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &fakeIdentityProvider{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "test",
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if r.PostForm.Get("code") != idp.code || base64.RawURLEncoding.EncodeToString(sum[:]) != idp.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.claims)
+		token.Header["kid"] = "test"
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Error(err)
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": signed})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func newTestProvider(issuerURL string) Provider {
+	cfg := &config.Conf{}
+	cfg.OIDC.IssuerURL = issuerURL
+	cfg.OIDC.ClientID = "cps"
+	cfg.OIDC.ClientSecret = "secret"
+	cfg.OIDC.RedirectURL = "https://app.example.com/login/sso"
+	cfg.OIDC.AllowedDomains = "example.com, Example.org"
+	return NewProvider(cfg)
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	idp := newFakeIdentityProvider(t)
+	p := newTestProvider(idp.server.URL)
+	ctx := context.Background()
+
+	verifier, challenge, err := p.NewPKCE()
+	if err != nil {
+		t.Fatal(err)
+	}
+	authURL, err := p.AuthCodeURL(ctx, "state1", "nonce1", challenge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(authURL)
+	if q := u.Query(); q.Get("state") != "state1" || q.Get("nonce") != "nonce1" || q.Get("code_challenge") != challenge || q.Get("code_challenge_method") != "S256" {
+		t.Fatalf("unexpected authorization url %s", authURL)
+	}
+
+	idp.code = "code1"
+	idp.challenge = challenge
+	idp.claims = jwt.MapClaims{
+		"iss":            idp.server.URL,
+		"aud":            "cps",
+		"sub":            "1234",
+		"email":          "grader@example.com",
+		"email_verified": true,
+		"nonce":          "nonce1",
+		"exp":            time.Now().Add(time.Minute).Unix(),
+	}
+	c, err := p.Exchange(ctx, "code1", verifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Subject != "1234" || c.Email != "grader@example.com" || !c.EmailVerified || c.Nonce != "nonce1" {
+		t.Fatalf("unexpected claims %+v", c)
+	}
+
+	// The code verifier must match the challenge.
+	if _, err := p.Exchange(ctx, "code1", "wrong"); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected invalid token for wrong verifier, got %v", err)
+	}
+
+	// Tokens for other clients and expired tokens are rejected.
+	idp.claims["aud"] = "someone-else"
+	if _, err := p.Exchange(ctx, "code1", verifier); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected invalid token for wrong audience, got %v", err)
+	}
+	idp.claims["aud"] = []string{"someone-else", "cps"}
+	idp.claims["exp"] = time.Now().Add(-time.Minute).Unix()
+	if _, err := p.Exchange(ctx, "code1", verifier); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected invalid token for expired token, got %v", err)
+	}
+}
+
+func TestIsAllowedEmail(t *testing.T) {
+	p := newTestProvider("https://idp.example.com")
+	for email, want := range map[string]bool{
+		"a@example.com":      true,
+		"a@EXAMPLE.ORG":      true,
+		"a@example.com.evil": false,
+		"a@sub.example.com":  false,
+		"example.com":        false,
+	} {
+		if got := p.IsAllowedEmail(email); got != want {
+			t.Errorf("IsAllowedEmail(%q) = %v, want %v", email, got, want)
+		}
+	}
+
+	// An identity provider usually has accounts we do not trust, like
+	// personal accounts, so forgetting the domains must not let them in.
+	cfg := &config.Conf{}
+	cfg.OIDC.IssuerURL = "https://idp.example.com"
+	cfg.OIDC.ClientID = "cps"
+	if NewProvider(cfg).IsAllowedEmail("a@example.com") {
+		t.Error("expected no email to be allowed without allowed domains")
+	}
+
+	if newTestProvider("").IsEnabled() {
+		t.Error("expected provider without issuer to be disabled")
+	}
+}
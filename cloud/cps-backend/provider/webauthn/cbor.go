@@ -0,0 +1,139 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var errCBOR = errors.New("malformed cbor")
+
+// maxCBORDepth stops maliciously deep nesting from exhausting the stack.
+const maxCBORDepth = 16
+
+// decodeCBOR decodes the first CBOR data item of the buffer and returns it
+// with the number of bytes read. Only the subset used by WebAuthn is
+// supported: integers, byte and text strings, arrays, maps and the simple
+// values. Integers are returned as `int64`, maps as `map[interface{}]interface{}`.
+func decodeCBOR(b []byte) (interface{}, int, error) {
+	return decodeCBORItem(b, 0)
+}
+
+func decodeCBORItem(b []byte, depth int) (interface{}, int, error) {
+	if depth > maxCBORDepth || len(b) == 0 {
+		return nil, 0, errCBOR
+	}
+	major := b[0] >> 5
+	info := b[0] & 0x1f
+
+	// Read the argument which is either the value, the length or the count.
+	var arg uint64
+	n := 1
+	switch {
+	case info < 24:
+		arg = uint64(info)
+	case info == 24:
+		if len(b) < 2 {
+			return nil, 0, errCBOR
+		}
+		arg = uint64(b[1])
+		n = 2
+	case info == 25:
+		if len(b) < 3 {
+			return nil, 0, errCBOR
+		}
+		arg = uint64(binary.BigEndian.Uint16(b[1:3]))
+		n = 3
+	case info == 26:
+		if len(b) < 5 {
+			return nil, 0, errCBOR
+		}
+		arg = uint64(binary.BigEndian.Uint32(b[1:5]))
+		n = 5
+	case info == 27:
+		if len(b) < 9 {
+			return nil, 0, errCBOR
+		}
+		arg = binary.BigEndian.Uint64(b[1:9])
+		n = 9
+	default:
+		// Indefinite lengths are not allowed in WebAuthn.
+		return nil, 0, errCBOR
+	}
+
+	switch major {
+	case 0: // unsigned integer
+		if arg > 1<<63-1 {
+			return nil, 0, errCBOR
+		}
+		return int64(arg), n, nil
+	case 1: // negative integer
+		if arg > 1<<63-1 {
+			return nil, 0, errCBOR
+		}
+		return -1 - int64(arg), n, nil
+	case 2, 3: // byte string, text string
+		if arg > uint64(len(b)-n) {
+			return nil, 0, errCBOR
+		}
+		v := b[n : n+int(arg)]
+		n += int(arg)
+		if major == 3 {
+			return string(v), n, nil
+		}
+		return append([]byte(nil), v...), n, nil
+	case 4: // array
+		if arg > uint64(len(b)) {
+			return nil, 0, errCBOR
+		}
+		arr := make([]interface{}, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			v, m, err := decodeCBORItem(b[n:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			arr = append(arr, v)
+			n += m
+		}
+		return arr, n, nil
+	case 5: // map
+		if arg > uint64(len(b)) {
+			return nil, 0, errCBOR
+		}
+		m := make(map[interface{}]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			k, kn, err := decodeCBORItem(b[n:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			n += kn
+			switch k.(type) {
+			case int64, string:
+			default:
+				return nil, 0, errCBOR
+			}
+			v, vn, err := decodeCBORItem(b[n:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			n += vn
+			m[k] = v
+		}
+		return m, n, nil
+	case 6: // tag, the tagged item is returned as is
+		v, m, err := decodeCBORItem(b[n:], depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		return v, n + m, nil
+	case 7: // simple values
+		switch info {
+		case 20:
+			return false, n, nil
+		case 21:
+			return true, n, nil
+		case 22, 23:
+			return nil, n, nil
+		}
+	}
+	return nil, 0, errCBOR
+}
@@ -0,0 +1,326 @@
+// Package webauthn verifies the WebAuthn registration and assertion
+// ceremonies used for hardware security keys and passkeys. We request no
+// attestation so only the signatures made with the registered public key
+// are verified, see https://www.w3.org/TR/webauthn-2/.
+package webauthn
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
+)
+
+// ErrVerification is returned, wrapped, whenever a ceremony fails to verify.
+var ErrVerification = errors.New("webauthn verification failed")
+
+// COSE algorithm identifiers we support, in order of preference.
+const (
+	AlgES256 int64 = -7
+	AlgEdDSA int64 = -8
+	AlgRS256 int64 = -257
+)
+
+// SupportedAlgorithms are given to the browser as `pubKeyCredParams`.
+var SupportedAlgorithms = []int64{AlgES256, AlgEdDSA, AlgRS256}
+
+// Authenticator data flags.
+const (
+	flagUserPresent            = 0x01
+	flagUserVerified           = 0x04
+	flagBackupEligible         = 0x08
+	flagAttestedCredentialData = 0x40
+)
+
+// Credential is a newly registered public key credential.
+type Credential struct {
+	ID []byte
+	// PublicKey is the COSE encoded public key.
+	PublicKey      []byte
+	Algorithm      int64
+	SignCount      uint32
+	AAGUID         []byte
+	UserVerified   bool
+	BackupEligible bool
+}
+
+// Assertion is the result of a verified authentication ceremony.
+type Assertion struct {
+	SignCount    uint32
+	UserVerified bool
+}
+
+// Provider verifies the WebAuthn ceremonies for our relying party.
+type Provider interface {
+	RelyingPartyID() string
+	RelyingPartyName() string
+	// NewChallenge returns a random base64url encoded challenge.
+	NewChallenge() (string, error)
+	VerifyRegistration(challenge string, clientDataJSON, attestationObject []byte) (*Credential, error)
+	// VerifyAssertion verifies the signature made by the credential and
+	// returns an error if the signature counter went backwards, which
+	// indicates a cloned authenticator.
+	VerifyAssertion(challenge string, publicKey []byte, storedSignCount uint32, clientDataJSON, authenticatorData, signature []byte, requireUserVerification bool) (*Assertion, error)
+}
+
+type webauthnProvider struct {
+	rpID    string
+	rpName  string
+	origins []string
+}
+
+// NewProvider Constructor that returns the WebAuthn verifier for the app domain.
+func NewProvider(cfg *config.Conf) Provider {
+	rpID := cfg.AppServer.AppDomainName
+	if host, _, err := net.SplitHostPort(rpID); err == nil {
+		rpID = host
+	}
+	origins := []string{"https://" + cfg.AppServer.AppDomainName}
+	if cfg.AppServer.IsDeveloperMode {
+		origins = append(origins, "http://"+cfg.AppServer.AppDomainName)
+	}
+	return &webauthnProvider{
+		rpID:    rpID,
+		rpName:  "Comic Book Grading",
+		origins: origins,
+	}
+}
+
+func (p *webauthnProvider) RelyingPartyID() string {
+	return p.rpID
+}
+
+func (p *webauthnProvider) RelyingPartyName() string {
+	return p.rpName
+}
+
+func (p *webauthnProvider) NewChallenge() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (p *webauthnProvider) VerifyRegistration(challenge string, clientDataJSON, attestationObject []byte) (*Credential, error) {
+	if err := p.verifyClientData(clientDataJSON, "webauthn.create", challenge); err != nil {
+		return nil, err
+	}
+
+	v, _, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: attestation object: %v", ErrVerification, err)
+	}
+	att, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: attestation object is not a map", ErrVerification)
+	}
+	rawAuthData, ok := att["authData"].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing authenticator data", ErrVerification)
+	}
+
+	ad, err := p.parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if ad.flags&flagAttestedCredentialData == 0 {
+		return nil, fmt.Errorf("%w: missing attested credential data", ErrVerification)
+	}
+	_, alg, err := parsePublicKey(ad.publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		ID:             ad.credentialID,
+		PublicKey:      ad.publicKey,
+		Algorithm:      alg,
+		SignCount:      ad.signCount,
+		AAGUID:         ad.aaguid,
+		UserVerified:   ad.flags&flagUserVerified != 0,
+		BackupEligible: ad.flags&flagBackupEligible != 0,
+	}, nil
+}
+
+func (p *webauthnProvider) VerifyAssertion(challenge string, publicKey []byte, storedSignCount uint32, clientDataJSON, authenticatorData, signature []byte, requireUserVerification bool) (*Assertion, error) {
+	if err := p.verifyClientData(clientDataJSON, "webauthn.get", challenge); err != nil {
+		return nil, err
+	}
+	ad, err := p.parseAuthenticatorData(authenticatorData)
+	if err != nil {
+		return nil, err
+	}
+	if requireUserVerification && ad.flags&flagUserVerified == 0 {
+		return nil, fmt.Errorf("%w: user was not verified", ErrVerification)
+	}
+
+	pub, alg, err := parsePublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte(nil), authenticatorData...), clientDataHash[:]...)
+	if err := verifySignature(pub, alg, signed, signature); err != nil {
+		return nil, err
+	}
+
+	// Authenticators which do not keep a counter always return zero.
+	if (ad.signCount != 0 || storedSignCount != 0) && ad.signCount <= storedSignCount {
+		return nil, fmt.Errorf("%w: signature counter did not increase", ErrVerification)
+	}
+
+	return &Assertion{
+		SignCount:    ad.signCount,
+		UserVerified: ad.flags&flagUserVerified != 0,
+	}, nil
+}
+
+func (p *webauthnProvider) verifyClientData(clientDataJSON []byte, typ, challenge string) error {
+	var cd struct {
+		Type      string `json:"type"`
+		Challenge string `json:"challenge"`
+		Origin    string `json:"origin"`
+	}
+	if err := json.Unmarshal(clientDataJSON, &cd); err != nil {
+		return fmt.Errorf("%w: client data: %v", ErrVerification, err)
+	}
+	if cd.Type != typ {
+		return fmt.Errorf("%w: unexpected client data type %q", ErrVerification, cd.Type)
+	}
+	if challenge == "" || subtle.ConstantTimeCompare([]byte(cd.Challenge), []byte(challenge)) != 1 {
+		return fmt.Errorf("%w: challenge does not match", ErrVerification)
+	}
+	for _, o := range p.origins {
+		if cd.Origin == o {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: unexpected origin %q", ErrVerification, cd.Origin)
+}
+
+type authenticatorData struct {
+	flags        byte
+	signCount    uint32
+	aaguid       []byte
+	credentialID []byte
+	publicKey    []byte
+}
+
+func (p *webauthnProvider) parseAuthenticatorData(b []byte) (*authenticatorData, error) {
+	if len(b) < 37 {
+		return nil, fmt.Errorf("%w: authenticator data too short", ErrVerification)
+	}
+	rpIDHash := sha256.Sum256([]byte(p.rpID))
+	if !bytes.Equal(b[:32], rpIDHash[:]) {
+		return nil, fmt.Errorf("%w: relying party id does not match", ErrVerification)
+	}
+	ad := &authenticatorData{
+		flags:     b[32],
+		signCount: binary.BigEndian.Uint32(b[33:37]),
+	}
+	if ad.flags&flagUserPresent == 0 {
+		return nil, fmt.Errorf("%w: user was not present", ErrVerification)
+	}
+	if ad.flags&flagAttestedCredentialData == 0 {
+		return ad, nil
+	}
+
+	rest := b[37:]
+	if len(rest) < 18 {
+		return nil, fmt.Errorf("%w: attested credential data too short", ErrVerification)
+	}
+	ad.aaguid = append([]byte(nil), rest[:16]...)
+	idLen := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if idLen == 0 || len(rest) < idLen {
+		return nil, fmt.Errorf("%w: invalid credential id", ErrVerification)
+	}
+	ad.credentialID = append([]byte(nil), rest[:idLen]...)
+	rest = rest[idLen:]
+
+	// The public key is followed by the optional extensions so we decode it
+	// to find out where it ends.
+	_, n, err := decodeCBOR(rest)
+	if err != nil {
+		return nil, fmt.Errorf("%w: credential public key: %v", ErrVerification, err)
+	}
+	ad.publicKey = append([]byte(nil), rest[:n]...)
+	return ad, nil
+}
+
+// parsePublicKey decodes the COSE encoded public key.
+func parsePublicKey(coseKey []byte) (crypto.PublicKey, int64, error) {
+	v, _, err := decodeCBOR(coseKey)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: public key: %v", ErrVerification, err)
+	}
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: public key is not a map", ErrVerification)
+	}
+	kty, _ := m[int64(1)].(int64)
+	alg, _ := m[int64(3)].(int64)
+
+	switch {
+	case kty == 2 && alg == AlgES256:
+		crv, _ := m[int64(-1)].(int64)
+		x, _ := m[int64(-2)].([]byte)
+		y, _ := m[int64(-3)].([]byte)
+		if crv != 1 || len(x) != 32 || len(y) != 32 {
+			return nil, 0, fmt.Errorf("%w: invalid ec2 key", ErrVerification)
+		}
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, 0, fmt.Errorf("%w: ec2 point is not on the curve", ErrVerification)
+		}
+		return pub, alg, nil
+	case kty == 1 && alg == AlgEdDSA:
+		crv, _ := m[int64(-1)].(int64)
+		x, _ := m[int64(-2)].([]byte)
+		if crv != 6 || len(x) != ed25519.PublicKeySize {
+			return nil, 0, fmt.Errorf("%w: invalid okp key", ErrVerification)
+		}
+		return ed25519.PublicKey(x), alg, nil
+	case kty == 3 && alg == AlgRS256:
+		n, _ := m[int64(-1)].([]byte)
+		e, _ := m[int64(-2)].([]byte)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, 0, fmt.Errorf("%w: invalid rsa key", ErrVerification)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, alg, nil
+	}
+	return nil, 0, fmt.Errorf("%w: unsupported key type %d with algorithm %d", ErrVerification, kty, alg)
+}
+
+func verifySignature(pub crypto.PublicKey, alg int64, data, sig []byte) error {
+	ok := false
+	switch alg {
+	case AlgES256:
+		h := sha256.Sum256(data)
+		ok = ecdsa.VerifyASN1(pub.(*ecdsa.PublicKey), h[:], sig)
+	case AlgEdDSA:
+		ok = ed25519.Verify(pub.(ed25519.PublicKey), data, sig)
+	case AlgRS256:
+		h := sha256.Sum256(data)
+		ok = rsa.VerifyPKCS1v15(pub.(*rsa.PublicKey), crypto.SHA256, h[:], sig) == nil
+	}
+	if !ok {
+		return fmt.Errorf("%w: invalid signature", ErrVerification)
+	}
+	return nil
+}
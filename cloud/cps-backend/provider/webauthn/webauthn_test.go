@@ -0,0 +1,138 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// The following helpers encode the small subset of CBOR the tests need.
+
+func cborHead(major byte, n int) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n < 256:
+		return []byte{major<<5 | 24, byte(n)}
+	default:
+		return []byte{major<<5 | 25, byte(n >> 8), byte(n)}
+	}
+}
+
+func cborInt(v int) []byte {
+	if v < 0 {
+		return cborHead(1, -1-v)
+	}
+	return cborHead(0, v)
+}
+
+func cborBytes(b []byte) []byte { return append(cborHead(2, len(b)), b...) }
+
+func cborText(s string) []byte { return append(cborHead(3, len(s)), s...) }
+
+func testProvider() *webauthnProvider {
+	return &webauthnProvider{rpID: "example.com", rpName: "Test", origins: []string{"https://example.com"}}
+}
+
+func testClientData(t *testing.T, typ, challenge string) []byte {
+	b, err := json.Marshal(map[string]string{"type": typ, "challenge": challenge, "origin": "https://example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func testAuthData(flags byte, signCount uint32, attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte("example.com"))
+	b := append([]byte(nil), rpIDHash[:]...)
+	b = append(b, flags)
+	b = binary.BigEndian.AppendUint32(b, signCount)
+	return append(b, attested...)
+}
+
+func TestRegistrationAndAssertion(t *testing.T) {
+	p := testProvider()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// COSE key: {1: 2, 3: -7, -1: 1, -2: x, -3: y}
+	coseKey := append(cborHead(5, 5), cborInt(1)...)
+	coseKey = append(coseKey, cborInt(2)...)
+	coseKey = append(coseKey, cborInt(3)...)
+	coseKey = append(coseKey, cborInt(-7)...)
+	coseKey = append(coseKey, cborInt(-1)...)
+	coseKey = append(coseKey, cborInt(1)...)
+	coseKey = append(coseKey, cborInt(-2)...)
+	coseKey = append(coseKey, cborBytes(key.X.FillBytes(make([]byte, 32)))...)
+	coseKey = append(coseKey, cborInt(-3)...)
+	coseKey = append(coseKey, cborBytes(key.Y.FillBytes(make([]byte, 32)))...)
+
+	credID := []byte("credential-id")
+	attested := make([]byte, 16) // aaguid
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(credID)))
+	attested = append(attested, credID...)
+	attested = append(attested, coseKey...)
+	authData := testAuthData(flagUserPresent|flagUserVerified|flagAttestedCredentialData, 0, attested)
+
+	attObj := append(cborHead(5, 3), cborText("fmt")...)
+	attObj = append(attObj, cborText("none")...)
+	attObj = append(attObj, cborText("attStmt")...)
+	attObj = append(attObj, cborHead(5, 0)...)
+	attObj = append(attObj, cborText("authData")...)
+	attObj = append(attObj, cborBytes(authData)...)
+
+	cred, err := p.VerifyRegistration("register-challenge", testClientData(t, "webauthn.create", "register-challenge"), attObj)
+	if err != nil {
+		t.Fatalf("registration failed: %v", err)
+	}
+	if string(cred.ID) != string(credID) || cred.Algorithm != AlgES256 || !cred.UserVerified {
+		t.Fatalf("unexpected credential: %+v", cred)
+	}
+
+	if _, err := p.VerifyRegistration("other-challenge", testClientData(t, "webauthn.create", "register-challenge"), attObj); !errors.Is(err, ErrVerification) {
+		t.Errorf("expected challenge mismatch to fail, got %v", err)
+	}
+
+	// Sign an assertion with the registered key.
+	assertAuthData := testAuthData(flagUserPresent, 5, nil)
+	clientData := testClientData(t, "webauthn.get", "login-challenge")
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte(nil), assertAuthData...), clientDataHash[:]...))
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := p.VerifyAssertion("login-challenge", cred.PublicKey, 4, clientData, assertAuthData, sig, false)
+	if err != nil {
+		t.Fatalf("assertion failed: %v", err)
+	}
+	if a.SignCount != 5 {
+		t.Errorf("unexpected sign count, got: %v, want: %v", a.SignCount, 5)
+	}
+	if _, err := p.VerifyAssertion("login-challenge", cred.PublicKey, 4, clientData, assertAuthData, sig, true); err == nil {
+		t.Error("expected assertion without user verification to fail when required")
+	}
+	if _, err := p.VerifyAssertion("login-challenge", cred.PublicKey, 5, clientData, assertAuthData, sig, false); err == nil {
+		t.Error("expected assertion with a counter that did not increase to fail")
+	}
+	sig[len(sig)-1] ^= 0xff
+	if _, err := p.VerifyAssertion("login-challenge", cred.PublicKey, 4, clientData, assertAuthData, sig, false); err == nil {
+		t.Error("expected tampered signature to fail")
+	}
+}
+
+func TestDecodeCBORRejectsTruncatedInput(t *testing.T) {
+	for _, b := range [][]byte{{}, {0x58}, {0x42, 0x01}, {0xa1, 0x01}, {0x9f}} {
+		if _, _, err := decodeCBOR(b); err == nil {
+			t.Errorf("expected %x to fail", b)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package i18n
+
+// catalog holds the translations keyed by the English text. Formatted text
+// keeps its verbs so callers translate before calling `fmt.Sprintf`.
+var catalog = map[string]map[string]string{
+	Spanish: {
+		// Service types, see `ServiceTypeMap`.
+		"Pre-Screening Service":            "Servicio de preselección",
+		"CPS Pedigree Service":             "Servicio de pedigrí CPS",
+		"CPS Capsule":                      "Cápsula CPS",
+		"CPS Capsule Indie Mint Gem":       "Cápsula CPS Indie Mint Gem",
+		"CPS Capsule Signature Collection": "Cápsula CPS Colección de Firmas",
+		"CPS Capsule U-Grade":              "Cápsula CPS U-Grade",
+
+		// Key issues, see `KeyIssueMap`.
+		"1st appearance of":            "1ra aparición de",
+		"1st cameo of":                 "1er cameo de",
+		"1st Team Up of":               "1er equipo de",
+		"1st appearance in solo title": "1ra aparición en título propio",
+		"Death of":                     "Muerte de",
+		"Wedding of":                   "Boda de",
+		"Birth of":                     "Nacimiento de",
+		"Iconic cover by":              "Portada icónica de",
+		"Origin of":                    "Origen de",
+		"Other":                        "Otro",
+
+		// Grades and findings.
+		"Poor":           "Pobre",
+		"Fair":           "Regular",
+		"Good":           "Bueno",
+		"Very good":      "Muy bueno",
+		"Fine":           "Fino",
+		"Very Fine":      "Muy fino",
+		"Near Mint":      "Casi nuevo",
+		"Near Mint Plus": "Casi nuevo plus",
+
+		// Primary label details.
+		"Regular Edition":        "Edición regular",
+		"Direct Edition":         "Edición directa",
+		"Newsstand Edition":      "Edición de puesto de periódicos",
+		"Variant Cover":          "Portada variante",
+		"Canadian Price Variant": "Variante de precio canadiense",
+		"Facsimile":              "Facsímil",
+		"Reprint":                "Reimpresión",
+
+		// Submission PDFs.
+		"Volume:": "Volumen:",
+		"Date:":   "Fecha:",
+
+		// Submission statuses, see `StatusNameMap`.
+		"Waiting":                     "En espera",
+		"Received":                    "Recibido",
+		"Pending":                     "Pendiente",
+		"In Process":                  "En proceso",
+		"Complete":                    "Completado",
+		"Shipped":                     "Enviado",
+		"Completed by Retail Partner": "Completado por el socio minorista",
+
+		// Roles.
+		"Root":           "Administrador",
+		"Grader":         "Calificador",
+		"Shipping Clerk": "Encargado de envíos",
+		"Store Manager":  "Gerente de tienda",
+		"Retailer":       "Minorista",
+		"Customer":       "Cliente",
+
+		// Email subjects.
+		"Activate your CPS Retail Partner Account":              "Activa tu cuenta de socio minorista de CPS",
+		"Forgot Password":                                       "Olvidaste tu contraseña",
+		"Invoice %v from CPS":                                   "Factura %v de CPS",
+		"Reminder: invoice %v is overdue":                       "Recordatorio: la factura %v está vencida",
+		"Your store is active":                                  "Tu tienda está activa",
+		"New Comic Submission":                                  "Nuevo envío de cómic",
+		"New CPS Retail Partner Application":                    "Nueva solicitud de socio minorista de CPS",
+		"Payment Reconciliation Report":                         "Informe de conciliación de pagos",
+		"Payment Reconciliation Report: %v discrepancies found": "Informe de conciliación de pagos: %v discrepancias encontradas",
+		"Your account was locked":                               "Tu cuenta fue bloqueada",
+		"Confirm your new email":                                "Confirma tu nuevo correo electrónico",
+		"Your email is being changed":                           "Tu correo electrónico está siendo cambiado",
+		"You have been invited to join %v":                      "Has sido invitado a unirte a %v",
+		"Welcome to your new account":                           "Bienvenido a tu nueva cuenta",
+		"Your submission is now %v":                             "Tu envío ahora está: %v",
+
+		// In-app notifications.
+		"%v commented on your submission":                            "%v comentó en tu envío",
+		"The store %v was approved, you can now create submissions.": "La tienda %v fue aprobada, ya puedes crear envíos.",
+		"You have received credits":                                  "Has recibido créditos",
+		"%v credits were added for %v.":                              "Se agregaron %v créditos para %v.",
+	},
+	French: {
+		// Service types, see `ServiceTypeMap`.
+		"Pre-Screening Service":            "Service de présélection",
+		"CPS Pedigree Service":             "Service de pedigree CPS",
+		"CPS Capsule":                      "Capsule CPS",
+		"CPS Capsule Indie Mint Gem":       "Capsule CPS Indie Mint Gem",
+		"CPS Capsule Signature Collection": "Capsule CPS Collection signature",
+		"CPS Capsule U-Grade":              "Capsule CPS U-Grade",
+
+		// Key issues, see `KeyIssueMap`.
+		"1st appearance of":            "1re apparition de",
+		"1st cameo of":                 "1er caméo de",
+		"1st Team Up of":               "1re équipe de",
+		"1st appearance in solo title": "1re apparition en titre solo",
+		"Death of":                     "Mort de",
+		"Wedding of":                   "Mariage de",
+		"Birth of":                     "Naissance de",
+		"Iconic cover by":              "Couverture iconique par",
+		"Origin of":                    "Origine de",
+		"Other":                        "Autre",
+
+		// Grades and findings.
+		"Poor":           "Médiocre",
+		"Fair":           "Passable",
+		"Good":           "Bon",
+		"Very good":      "Très bon",
+		"Fine":           "Fin",
+		"Very Fine":      "Très fin",
+		"Near Mint":      "Presque neuf",
+		"Near Mint Plus": "Presque neuf plus",
+
+		// Primary label details.
+		"Regular Edition":        "Édition régulière",
+		"Direct Edition":         "Édition directe",
+		"Newsstand Edition":      "Édition kiosque",
+		"Variant Cover":          "Couverture variante",
+		"Canadian Price Variant": "Variante de prix canadien",
+		"Facsimile":              "Fac-similé",
+		"Reprint":                "Réimpression",
+
+		// Submission PDFs.
+		"Volume:": "Volume :",
+		"Date:":   "Date :",
+
+		// Submission statuses, see `StatusNameMap`.
+		"Waiting":                     "En attente",
+		"Received":                    "Reçu",
+		"Pending":                     "En suspens",
+		"In Process":                  "En traitement",
+		"Complete":                    "Terminé",
+		"Shipped":                     "Expédié",
+		"Completed by Retail Partner": "Terminé par le partenaire détaillant",
+
+		// Roles.
+		"Root":           "Administrateur",
+		"Grader":         "Évaluateur",
+		"Shipping Clerk": "Commis à l'expédition",
+		"Store Manager":  "Gérant de magasin",
+		"Retailer":       "Détaillant",
+		"Customer":       "Client",
+
+		// Email subjects.
+		"Activate your CPS Retail Partner Account":              "Activez votre compte de partenaire détaillant CPS",
+		"Forgot Password":                                       "Mot de passe oublié",
+		"Invoice %v from CPS":                                   "Facture %v de CPS",
+		"Reminder: invoice %v is overdue":                       "Rappel : la facture %v est en retard",
+		"Your store is active":                                  "Votre magasin est actif",
+		"New Comic Submission":                                  "Nouvelle soumission de bande dessinée",
+		"New CPS Retail Partner Application":                    "Nouvelle demande de partenaire détaillant CPS",
+		"Payment Reconciliation Report":                         "Rapport de rapprochement des paiements",
+		"Payment Reconciliation Report: %v discrepancies found": "Rapport de rapprochement des paiements : %v écarts trouvés",
+		"Your account was locked":                               "Votre compte a été verrouillé",
+		"Confirm your new email":                                "Confirmez votre nouveau courriel",
+		"Your email is being changed":                           "Votre courriel est en cours de modification",
+		"You have been invited to join %v":                      "Vous avez été invité à rejoindre %v",
+		"Welcome to your new account":                           "Bienvenue dans votre nouveau compte",
+		"Your submission is now %v":                             "Votre soumission est maintenant : %v",
+
+		// In-app notifications.
+		"%v commented on your submission":                            "%v a commenté votre soumission",
+		"The store %v was approved, you can now create submissions.": "Le magasin %v a été approuvé, vous pouvez maintenant créer des soumissions.",
+		"You have received credits":                                  "Vous avez reçu des crédits",
+		"%v credits were added for %v.":                              "%v crédits ont été ajoutés pour %v.",
+	},
+}
@@ -0,0 +1,53 @@
+package i18n
+
+import (
+	"strings"
+)
+
+// The locales we translate to, saved on users and stores as their two
+// letter ISO 639-1 code.
+const (
+	English = "en"
+	Spanish = "es"
+	French  = "fr"
+
+	// DefaultLocale is used when no locale was picked or we do not support
+	// the one picked.
+	DefaultLocale = English
+)
+
+var SupportedLocales = []string{English, Spanish, French}
+
+// IsSupported returns true if the locale is exactly one we translate to.
+func IsSupported(locale string) bool {
+	for _, l := range SupportedLocales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize returns the supported locale for the value, for example `fr-CA`
+// and `FR` become `fr`. The default locale is returned if unsupported.
+func Normalize(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		locale = locale[:i]
+	}
+	if IsSupported(locale) {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// T returns the translation of the English text into the locale. The text is
+// returned as is if it was not translated so nothing ever shows up blank.
+func T(locale, text string) string {
+	if m, ok := catalog[Normalize(locale)]; ok {
+		if translated, ok := m[text]; ok {
+			return translated
+		}
+	}
+	return text
+}
@@ -0,0 +1,46 @@
+package i18n
+
+import (
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	data := map[string]string{
+		"en":    English,
+		"es":    Spanish,
+		"es-MX": Spanish,
+		"fr_CA": French,
+		" FR ":  French,
+		"de":    DefaultLocale,
+		"":      DefaultLocale,
+	}
+	for locale, expected := range data {
+		if actual := Normalize(locale); actual != expected {
+			t.Errorf("Incorrect locale for `%v`, got: %v, want: %v.", locale, actual, expected)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if actual := T("fr-CA", "Near Mint"); actual != "Presque neuf" {
+		t.Errorf("Incorrect translation, got: %v, want: %v.", actual, "Presque neuf")
+	}
+	if actual := T("en", "Near Mint"); actual != "Near Mint" {
+		t.Errorf("Incorrect translation, got: %v, want: %v.", actual, "Near Mint")
+	}
+	if actual := T("es", "Not translated"); actual != "Not translated" {
+		t.Errorf("Incorrect fallback, got: %v, want: %v.", actual, "Not translated")
+	}
+}
+
+func TestCatalogIsComplete(t *testing.T) {
+	for locale, m := range catalog {
+		for other, om := range catalog {
+			for text := range om {
+				if _, ok := m[text]; !ok {
+					t.Errorf("Missing %v translation of `%v` which exists in %v.", locale, text, other)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Package metrics holds the Prometheus collectors of the backend. Everything
+// is registered on the default registry so the `/metrics` endpoint also
+// exposes the Go runtime and process metrics.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "cps"
+
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests handled by route, method and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of the HTTP requests by route and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	httpRequestsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "Number of HTTP requests currently being handled.",
+	})
+
+	mongoOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "mongodb_operation_duration_seconds",
+		Help:      "Latency of the MongoDB commands by collection, command and outcome.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"collection", "command", "outcome"})
+
+	pdfGenerationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "pdf_generation_duration_seconds",
+		Help:      "Time it took to generate a PDF by builder and outcome.",
+		Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"builder", "outcome"})
+
+	s3UploadFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "s3_upload_failures_total",
+		Help:      "Number of failed uploads to the S3 bucket by method.",
+	}, []string{"method"})
+
+	webhookDeliveriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_total",
+		Help:      "Number of webhook delivery attempts by event and outcome.",
+	}, []string{"event", "outcome"})
+
+	cacheRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Number of cache lookups by result, either `hit` or `miss`.",
+	}, []string{"result"})
+
+	submissionsCreatedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "submissions_created_total",
+		Help:      "Number of comic submissions created by service type.",
+	}, []string{"service_type"})
+
+	submissionStatusChangesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "submission_status_changes_total",
+		Help:      "Number of comic submissions which moved into a status.",
+	}, []string{"status"})
+
+	creditsGrantedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "credits_granted_total",
+		Help:      "Number of credits granted to stores by business function.",
+	}, []string{"business_function"})
+)
+
+// Webhook delivery outcomes.
+const (
+	WebhookDelivered = "delivered"
+	WebhookRetrying  = "retrying"
+	WebhookFailed    = "failed"
+)
+
+// outcome returns the label value we use for the result of an operation.
+func outcome(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
+
+// ObserveHTTPRequest records a handled HTTP request, the route must be the
+// pattern of the path and not the path itself to keep the cardinality low.
+func ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	httpRequestsTotal.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	httpRequestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// TrackHTTPRequestInFlight increments the in-flight gauge and returns the
+// function to call once the request was handled.
+func TrackHTTPRequestInFlight() func() {
+	httpRequestsInFlight.Inc()
+	return httpRequestsInFlight.Dec
+}
+
+// ObservePDFGeneration records how long a builder took to generate a PDF, it
+// is meant to be deferred at the start of `GeneratePDF` as follows:
+//
+//	defer metrics.ObservePDFGeneration("cbff", time.Now(), &err)
+func ObservePDFGeneration(builder string, start time.Time, err *error) {
+	pdfGenerationDuration.WithLabelValues(builder, outcome(*err)).Observe(time.Since(start).Seconds())
+}
+
+// IncS3UploadFailure counts a failed upload to the S3 bucket.
+func IncS3UploadFailure(method string) {
+	s3UploadFailuresTotal.WithLabelValues(method).Inc()
+}
+
+// IncWebhookDelivery counts a webhook delivery attempt by its outcome.
+func IncWebhookDelivery(event, outcome string) {
+	webhookDeliveriesTotal.WithLabelValues(event, outcome).Inc()
+}
+
+// IncCacheRequest counts a cache lookup as either a hit or a miss.
+func IncCacheRequest(hit bool) {
+	if hit {
+		cacheRequestsTotal.WithLabelValues("hit").Inc()
+		return
+	}
+	cacheRequestsTotal.WithLabelValues("miss").Inc()
+}
+
+// IncSubmissionCreated counts a created comic submission.
+func IncSubmissionCreated(serviceType int8) {
+	submissionsCreatedTotal.WithLabelValues(strconv.Itoa(int(serviceType))).Inc()
+}
+
+// IncSubmissionStatusChange counts a comic submission moving into a status.
+func IncSubmissionStatusChange(status int8) {
+	submissionStatusChangesTotal.WithLabelValues(strconv.Itoa(int(status))).Inc()
+}
+
+// AddCreditsGranted counts the credits granted to a store.
+func AddCreditsGranted(businessFunction int8, numberOfCredits int) {
+	creditsGrantedTotal.WithLabelValues(strconv.Itoa(int(businessFunction))).Add(float64(numberOfCredits))
+}
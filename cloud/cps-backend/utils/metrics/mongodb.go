@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// commandKey identifies a command in flight, the request ID is only unique
+// within a connection.
+type commandKey struct {
+	ConnectionID string
+	RequestID    int64
+}
+
+// NewCommandMonitor returns the MongoDB command monitor which records the
+// latency of every command by collection. As every storer owns a collection
+// this gives us the latency per storer.
+func NewCommandMonitor() *event.CommandMonitor {
+	var started sync.Map
+	finished := func(connectionID string, requestID int64, commandName string, duration time.Duration, result string) {
+		collection := "none"
+		if v, ok := started.LoadAndDelete(commandKey{connectionID, requestID}); ok {
+			collection = v.(string)
+		}
+		mongoOperationDuration.WithLabelValues(collection, commandName, result).Observe(duration.Seconds())
+	}
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+			if collection := collectionName(evt.CommandName, evt.Command); collection != "" {
+				started.Store(commandKey{evt.ConnectionID, evt.RequestID}, collection)
+			}
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			finished(evt.ConnectionID, evt.RequestID, evt.CommandName, evt.Duration, "success")
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			finished(evt.ConnectionID, evt.RequestID, evt.CommandName, evt.Duration, "error")
+		},
+	}
+}
+
+// collectionName returns the collection a command runs against. Most commands
+// have the collection as the value of the command name, `getMore` has it in a
+// separate field and administrative commands have none.
+func collectionName(commandName string, command bson.Raw) string {
+	field := commandName
+	if commandName == "getMore" {
+		field = "collection"
+	}
+	if v, err := command.LookupErr(field); err == nil {
+		if s, ok := v.StringValueOK(); ok {
+			return s
+		}
+	}
+	return ""
+}
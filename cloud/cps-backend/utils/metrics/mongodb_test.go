@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCollectionName(t *testing.T) {
+	raw := func(d bson.D) bson.Raw {
+		b, err := bson.Marshal(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	tests := []struct {
+		name    string
+		command bson.Raw
+		want    string
+	}{
+		{"find", raw(bson.D{{"find", "users"}, {"filter", bson.D{}}}), "users"},
+		{"insert", raw(bson.D{{"insert", "comic_submissions"}}), "comic_submissions"},
+		{"getMore", raw(bson.D{{"getMore", int64(42)}, {"collection", "notifications"}}), "notifications"},
+		{"ping", raw(bson.D{{"ping", 1}}), ""},
+		{"commitTransaction", raw(bson.D{{"commitTransaction", 1}}), ""},
+	}
+	for _, tt := range tests {
+		if got := collectionName(tt.name, tt.command); got != tt.want {
+			t.Errorf("collectionName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Package permission is the central place which decides what every role is
+// allowed to do. Controllers should check for a named permission instead of
+// checking for a specific role.
+package permission
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	u_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/utils/httperror"
+)
+
+// Permission is the name of an action a user may perform.
+type Permission string
+
+const (
+	SubmissionCreate Permission = "submission.create"
+	// SubmissionViewAll grants access to the submissions of every store,
+	// without it the user is limited to their own store.
+	SubmissionViewAll     Permission = "submission.view_all"
+	SubmissionUpdate      Permission = "submission.update"
+	SubmissionGrade       Permission = "submission.grade"
+	SubmissionSetStatus   Permission = "submission.set_status"
+	SubmissionAssignStore Permission = "submission.assign_store"
+	// SubmissionViewLabel grants access to the printable label, everyone else
+	// gets a censored label so they cannot print it themselves.
+	SubmissionViewLabel Permission = "submission.view_label"
+
+	AttachmentViewAll Permission = "attachment.view_all"
+	AttachmentDelete  Permission = "attachment.delete"
+
+	CreditGrant   Permission = "credit.grant"
+	CreditViewAll Permission = "credit.view_all"
+
+	CustomerViewAll Permission = "customer.view_all"
+
+	StoreCreate  Permission = "store.create"
+	StoreApprove Permission = "store.approve"
+	StoreDelete  Permission = "store.delete"
+	StoreViewAll Permission = "store.view_all"
+
+	// UserManage grants managing the users of every store.
+	UserManage Permission = "user.manage"
+	// UserManageStore grants managing the staff of the user's own store.
+	UserManageStore Permission = "user.manage_store"
+	UserAssignRole  Permission = "user.assign_role"
+
+	OfferManage Permission = "offer.manage"
+
+	ReceiptManage  Permission = "receipt.manage"
+	ReceiptViewAll Permission = "receipt.view_all"
+
+	PurchaseManage  Permission = "purchase.manage"
+	PurchaseViewAll Permission = "purchase.view_all"
+
+	ReconciliationManage Permission = "reconciliation.manage"
+
+	InvoiceManage  Permission = "invoice.manage"
+	InvoiceViewAll Permission = "invoice.view_all"
+
+	SubscriptionManage Permission = "subscription.manage"
+
+	// SessionManage grants listing and revoking the sessions of other users.
+	SessionManage Permission = "session.manage"
+
+	// SyncJobManage grants viewing and retrying the jobs which copy store and
+	// user changes onto their related records.
+	SyncJobManage Permission = "sync_job.manage"
+
+	// OutboxManage grants viewing and retrying the queued emails.
+	OutboxManage Permission = "outbox.manage"
+
+	// EmailTemplatePreview grants rendering the email templates in every
+	// locale to review the translations.
+	EmailTemplatePreview Permission = "email_template.preview"
+
+	// WebhookManage grants configuring the webhooks which send the events of
+	// a store to the partner's own systems.
+	WebhookManage Permission = "webhook.manage"
+)
+
+const (
+	// ScopeSystem is for our own staff who are not tied to a store.
+	ScopeSystem = "system"
+	// ScopeStore is for retailer staff who are limited to their store.
+	ScopeStore = "store"
+	// ScopeCustomer is for customers who are limited to their own records.
+	ScopeCustomer = "customer"
+)
+
+// Role is a named set of permissions.
+type Role struct {
+	ID          int8         `json:"id"`
+	Name        string       `json:"name"`
+	Scope       string       `json:"scope"`
+	Permissions []Permission `json:"permissions"`
+}
+
+// retailerPermissions is shared by every retailer staff role.
+var retailerPermissions = []Permission{
+	SubmissionCreate,
+	SubmissionUpdate,
+	SubmissionGrade,
+	AttachmentDelete,
+	SubscriptionManage,
+}
+
+var roles = map[int8]*Role{
+	u_d.UserRoleRoot: {
+		ID:    u_d.UserRoleRoot,
+		Name:  "Root",
+		Scope: ScopeSystem,
+		Permissions: []Permission{
+			SubmissionCreate, SubmissionViewAll, SubmissionUpdate, SubmissionGrade,
+			SubmissionSetStatus, SubmissionAssignStore, SubmissionViewLabel,
+			AttachmentViewAll, AttachmentDelete,
+			CreditGrant, CreditViewAll,
+			CustomerViewAll,
+			StoreCreate, StoreApprove, StoreDelete, StoreViewAll,
+			UserManage, UserManageStore, UserAssignRole,
+			OfferManage,
+			ReceiptManage, ReceiptViewAll,
+			PurchaseManage, PurchaseViewAll,
+			ReconciliationManage,
+			InvoiceManage, InvoiceViewAll,
+			SubscriptionManage,
+			SessionManage,
+			SyncJobManage,
+			OutboxManage,
+			EmailTemplatePreview,
+			WebhookManage,
+		},
+	},
+	u_d.UserRoleGrader: {
+		ID:    u_d.UserRoleGrader,
+		Name:  "Grader",
+		Scope: ScopeSystem,
+		Permissions: []Permission{
+			SubmissionViewAll, SubmissionUpdate, SubmissionGrade,
+			SubmissionSetStatus, SubmissionViewLabel,
+			AttachmentViewAll,
+			CustomerViewAll,
+			StoreViewAll,
+		},
+	},
+	u_d.UserRoleShippingClerk: {
+		ID:    u_d.UserRoleShippingClerk,
+		Name:  "Shipping Clerk",
+		Scope: ScopeSystem,
+		Permissions: []Permission{
+			SubmissionViewAll, SubmissionSetStatus, SubmissionViewLabel,
+			CustomerViewAll,
+			StoreViewAll,
+		},
+	},
+	u_d.UserRoleStoreManager: {
+		ID:          u_d.UserRoleStoreManager,
+		Name:        "Store Manager",
+		Scope:       ScopeStore,
+		Permissions: append([]Permission{UserManageStore, WebhookManage}, retailerPermissions...),
+	},
+	u_d.UserRoleRetailer: {
+		ID:          u_d.UserRoleRetailer,
+		Name:        "Retailer",
+		Scope:       ScopeStore,
+		Permissions: retailerPermissions,
+	},
+	u_d.UserRoleCustomer: {
+		ID:    u_d.UserRoleCustomer,
+		Name:  "Customer",
+		Scope: ScopeCustomer,
+		Permissions: []Permission{
+			SubmissionCreate,
+			SubmissionUpdate,
+		},
+	},
+}
+
+// GetRole returns the role or nil if the role does not exist.
+func GetRole(role int8) *Role {
+	return roles[role]
+}
+
+// Roles returns every role sorted by id.
+func Roles() []*Role {
+	res := make([]*Role, 0, len(roles))
+	for _, r := range roles {
+		res = append(res, r)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res
+}
+
+// RolesForScope returns the ids of the roles with the scope.
+func RolesForScope(scope string) []int8 {
+	var res []int8
+	for _, r := range Roles() {
+		if r.Scope == scope {
+			res = append(res, r.ID)
+		}
+	}
+	return res
+}
+
+// Has returns true if the role was granted the permission.
+func Has(role int8, p Permission) bool {
+	r, ok := roles[role]
+	if !ok {
+		return false
+	}
+	for _, rp := range r.Permissions {
+		if rp == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Can returns true if the logged in user was granted the permission. When
+// the request was made with an api key the key must also include it.
+func Can(ctx context.Context, p Permission) bool {
+	role, _ := ctx.Value(constants.SessionUserRole).(int8)
+	if keyPermissions, ok := ctx.Value(constants.SessionAPIKeyPermissions).([]Permission); ok {
+		if !slices.Contains(keyPermissions, p) {
+			return false
+		}
+	}
+	return Has(role, p)
+}
+
+// Require returns a `403 Forbidden` error if the logged in user was not
+// granted the permission.
+func Require(ctx context.Context, p Permission) error {
+	if Can(ctx, p) {
+		return nil
+	}
+	return httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+}
+
+// RequireAny returns a `403 Forbidden` error if the logged in user was not
+// granted at least one of the permissions.
+func RequireAny(ctx context.Context, ps ...Permission) error {
+	for _, p := range ps {
+		if Can(ctx, p) {
+			return nil
+		}
+	}
+	return httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+}
+
+// Scope returns the scope of the logged in user's role.
+func Scope(ctx context.Context) string {
+	role, _ := ctx.Value(constants.SessionUserRole).(int8)
+	if r, ok := roles[role]; ok {
+		return r.Scope
+	}
+	return ""
+}
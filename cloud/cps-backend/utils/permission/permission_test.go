@@ -0,0 +1,72 @@
+package permission
+
+import (
+	"context"
+	"testing"
+
+	u_d "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/config/constants"
+)
+
+func TestHas(t *testing.T) {
+	tests := []struct {
+		role int8
+		p    Permission
+		want bool
+	}{
+		{u_d.UserRoleRoot, StoreApprove, true},
+		{u_d.UserRoleRetailer, StoreApprove, false},
+		{u_d.UserRoleRetailer, SubmissionViewLabel, false},
+		{u_d.UserRoleGrader, SubmissionGrade, true},
+		{u_d.UserRoleGrader, CreditGrant, false},
+		{u_d.UserRoleShippingClerk, SubmissionViewLabel, true},
+		{u_d.UserRoleShippingClerk, SubmissionGrade, false},
+		{u_d.UserRoleStoreManager, UserManageStore, true},
+		{u_d.UserRoleStoreManager, SubmissionCreate, true},
+		{u_d.UserRoleRetailer, UserManageStore, false},
+		{u_d.UserRoleCustomer, SubmissionGrade, false},
+		{0, SubmissionCreate, false},
+	}
+	for _, tt := range tests {
+		if got := Has(tt.role, tt.p); got != tt.want {
+			t.Errorf("Has(%v, %v) = %v, want %v", tt.role, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestRootHasEveryPermission(t *testing.T) {
+	for _, r := range Roles() {
+		for _, p := range r.Permissions {
+			if !Has(u_d.UserRoleRoot, p) {
+				t.Errorf("root is missing permission %v", p)
+			}
+		}
+	}
+}
+
+func TestRequire(t *testing.T) {
+	ctx := context.WithValue(context.Background(), constants.SessionUserRole, int8(u_d.UserRoleRetailer))
+	if err := Require(ctx, SubmissionCreate); err != nil {
+		t.Errorf("expected permission to be granted, got %v", err)
+	}
+	if err := Require(ctx, CreditGrant); err == nil {
+		t.Error("expected permission to be denied")
+	}
+	if err := Require(context.Background(), SubmissionCreate); err == nil {
+		t.Error("expected permission to be denied without a session")
+	}
+}
+
+func TestCanWithAPIKey(t *testing.T) {
+	ctx := context.WithValue(context.Background(), constants.SessionUserRole, int8(u_d.UserRoleStoreManager))
+	ctx = context.WithValue(ctx, constants.SessionAPIKeyPermissions, []Permission{SubmissionCreate, CreditGrant})
+	if !Can(ctx, SubmissionCreate) {
+		t.Error("expected permission granted to both the role and the key")
+	}
+	if Can(ctx, UserManageStore) {
+		t.Error("expected permission missing from the key to be denied")
+	}
+	if Can(ctx, CreditGrant) {
+		t.Error("expected permission missing from the role to be denied")
+	}
+}
@@ -0,0 +1,31 @@
+package retry
+
+import "time"
+
+// Policy is how many times and how far apart a background task like an
+// outbox message, a sync job or a webhook delivery gets attempted.
+type Policy struct {
+	// MaxAttempts is how many times we try before giving up.
+	MaxAttempts int
+	// BaseDelay is how long we wait after the first attempt, the delay
+	// doubles after every attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps how long we wait between attempts.
+	MaxDelay time.Duration
+}
+
+// GiveUp returns whether the task was attempted as many times as allowed.
+func (p Policy) GiveUp(attempts int) bool {
+	return attempts >= p.MaxAttempts
+}
+
+// Delay returns how long to wait before the next attempt after `attempts`
+// failed ones, backing off exponentially so an outage of whatever the task
+// depends on does not get hammered.
+func (p Policy) Delay(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay)
+}
@@ -0,0 +1,36 @@
+package retry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDelay(t *testing.T) {
+	p := Policy{MaxAttempts: 16, BaseDelay: time.Minute, MaxDelay: 6 * time.Hour}
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{5, 16 * time.Minute},
+		{9, 256 * time.Minute},
+		{10, 6 * time.Hour},
+		{100, 6 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := p.Delay(tt.attempts); got != tt.want {
+			t.Errorf("Delay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestGiveUp(t *testing.T) {
+	p := Policy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Minute}
+	if p.GiveUp(2) {
+		t.Error("GiveUp(2) = true, want false")
+	}
+	if !p.GiveUp(3) {
+		t.Error("GiveUp(3) = false, want true")
+	}
+}
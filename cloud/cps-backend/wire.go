@@ -7,12 +7,16 @@ import (
 	"github.com/google/wire"
 
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/cache/mongodbcache"
-	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/emailer/mailgun"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/emailer"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/paymentprocessor/stripe"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/pdfbuilder"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/storage/mongodb"
 	s3_storage "github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/storage/s3"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/webhookpublisher"
+	apikey_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/apikey/controller"
+	apikey_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/apikey/datastore"
+	apikey_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/apikey/httptransport"
 	attachment_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/attachment/controller"
 	attachment_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/attachment/datastore"
 	attachment_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/attachment/httptransport"
@@ -24,37 +28,74 @@ import (
 	credit_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/credit/httptransport"
 	customer_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/customer/controller"
 	customer_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/customer/httptransport"
+	emailtemplate_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/emailtemplate/controller"
+	emailtemplate_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/emailtemplate/httptransport"
 	eventlog_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/eventlog/datastore"
 	gateway_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/controller"
 	gateway_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/httptransport"
+	health_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/health/controller"
+	health_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/health/httptransport"
+	invitation_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/controller"
+	invitation_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/datastore"
+	invitation_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/httptransport"
+	invoice_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/controller"
+	invoice_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/datastore"
+	invoice_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/httptransport"
+	joblease_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/joblease/datastore"
+	loginattempt_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/loginattempt/datastore"
+	notification_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/controller"
+	notification_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/datastore"
+	notification_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/httptransport"
 	off_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/controller"
 	off_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
 	off_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/httptransport"
+	outbox_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/outbox/controller"
+	outbox_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/outbox/datastore"
+	outbox_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/outbox/httptransport"
+	passwordreset_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/passwordreset/datastore"
 	strpayproc_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/paymentprocessor/controller/stripe"
 	strpayproc_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/paymentprocessor/httptransport/stripe"
 	receipt_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/controller"
 	receipt_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/datastore"
 	receipt_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/httptransport"
+	reconciliation_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/reconciliation/controller"
+	reconciliation_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/reconciliation/datastore"
+	reconciliation_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/reconciliation/httptransport"
+	session_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/session/controller"
+	session_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/session/datastore"
+	session_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/session/httptransport"
 	store_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/controller"
 	store_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
 	store_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/httptransport"
+	syncjob_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/controller"
+	syncjob_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/datastore"
+	syncjob_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/httptransport"
 	user_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/controller"
 	user_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	user_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/httptransport"
 	userpurchase_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/controller"
 	userpurchase_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/datastore"
 	userpurchase_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/httptransport"
+	webauthn_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webauthn/datastore"
+	webhook_c "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/controller"
+	webhook_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/datastore"
+	webhook_http "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/httptransport"
+	webhookdelivery_s "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhookdelivery/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/inputport/http"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/inputport/http/middleware"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/inputport/scheduler"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/blacklist"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/cpsrn"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/currency"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/jwt"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/kmutex"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/logger"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/oidc"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/password"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/time"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
-	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/blacklist"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/webauthn"
 )
 
 func InitializeEvent() Application {
@@ -68,9 +109,13 @@ func InitializeEvent() Application {
 		logger.NewProvider,
 		jwt.NewProvider,
 		kmutex.NewProvider,
-		mailgun.NewEmailer,
+		currency.NewProvider,
+		emailer.NewEmailer,
 		templatedemailer.NewTemplatedEmailer,
+		webhookpublisher.NewPublisher,
 		password.NewProvider,
+		webauthn.NewProvider,
+		oidc.NewProvider,
 		cpsrn.NewProvider,
 		mongodb.NewStorage,
 		blacklist.NewProvider,
@@ -82,6 +127,7 @@ func InitializeEvent() Application {
 		pdfbuilder.NewCCSCBuilder,
 		pdfbuilder.NewCCBuilder,
 		pdfbuilder.NewCCUGBuilder,
+		pdfbuilder.NewInvoiceBuilder,
 		stripe.NewPaymentProcessor,
 		eventlog_s.NewDatastore,
 		user_s.NewDatastore,
@@ -103,6 +149,30 @@ func InitializeEvent() Application {
 		attachment_c.NewController,
 		credit_s.NewDatastore,
 		credit_c.NewController,
+		reconciliation_s.NewDatastore,
+		reconciliation_c.NewController,
+		invoice_s.NewDatastore,
+		invoice_c.NewController,
+		session_s.NewDatastore,
+		loginattempt_s.NewDatastore,
+		webauthn_s.NewDatastore,
+		passwordreset_s.NewDatastore,
+		session_c.NewController,
+		invitation_s.NewDatastore,
+		invitation_c.NewController,
+		apikey_s.NewDatastore,
+		apikey_c.NewController,
+		syncjob_s.NewDatastore,
+		syncjob_c.NewController,
+		outbox_s.NewDatastore,
+		outbox_c.NewController,
+		emailtemplate_c.NewController,
+		notification_s.NewDatastore,
+		notification_c.NewController,
+		webhook_s.NewDatastore,
+		webhookdelivery_s.NewDatastore,
+		joblease_s.NewDatastore,
+		webhook_c.NewController,
 		strpayproc_http.NewHandler,
 		gateway_http.NewHandler,
 		user_http.NewHandler,
@@ -114,8 +184,21 @@ func InitializeEvent() Application {
 		comicsub_http.NewHandler,
 		attachment_http.NewHandler,
 		credit_http.NewHandler,
+		reconciliation_http.NewHandler,
+		invoice_http.NewHandler,
+		session_http.NewHandler,
+		invitation_http.NewHandler,
+		apikey_http.NewHandler,
+		syncjob_http.NewHandler,
+		outbox_http.NewHandler,
+		emailtemplate_http.NewHandler,
+		notification_http.NewHandler,
+		webhook_http.NewHandler,
+		health_c.NewController,
+		health_http.NewHandler,
 		middleware.NewMiddleware,
 		http.NewInputPort,
+		scheduler.NewInputPort,
 		NewApplication)
 	return Application{}
 }
@@ -8,12 +8,16 @@ package main
 
 import (
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/cache/mongodbcache"
-	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/emailer/mailgun"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/emailer"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/paymentprocessor/stripe"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/pdfbuilder"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/storage/mongodb"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/storage/s3"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/templatedemailer"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/adapter/webhookpublisher"
+	controller15 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/apikey/controller"
+	datastore15 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/apikey/datastore"
+	httptransport15 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/apikey/httptransport"
 	controller6 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/attachment/controller"
 	datastore5 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/attachment/datastore"
 	httptransport6 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/attachment/httptransport"
@@ -25,37 +29,74 @@ import (
 	httptransport10 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/credit/httptransport"
 	controller5 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/customer/controller"
 	httptransport5 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/customer/httptransport"
+	controller18 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/emailtemplate/controller"
+	httptransport18 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/emailtemplate/httptransport"
 	datastore9 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/eventlog/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/controller"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/app/gateway/httptransport"
+	controller21 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/health/controller"
+	httptransport21 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/health/httptransport"
+	controller14 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/controller"
+	datastore14 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/datastore"
+	httptransport14 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invitation/httptransport"
+	controller12 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/controller"
+	datastore11 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/datastore"
+	httptransport12 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/invoice/httptransport"
+	datastore23 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/joblease/datastore"
+	datastore13 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/loginattempt/datastore"
+	controller19 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/controller"
+	datastore20 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/datastore"
+	httptransport19 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/notification/httptransport"
 	controller7 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/controller"
 	datastore8 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/datastore"
 	httptransport7 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/offer/httptransport"
+	controller17 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/outbox/controller"
+	datastore19 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/outbox/datastore"
+	httptransport17 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/outbox/httptransport"
+	datastore17 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/passwordreset/datastore"
 	stripe2 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/paymentprocessor/controller/stripe"
 	stripe3 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/paymentprocessor/httptransport/stripe"
 	controller8 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/controller"
 	datastore6 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/datastore"
 	httptransport8 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/receipt/httptransport"
+	controller11 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/reconciliation/controller"
+	datastore10 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/reconciliation/datastore"
+	httptransport11 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/reconciliation/httptransport"
+	controller13 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/session/controller"
+	datastore12 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/session/datastore"
+	httptransport13 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/session/httptransport"
 	controller3 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/controller"
 	datastore2 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/datastore"
 	httptransport3 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/store/httptransport"
+	controller16 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/controller"
+	datastore18 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/datastore"
+	httptransport16 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/syncjob/httptransport"
 	controller2 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/controller"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/datastore"
 	httptransport2 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/user/httptransport"
 	controller9 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/controller"
 	datastore7 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/datastore"
 	httptransport9 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/userpurchase/httptransport"
+	datastore16 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webauthn/datastore"
+	controller20 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/controller"
+	datastore21 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/datastore"
+	httptransport20 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhook/httptransport"
+	datastore22 "github.com/LuchaComics/monorepo/cloud/cps-backend/app/webhookdelivery/datastore"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/config"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/inputport/http"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/inputport/http/middleware"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/inputport/scheduler"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/blacklist"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/cpsrn"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/currency"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/jwt"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/kmutex"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/logger"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/oidc"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/password"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/time"
 	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/uuid"
+	"github.com/LuchaComics/monorepo/cloud/cps-backend/provider/webauthn"
 )
 
 import (
@@ -73,26 +114,42 @@ func InitializeEvent() Application {
 	jwtProvider := jwt.NewProvider(conf)
 	blacklistProvider := blacklist.NewProvider()
 	kmutexProvider := kmutex.NewProvider()
+	currencyProvider := currency.NewProvider(conf)
 	passwordProvider := password.NewProvider()
 	client := mongodb.NewStorage(conf, slogLogger)
 	cacher := mongodbcache.NewCache(conf, slogLogger, client)
-	emailer := mailgun.NewEmailer(conf, slogLogger, provider)
-	templatedEmailer := templatedemailer.NewTemplatedEmailer(conf, slogLogger, provider, emailer)
+	emailerEmailer := emailer.NewEmailer(conf, slogLogger, provider)
+	outboxMessageStorer := datastore19.NewDatastore(conf, slogLogger, client)
+	templatedEmailer := templatedemailer.NewTemplatedEmailer(conf, slogLogger, provider, emailerEmailer, outboxMessageStorer)
 	paymentProcessor := stripe.NewPaymentProcessor(conf, slogLogger, provider)
 	userStorer := datastore.NewDatastore(conf, slogLogger, client)
 	storeStorer := datastore2.NewDatastore(conf, slogLogger, client)
-	gatewayController := controller.NewController(conf, slogLogger, provider, jwtProvider, kmutexProvider, passwordProvider, cacher, client, templatedEmailer, paymentProcessor, userStorer, storeStorer)
-	middlewareMiddleware := middleware.NewMiddleware(conf, slogLogger, provider, timeProvider, jwtProvider, blacklistProvider, gatewayController)
+	sessionStorer := datastore12.NewDatastore(conf, slogLogger, client)
+	loginAttemptStorer := datastore13.NewDatastore(conf, slogLogger, client)
+	webauthnProvider := webauthn.NewProvider(conf)
+	oidcProvider := oidc.NewProvider(conf)
+	webAuthnCredentialStorer := datastore16.NewDatastore(conf, slogLogger, client)
+	passwordResetTokenStorer := datastore17.NewDatastore(conf, slogLogger, client)
+	syncJobStorer := datastore18.NewDatastore(conf, slogLogger, client)
+	gatewayController := controller.NewController(conf, slogLogger, provider, jwtProvider, kmutexProvider, passwordProvider, webauthnProvider, oidcProvider, cacher, client, templatedEmailer, paymentProcessor, userStorer, storeStorer, sessionStorer, loginAttemptStorer, webAuthnCredentialStorer, passwordResetTokenStorer, syncJobStorer)
+	apiKeyStorer := datastore15.NewDatastore(conf, slogLogger, client)
+	apiKeyController := controller15.NewController(conf, slogLogger, passwordProvider, client, userStorer, apiKeyStorer)
+	middlewareMiddleware := middleware.NewMiddleware(conf, slogLogger, provider, timeProvider, jwtProvider, blacklistProvider, gatewayController, apiKeyController)
 	handler := httptransport.NewHandler(slogLogger, gatewayController)
 	comicSubmissionStorer := datastore3.NewDatastore(conf, slogLogger, client)
 	creditStorer := datastore4.NewDatastore(conf, slogLogger, client)
 	attachmentStorer := datastore5.NewDatastore(conf, slogLogger, client)
 	receiptStorer := datastore6.NewDatastore(conf, slogLogger, client)
 	userPurchaseStorer := datastore7.NewDatastore(conf, slogLogger, client)
-	userController := controller2.NewController(conf, slogLogger, provider, passwordProvider, client, storeStorer, userStorer, comicSubmissionStorer, creditStorer, attachmentStorer, receiptStorer, userPurchaseStorer, templatedEmailer)
+	notificationStorer := datastore20.NewDatastore(conf, slogLogger, client)
+	webhookStorer := datastore21.NewDatastore(conf, slogLogger, client)
+	webhookDeliveryStorer := datastore22.NewDatastore(conf, slogLogger, client)
+	jobLeaseStorer := datastore23.NewDatastore(conf, slogLogger, client)
+	publisher := webhookpublisher.NewPublisher(conf, slogLogger, webhookStorer, webhookDeliveryStorer)
+	userController := controller2.NewController(conf, slogLogger, provider, passwordProvider, client, storeStorer, userStorer, sessionStorer, loginAttemptStorer, syncJobStorer, templatedEmailer)
 	httptransportHandler := httptransport2.NewHandler(slogLogger, userController)
 	s3Storager := s3.NewStorage(conf, slogLogger, provider)
-	storeController := controller3.NewController(conf, slogLogger, provider, s3Storager, emailer, templatedEmailer, client, storeStorer, userStorer, comicSubmissionStorer, creditStorer, attachmentStorer, receiptStorer, userPurchaseStorer)
+	storeController := controller3.NewController(conf, slogLogger, provider, currencyProvider, s3Storager, emailerEmailer, templatedEmailer, client, storeStorer, userStorer, syncJobStorer, notificationStorer)
 	handler2 := httptransport3.NewHandler(slogLogger, storeController)
 	cpsrnProvider := cpsrn.NewProvider()
 	cbffBuilder := pdfbuilder.NewCBFFBuilder(conf, slogLogger, provider)
@@ -101,25 +158,51 @@ func InitializeEvent() Application {
 	ccscBuilder := pdfbuilder.NewCCSCBuilder(conf, slogLogger, provider)
 	ccBuilder := pdfbuilder.NewCCBuilder(conf, slogLogger, provider)
 	ccugBuilder := pdfbuilder.NewCCUGBuilder(conf, slogLogger, provider)
-	comicSubmissionController := controller4.NewController(conf, slogLogger, provider, s3Storager, passwordProvider, kmutexProvider, cpsrnProvider, cbffBuilder, pcBuilder, ccimgBuilder, ccscBuilder, ccBuilder, ccugBuilder, emailer, client, templatedEmailer, userStorer, comicSubmissionStorer, storeStorer, creditStorer)
+	offerStorer := datastore8.NewDatastore(conf, slogLogger, client)
+	invoiceStorer := datastore11.NewDatastore(conf, slogLogger, client)
+	comicSubmissionController := controller4.NewController(conf, slogLogger, provider, s3Storager, passwordProvider, jwtProvider, kmutexProvider, cpsrnProvider, cbffBuilder, pcBuilder, ccimgBuilder, ccscBuilder, ccBuilder, ccugBuilder, client, templatedEmailer, publisher, userStorer, comicSubmissionStorer, storeStorer, creditStorer, offerStorer, invoiceStorer, notificationStorer)
 	handler3 := httptransport4.NewHandler(slogLogger, comicSubmissionController)
-	customerController := controller5.NewController(conf, slogLogger, provider, s3Storager, passwordProvider, paymentProcessor, cbffBuilder, templatedEmailer, client, userStorer, comicSubmissionStorer)
+	customerController := controller5.NewController(conf, slogLogger, provider, s3Storager, passwordProvider, paymentProcessor, cbffBuilder, templatedEmailer, client, userStorer, syncJobStorer)
 	handler4 := httptransport5.NewHandler(slogLogger, customerController)
-	attachmentController := controller6.NewController(conf, slogLogger, provider, s3Storager, emailer, client, attachmentStorer, userStorer, comicSubmissionStorer)
+	attachmentController := controller6.NewController(conf, slogLogger, provider, s3Storager, emailerEmailer, client, attachmentStorer, userStorer, comicSubmissionStorer)
 	handler5 := httptransport6.NewHandler(slogLogger, attachmentController)
-	offerStorer := datastore8.NewDatastore(conf, slogLogger, client)
-	offerontroller := controller7.NewController(conf, slogLogger, provider, client, storeStorer, offerStorer, userStorer)
+	offerontroller := controller7.NewController(conf, slogLogger, provider, paymentProcessor, client, storeStorer, offerStorer, userStorer)
 	handler6 := httptransport7.NewHandler(slogLogger, offerontroller)
-	receiptController := controller8.NewController(conf, slogLogger, provider, client, storeStorer, receiptStorer)
+	receiptController := controller8.NewController(conf, slogLogger, provider, currencyProvider, client, storeStorer, receiptStorer)
 	handler7 := httptransport8.NewHandler(slogLogger, receiptController)
 	userPurchaseController := controller9.NewController(conf, slogLogger, provider, client, storeStorer, userPurchaseStorer)
 	handler8 := httptransport9.NewHandler(slogLogger, userPurchaseController)
 	eventLogStorer := datastore9.NewDatastore(conf, slogLogger, client)
-	stripePaymentProcessorController := stripe2.NewController(conf, slogLogger, provider, s3Storager, passwordProvider, emailer, templatedEmailer, paymentProcessor, kmutexProvider, client, storeStorer, userStorer, receiptStorer, offerStorer, eventLogStorer, comicSubmissionStorer, userPurchaseStorer)
+	stripePaymentProcessorController := stripe2.NewController(conf, slogLogger, provider, s3Storager, passwordProvider, emailerEmailer, templatedEmailer, paymentProcessor, kmutexProvider, currencyProvider, client, storeStorer, userStorer, receiptStorer, offerStorer, eventLogStorer, comicSubmissionStorer, userPurchaseStorer, creditStorer)
 	stripeHandler := stripe3.NewHandler(slogLogger, stripePaymentProcessorController)
-	creditController := controller10.NewController(conf, slogLogger, provider, client, storeStorer, creditStorer, userStorer, offerStorer)
+	creditController := controller10.NewController(conf, slogLogger, provider, client, publisher, storeStorer, creditStorer, userStorer, offerStorer, notificationStorer)
 	handler9 := httptransport10.NewHandler(slogLogger, creditController)
-	inputPortServer := http.NewInputPort(conf, slogLogger, middlewareMiddleware, handler, httptransportHandler, handler2, handler3, handler4, handler5, handler6, handler7, handler8, stripeHandler, handler9)
-	application := NewApplication(slogLogger, inputPortServer)
+	reconciliationReportStorer := datastore10.NewDatastore(conf, slogLogger, client)
+	reconciliationController := controller11.NewController(conf, slogLogger, provider, kmutexProvider, templatedEmailer, paymentProcessor, client, userStorer, receiptStorer, userPurchaseStorer, comicSubmissionStorer, reconciliationReportStorer)
+	handler10 := httptransport11.NewHandler(slogLogger, reconciliationController)
+	invoiceBuilder := pdfbuilder.NewInvoiceBuilder(conf, slogLogger, provider)
+	invoiceController := controller12.NewController(conf, slogLogger, provider, s3Storager, kmutexProvider, invoiceBuilder, templatedEmailer, client, userStorer, storeStorer, invoiceStorer)
+	handler11 := httptransport12.NewHandler(slogLogger, invoiceController)
+	sessionController := controller13.NewController(conf, slogLogger, provider, client, userStorer, sessionStorer)
+	handler12 := httptransport13.NewHandler(slogLogger, sessionController)
+	invitationStorer := datastore14.NewDatastore(conf, slogLogger, client)
+	invitationController := controller14.NewController(conf, slogLogger, passwordProvider, client, invitationStorer, userStorer, storeStorer, templatedEmailer, paymentProcessor)
+	handler13 := httptransport14.NewHandler(slogLogger, invitationController)
+	handler14 := httptransport15.NewHandler(slogLogger, apiKeyController)
+	syncJobController := controller16.NewController(conf, slogLogger, syncJobStorer, userStorer, storeStorer, comicSubmissionStorer, attachmentStorer, creditStorer, receiptStorer, userPurchaseStorer)
+	handler15 := httptransport16.NewHandler(slogLogger, syncJobController)
+	outboxController := controller17.NewController(conf, slogLogger, emailerEmailer, outboxMessageStorer)
+	handler16 := httptransport17.NewHandler(slogLogger, outboxController)
+	emailTemplateController := controller18.NewController(conf, slogLogger, templatedEmailer)
+	handler17 := httptransport18.NewHandler(slogLogger, emailTemplateController)
+	notificationController := controller19.NewController(conf, slogLogger, notificationStorer, sessionStorer)
+	handler18 := httptransport19.NewHandler(slogLogger, notificationController)
+	webhookController := controller20.NewController(conf, slogLogger, storeStorer, webhookStorer, webhookDeliveryStorer)
+	handler19 := httptransport20.NewHandler(slogLogger, webhookController)
+	healthController := controller21.NewController(conf, slogLogger, client, s3Storager)
+	handler20 := httptransport21.NewHandler(slogLogger, healthController)
+	inputPortServer := http.NewInputPort(conf, slogLogger, middlewareMiddleware, handler, httptransportHandler, handler2, handler3, handler4, handler5, handler6, handler7, handler8, stripeHandler, handler9, handler10, handler11, handler12, handler13, handler14, handler15, handler16, handler17, handler18, handler19, handler20)
+	schedulerInputPortServer := scheduler.NewInputPort(conf, slogLogger, reconciliationController, invoiceController, syncJobController, outboxController, webhookController, jobLeaseStorer)
+	application := NewApplication(slogLogger, inputPortServer, schedulerInputPortServer)
 	return application
 }